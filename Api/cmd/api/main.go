@@ -1,13 +1,21 @@
 package main
 
 import (
+	"api"
 	"api/internal/presentation/handlers"
+	"api/internal/presentation/middlewares"
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-migrate/migrate/v4"
@@ -17,11 +25,16 @@ import (
 	"gorm.io/gorm"
 
 	"api/internal/application/useCase"
+	"api/internal/domain/entities"
 	"api/internal/domain/interfaces"
 	infraCache "api/internal/infrastructure/cache"
+	"api/internal/infrastructure/health"
 	infraMessaging "api/internal/infrastructure/messaging"
+	"api/internal/infrastructure/metrics"
 	postgresrepo "api/internal/infrastructure/repository"
 	"api/internal/infrastructure/storage"
+	"api/internal/infrastructure/tracing"
+	"api/internal/logging"
 )
 
 // runMigrations initializes and applies DB migrations, ensuring proper cleanup.
@@ -33,7 +46,7 @@ func runMigrations(dsn, migPath string) error {
 	defer func() {
 		srcErr, dbErr := m.Close()
 		if srcErr != nil || dbErr != nil {
-			log.Printf("migrate close warnings: src=%v db=%v", srcErr, dbErr)
+			slog.Warn("migrate close warnings", "src_err", srcErr, "db_err", dbErr)
 		}
 	}()
 
@@ -54,6 +67,24 @@ func getEnvOrDefault(key, def string) string {
 	return def
 }
 
+// voteRulesFromEnv arma las reglas antifraude de votos; un limite en 0 deshabilita la regla.
+func voteRulesFromEnv() []useCase.VoteRule {
+	seconds := func(key string, def time.Duration) time.Duration {
+		return time.Duration(atoiOrDefault(os.Getenv(key), int(def/time.Second))) * time.Second
+	}
+	var rules []useCase.VoteRule
+	if n := atoiOrDefault(os.Getenv("VOTE_VELOCITY_MAX"), useCase.DefaultVoteVelocityMax); n > 0 {
+		rules = append(rules, useCase.VelocityRule{MaxVotes: n, Window: seconds("VOTE_VELOCITY_WINDOW_SECONDS", useCase.DefaultVoteVelocityWindow)})
+	}
+	if n := atoiOrDefault(os.Getenv("VOTE_IP_MAX_ACCOUNTS"), useCase.DefaultVoteIPMaxAccounts); n > 0 {
+		rules = append(rules, useCase.SharedIPRule{MaxAccounts: n, Window: seconds("VOTE_IP_WINDOW_SECONDS", useCase.DefaultVoteIPWindow)})
+	}
+	if age := seconds("VOTE_MIN_ACCOUNT_AGE_SECONDS", useCase.DefaultVoteMinAccountAge); age > 0 {
+		rules = append(rules, useCase.NewAccountRule{MinAge: age})
+	}
+	return rules
+}
+
 func atoiOrDefault(s string, def int) int {
 	if s == "" {
 		return def
@@ -75,20 +106,127 @@ func loadS3ConfigFromEnv() storage.S3Config {
 	}
 }
 
-func setupRabbitPublisher(rabbitURL, queue string) interfaces.MessagePublisher {
+// loadMediaSignerConfigFromEnv reads the media URL signing settings.
+// - MEDIA_URL_SIGNER: "s3" (presigned S3 URLs), "secure_link" (nginx secure_link_md5) or empty to disable
+// - MEDIA_URL_TTL_SECONDS (default: 900)
+// - S3_BUCKET / S3_BUCKET_PROCESSED: buckets for original and processed media (s3 mode)
+// - MEDIA_ORIGINAL_BASE_URL / MEDIA_PROCESSED_BASE_URL / MEDIA_SECURE_LINK_SECRET (secure_link mode)
+func loadMediaSignerConfigFromEnv(s3Cfg storage.S3Config) storage.MediaSignerConfig {
+	return storage.MediaSignerConfig{
+		Mode:             os.Getenv("MEDIA_URL_SIGNER"),
+		TTL:              time.Duration(atoiOrDefault(os.Getenv("MEDIA_URL_TTL_SECONDS"), 900)) * time.Second,
+		S3:               s3Cfg,
+		OriginalBucket:   s3Cfg.Bucket,
+		ProcessedBucket:  getEnvOrDefault("S3_BUCKET_PROCESSED", "processed-videos"),
+		OriginalBaseURL:  os.Getenv("MEDIA_ORIGINAL_BASE_URL"),
+		ProcessedBaseURL: os.Getenv("MEDIA_PROCESSED_BASE_URL"),
+		Secret:           os.Getenv("MEDIA_SECURE_LINK_SECRET"),
+	}
+}
+
+func setupRabbitPublisher(rabbitURL string, queues ...string) interfaces.MessagePublisher {
 	if rabbitURL == "" {
 		return nil
 	}
 	p, err := infraMessaging.NewRabbitMQPublisher(rabbitURL)
 	if err != nil {
-		log.Printf("warning: rabbitmq publisher init failed: %v", err)
+		slog.Warn("rabbitmq publisher init failed", "err", err)
 		return nil
 	}
 	maxLen := atoiOrDefault(os.Getenv("RABBITMQ_QUEUE_MAXLEN"), 1000)
-	_ = p.EnsureQueue(queue, maxLen, false)
+	for _, queue := range queues {
+		_ = p.EnsureQueue(queue, maxLen, false)
+	}
 	return p
 }
 
+// startVoteEventRelay publica en segundo plano los eventos de voto del outbox.
+// Usa un publisher dedicado con confirmaciones; si RabbitMQ no responde al arrancar se
+// reintenta la conexion y los eventos esperan en el outbox. Devuelve la funcion de parada.
+func startVoteEventRelay(rabbitURL string, outbox interfaces.VoteEventOutbox) func() {
+	if rabbitURL == "" {
+		slog.Info("vote events: RABBITMQ_URL not set, events stay in the outbox")
+		return func() {}
+	}
+	exchange := getEnvOrDefault("VOTE_EVENTS_EXCHANGE", useCase.DefaultVoteEventsExchange)
+	interval := time.Duration(atoiOrDefault(os.Getenv("VOTE_EVENTS_POLL_MS"), 500)) * time.Millisecond
+	retention := time.Duration(atoiOrDefault(os.Getenv("VOTE_EVENTS_RETENTION_HOURS"), 168)) * time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var p *infraMessaging.RabbitMQPublisher
+		for backoff := time.Second; ; backoff = min(2*backoff, 30*time.Second) {
+			var err error
+			if p, err = infraMessaging.NewRabbitMQPublisher(rabbitURL); err == nil {
+				if err = p.EnableConfirms(); err == nil {
+					break
+				}
+				_ = p.Close()
+			}
+			slog.Warn("vote events: rabbitmq publisher init failed, retrying", "backoff", backoff.String(), "err", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+		}
+		defer p.Close()
+		useCase.NewVoteEventRelay(outbox, p, exchange).WithPollInterval(interval).WithRetention(retention).Run(ctx)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// setupTrustedProxiesFromEnv define que peers pueden fijar la IP del cliente con
+// X-Forwarded-For / X-Real-IP; de esa IP dependen los limites por IP y las senales
+// antifraude de votos. Sin configurarlo gin confia en cualquier peer y rotar el header
+// daria un limite nuevo en cada solicitud. TRUSTED_PROXIES son IPs o CIDRs separados por
+// coma (p.ej. la subred de nginx); vacio no confia en ninguno y se usa la IP del peer.
+func setupTrustedProxiesFromEnv(r *gin.Engine) error {
+	var proxies []string
+	for _, p := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return r.SetTrustedProxies(proxies)
+}
+
+// readinessChecker lo implementan los adaptadores que saben probar su dependencia.
+type readinessChecker interface {
+	Ready(ctx context.Context) error
+}
+
+// setupHealthChecks arma las dependencias que evalua GET /readyz. Postgres y S3 son
+// criticas; Redis (la cache y los limites tienen fallback) y RabbitMQ (las subidas no
+// fallan sin publisher) solo degradan el estado.
+func setupHealthChecks(db *gorm.DB, videoStorage interfaces.VideoStorage, publisher interfaces.MessagePublisher, cache interfaces.Cache) []health.Check {
+	checks := []health.Check{{Name: "postgres", Run: func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}}}
+	if r, ok := videoStorage.(readinessChecker); ok {
+		checks = append(checks, health.Check{Name: "s3", Run: r.Ready})
+	}
+	if os.Getenv("RABBITMQ_URL") != "" {
+		run := func(context.Context) error { return errors.New("rabbitmq publisher not initialized") }
+		if r, ok := publisher.(readinessChecker); ok {
+			run = r.Ready
+		}
+		checks = append(checks, health.Check{Name: "rabbitmq", Optional: true, Run: run})
+	}
+	if r, ok := cache.(readinessChecker); ok {
+		checks = append(checks, health.Check{Name: "redis", Optional: true, Run: r.Ready})
+	}
+	return checks
+}
+
 // setupRedisCacheFromEnv initializes a Redis-backed cache if REDIS_ADDR is set.
 // It uses the same env var names as Workers/AdminCache for consistency:
 // - REDIS_ADDR (e.g., "redis:6379")
@@ -103,17 +241,90 @@ func setupRedisCacheFromEnv() interfaces.Cache {
 	return infraCache.NewRedisCache(rdb, prefix)
 }
 
+// setupRateLimiterFromEnv builds the request rate limiter and its per-group limits.
+// Limits use the "limit/period" format (e.g. "10/1m") or "off".
+// - RATE_LIMIT_AUTH: signup and login per IP (default: "10/1m")
+// - RATE_LIMIT_UPLOADS: video uploads per user (default: "10/1h")
+// - RATE_LIMIT_VOTES: votes and retractions per user (default: "30/1m")
+// - RATE_LIMIT_FALLBACK_COOLDOWN_SECONDS (default: 5)
+// With REDIS_ADDR limits are shared across instances and fall back to in-memory
+// limits while Redis is unreachable; without it limits are per instance.
+func setupRateLimiterFromEnv() (interfaces.RateLimiter, handlers.RateLimitPolicies, error) {
+	var policies handlers.RateLimitPolicies
+	for _, p := range []struct {
+		env, def string
+		dst      *entities.RateLimit
+	}{
+		{"RATE_LIMIT_AUTH", "10/1m", &policies.Auth},
+		{"RATE_LIMIT_UPLOADS", "10/1h", &policies.Uploads},
+		{"RATE_LIMIT_VOTES", "30/1m", &policies.Votes},
+	} {
+		limit, err := entities.ParseRateLimit(getEnvOrDefault(p.env, p.def))
+		if err != nil {
+			return nil, policies, fmt.Errorf("%s: %w", p.env, err)
+		}
+		*p.dst = limit
+	}
+
+	memory := infraCache.NewMemoryRateLimiter()
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		return memory, policies, nil
+	}
+	prefix := getEnvOrDefault("CACHE_PREFIX", "videorank:") + "ratelimit:"
+	cooldown := time.Duration(atoiOrDefault(os.Getenv("RATE_LIMIT_FALLBACK_COOLDOWN_SECONDS"), 5)) * time.Second
+	limiter := infraCache.NewRedisRateLimiter(infraCache.NewRedisClient(addr), prefix).
+		WithFallback(memory).
+		WithFallbackCooldown(cooldown)
+	return limiter, policies, nil
+}
+
+// setupOpenAPIValidatorFromEnv builds the request validator from the embedded openapi.yaml.
+// OPENAPI_VALIDATION=off disables it (default: on).
+func setupOpenAPIValidatorFromEnv() (*middlewares.OpenAPIValidator, error) {
+	if strings.EqualFold(os.Getenv("OPENAPI_VALIDATION"), "off") {
+		return nil, nil
+	}
+	return middlewares.NewOpenAPIValidator(api.OpenAPISpec)
+}
+
+// setupMetricsFromEnv builds the Prometheus metrics served on GET /metrics.
+// METRICS_ENABLED=off disables them (default: on).
+func setupMetricsFromEnv() *metrics.Metrics {
+	if strings.EqualFold(os.Getenv("METRICS_ENABLED"), "off") {
+		return nil
+	}
+	return metrics.New()
+}
+
 // Redis Aggregates removed; rankings served from DB.
 
 func main() {
+	logging.Setup(getEnvOrDefault("OTEL_SERVICE_NAME", "api"))
+
+	shutdownTracing, err := tracing.Setup(context.Background(), getEnvOrDefault("OTEL_SERVICE_NAME", "api"))
+	if err != nil {
+		logging.Fatal("tracing setup failed", "err", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Warn("tracing shutdown", "err", err)
+		}
+	}()
+
 	dsn := os.Getenv("DATABASE_URL")
 	if err := runMigrations(dsn, "file://internal/infrastructure/migrations"); err != nil {
-		log.Fatalf("%v", err)
+		logging.Fatal("migrations failed", "err", err)
 	}
 
 	db, err := openDB(dsn)
 	if err != nil {
-		log.Fatalf("database connection failed: %v", err)
+		logging.Fatal("database connection failed", "err", err)
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer closeQuietly("postgres", sqlDB)
 	}
 
 	jwtSecret := getEnvOrDefault("JWT_SECRET", "secret")
@@ -125,7 +336,12 @@ func main() {
 	s3Cfg := loadS3ConfigFromEnv()
 	videoStorage, err := storage.NewS3VideoStorage(s3Cfg)
 	if err != nil {
-		log.Fatalf("s3 storage init failed: %v", err)
+		logging.Fatal("s3 storage init failed", "err", err)
+	}
+
+	mediaSigner, err := storage.NewMediaURLSigner(loadMediaSignerConfigFromEnv(s3Cfg))
+	if err != nil {
+		logging.Fatal("media url signer init failed", "err", err)
 	}
 
 	authService := useCase.NewAuthService(userRepo, jwtSecret)
@@ -141,10 +357,31 @@ func main() {
 		}
 	}()
 
+	stopVoteEvents := startVoteEventRelay(os.Getenv("RABBITMQ_URL"), postgresrepo.NewVoteEventOutbox(db))
+	defer stopVoteEvents()
+
 	// Build use cases (inject publisher into use case, not handlers)
 	uploadsUC := useCase.NewUploadsUseCase(videoRepo, videoStorage, messagePublisher, audioQueue)
+	moderationUC := useCase.NewModerationUseCase(postgresrepo.NewModerationRepository(db))
+	notificationUC := useCase.NewNotificationUseCase(postgresrepo.NewNotificationRepository(db))
+	reportUC := useCase.NewReportUseCase(publicRepo, postgresrepo.NewReportRepository(db),
+		atoiOrDefault(os.Getenv("REPORT_HIDE_THRESHOLD"), useCase.DefaultReportHideThreshold))
+	commentUC := useCase.NewCommentUseCase(publicRepo, postgresrepo.NewCommentRepository(db)).
+		WithRateLimit(
+			atoiOrDefault(os.Getenv("COMMENT_RATE_LIMIT"), useCase.DefaultCommentRateLimit),
+			time.Duration(atoiOrDefault(os.Getenv("COMMENT_RATE_WINDOW_SECONDS"), 60))*time.Second,
+		)
+	contestRepo := postgresrepo.NewContestRepository(db)
+	contestUC := useCase.NewContestUseCase(contestRepo)
+	voteReviewUC := useCase.NewVoteReviewUseCase(postgresrepo.NewVoteQuarantineRepository(db))
 
-	r := gin.Default()
+	// gin.New en lugar de gin.Default: el access log de gin se reemplaza por uno en slog
+	// que incluye el request_id.
+	r := gin.New()
+	if err := setupTrustedProxiesFromEnv(r); err != nil {
+		logging.Fatal("trusted proxies config", "err", err)
+	}
+	r.Use(gin.Recovery(), middlewares.RequestID(), middlewares.AccessLog())
 
 	// Lightweight CORS middleware (avoids external deps)
 	allowed := getEnvOrDefault("CORS_ORIGIN", "*")
@@ -168,9 +405,9 @@ func main() {
 			}
 		}
 		c.Writer.Header().Set("Vary", "Origin")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization,Content-Type,Accept,Origin")
-		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization,Content-Type,Accept,Origin,X-Request-ID")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length,ETag,X-Cache,X-Next-Cursor,Retry-After,X-Request-ID,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,RateLimit-Policy")
 		c.Writer.Header().Set("Access-Control-Max-Age", "86400")
 
 		if c.Request.Method == "OPTIONS" {
@@ -183,8 +420,27 @@ func main() {
 	statusService := useCase.NewStatusService()
 	// Redis cache solo lectura
 	cache := setupRedisCacheFromEnv()
+	defer closeQuietly("redis cache", cache)
 	// Public service without Redis aggregates
-	publicService := useCase.NewPublicService(publicRepo, voteRepo)
+	voteBudgets, err := useCase.ParseVoteBudgetPolicies(os.Getenv("VOTE_BUDGETS"))
+	if err != nil {
+		logging.Fatal("vote budget config", "err", err)
+	}
+	publicService := useCase.NewPublicService(publicRepo, voteRepo).
+		WithContests(contestRepo).
+		WithVoteBudget(voteBudgets).
+		WithFraudDetector(useCase.NewVoteFraudDetector(postgresrepo.NewVoteSignalReader(db), voteRulesFromEnv()...))
+
+	rateLimiter, rateLimits, err := setupRateLimiterFromEnv()
+	if err != nil {
+		logging.Fatal("rate limit config", "err", err)
+	}
+	defer closeQuietly("redis rate limiter", rateLimiter)
+
+	openAPIValidator, err := setupOpenAPIValidatorFromEnv()
+	if err != nil {
+		logging.Fatal("openapi validator", "err", err)
+	}
 
 	processedBase := strings.TrimRight(os.Getenv("PROCESSED_VIDEO_BASE_URL"), "/")
 	processedVideoURL := ""
@@ -202,14 +458,72 @@ func main() {
 		UploadsUC:          uploadsUC,
 		PublicService:      publicService,
 		StatusService:      statusService,
+		ModerationUC:       moderationUC,
+		ReportUC:           reportUC,
+		CommentUC:          commentUC,
+		ContestUC:          contestUC,
+		VoteReviewUC:       voteReviewUC,
+		NotificationUC:     notificationUC,
 		JWTSecret:          jwtSecret,
 		Cache:              cache,
 		CacheSchemaVersion: getEnvOrDefault("SCHEMA_VERSION", "v2"),
 		ProcessedVideoURL:  processedVideoURL,
+		MediaSigner:        mediaSigner,
+		RateLimiter:        rateLimiter,
+		RateLimits:         rateLimits,
+		OpenAPI:            openAPIValidator,
+		Metrics:            setupMetricsFromEnv(),
+		Tracing:            true,
+		HealthChecks:       setupHealthChecks(db, videoStorage, messagePublisher, cache),
+		// HEALTH_CHECK_TIMEOUT_MS acota cada chequeo de /readyz (default: 2000)
+		HealthTimeout: time.Duration(atoiOrDefault(os.Getenv("HEALTH_CHECK_TIMEOUT_MS"), 2000)) * time.Millisecond,
 	})
 
-	port := getEnvOrDefault("PORT", "8080")
-	if err := r.Run(":" + port); err != nil {
-		log.Fatalf("server failed: %v", err)
+	srv := &http.Server{
+		Addr:              ":" + getEnvOrDefault("PORT", "8080"),
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	// SHUTDOWN_TIMEOUT_SECONDS: plazo para que terminen las solicitudes en curso (default: 30)
+	shutdownTimeout := time.Duration(atoiOrDefault(os.Getenv("SHUTDOWN_TIMEOUT_SECONDS"), 30)) * time.Second
+	if err := serveUntilSignal(srv, shutdownTimeout); err != nil {
+		logging.Fatal("server failed", "err", err)
+	}
+	// Los defers cierran el relay de votos, AMQP, Redis, Postgres y por ultimo las trazas
+}
+
+// serveUntilSignal atiende solicitudes hasta SIGINT/SIGTERM. Entonces deja de aceptar
+// conexiones y espera hasta timeout a que terminen las solicitudes en curso (p.ej. subidas);
+// las que siguen abiertas al vencer el plazo se cortan.
+func serveUntilSignal(srv *http.Server, timeout time.Duration) error {
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.ListenAndServe() }()
+	slog.Info("API listening", "addr", srv.Addr)
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+	select {
+	case err := <-serveErr:
+		return err
+	case sig := <-quit:
+		slog.Info("shutting down, draining in-flight requests", "signal", sig.String(), "timeout", timeout.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		slog.Warn("in-flight requests did not finish before the shutdown deadline", "err", err)
+		_ = srv.Close()
+	}
+	return nil
+}
+
+// closeQuietly cierra v si implementa io.Closer (clientes Redis, conexion a Postgres).
+func closeQuietly(name string, v any) {
+	if c, ok := v.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			slog.Warn("close failed", "component", name, "err", err)
+		}
 	}
 }
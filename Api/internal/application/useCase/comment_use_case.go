@@ -0,0 +1,205 @@
+package useCase
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+)
+
+const (
+	// MaxCommentLength coincide con comment.body VARCHAR(1000).
+	MaxCommentLength = 1000
+	// DefaultCommentRateLimit comentarios permitidos por usuario en DefaultCommentRateWindow.
+	DefaultCommentRateLimit  = 5
+	DefaultCommentRateWindow = time.Minute
+)
+
+// CommentUseCase gestiona comentarios sobre videos publicos.
+type CommentUseCase struct {
+	publicRepo interfaces.PublicRepository
+	repo       interfaces.CommentRepository
+	rateLimit  int
+	rateWindow time.Duration
+	now        func() time.Time
+}
+
+func NewCommentUseCase(publicRepo interfaces.PublicRepository, repo interfaces.CommentRepository) *CommentUseCase {
+	return &CommentUseCase{
+		publicRepo: publicRepo,
+		repo:       repo,
+		rateLimit:  DefaultCommentRateLimit,
+		rateWindow: DefaultCommentRateWindow,
+		now:        time.Now,
+	}
+}
+
+// WithRateLimit configura cuantos comentarios puede publicar un usuario por ventana.
+// max <= 0 desactiva el limite.
+func (uc *CommentUseCase) WithRateLimit(max int, window time.Duration) *CommentUseCase {
+	uc.rateLimit = max
+	if window > 0 {
+		uc.rateWindow = window
+	}
+	return uc
+}
+
+// WithClock overrides the time source (useful for tests).
+func (uc *CommentUseCase) WithClock(now func() time.Time) *CommentUseCase {
+	uc.now = now
+	return uc
+}
+
+// List devuelve una pagina de comentarios de primer nivel, o de respuestas si parentID no es nil.
+// cursor es el next_cursor de la pagina anterior (vacio para la primera).
+func (uc *CommentUseCase) List(ctx context.Context, videoID uint, parentID *uint, cursor string, limit int) (*responses.CommentPage, error) {
+	afterID, err := decodeCommentCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.publicRepo.GetPublicByID(ctx, videoID); err != nil {
+		return nil, err
+	}
+	// Se pide un elemento extra para saber si existe una pagina siguiente
+	items, err := uc.repo.List(ctx, videoID, parentID, afterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &responses.CommentPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		next := encodeCommentCursor(page.Items[limit-1].CommentID)
+		page.NextCursor = &next
+	}
+	if page.Items == nil {
+		page.Items = []responses.CommentResponse{}
+	}
+	return page, nil
+}
+
+// Create publica un comentario de userID.
+// Rules:
+// - body is required, at most MaxCommentLength characters.
+// - The video must be publicly visible.
+// - parentID, if set, must be a top-level comment of the same video (one level of replies).
+// - At most rateLimit comments per user per rateWindow (domain.ErrRateLimited).
+func (uc *CommentUseCase) Create(ctx context.Context, userID, videoID uint, parentID *uint, body string) (*entities.Comment, error) {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.publicRepo.GetPublicByID(ctx, videoID); err != nil {
+		return nil, err
+	}
+	if parentID != nil {
+		parent, err := uc.repo.GetByID(ctx, *parentID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, fmt.Errorf("%w: parent comment not found", domain.ErrInvalid)
+			}
+			return nil, err
+		}
+		if parent.VideoID != videoID || parent.ParentID != nil {
+			return nil, fmt.Errorf("%w: replies are limited to top-level comments of the same video", domain.ErrInvalid)
+		}
+	}
+	c := &entities.Comment{VideoID: videoID, UserID: userID, ParentID: parentID, Body: body}
+	if uc.rateLimit > 0 {
+		err = uc.repo.CreateWithinLimit(ctx, c, uc.rateLimit, uc.now().Add(-uc.rateWindow))
+	} else {
+		err = uc.repo.Create(ctx, c)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Update edita el texto de un comentario; solo su autor puede hacerlo.
+func (uc *CommentUseCase) Update(ctx context.Context, userID, videoID, commentID uint, body string) error {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return err
+	}
+	c, err := uc.commentOfVideo(ctx, videoID, commentID)
+	if err != nil {
+		return err
+	}
+	if c.UserID != userID {
+		return domain.ErrForbidden
+	}
+	return uc.repo.UpdateBody(ctx, commentID, body)
+}
+
+// Delete elimina un comentario (y sus respuestas).
+// Allowed for the author, the owner of the video and moderators (canModerate). El propietario
+// se busca sin filtrar por visibilidad: debe poder limpiar comentarios de un video retirado.
+func (uc *CommentUseCase) Delete(ctx context.Context, userID, videoID, commentID uint, canModerate bool) error {
+	c, err := uc.commentOfVideo(ctx, videoID, commentID)
+	if err != nil {
+		return err
+	}
+	if c.UserID != userID && !canModerate {
+		owner, err := uc.repo.VideoOwnerID(ctx, videoID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		if owner != userID {
+			return domain.ErrForbidden
+		}
+	}
+	return uc.repo.Delete(ctx, commentID)
+}
+
+// commentOfVideo obtiene el comentario validando que pertenezca al video de la ruta.
+func (uc *CommentUseCase) commentOfVideo(ctx context.Context, videoID, commentID uint) (*entities.Comment, error) {
+	c, err := uc.repo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if c.VideoID != videoID {
+		return nil, domain.ErrNotFound
+	}
+	return c, nil
+}
+
+func normalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("%w: comment body is required", domain.ErrInvalid)
+	}
+	if utf8.RuneCountInString(body) > MaxCommentLength {
+		return "", fmt.Errorf("%w: comment exceeds %d characters", domain.ErrInvalid, MaxCommentLength)
+	}
+	return body, nil
+}
+
+// El cursor es opaco para el cliente: base64url del ultimo comment_id entregado.
+func encodeCommentCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+func decodeCommentCursor(cursor string) (uint, error) {
+	cursor = strings.TrimSpace(cursor)
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid cursor", domain.ErrInvalid)
+	}
+	id, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: invalid cursor", domain.ErrInvalid)
+	}
+	return uint(id), nil
+}
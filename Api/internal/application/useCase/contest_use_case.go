@@ -0,0 +1,94 @@
+package useCase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+)
+
+// MaxContestNameLength coincide con contest.name VARCHAR(120).
+const MaxContestNameLength = 120
+
+// ContestInput son los datos para crear un concurso. Las ventanas son semiabiertas [inicio, fin).
+type ContestInput struct {
+	Name               string
+	SubmissionStartsAt time.Time
+	SubmissionEndsAt   time.Time
+	VotingStartsAt     time.Time
+	VotingEndsAt       time.Time
+}
+
+// ContestUseCase gestiona los concursos por temporada y sus clasificaciones.
+type ContestUseCase struct {
+	repo interfaces.ContestRepository
+}
+
+func NewContestUseCase(repo interfaces.ContestRepository) *ContestUseCase {
+	return &ContestUseCase{repo: repo}
+}
+
+// Create valida y registra un concurso.
+// Rules:
+// - name is required, at most MaxContestNameLength characters.
+// - Each window must start before it ends.
+// - Voting cannot start before submissions open nor end before they close.
+func (uc *ContestUseCase) Create(ctx context.Context, in ContestInput) (*entities.Contest, error) {
+	name := strings.TrimSpace(in.Name)
+	if name == "" || utf8.RuneCountInString(name) > MaxContestNameLength {
+		return nil, fmt.Errorf("%w: name is required and must have at most %d characters", domain.ErrInvalid, MaxContestNameLength)
+	}
+	if !in.SubmissionStartsAt.Before(in.SubmissionEndsAt) {
+		return nil, fmt.Errorf("%w: submission window must start before it ends", domain.ErrInvalid)
+	}
+	if !in.VotingStartsAt.Before(in.VotingEndsAt) {
+		return nil, fmt.Errorf("%w: voting window must start before it ends", domain.ErrInvalid)
+	}
+	if in.VotingStartsAt.Before(in.SubmissionStartsAt) || in.VotingEndsAt.Before(in.SubmissionEndsAt) {
+		return nil, fmt.Errorf("%w: voting window must not precede the submission window", domain.ErrInvalid)
+	}
+	contest := &entities.Contest{
+		Name:               name,
+		SubmissionStartsAt: in.SubmissionStartsAt.UTC(),
+		SubmissionEndsAt:   in.SubmissionEndsAt.UTC(),
+		VotingStartsAt:     in.VotingStartsAt.UTC(),
+		VotingEndsAt:       in.VotingEndsAt.UTC(),
+	}
+	if err := uc.repo.Create(ctx, contest); err != nil {
+		return nil, err
+	}
+	return contest, nil
+}
+
+func (uc *ContestUseCase) List(ctx context.Context) ([]entities.Contest, error) {
+	return uc.repo.List(ctx)
+}
+
+func (uc *ContestUseCase) Get(ctx context.Context, contestID uint) (*entities.Contest, error) {
+	return uc.repo.GetByID(ctx, contestID)
+}
+
+// Close cierra la ronda: deja de aceptar votos y congela la clasificacion final.
+func (uc *ContestUseCase) Close(ctx context.Context, contestID uint) (*entities.Contest, error) {
+	contest, err := uc.repo.Close(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "contests: contest closed, standings frozen", "contest_id", contestID)
+	return contest, nil
+}
+
+// Rankings devuelve la clasificacion del concurso (domain.ErrNotFound si no existe).
+func (uc *ContestUseCase) Rankings(ctx context.Context, contestID uint, city *string, page, pageSize int) ([]responses.RankingItem, error) {
+	if _, err := uc.repo.GetByID(ctx, contestID); err != nil {
+		return nil, err
+	}
+	return uc.repo.Rankings(ctx, contestID, city, page, pageSize)
+}
@@ -0,0 +1,86 @@
+package useCase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+)
+
+// MediaURLService convierte claves de objeto almacenadas en URLs entregables,
+// aplicando las reglas de acceso por estado y propietario del video.
+type MediaURLService struct {
+	signer           interfaces.MediaURLSigner
+	processedPattern string
+}
+
+// NewMediaURLService crea el servicio. Si signer es nil se conserva el comportamiento
+// anterior: processedPattern (fmt con un %s) construye la URL publica sin firma.
+func NewMediaURLService(signer interfaces.MediaURLSigner, processedPattern string) *MediaURLService {
+	return &MediaURLService{signer: signer, processedPattern: processedPattern}
+}
+
+// OwnerURLs devuelve las URLs del original y del procesado para el propietario del video.
+// Reglas:
+// - Solo el propietario recibe URLs; cualquier otro solicitante obtiene nil.
+// - El procesado solo se expone cuando el pipeline termino (PROCESSED, en revision, PUBLISHED o REJECTED).
+func (s *MediaURLService) OwnerURLs(ctx context.Context, requesterID uint, v *entities.Video) (original, processed *string) {
+	if v == nil || requesterID == 0 || v.UserID != requesterID {
+		return nil, nil
+	}
+	if v.OriginalFile != "" {
+		original = s.resolve(ctx, interfaces.MediaOriginal, v.OriginalFile)
+	}
+	if v.ProcessedFile != nil && *v.ProcessedFile != "" && processedVisible(v.Status) {
+		processed = s.resolve(ctx, interfaces.MediaProcessed, *v.ProcessedFile)
+	}
+	return original, processed
+}
+
+// ReviewURL devuelve la URL del procesado para un moderador.
+// El llamador garantiza el privilegio de moderacion y que el video esta en revision.
+func (s *MediaURLService) ReviewURL(ctx context.Context, processedKey *string) *string {
+	return s.PublicURL(ctx, processedKey)
+}
+
+// PublicURL devuelve la URL del archivo procesado de un video publicado.
+// El llamador garantiza que el video esta PUBLISHED (los repositorios publicos ya filtran por estado).
+func (s *MediaURLService) PublicURL(ctx context.Context, processedKey *string) *string {
+	if processedKey == nil || *processedKey == "" {
+		return nil
+	}
+	return s.resolve(ctx, interfaces.MediaProcessed, *processedKey)
+}
+
+func (s *MediaURLService) resolve(ctx context.Context, kind interfaces.MediaKind, stored string) *string {
+	if s.signer != nil {
+		signed, err := s.signer.SignURL(ctx, kind, stored)
+		if err != nil {
+			slog.WarnContext(ctx, "media url signing failed", "kind", kind, "err", err)
+			return nil
+		}
+		return &signed
+	}
+	// Sin firmador: compatibilidad con URLs completas almacenadas o patron publico.
+	if kind == interfaces.MediaProcessed && !isAbsoluteURL(stored) && s.processedPattern != "" {
+		out := fmt.Sprintf(s.processedPattern, stored)
+		return &out
+	}
+	out := stored
+	return &out
+}
+
+func processedVisible(status string) bool {
+	switch entities.VideoStatus(status) {
+	case entities.StatusProcessed, entities.StatusPendingReview, entities.StatusPublished, entities.StatusRejected:
+		return true
+	}
+	return false
+}
+
+func isAbsoluteURL(v string) bool {
+	return strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://")
+}
@@ -0,0 +1,52 @@
+package useCase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"api/internal/domain"
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+)
+
+// MaxRejectionReasonLength coincide con video.rejection_reason VARCHAR(500).
+const MaxRejectionReasonLength = 500
+
+// ModerationUseCase gestiona la cola de revision previa a la publicacion.
+type ModerationUseCase struct {
+	repo interfaces.ModerationRepository
+}
+
+// NewModerationUseCase crea el caso de uso sobre el repositorio de moderacion.
+func NewModerationUseCase(repo interfaces.ModerationRepository) *ModerationUseCase {
+	return &ModerationUseCase{repo: repo}
+}
+
+// ListPending devuelve la cola de videos pendientes de revision.
+func (uc *ModerationUseCase) ListPending(ctx context.Context, page, pageSize int) ([]responses.ModerationQueueItem, error) {
+	return uc.repo.ListPending(ctx, page, pageSize)
+}
+
+// Approve publica un video pendiente de revision.
+func (uc *ModerationUseCase) Approve(ctx context.Context, moderatorID, videoID uint) error {
+	_, err := uc.repo.Approve(ctx, videoID, moderatorID)
+	return err
+}
+
+// Reject rechaza un video pendiente de revision; el repositorio deja la notificacion
+// para el propietario (GET /api/me/notifications) junto con la decision.
+// Rules:
+// - reason is required and at most MaxRejectionReasonLength characters.
+func (uc *ModerationUseCase) Reject(ctx context.Context, moderatorID, videoID uint, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("%w: reason is required", domain.ErrInvalid)
+	}
+	if utf8.RuneCountInString(reason) > MaxRejectionReasonLength {
+		return fmt.Errorf("%w: reason exceeds %d characters", domain.ErrInvalid, MaxRejectionReasonLength)
+	}
+	_, err := uc.repo.Reject(ctx, videoID, moderatorID, reason)
+	return err
+}
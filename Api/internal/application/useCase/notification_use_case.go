@@ -0,0 +1,34 @@
+package useCase
+
+import (
+	"context"
+
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+)
+
+// NotificationUseCase expone las notificaciones del usuario autenticado.
+type NotificationUseCase struct {
+	repo interfaces.NotificationRepository
+}
+
+func NewNotificationUseCase(repo interfaces.NotificationRepository) *NotificationUseCase {
+	return &NotificationUseCase{repo: repo}
+}
+
+// List devuelve una pagina de notificaciones de userID, las mas recientes primero.
+func (uc *NotificationUseCase) List(ctx context.Context, userID uint, page, pageSize int) ([]responses.NotificationResponse, error) {
+	items, err := uc.repo.ListByUser(ctx, userID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []responses.NotificationResponse{}
+	}
+	return items, nil
+}
+
+// MarkRead marca como leida una notificacion de userID. Errores: domain.ErrNotFound.
+func (uc *NotificationUseCase) MarkRead(ctx context.Context, userID, notificationID uint) error {
+	return uc.repo.MarkRead(ctx, userID, notificationID)
+}
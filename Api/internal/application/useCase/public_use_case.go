@@ -2,20 +2,76 @@ package useCase
 
 import (
 	"api/internal/domain"
+	"api/internal/domain/entities"
 	"api/internal/domain/interfaces"
 	"api/internal/domain/responses"
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // PublicService expone operaciones publicas relacionadas con videos.
 type PublicService struct {
 	repo     interfaces.PublicRepository
 	voteRepo interfaces.VoteRepository
+	contests interfaces.ContestRepository
+	budgets  []entities.VoteBudgetPolicy
+	fraud    *VoteFraudDetector
+	now      func() time.Time
 }
 
 func NewPublicService(repo interfaces.PublicRepository, voteRepo interfaces.VoteRepository) *PublicService {
-	return &PublicService{repo: repo, voteRepo: voteRepo}
+	return &PublicService{repo: repo, voteRepo: voteRepo, now: time.Now}
+}
+
+// WithContests habilita la ventana de votacion por concurso; sin repositorio de concursos
+// la votacion queda siempre abierta.
+func (s *PublicService) WithContests(contests interfaces.ContestRepository) *PublicService {
+	s.contests = contests
+	return s
+}
+
+// WithVoteBudget habilita presupuestos de votos por usuario. Requiere un repositorio
+// que implemente interfaces.VoteRepositoryWithBudget; de lo contrario no se aplican.
+func (s *PublicService) WithVoteBudget(policies []entities.VoteBudgetPolicy) *PublicService {
+	s.budgets = policies
+	return s
+}
+
+// WithFraudDetector habilita la evaluacion antifraude de cada voto. Los votos marcados se
+// guardan en cuarentena y no cuentan en los rankings hasta que un revisor los libere.
+// Requiere un repositorio que implemente interfaces.VoteRepositoryWithAudit.
+func (s *PublicService) WithFraudDetector(detector *VoteFraudDetector) *PublicService {
+	s.fraud = detector
+	return s
+}
+
+// WithClock permite fijar el reloj (tests).
+func (s *PublicService) WithClock(now func() time.Time) *PublicService {
+	s.now = now
+	return s
+}
+
+// ensureVotingOpen devuelve domain.ErrVotingClosed si el video participa en un concurso
+// cuya ventana de votacion no contiene el instante actual (o que ya fue cerrado).
+// Los videos sin concurso aceptan votos en cualquier momento.
+func (s *PublicService) ensureVotingOpen(ctx context.Context, videoID uint) error {
+	if s.contests == nil {
+		return nil
+	}
+	contest, err := s.contests.GetForVideo(ctx, videoID)
+	if err != nil {
+		return err
+	}
+	if contest != nil && !contest.VotingOpen(s.now()) {
+		return domain.ErrVotingClosed
+	}
+	return nil
 }
 
 // NewPublicServiceWithAgg permite inyectar un lector de agregados (Redis) sin acoplar al handler.
@@ -25,13 +81,148 @@ func (s *PublicService) ListPublicVideos(ctx context.Context) ([]responses.Publi
 	return s.repo.ListPublicVideos(ctx)
 }
 
+const (
+	// DefaultGalleryLimit y MaxGalleryLimit acotan el tamaño de pagina de la galeria publica.
+	DefaultGalleryLimit = 20
+	MaxGalleryLimit     = 100
+	// MaxGallerySearchLength limita el texto de busqueda por titulo.
+	MaxGallerySearchLength = 100
+)
+
+// SearchPublicVideos devuelve una pagina de la galeria publica.
+// cursor es el next_cursor de la pagina anterior y solo es valido con el mismo orden
+// (domain.ErrInvalid en caso contrario). Si el repositorio no soporta la galeria, la consulta
+// sin busqueda, filtros ni cursor devuelve el listado completo en una sola pagina.
+func (s *PublicService) SearchPublicVideos(ctx context.Context, q entities.GalleryQuery, cursor string) (*responses.PublicVideoPage, error) {
+	q.Search = strings.TrimSpace(q.Search)
+	if utf8.RuneCountInString(q.Search) > MaxGallerySearchLength {
+		return nil, fmt.Errorf("%w: search exceeds %d characters", domain.ErrInvalid, MaxGallerySearchLength)
+	}
+	if q.Sort == "" {
+		q.Sort = entities.GallerySortNewest
+	}
+	if q.Limit <= 0 || q.Limit > MaxGalleryLimit {
+		q.Limit = DefaultGalleryLimit
+	}
+	after, err := decodeGalleryCursor(cursor, q.Sort)
+	if err != nil {
+		return nil, err
+	}
+	q.After = after
+	if q.Sort == entities.GallerySortTrending {
+		// La ventana se fija en la primera pagina y viaja en el cursor: recalcularla en cada
+		// pagina moveria los conteos y el keyset podria saltar o repetir videos.
+		if after != nil {
+			q.TrendingSince = time.UnixMicro(after.Since).UTC()
+		} else {
+			q.TrendingSince = time.UnixMicro(s.now().Add(-entities.GalleryTrendingWindow).UnixMicro()).UTC()
+		}
+	}
+
+	gallery, ok := s.repo.(interfaces.PublicRepositoryWithGallery)
+	if !ok {
+		if !q.IsDefault() {
+			return nil, fmt.Errorf("%w: gallery filters not supported", domain.ErrInvalid)
+		}
+		items, err := s.repo.ListPublicVideos(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if items == nil {
+			items = []responses.PublicVideoResponse{}
+		}
+		return &responses.PublicVideoPage{Items: items}, nil
+	}
+
+	// Se pide un elemento extra para saber si existe una pagina siguiente
+	limit := q.Limit
+	q.Limit = limit + 1
+	rows, err := gallery.SearchPublicVideos(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	page := &responses.PublicVideoPage{Items: make([]responses.PublicVideoResponse, 0, min(len(rows), limit))}
+	for i, row := range rows {
+		if i == limit {
+			next := encodeGalleryCursor(galleryCursorFor(q, rows[limit-1]))
+			page.NextCursor = &next
+			break
+		}
+		page.Items = append(page.Items, row.PublicVideoResponse)
+	}
+	return page, nil
+}
+
+// PublicVideoDetail devuelve el video publico con el username del autor.
+// viewerID distinto de 0 agrega VotedByMe. Errores: domain.ErrNotFound.
+func (s *PublicService) PublicVideoDetail(ctx context.Context, videoID, viewerID uint) (*responses.PublicVideoDetail, error) {
+	video, err := s.repo.GetPublicByID(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if video == nil {
+		return nil, domain.ErrNotFound
+	}
+	detail := &responses.PublicVideoDetail{PublicVideoResponse: *video}
+	owners, err := s.repo.GetUsersBasicByIDs(ctx, []uint{video.OwnerUserID})
+	if err != nil {
+		return nil, err
+	}
+	if len(owners) > 0 {
+		detail.OwnerUsername = owners[0].Username
+	}
+	videos := []responses.PublicVideoResponse{detail.PublicVideoResponse}
+	if err := s.MarkVotedByMe(ctx, viewerID, videos); err != nil {
+		return nil, err
+	}
+	detail.VotedByMe = videos[0].VotedByMe
+	return detail, nil
+}
+
+// MarkVotedByMe completa VotedByMe de videos para viewerID (0 = anonimo, no hace nada).
+// Usa una sola consulta si el repositorio de votos implementa VoteRepositoryWithVotedLookup.
+func (s *PublicService) MarkVotedByMe(ctx context.Context, viewerID uint, videos []responses.PublicVideoResponse) error {
+	if viewerID == 0 || s.voteRepo == nil || len(videos) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(videos))
+	for i := range videos {
+		ids[i] = videos[i].VideoID
+	}
+
+	var voted map[uint]bool
+	if lookup, ok := s.voteRepo.(interfaces.VoteRepositoryWithVotedLookup); ok {
+		var err error
+		if voted, err = lookup.VotedVideoIDs(ctx, viewerID, ids); err != nil {
+			return err
+		}
+	} else {
+		voted = make(map[uint]bool, len(ids))
+		for _, id := range ids {
+			has, err := s.voteRepo.HasUserVoted(ctx, id, viewerID)
+			if err != nil {
+				return err
+			}
+			voted[id] = has
+		}
+	}
+
+	for i := range videos {
+		v := voted[videos[i].VideoID]
+		videos[i].VotedByMe = &v
+	}
+	return nil
+}
+
 // WithVotes was removed; voteRepo is injected in constructor.
 
 func (s *PublicService) GetPublicByID(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
 	return s.repo.GetPublicByID(ctx, id)
 }
 
-// VotePublicVideo aplica la regla de un voto por usuario por video
+// VotePublicVideo aplica la regla de un voto por usuario por video.
+// Si el video participa en un concurso, el voto debe llegar dentro de su ventana de votacion
+// (domain.ErrVotingClosed en caso contrario).
 func (s *PublicService) VotePublicVideo(ctx context.Context, videoID, userID uint) error {
 	if s.voteRepo == nil {
 		return errors.New("vote repository not configured")
@@ -43,6 +234,9 @@ func (s *PublicService) VotePublicVideo(ctx context.Context, videoID, userID uin
 		}
 		return err
 	}
+	if err := s.ensureVotingOpen(ctx, videoID); err != nil {
+		return err
+	}
 	// Verifica si ya voto
 	already, err := s.voteRepo.HasUserVoted(ctx, videoID, userID)
 	if err != nil {
@@ -51,6 +245,9 @@ func (s *PublicService) VotePublicVideo(ctx context.Context, videoID, userID uin
 	if already {
 		return domain.ErrConflict
 	}
+	if repoBudget, ok := s.budgetRepo(); ok {
+		return repoBudget.CreateWithBudget(ctx, videoID, userID, nil, s.budgets)
+	}
 	// Crear voto (con indice unico anti-race)
 	if err := s.voteRepo.Create(ctx, videoID, userID); err != nil {
 		return err
@@ -71,6 +268,13 @@ func (s *PublicService) VotePublicVideoWithEvent(ctx context.Context, videoID, u
 		}
 		return err
 	}
+	if err := s.ensureVotingOpen(ctx, videoID); err != nil {
+		return err
+	}
+	// Con presupuesto configurado el limite se verifica en la misma transaccion del insert.
+	if repoBudget, ok := s.budgetRepo(); ok {
+		return repoBudget.CreateWithBudget(ctx, videoID, userID, eventID, s.budgets)
+	}
 	// Insertar con eventID si el repo lo soporta, si no fallback a Create
 	if repoEvt, ok := interface{}(s.voteRepo).(interfaces.VoteRepositoryWithEvent); ok {
 		if err := repoEvt.CreateWithEvent(ctx, videoID, userID, eventID); err != nil {
@@ -87,12 +291,214 @@ func (s *PublicService) VotePublicVideoWithEvent(ctx context.Context, videoID, u
 	return nil
 }
 
+// VotePublicVideoWithMetadata registra el voto junto con los metadatos de la solicitud
+// (IP, hash del user agent, antiguedad de la cuenta) y el resultado de las reglas antifraude.
+// Sin detector o sin soporte de auditoria en el repositorio se comporta como
+// VotePublicVideoWithEvent (eventID no nil) o VotePublicVideo.
+func (s *PublicService) VotePublicVideoWithMetadata(ctx context.Context, videoID, userID uint, eventID *string, meta entities.VoteMetadata) error {
+	repoAudit, ok := interface{}(s.voteRepo).(interfaces.VoteRepositoryWithAudit)
+	if s.fraud == nil || !ok {
+		if eventID != nil {
+			return s.VotePublicVideoWithEvent(ctx, videoID, userID, eventID)
+		}
+		return s.VotePublicVideo(ctx, videoID, userID)
+	}
+	if _, err := s.repo.GetPublicByID(ctx, videoID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	if err := s.ensureVotingOpen(ctx, videoID); err != nil {
+		return err
+	}
+	if eventID == nil {
+		// Sin eventID un voto repetido es un conflicto; se evita evaluar reglas en vano.
+		already, err := s.voteRepo.HasUserVoted(ctx, videoID, userID)
+		if err != nil {
+			return err
+		}
+		if already {
+			return domain.ErrConflict
+		}
+	}
+	audit := s.fraud.Evaluate(ctx, videoID, userID, meta, s.now())
+	return repoAudit.CreateWithAudit(ctx, videoID, userID, eventID, audit, s.budgets)
+}
+
+// RetractVote retira el voto del usuario sobre un video publico.
+// Mismas reglas de idempotencia que el voto: un eventID repetido devuelve domain.ErrIdempotent.
+// Si el usuario no ha votado devuelve domain.ErrConflict.
+func (s *PublicService) RetractVote(ctx context.Context, videoID, userID uint, eventID *string) error {
+	if s.voteRepo == nil {
+		return errors.New("vote repository not configured")
+	}
+	repoRetract, ok := interface{}(s.voteRepo).(interfaces.VoteRepositoryWithRetract)
+	if !ok {
+		return errors.New("vote repository does not support retraction")
+	}
+	// Verificar existencia de video publico
+	if _, err := s.repo.GetPublicByID(ctx, videoID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	// Retirar un voto fuera de la ventana alteraria una clasificacion ya definida.
+	if err := s.ensureVotingOpen(ctx, videoID); err != nil {
+		return err
+	}
+	return repoRetract.Retract(ctx, videoID, userID, eventID)
+}
+
+// budgetRepo devuelve el repositorio con presupuestos si hay politicas configuradas.
+func (s *PublicService) budgetRepo() (interfaces.VoteRepositoryWithBudget, bool) {
+	if len(s.budgets) == 0 {
+		return nil, false
+	}
+	repoBudget, ok := interface{}(s.voteRepo).(interfaces.VoteRepositoryWithBudget)
+	return repoBudget, ok
+}
+
+// MyVotes devuelve el presupuesto restante del usuario y su historial de votos (vigentes y retirados).
+// El presupuesto por concurso se informa para cada concurso con la votacion abierta.
+func (s *PublicService) MyVotes(ctx context.Context, userID uint, page, pageSize int) (*responses.MyVotesResponse, error) {
+	repoBudget, ok := interface{}(s.voteRepo).(interfaces.VoteRepositoryWithBudget)
+	if !ok {
+		return nil, errors.New("vote repository does not support vote history")
+	}
+	now := s.now().UTC()
+	out := &responses.MyVotesResponse{Budgets: []responses.VoteBudgetStatus{}}
+	for _, p := range s.budgets {
+		if p.Limit <= 0 {
+			continue
+		}
+		switch p.Scope {
+		case entities.VoteBudgetDaily:
+			start := now.Truncate(24 * time.Hour)
+			resets := start.Add(24 * time.Hour)
+			used, err := repoBudget.CountVotes(ctx, userID, &start, nil)
+			if err != nil {
+				return nil, err
+			}
+			out.Budgets = append(out.Budgets, budgetStatus(p, used, nil, &resets))
+		case entities.VoteBudgetContest:
+			if s.contests == nil {
+				continue
+			}
+			contests, err := s.contests.List(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, ct := range contests {
+				if !ct.VotingOpen(now) {
+					continue
+				}
+				contestID := ct.ContestID
+				used, err := repoBudget.CountVotes(ctx, userID, nil, &contestID)
+				if err != nil {
+					return nil, err
+				}
+				out.Budgets = append(out.Budgets, budgetStatus(p, used, &contestID, nil))
+			}
+		}
+	}
+	votes, err := repoBudget.ListByUser(ctx, userID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if votes == nil {
+		votes = []responses.UserVoteItem{}
+	}
+	out.Votes = votes
+	return out, nil
+}
+
+func budgetStatus(p entities.VoteBudgetPolicy, used int, contestID *uint, resetsAt *time.Time) responses.VoteBudgetStatus {
+	remaining := p.Limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return responses.VoteBudgetStatus{
+		Scope:     string(p.Scope),
+		ContestID: contestID,
+		Limit:     p.Limit,
+		Used:      used,
+		Remaining: remaining,
+		ResetsAt:  resetsAt,
+	}
+}
+
+// ParseVoteBudgetPolicies interpreta una especificacion "alcance:limite" separada por comas,
+// por ejemplo "day:10,contest:5". Una cadena vacia no define politicas.
+func ParseVoteBudgetPolicies(spec string) ([]entities.VoteBudgetPolicy, error) {
+	var policies []entities.VoteBudgetPolicy
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		scope, rawLimit, found := strings.Cut(part, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid vote budget %q: expected scope:limit", part)
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(rawLimit))
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("invalid vote budget %q: limit must be a positive integer", part)
+		}
+		switch s := entities.VoteBudgetScope(strings.ToLower(strings.TrimSpace(scope))); s {
+		case entities.VoteBudgetDaily, entities.VoteBudgetContest:
+			policies = append(policies, entities.VoteBudgetPolicy{Scope: s, Limit: limit})
+		default:
+			return nil, fmt.Errorf("invalid vote budget %q: unknown scope", part)
+		}
+	}
+	return policies, nil
+}
+
 // Rankings retorna el ranking paginado por votos acumulados por usuario.
 func (s *PublicService) Rankings(ctx context.Context, city *string, page, pageSize int) ([]responses.RankingItem, error) {
 	// Orquestación: primero intentar desde agregados (Redis) si está disponible.
 	return s.repo.Rankings(ctx, city, page, pageSize)
 }
 
+// FilteredRankings retorna el ranking por usuario filtrado por ciudad, pais y ventana de tiempo.
+// Sin pais ni ventana equivale a Rankings.
+func (s *PublicService) FilteredRankings(ctx context.Context, filter entities.RankingFilter, page, pageSize int) ([]responses.RankingItem, error) {
+	if filter.IsAllTime() && (filter.Country == nil || *filter.Country == "") {
+		return s.repo.Rankings(ctx, filter.City, page, pageSize)
+	}
+	fr, ok := s.repo.(interfaces.PublicRepositoryWithRankingFilters)
+	if !ok {
+		return nil, fmt.Errorf("%w: ranking filters not supported", domain.ErrInvalid)
+	}
+	return fr.FilteredRankings(ctx, filter, page, pageSize)
+}
+
+// UserBasicByUsername resuelve un username publico. Errores: domain.ErrNotFound.
+func (s *PublicService) UserBasicByUsername(ctx context.Context, username string) (*responses.UserBasic, error) {
+	rl, ok := s.repo.(interfaces.PublicRepositoryWithRankLookup)
+	if !ok {
+		return nil, fmt.Errorf("%w: rank lookup not supported", domain.ErrInvalid)
+	}
+	return rl.UserBasicByUsername(ctx, username)
+}
+
+// RankNeighborhood devuelve la posicion del usuario en el ranking historico (global o de city)
+// junto a sus vecinos inmediatos; vacio si no esta rankeado.
+func (s *PublicService) RankNeighborhood(ctx context.Context, userID uint, city *string) ([]responses.RankedUserItem, error) {
+	rl, ok := s.repo.(interfaces.PublicRepositoryWithRankLookup)
+	if !ok {
+		return nil, fmt.Errorf("%w: rank lookup not supported", domain.ErrInvalid)
+	}
+	return rl.RankNeighborhood(ctx, userID, city)
+}
+
+// VideoRankings retorna el ranking paginado de videos por votos.
+func (s *PublicService) VideoRankings(ctx context.Context, city *string, page, pageSize int) ([]responses.VideoRankingItem, error) {
+	return s.repo.VideoRankings(ctx, city, page, pageSize)
+}
+
 // normalizeCityKey replica la normalización usada en handlers para claves por ciudad.
 // GetUsersBasicByIDs expone informacion basica de usuarios para enriquecer rankings desde Redis.
 // GetUsersBasicByIDs removido; se usaba solo para enriquecer rankings desde Redis.
@@ -104,3 +510,62 @@ func (s *PublicService) UserBasicsByIDs(ctx context.Context, ids []uint) ([]resp
 	}
 	return s.repo.GetUsersBasicByIDs(ctx, ids)
 }
+
+// galleryCursorFor arma el cursor que sigue a row en el orden de q.
+func galleryCursorFor(q entities.GalleryQuery, row responses.GalleryVideo) entities.GalleryCursor {
+	c := entities.GalleryCursor{Sort: q.Sort, VideoID: row.VideoID}
+	switch q.Sort {
+	case entities.GallerySortMostVoted:
+		c.Key = int64(row.Votes)
+	case entities.GallerySortTrending:
+		c.Key = int64(row.TrendingVotes)
+		c.Since = q.TrendingSince.UnixMicro()
+	default:
+		c.Key = row.UploadedAt.UnixMicro()
+	}
+	return c
+}
+
+// El cursor de la galeria es opaco para el cliente: base64url de "orden:clave:video_id";
+// trending agrega ":inicio_de_ventana".
+func encodeGalleryCursor(c entities.GalleryCursor) string {
+	raw := fmt.Sprintf("%s:%d:%d", c.Sort, c.Key, c.VideoID)
+	if c.Sort == entities.GallerySortTrending {
+		raw += fmt.Sprintf(":%d", c.Since)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeGalleryCursor(cursor string, sort entities.GallerySort) (*entities.GalleryCursor, error) {
+	cursor = strings.TrimSpace(cursor)
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", domain.ErrInvalid)
+	}
+	parts := strings.Split(string(raw), ":")
+	want := 3
+	if sort == entities.GallerySortTrending {
+		want = 4
+	}
+	if len(parts) != want || entities.GallerySort(parts[0]) != sort {
+		return nil, fmt.Errorf("%w: invalid cursor", domain.ErrInvalid)
+	}
+	key, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", domain.ErrInvalid)
+	}
+	id, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil || id == 0 {
+		return nil, fmt.Errorf("%w: invalid cursor", domain.ErrInvalid)
+	}
+	c := &entities.GalleryCursor{Sort: sort, Key: key, VideoID: uint(id)}
+	if want == 4 {
+		if c.Since, err = strconv.ParseInt(parts[3], 10, 64); err != nil || c.Since <= 0 {
+			return nil, fmt.Errorf("%w: invalid cursor", domain.ErrInvalid)
+		}
+	}
+	return c, nil
+}
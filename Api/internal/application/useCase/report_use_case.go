@@ -0,0 +1,97 @@
+package useCase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+)
+
+// DefaultReportHideThreshold es el numero de denuncias abiertas que oculta un video automaticamente.
+const DefaultReportHideThreshold = 5
+
+// MaxReportDetailsLength coincide con video_report.details VARCHAR(500).
+const MaxReportDetailsLength = 500
+
+// ReportUseCase gestiona denuncias de usuarios y el triage de administradores.
+type ReportUseCase struct {
+	publicRepo    interfaces.PublicRepository
+	repo          interfaces.ReportRepository
+	hideThreshold int
+}
+
+// NewReportUseCase crea el caso de uso; hideThreshold <= 0 usa DefaultReportHideThreshold.
+func NewReportUseCase(publicRepo interfaces.PublicRepository, repo interfaces.ReportRepository, hideThreshold int) *ReportUseCase {
+	if hideThreshold <= 0 {
+		hideThreshold = DefaultReportHideThreshold
+	}
+	return &ReportUseCase{publicRepo: publicRepo, repo: repo, hideThreshold: hideThreshold}
+}
+
+// ReportVideo registra la denuncia de userID sobre un video publico.
+// Rules:
+// - category must be one of entities.AllReportCategories (case-insensitive).
+// - details is optional, at most MaxReportDetailsLength characters.
+// - The video must be publicly visible (domain.ErrNotFound otherwise).
+// - One report per user and video (domain.ErrConflict on duplicates).
+func (uc *ReportUseCase) ReportVideo(ctx context.Context, userID, videoID uint, category, details string) error {
+	cat, ok := parseReportCategory(category)
+	if !ok {
+		return fmt.Errorf("%w: unknown report category", domain.ErrInvalid)
+	}
+	details = strings.TrimSpace(details)
+	if utf8.RuneCountInString(details) > MaxReportDetailsLength {
+		return fmt.Errorf("%w: details exceed %d characters", domain.ErrInvalid, MaxReportDetailsLength)
+	}
+	if _, err := uc.publicRepo.GetPublicByID(ctx, videoID); err != nil {
+		return err
+	}
+	report := &entities.VideoReport{
+		VideoID:    videoID,
+		ReporterID: userID,
+		Category:   string(cat),
+		Status:     string(entities.ReportOpen),
+	}
+	if details != "" {
+		report.Details = &details
+	}
+	hidden, err := uc.repo.Create(ctx, report, uc.hideThreshold)
+	if err != nil {
+		return err
+	}
+	if hidden {
+		slog.InfoContext(ctx, "reports: video hidden after reaching open reports threshold", "video_id", videoID, "threshold", uc.hideThreshold)
+	}
+	return nil
+}
+
+// ListOpen devuelve la cola de triage.
+func (uc *ReportUseCase) ListOpen(ctx context.Context, page, pageSize int) ([]responses.ReportResponse, error) {
+	return uc.repo.ListOpen(ctx, page, pageSize)
+}
+
+// Dismiss descarta una denuncia; el video vuelve a ser visible si deja de alcanzar el umbral.
+func (uc *ReportUseCase) Dismiss(ctx context.Context, adminID, reportID uint) error {
+	return uc.repo.Dismiss(ctx, reportID, adminID, uc.hideThreshold)
+}
+
+// TakeDown retira el video denunciado de forma definitiva.
+func (uc *ReportUseCase) TakeDown(ctx context.Context, adminID, reportID uint) (uint, error) {
+	return uc.repo.TakeDown(ctx, reportID, adminID)
+}
+
+func parseReportCategory(raw string) (entities.ReportCategory, bool) {
+	candidate := entities.ReportCategory(strings.ToUpper(strings.TrimSpace(raw)))
+	for _, c := range entities.AllReportCategories() {
+		if c == candidate {
+			return c, true
+		}
+	}
+	return "", false
+}
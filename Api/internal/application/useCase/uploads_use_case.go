@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"mime/multipart"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"api/internal/domain"
 	"api/internal/domain/entities"
 	"api/internal/domain/interfaces"
+	"api/internal/logging"
 
 	"github.com/google/uuid"
 )
@@ -106,33 +109,32 @@ func (uc *UploadsUseCase) persistVideo(ctx context.Context, userID uint, input U
 	return video, nil
 }
 
-func (uc *UploadsUseCase) publishVideo(videoID uint) {
+func (uc *UploadsUseCase) publishVideo(ctx context.Context, videoID uint) {
 	// Publish the saved video ID for async processing.
 	// Do not fail the upload if messaging fails.
-	fmt.Printf("DEBUG: Checking publisher - publisher nil: %t, queue: '%s'\n", uc.publisher == nil, uc.queue)
+	ctx = logging.WithVideoID(ctx, strconv.FormatUint(uint64(videoID), 10))
 	if uc.publisher == nil || strings.TrimSpace(uc.queue) == "" {
-		if uc.publisher == nil {
-			fmt.Printf("ERROR: Publisher is nil, cannot publish message for video ID: %d\n", videoID)
-		} else {
-			fmt.Printf("ERROR: Queue name is empty, cannot publish message for video ID: %d\n", videoID)
-		}
+		slog.ErrorContext(ctx, "uploads: cannot publish video for processing",
+			"publisher_configured", uc.publisher != nil, "queue", uc.queue)
 		return
 	}
+	// correlation_id lleva el X-Request-ID de la subida a los logs de todos los workers.
 	payload := struct {
-		VideoID string `json:"videoId"`
-	}{VideoID: fmt.Sprintf("%d", videoID)}
+		VideoID       string `json:"videoId"`
+		CorrelationID string `json:"correlation_id,omitempty"`
+	}{VideoID: fmt.Sprintf("%d", videoID), CorrelationID: logging.RequestID(ctx)}
 
 	b, err := json.Marshal(payload)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to marshal message payload: %v\n", err)
+		slog.ErrorContext(ctx, "uploads: marshal message payload", "err", err)
 		return
 	}
-	fmt.Printf("DEBUG: Publishing message for video ID: %d to queue: %s, payload: %s\n", videoID, uc.queue, string(b))
-	if publishErr := uc.publisher.Publish(uc.queue, b); publishErr != nil {
-		fmt.Printf("ERROR: Failed to publish message to queue %s: %v\n", uc.queue, publishErr)
+	slog.DebugContext(ctx, "uploads: publishing video", "queue", uc.queue, "payload", string(b))
+	if publishErr := uc.publisher.Publish(ctx, uc.queue, b); publishErr != nil {
+		slog.ErrorContext(ctx, "uploads: publish video", "queue", uc.queue, "err", publishErr)
 		return
 	}
-	fmt.Printf("SUCCESS: Message published to queue %s for video ID: %d\n", uc.queue, videoID)
+	slog.InfoContext(ctx, "uploads: video published for processing", "queue", uc.queue)
 }
 
 // UploadMultipart handles the classic multipart upload path.
@@ -184,7 +186,7 @@ func (uc *UploadsUseCase) UploadMultipart(ctx context.Context, input UploadVideo
 		return nil, err
 	}
 
-	uc.publishVideo(video.VideoID)
+	uc.publishVideo(ctx, video.VideoID)
 
 	return &UploadVideoOutput{
 		VideoID:      video.VideoID,
@@ -205,15 +207,17 @@ func (uc *UploadsUseCase) GetUserVideoByID(ctx context.Context, userID, videoID
 }
 
 // DeleteUserVideoIfEligible deletes a user's own video if it meets business rules.
-// Rules: owner-only, and cannot delete if the video is already processed (published for voting).
+// Rules: owner-only, and cannot delete if the video is already processed (published for voting)
+// or waiting for moderation.
 func (uc *UploadsUseCase) DeleteUserVideoIfEligible(ctx context.Context, userID, videoID uint) error {
 	// Ensure ownership and existence
 	v, err := uc.videoRepo.GetByIDAndUser(ctx, videoID, userID)
 	if err != nil {
 		return err
 	}
-	// Eligibility: not allowed if processed or published (published for voting)
-	if v.Status == string(entities.StatusPublished) || v.Status == string(entities.StatusProcessed) {
+	// Eligibility: not allowed if processed, under review or published (published for voting)
+	switch entities.VideoStatus(v.Status) {
+	case entities.StatusPublished, entities.StatusProcessed, entities.StatusPendingReview:
 		return domain.ErrInvalid
 	}
 	// Proceed to delete
@@ -223,24 +227,26 @@ func (uc *UploadsUseCase) DeleteUserVideoIfEligible(ctx context.Context, userID,
 	return nil
 }
 
-// PublishVideo sets a processed video to PUBLISHED status.
+// PublishVideo submits the owner's processed video to moderation (PENDING_REVIEW).
+// A moderator later approves (PUBLISHED) or rejects (REJECTED) it.
 // Rules:
-// - Video must exist and be in PROCESSED status.
-// - processed_file should be present (best-effort validation).
-// - Idempotent: if already PUBLISHED, returns nil.
-func (uc *UploadsUseCase) PublishVideo(ctx context.Context, videoID uint) error {
-	v, err := uc.videoRepo.GetByID(ctx, videoID)
+// - Owner-only: domain.ErrForbidden if the video belongs to another user.
+// - Video must be in PROCESSED status with processed_file present.
+// - Idempotent: if already PENDING_REVIEW or PUBLISHED, returns nil.
+func (uc *UploadsUseCase) PublishVideo(ctx context.Context, userID, videoID uint) error {
+	v, err := uc.videoRepo.GetByIDAndUser(ctx, videoID, userID)
 	if err != nil {
 		return err
 	}
-	if v.Status == string(entities.StatusPublished) || v.Status == string(entities.StatusProcessed) {
+	switch entities.VideoStatus(v.Status) {
+	case entities.StatusPendingReview, entities.StatusPublished:
 		return nil
-	}
-	if v.Status != string(entities.StatusProcessed) {
+	case entities.StatusProcessed:
+	default:
 		return domain.ErrInvalid
 	}
 	if v.ProcessedFile == nil || *v.ProcessedFile == "" {
 		return domain.ErrInvalid
 	}
-	return uc.videoRepo.UpdateStatus(ctx, videoID, entities.StatusPublished)
+	return uc.videoRepo.UpdateStatus(ctx, videoID, entities.StatusPendingReview)
 }
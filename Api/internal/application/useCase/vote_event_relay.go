@@ -0,0 +1,167 @@
+package useCase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"api/internal/domain/interfaces"
+)
+
+// Valores por defecto del relay de eventos de voto.
+const (
+	DefaultVoteEventsExchange     = "votes"
+	DefaultVoteEventRelayBatch    = 100
+	DefaultVoteEventRelayInterval = 500 * time.Millisecond
+	DefaultVoteEventRetention     = 7 * 24 * time.Hour
+	defaultVoteEventRelayLease    = 30 * time.Second
+	defaultVoteEventPruneEvery    = time.Hour
+	voteEventPruneBatch           = 1000
+)
+
+const voteEventRelaySkippedAfterFail = "not attempted: earlier message in batch failed"
+
+// VoteEventRelay publica en un exchange topic los eventos vote.cast / vote.retracted que los
+// repositorios escriben en el outbox dentro de la transaccion del voto. Corre en segundo plano,
+// asi la respuesta del voto no espera al broker; si RabbitMQ no esta disponible los eventos
+// quedan pendientes y se reintentan (entrega al menos una vez, message_id = outbox_id).
+// Los mensajes ya publicados se borran cuando superan la retencion.
+type VoteEventRelay struct {
+	outbox     interfaces.VoteEventOutbox
+	publisher  interfaces.EventPublisher
+	exchange   string
+	batch      int
+	interval   time.Duration
+	lease      time.Duration
+	retention  time.Duration
+	pruneEvery time.Duration
+	now        func() time.Time
+}
+
+// NewVoteEventRelay crea el relay; exchange vacio usa DefaultVoteEventsExchange.
+func NewVoteEventRelay(outbox interfaces.VoteEventOutbox, publisher interfaces.EventPublisher, exchange string) *VoteEventRelay {
+	if exchange == "" {
+		exchange = DefaultVoteEventsExchange
+	}
+	return &VoteEventRelay{
+		outbox:     outbox,
+		publisher:  publisher,
+		exchange:   exchange,
+		batch:      DefaultVoteEventRelayBatch,
+		interval:   DefaultVoteEventRelayInterval,
+		lease:      defaultVoteEventRelayLease,
+		retention:  DefaultVoteEventRetention,
+		pruneEvery: defaultVoteEventPruneEvery,
+		now:        time.Now,
+	}
+}
+
+// WithPollInterval ajusta cada cuanto se consulta el outbox cuando no hay pendientes.
+func (r *VoteEventRelay) WithPollInterval(d time.Duration) *VoteEventRelay {
+	if d > 0 {
+		r.interval = d
+	}
+	return r
+}
+
+// WithRetention ajusta cuanto se conservan los mensajes ya publicados.
+func (r *VoteEventRelay) WithRetention(d time.Duration) *VoteEventRelay {
+	if d > 0 {
+		r.retention = d
+	}
+	return r
+}
+
+// WithClock reemplaza el reloj usado para calcular el corte de retencion (tests).
+func (r *VoteEventRelay) WithClock(now func() time.Time) *VoteEventRelay {
+	if now != nil {
+		r.now = now
+	}
+	return r
+}
+
+// Run publica eventos hasta que ctx se cancela. Una vez por pruneEvery tambien borra
+// los mensajes publicados que superan la retencion.
+func (r *VoteEventRelay) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	var nextPrune time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		n, err := r.RelayOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "vote events: relay failed", "err", err)
+		}
+		if now := r.now(); !now.Before(nextPrune) {
+			nextPrune = now.Add(r.pruneEvery)
+			if deleted, err := r.PruneOnce(ctx); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "vote events: prune failed", "err", err)
+			} else if deleted > 0 {
+				slog.InfoContext(ctx, "vote events: pruned published messages", "deleted", deleted)
+			}
+		}
+		// Un lote completo sugiere mas pendientes: se sigue sin esperar.
+		if err == nil && n == r.batch {
+			timer.Reset(0)
+		} else {
+			timer.Reset(r.interval)
+		}
+	}
+}
+
+// RelayOnce publica un lote en orden y devuelve cuantos mensajes se publicaron.
+// Se detiene en el primer error para no adelantar eventos posteriores del mismo lote;
+// los no publicados se liberan para el siguiente ciclo.
+func (r *VoteEventRelay) RelayOnce(ctx context.Context) (int, error) {
+	msgs, err := r.outbox.ClaimPending(ctx, r.batch, r.lease)
+	if err != nil {
+		return 0, err
+	}
+	published := make([]uint64, 0, len(msgs))
+	var publishErr error
+	for i, m := range msgs {
+		if err := r.publisher.PublishEvent(ctx, r.exchange, m.RoutingKey, strconv.FormatUint(m.ID, 10), m.Payload); err != nil {
+			publishErr = fmt.Errorf("publish outbox %d: %w", m.ID, err)
+			r.release(ctx, m.ID, err.Error())
+			for _, rest := range msgs[i+1:] {
+				r.release(ctx, rest.ID, voteEventRelaySkippedAfterFail)
+			}
+			break
+		}
+		published = append(published, m.ID)
+	}
+	if err := r.outbox.MarkPublished(ctx, published); err != nil {
+		// Se volveran a publicar al vencer el lease; los consumidores deduplican por message_id.
+		return 0, fmt.Errorf("mark published: %w", err)
+	}
+	return len(published), publishErr
+}
+
+// PruneOnce borra por lotes los mensajes publicados antes de now - retention y devuelve
+// cuantos borro. Varias instancias pueden hacerlo a la vez: cada DELETE es independiente.
+func (r *VoteEventRelay) PruneOnce(ctx context.Context) (int64, error) {
+	before := r.now().Add(-r.retention)
+	var total int64
+	for {
+		n, err := r.outbox.DeletePublishedBefore(ctx, before, voteEventPruneBatch)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < voteEventPruneBatch || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
+
+func (r *VoteEventRelay) release(ctx context.Context, id uint64, reason string) {
+	if err := r.outbox.MarkFailed(ctx, id, reason); err != nil {
+		slog.ErrorContext(ctx, "vote events: release outbox", "outbox_id", id, "err", err)
+	}
+}
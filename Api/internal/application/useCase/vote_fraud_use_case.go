@@ -0,0 +1,172 @@
+package useCase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"time"
+
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+)
+
+// Nombres de regla guardados en vote.quarantine_flags.
+const (
+	VoteRuleVelocity   = "velocity"
+	VoteRuleSharedIP   = "shared_ip"
+	VoteRuleNewAccount = "new_account"
+)
+
+// Valores por defecto de las reglas antifraude.
+const (
+	DefaultVoteVelocityMax    = 10
+	DefaultVoteVelocityWindow = time.Minute
+	DefaultVoteIPMaxAccounts  = 3
+	DefaultVoteIPWindow       = 24 * time.Hour
+	DefaultVoteMinAccountAge  = 24 * time.Hour
+)
+
+// VoteRuleInput es la informacion disponible para evaluar un voto antes de insertarlo.
+type VoteRuleInput struct {
+	VideoID    uint
+	UserID     uint
+	Metadata   entities.VoteMetadata
+	AccountAge time.Duration
+	Now        time.Time
+}
+
+// VoteRule decide si un voto es sospechoso. Name se guarda como motivo de la cuarentena.
+type VoteRule interface {
+	Name() string
+	Flag(ctx context.Context, in VoteRuleInput, signals interfaces.VoteSignalReader) (bool, error)
+}
+
+// VelocityRule marca al usuario que ya emitio MaxVotes o mas votos dentro de Window.
+type VelocityRule struct {
+	MaxVotes int
+	Window   time.Duration
+}
+
+func (r VelocityRule) Name() string { return VoteRuleVelocity }
+
+func (r VelocityRule) Flag(ctx context.Context, in VoteRuleInput, signals interfaces.VoteSignalReader) (bool, error) {
+	count, err := signals.CountUserVotesSince(ctx, in.UserID, in.Now.Add(-r.Window))
+	if err != nil {
+		return false, err
+	}
+	return count >= r.MaxVotes, nil
+}
+
+// SharedIPRule marca el voto cuando MaxAccounts o mas cuentas distintas ya votaron
+// desde la misma IP dentro de Window.
+type SharedIPRule struct {
+	MaxAccounts int
+	Window      time.Duration
+}
+
+func (r SharedIPRule) Name() string { return VoteRuleSharedIP }
+
+func (r SharedIPRule) Flag(ctx context.Context, in VoteRuleInput, signals interfaces.VoteSignalReader) (bool, error) {
+	if in.Metadata.IP == "" {
+		return false, nil
+	}
+	count, err := signals.CountOtherAccountsByIPSince(ctx, in.Metadata.IP, in.UserID, in.Now.Add(-r.Window))
+	if err != nil {
+		return false, err
+	}
+	return count >= r.MaxAccounts, nil
+}
+
+// NewAccountRule marca los votos de cuentas creadas hace menos de MinAge.
+type NewAccountRule struct {
+	MinAge time.Duration
+}
+
+func (r NewAccountRule) Name() string { return VoteRuleNewAccount }
+
+func (r NewAccountRule) Flag(_ context.Context, in VoteRuleInput, _ interfaces.VoteSignalReader) (bool, error) {
+	return in.AccountAge < r.MinAge, nil
+}
+
+// DefaultVoteRules devuelve las reglas con sus valores por defecto.
+func DefaultVoteRules() []VoteRule {
+	return []VoteRule{
+		VelocityRule{MaxVotes: DefaultVoteVelocityMax, Window: DefaultVoteVelocityWindow},
+		SharedIPRule{MaxAccounts: DefaultVoteIPMaxAccounts, Window: DefaultVoteIPWindow},
+		NewAccountRule{MinAge: DefaultVoteMinAccountAge},
+	}
+}
+
+// VoteFraudDetector evalua las reglas sobre cada voto y arma el registro de auditoria.
+type VoteFraudDetector struct {
+	signals interfaces.VoteSignalReader
+	rules   []VoteRule
+}
+
+func NewVoteFraudDetector(signals interfaces.VoteSignalReader, rules ...VoteRule) *VoteFraudDetector {
+	return &VoteFraudDetector{signals: signals, rules: rules}
+}
+
+// Evaluate devuelve la auditoria del voto con las reglas que lo marcaron.
+// Si una senal no se puede consultar la regla se omite (fail-open) y se registra en el log:
+// una base lenta no debe impedir votar, y el voto igual queda con sus metadatos para revision.
+func (d *VoteFraudDetector) Evaluate(ctx context.Context, videoID, userID uint, meta entities.VoteMetadata, now time.Time) entities.VoteAudit {
+	audit := entities.VoteAudit{Metadata: meta}
+	in := VoteRuleInput{VideoID: videoID, UserID: userID, Metadata: meta, Now: now}
+	if createdAt, err := d.signals.AccountCreatedAt(ctx, userID); err != nil {
+		slog.WarnContext(ctx, "vote fraud: account age unavailable", "user_id", userID, "err", err)
+		// Sin fecha de alta no se puede evaluar la antiguedad; se asume una cuenta antigua.
+		in.AccountAge = time.Duration(1<<63 - 1)
+	} else {
+		in.AccountAge = now.Sub(createdAt)
+		audit.AccountAgeSeconds = int64(in.AccountAge / time.Second)
+	}
+	for _, rule := range d.rules {
+		flagged, err := rule.Flag(ctx, in, d.signals)
+		if err != nil {
+			slog.WarnContext(ctx, "vote fraud: rule skipped", "rule", rule.Name(), "user_id", userID, "video_id", videoID, "err", err)
+			continue
+		}
+		if flagged {
+			audit.Flags = append(audit.Flags, rule.Name())
+		}
+	}
+	return audit
+}
+
+// NewVoteMetadata arma los metadatos de la solicitud; el user agent se guarda como SHA-256 hex.
+func NewVoteMetadata(ip, userAgent string) entities.VoteMetadata {
+	meta := entities.VoteMetadata{IP: strings.TrimSpace(ip)}
+	if ua := strings.TrimSpace(userAgent); ua != "" {
+		sum := sha256.Sum256([]byte(ua))
+		meta.UserAgentHash = hex.EncodeToString(sum[:])
+	}
+	return meta
+}
+
+// VoteReviewUseCase gestiona la revision de votos en cuarentena.
+type VoteReviewUseCase struct {
+	repo interfaces.VoteQuarantineRepository
+}
+
+func NewVoteReviewUseCase(repo interfaces.VoteQuarantineRepository) *VoteReviewUseCase {
+	return &VoteReviewUseCase{repo: repo}
+}
+
+// ListQuarantined devuelve la cola de revision.
+func (uc *VoteReviewUseCase) ListQuarantined(ctx context.Context, page, pageSize int) ([]responses.QuarantinedVote, error) {
+	return uc.repo.ListQuarantined(ctx, page, pageSize)
+}
+
+// Clear libera el voto para que cuente en los rankings.
+func (uc *VoteReviewUseCase) Clear(ctx context.Context, reviewerID, voteID uint) error {
+	return uc.repo.Clear(ctx, voteID, reviewerID)
+}
+
+// Discard elimina el voto; queda archivado en vote_history con sus motivos de cuarentena.
+func (uc *VoteReviewUseCase) Discard(ctx context.Context, reviewerID, voteID uint) error {
+	return uc.repo.Discard(ctx, voteID, reviewerID)
+}
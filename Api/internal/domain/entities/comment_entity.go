@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+type Comment struct {
+	CommentID uint `gorm:"column:comment_id;primaryKey;autoIncrement"`
+	VideoID   uint `gorm:"column:video_id;not null"`
+	UserID    uint `gorm:"column:user_id;not null"`
+	// ParentID es nil para comentarios de primer nivel; las respuestas no admiten mas anidamiento
+	ParentID  *uint      `gorm:"column:parent_id"`
+	Body      string     `gorm:"column:body;size:1000;not null"`
+	CreatedAt time.Time  `gorm:"column:created_at;not null;autoCreateTime"`
+	UpdatedAt *time.Time `gorm:"column:updated_at;autoUpdateTime:false"`
+}
+
+func (Comment) TableName() string { return "comment" }
@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+// Contest es una ronda de la liga con ventanas de postulacion y de votacion.
+// Las ventanas son semiabiertas: [inicio, fin).
+type Contest struct {
+	ContestID          uint       `gorm:"column:contest_id;primaryKey;autoIncrement"`
+	Name               string     `gorm:"column:name;size:120;not null"`
+	SubmissionStartsAt time.Time  `gorm:"column:submission_starts_at;not null"`
+	SubmissionEndsAt   time.Time  `gorm:"column:submission_ends_at;not null"`
+	VotingStartsAt     time.Time  `gorm:"column:voting_starts_at;not null"`
+	VotingEndsAt       time.Time  `gorm:"column:voting_ends_at;not null"`
+	ClosedAt           *time.Time `gorm:"column:closed_at"`
+	CreatedAt          time.Time  `gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+func (Contest) TableName() string { return "contest" }
+
+// VotingOpen indica si se aceptan votos en now. Un concurso cerrado no acepta votos
+// aunque su ventana siga vigente.
+func (c Contest) VotingOpen(now time.Time) bool {
+	if c.ClosedAt != nil {
+		return false
+	}
+	return !now.Before(c.VotingStartsAt) && now.Before(c.VotingEndsAt)
+}
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// GallerySort define el orden de la galeria publica de videos.
+type GallerySort string
+
+const (
+	// GallerySortNewest ordena por fecha de subida, los mas recientes primero.
+	GallerySortNewest GallerySort = "newest"
+	// GallerySortMostVoted ordena por votos contables acumulados.
+	GallerySortMostVoted GallerySort = "most_voted"
+	// GallerySortTrending ordena por votos contables de las ultimas GalleryTrendingWindow.
+	GallerySortTrending GallerySort = "trending"
+)
+
+// GalleryTrendingWindow es el periodo de votos que cuenta para GallerySortTrending.
+const GalleryTrendingWindow = 72 * time.Hour
+
+// ParseGallerySort valida el parametro sort; vacio equivale a GallerySortNewest.
+func ParseGallerySort(raw string) (GallerySort, bool) {
+	switch s := GallerySort(strings.ToLower(strings.TrimSpace(raw))); s {
+	case "", GallerySortNewest:
+		return GallerySortNewest, true
+	case GallerySortMostVoted, GallerySortTrending:
+		return s, true
+	default:
+		return "", false
+	}
+}
+
+// GalleryCursor es la posicion del ultimo video entregado (keyset pagination).
+// Key es uploaded_at en microsegundos Unix para GallerySortNewest y el conteo de votos
+// para GallerySortMostVoted y GallerySortTrending. Since (microsegundos Unix) guarda el
+// inicio de la ventana de trending de la primera pagina para que el orden no cambie entre paginas.
+type GalleryCursor struct {
+	Sort    GallerySort
+	Key     int64
+	VideoID uint
+	Since   int64
+}
+
+// GalleryQuery agrupa busqueda, filtros, orden y pagina de la galeria publica.
+// Search es texto libre sobre el titulo (sin tildes ni mayusculas); City y Country filtran
+// por la ubicacion del autor como en RankingFilter; OwnerUserID limita a los videos de un autor.
+// TrendingSince es el inicio de la ventana de GallerySortTrending (lo fija el servicio).
+type GalleryQuery struct {
+	Search        string
+	City          *string
+	Country       *string
+	OwnerUserID   *uint
+	Sort          GallerySort
+	After         *GalleryCursor
+	Limit         int
+	TrendingSince time.Time
+}
+
+// IsDefault indica si la consulta equivale al listado completo sin filtros.
+func (q GalleryQuery) IsDefault() bool {
+	return q.Search == "" &&
+		(q.City == nil || *q.City == "") &&
+		(q.Country == nil || *q.Country == "") &&
+		q.OwnerUserID == nil &&
+		(q.Sort == "" || q.Sort == GallerySortNewest) &&
+		q.After == nil
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// Tipos de notificacion (notification.type).
+const (
+	// NotificationVideoRejected avisa al propietario que su video fue rechazado en moderacion.
+	NotificationVideoRejected = "video.rejected"
+)
+
+// Notification es un aviso para un usuario; Payload es JSON y depende de Type.
+type Notification struct {
+	NotificationID uint       `gorm:"column:notification_id;primaryKey;autoIncrement"`
+	UserID         uint       `gorm:"column:user_id;not null"`
+	Type           string     `gorm:"column:type;size:64;not null"`
+	Payload        string     `gorm:"column:payload;type:jsonb;not null"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null;autoCreateTime"`
+	ReadAt         *time.Time `gorm:"column:read_at"`
+}
+
+func (Notification) TableName() string { return "notification" }
+
+// VideoRejectedPayload es el payload de NotificationVideoRejected.
+type VideoRejectedPayload struct {
+	VideoID uint   `json:"video_id"`
+	Title   string `json:"title"`
+	Reason  string `json:"reason"`
+}
@@ -0,0 +1,42 @@
+package entities
+
+import "strings"
+
+// RankingWindow limita el ranking a los votos emitidos en el periodo calendario en curso (UTC).
+type RankingWindow string
+
+const (
+	// RankingWindowAll cuenta todos los votos (ranking historico).
+	RankingWindowAll RankingWindow = "all"
+	// RankingWindowDay cuenta los votos desde la medianoche UTC.
+	RankingWindowDay RankingWindow = "day"
+	// RankingWindowWeek cuenta los votos desde el lunes 00:00 UTC.
+	RankingWindowWeek RankingWindow = "week"
+	// RankingWindowMonth cuenta los votos desde el dia 1 del mes a las 00:00 UTC.
+	RankingWindowMonth RankingWindow = "month"
+)
+
+// ParseRankingWindow valida el parametro window; vacio equivale a RankingWindowAll.
+func ParseRankingWindow(raw string) (RankingWindow, bool) {
+	switch w := RankingWindow(strings.ToLower(strings.TrimSpace(raw))); w {
+	case "", RankingWindowAll:
+		return RankingWindowAll, true
+	case RankingWindowDay, RankingWindowWeek, RankingWindowMonth:
+		return w, true
+	default:
+		return "", false
+	}
+}
+
+// RankingFilter agrupa los filtros del ranking por usuario.
+// City y Country comparan por nombre sin tildes ni mayusculas; Country acepta tambien el codigo ISO.
+type RankingFilter struct {
+	City    *string
+	Country *string
+	Window  RankingWindow
+}
+
+// IsAllTime indica si el filtro cubre todos los votos.
+func (f RankingFilter) IsAllTime() bool {
+	return f.Window == "" || f.Window == RankingWindowAll
+}
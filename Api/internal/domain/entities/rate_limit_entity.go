@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit permite Limit solicitudes por Period, admitiendo rafagas de hasta Limit.
+// El valor cero desactiva el limite.
+type RateLimit struct {
+	Limit  int
+	Period time.Duration
+}
+
+// Enabled indica si el limite debe aplicarse.
+func (l RateLimit) Enabled() bool {
+	return l.Limit > 0 && l.Period > 0
+}
+
+// EmissionInterval es el tiempo que tarda en recuperarse una solicitud (GCRA).
+func (l RateLimit) EmissionInterval() time.Duration {
+	return l.Period / time.Duration(l.Limit)
+}
+
+// String devuelve el limite con el formato aceptado por ParseRateLimit.
+func (l RateLimit) String() string {
+	return fmt.Sprintf("%d/%s", l.Limit, l.Period)
+}
+
+// RateLimitDecision es el resultado de consumir una solicitud de un RateLimit.
+// ResetAfter es lo que falta para recuperar la capacidad completa; RetryAfter solo
+// aplica cuando la solicitud fue rechazada.
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// ParseRateLimit interpreta "limite/periodo", por ejemplo "10/1m" o "100/1h".
+// Una cadena vacia o "off" desactiva el limite.
+func ParseRateLimit(spec string) (RateLimit, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" || strings.EqualFold(spec, "off") {
+		return RateLimit{}, nil
+	}
+	rawLimit, rawPeriod, found := strings.Cut(spec, "/")
+	if !found {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: expected limit/period", spec)
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(rawLimit))
+	if err != nil || limit < 1 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: limit must be a positive integer", spec)
+	}
+	period, err := time.ParseDuration(strings.TrimSpace(rawPeriod))
+	if err != nil || period < time.Duration(limit) {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: period must be a positive duration", spec)
+	}
+	return RateLimit{Limit: limit, Period: period}, nil
+}
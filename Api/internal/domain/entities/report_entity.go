@@ -0,0 +1,56 @@
+package entities
+
+import "time"
+
+// ReportCategory clasifica el motivo de una denuncia sobre un video publicado.
+type ReportCategory string
+
+const (
+	ReportSpam      ReportCategory = "SPAM"
+	ReportAbuse     ReportCategory = "ABUSE"
+	ReportCopyright ReportCategory = "COPYRIGHT"
+	ReportSexual    ReportCategory = "SEXUAL"
+	ReportViolence  ReportCategory = "VIOLENCE"
+	ReportOther     ReportCategory = "OTHER"
+)
+
+func AllReportCategories() []ReportCategory {
+	return []ReportCategory{
+		ReportSpam,
+		ReportAbuse,
+		ReportCopyright,
+		ReportSexual,
+		ReportViolence,
+		ReportOther,
+	}
+}
+
+// ReportStatus es el estado de triage de una denuncia.
+type ReportStatus string
+
+const (
+	ReportOpen      ReportStatus = "OPEN"
+	ReportDismissed ReportStatus = "DISMISSED"
+	// ReportActioned indica que el video denunciado fue retirado
+	ReportActioned ReportStatus = "ACTIONED"
+)
+
+// Motivos de ocultamiento de un video publicado (video.hidden_reason).
+const (
+	HiddenByReports  = "REPORTS"
+	HiddenByTakedown = "TAKEDOWN"
+)
+
+type VideoReport struct {
+	ReportID   uint       `gorm:"column:report_id;primaryKey;autoIncrement"`
+	VideoID    uint       `gorm:"column:video_id;not null"`
+	ReporterID uint       `gorm:"column:reporter_id;not null"`
+	Category   string     `gorm:"column:category;size:16;not null"`
+	Details    *string    `gorm:"column:details;size:500"`
+	Status     string     `gorm:"column:status;size:16;not null;default:OPEN"`
+	CreatedAt  time.Time  `gorm:"column:created_at;not null;autoCreateTime"`
+	ResolvedAt *time.Time `gorm:"column:resolved_at"`
+	ResolvedBy *uint      `gorm:"column:resolved_by"`
+}
+
+func (VideoReport) TableName() string { return "video_report" }
@@ -10,9 +10,13 @@ const (
 	StatusRemovingAudio    VideoStatus = "REMOVING_AUDIO"
 	StatusAddingIntroOutro VideoStatus = "ADDING_INTRO_OUTRO"
 	StatusProcessed        VideoStatus = "PROCESSED"
+	// StatusPendingReview indica que el propietario envio el video a moderacion
+	StatusPendingReview VideoStatus = "PENDING_REVIEW"
 	// StatusPublished indica que el video ya fue publicado para votación pública
 	StatusPublished VideoStatus = "PUBLISHED"
 	StatusFailed    VideoStatus = "FAILED"
+	// StatusRejected indica que un moderador rechazo la publicacion
+	StatusRejected VideoStatus = "REJECTED"
 )
 
 func AllVideoStatuses() []VideoStatus {
@@ -24,7 +28,9 @@ func AllVideoStatuses() []VideoStatus {
 		StatusRemovingAudio,
 		StatusAddingIntroOutro,
 		StatusProcessed,
+		StatusPendingReview,
 		StatusPublished,
 		StatusFailed,
+		StatusRejected,
 	}
 }
@@ -1,12 +1,17 @@
 package entities
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type User struct {
-	UserID       int       `gorm:"primaryKey;column:user_id"`
-	FirstName    string    `gorm:"column:first_name"`
-	LastName     string    `gorm:"column:last_name"`
-	Email        string    `gorm:"column:email;uniqueIndex;not null"`
+	UserID    int    `gorm:"primaryKey;column:user_id"`
+	FirstName string `gorm:"column:first_name"`
+	LastName  string `gorm:"column:last_name"`
+	Email     string `gorm:"column:email;uniqueIndex;not null"`
+	// Username es unico sin distinguir mayusculas; se deriva del email al registrarse
+	Username     string    `gorm:"column:username;size:255;not null"`
 	PasswordHash string    `gorm:"column:password_hash;not null"`
 	CityID       int       `gorm:"column:city_id;not null"`
 	CreatedAt    time.Time `gorm:"column:created_at"`
@@ -15,3 +20,9 @@ type User struct {
 func (User) TableName() string {
 	return "users"
 }
+
+// UsernameFromEmail devuelve la parte local del email, igual que split_part(email,'@',1).
+func UsernameFromEmail(email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	return local
+}
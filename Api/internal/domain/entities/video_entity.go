@@ -11,6 +11,13 @@ type Video struct {
 	Status        string     `gorm:"column:status;type:video_status;not null;default:UPLOADED"`
 	UploadedAt    time.Time  `gorm:"column:uploaded_at;not null;autoCreateTime"`
 	ProcessedAt   *time.Time `gorm:"column:processed_at"`
+	// RejectionReason se llena cuando un moderador rechaza el video
+	RejectionReason *string `gorm:"column:rejection_reason;size:500"`
+	// HiddenAt/HiddenReason retiran un video publicado de los listados publicos y rankings
+	HiddenAt     *time.Time `gorm:"column:hidden_at"`
+	HiddenReason *string    `gorm:"column:hidden_reason;size:16"`
+	// ContestID es el concurso en el que quedo inscrito el video al publicarse
+	ContestID *uint `gorm:"column:contest_id"`
 }
 
 func (Video) TableName() string { return "video" }
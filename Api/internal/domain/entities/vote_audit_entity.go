@@ -0,0 +1,19 @@
+package entities
+
+// VoteMetadata son los datos de la solicitud de voto usados por las reglas antifraude.
+// Solo se guarda el hash del user agent.
+type VoteMetadata struct {
+	IP            string
+	UserAgentHash string
+}
+
+// VoteAudit es lo que se persiste junto al voto: metadatos, antiguedad de la cuenta al votar
+// y las reglas que lo marcaron. Con Flags no vacio el voto queda en cuarentena.
+type VoteAudit struct {
+	Metadata          VoteMetadata
+	AccountAgeSeconds int64
+	Flags             []string
+}
+
+// Quarantined indica si alguna regla marco el voto.
+func (a VoteAudit) Quarantined() bool { return len(a.Flags) > 0 }
@@ -0,0 +1,18 @@
+package entities
+
+// VoteBudgetScope define sobre que periodo se cuenta el presupuesto de votos de un usuario.
+type VoteBudgetScope string
+
+const (
+	// VoteBudgetDaily cuenta los votos emitidos desde la medianoche UTC del dia en curso.
+	VoteBudgetDaily VoteBudgetScope = "day"
+	// VoteBudgetContest cuenta los votos sobre videos del mismo concurso.
+	VoteBudgetContest VoteBudgetScope = "contest"
+)
+
+// VoteBudgetPolicy limita cuantos votos vigentes puede tener un usuario en un alcance.
+// Los votos retirados liberan presupuesto.
+type VoteBudgetPolicy struct {
+	Scope VoteBudgetScope
+	Limit int
+}
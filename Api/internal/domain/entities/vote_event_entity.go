@@ -0,0 +1,37 @@
+package entities
+
+import "time"
+
+// Routing keys de los eventos de voto publicados en el exchange topic.
+const (
+	VoteEventCast      = "vote.cast"
+	VoteEventRetracted = "vote.retracted"
+)
+
+// VoteEvent es el cuerpo JSON de vote.cast y vote.retracted.
+// El puntaje de rankings pertenece al dueño del video, por eso se incluye su ciudad ademas
+// de la del votante. Los votos en cuarentena se publican con Quarantined=true y no deben sumarse.
+type VoteEvent struct {
+	Type          string    `json:"type"`
+	VoteID        uint      `json:"vote_id"`
+	VideoID       uint      `json:"video_id"`
+	OwnerID       uint      `json:"owner_id"`
+	OwnerUsername string    `json:"owner_username"`
+	OwnerCityID   uint      `json:"owner_city_id"`
+	OwnerCity     string    `json:"owner_city"`
+	VoterID       uint      `json:"voter_id"`
+	VoterCityID   uint      `json:"voter_city_id"`
+	VoterCity     string    `json:"voter_city"`
+	ContestID     *uint     `json:"contest_id,omitempty"`
+	Quarantined   bool      `json:"quarantined"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// OutboxMessage es un evento pendiente de publicar. ID se usa como message_id AMQP
+// para que los consumidores descarten duplicados (entrega al menos una vez).
+type OutboxMessage struct {
+	ID         uint64
+	RoutingKey string
+	Payload    []byte
+	Attempts   int
+}
@@ -8,4 +8,10 @@ var (
 	ErrConflict   = errors.New("conflict")
 	ErrForbidden  = errors.New("forbidden")
 	ErrIdempotent = errors.New("idempotent")
+	// ErrRateLimited indica que el usuario excedio un limite de frecuencia de la operacion
+	ErrRateLimited = errors.New("rate limited")
+	// ErrVotingClosed indica que el voto llega fuera de la ventana de votacion del concurso
+	ErrVotingClosed = errors.New("voting closed")
+	// ErrVoteBudgetExceeded indica que el usuario agoto su presupuesto de votos
+	ErrVoteBudgetExceeded = errors.New("vote budget exceeded")
 )
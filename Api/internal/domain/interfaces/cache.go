@@ -1,9 +1,22 @@
 package interfaces
 
-import "context"
+import (
+	"api/internal/domain/responses"
+	"context"
+)
 
 // Cache abstrae un almacenamiento clave-valor de solo lectura usado por la capa de API.
 type Cache interface {
 	// GetBytes returns the value stored at key or an error if not found.
 	GetBytes(ctx context.Context, key string) ([]byte, error)
 }
+
+// LeaderboardCache es una extension opcional de Cache que lee los leaderboards
+// incrementales (ZSET) mantenidos por AdminCache.
+type LeaderboardCache interface {
+	Cache
+	// LeaderboardNeighborhood devuelve la fila de userID en boardKey y las inmediatamente
+	// anterior y siguiente, con usernames del hash usersKey. Vacio si el usuario no esta;
+	// redis.Nil si el leaderboard no existe (p. ej. AdminCache sin eventos de voto).
+	LeaderboardNeighborhood(ctx context.Context, boardKey, usersKey string, userID uint) ([]responses.RankedUserItem, error)
+}
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"context"
+	"time"
+)
+
+// CommentRepository define la persistencia de comentarios sobre videos publicos.
+type CommentRepository interface {
+	Create(ctx context.Context, comment *entities.Comment) error
+	// GetByID returns domain.ErrNotFound if the comment does not exist.
+	GetByID(ctx context.Context, id uint) (*entities.Comment, error)
+	// List pagina por comment_id: los de primer nivel (parentID nil) del mas nuevo al mas antiguo
+	// y las respuestas de parentID del mas antiguo al mas nuevo. afterID 0 inicia desde el principio.
+	List(ctx context.Context, videoID uint, parentID *uint, afterID uint, limit int) ([]responses.CommentResponse, error)
+	UpdateBody(ctx context.Context, id uint, body string) error
+	// Delete elimina el comentario y sus respuestas.
+	Delete(ctx context.Context, id uint) error
+	// CreateWithinLimit inserta el comentario solo si su autor publico menos de max desde since;
+	// si no, devuelve domain.ErrRateLimited. El conteo y el insert son atomicos por usuario.
+	CreateWithinLimit(ctx context.Context, comment *entities.Comment, max int, since time.Time) error
+	// VideoOwnerID devuelve el propietario del video sin importar su visibilidad
+	// (domain.ErrNotFound si no existe).
+	VideoOwnerID(ctx context.Context, videoID uint) (uint, error)
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"context"
+)
+
+// ContestRepository define la persistencia de concursos y sus clasificaciones.
+type ContestRepository interface {
+	Create(ctx context.Context, contest *entities.Contest) error
+	// GetByID devuelve el concurso o domain.ErrNotFound.
+	GetByID(ctx context.Context, contestID uint) (*entities.Contest, error)
+	// List devuelve los concursos, los mas recientes primero.
+	List(ctx context.Context) ([]entities.Contest, error)
+	// GetForVideo devuelve el concurso en el que esta inscrito el video, o nil si no participa en ninguno.
+	GetForVideo(ctx context.Context, videoID uint) (*entities.Contest, error)
+	// Close marca el concurso como cerrado y congela su clasificacion en la misma transaccion.
+	// Returns domain.ErrNotFound for unknown contests and domain.ErrConflict if already closed.
+	Close(ctx context.Context, contestID uint) (*entities.Contest, error)
+	// Rankings devuelve la clasificacion del concurso: en vivo mientras esta abierto y
+	// la congelada una vez cerrado. city filtra opcionalmente por nombre de ciudad.
+	Rankings(ctx context.Context, contestID uint, city *string, page, pageSize int) ([]responses.RankingItem, error)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import "context"
+
+// MediaKind identifica el origen de un objeto multimedia (bucket o ruta base).
+type MediaKind string
+
+const (
+	// MediaOriginal es el archivo subido por el usuario (bucket raw).
+	MediaOriginal MediaKind = "original"
+	// MediaProcessed es el archivo final generado por el pipeline de workers.
+	MediaProcessed MediaKind = "processed"
+)
+
+// MediaURLSigner convierte claves de objeto almacenadas en URLs firmadas de corta duracion.
+// Implementado en infra (S3 presign o nginx secure_link).
+type MediaURLSigner interface {
+	SignURL(ctx context.Context, kind MediaKind, objectKey string) (string, error)
+}
@@ -1,8 +1,19 @@
 package interfaces
 
+import "context"
+
 // MessagePublisher abstracts a message broker publisher.
-// Implemented in infra (e.g., RabbitMQPublisher).
+// Implemented in infra (e.g., RabbitMQPublisher). ctx carries the trace context,
+// which implementations propagate in the message headers.
 type MessagePublisher interface {
-	Publish(queue string, body []byte) error
+	Publish(ctx context.Context, queue string, body []byte) error
 	Close() error
 }
+
+// EventPublisher is an optional extension of MessagePublisher for topic exchanges.
+// PublishEvent returns nil only once the broker has accepted the message, so callers
+// can retry on error without losing events.
+type EventPublisher interface {
+	MessagePublisher
+	PublishEvent(ctx context.Context, exchange, routingKey, messageID string, body []byte) error
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"context"
+)
+
+// ModerationRepository define la persistencia del flujo de revision previo a la publicacion.
+type ModerationRepository interface {
+	// ListPending devuelve los videos en PENDING_REVIEW, los mas antiguos primero.
+	ListPending(ctx context.Context, page, pageSize int) ([]responses.ModerationQueueItem, error)
+	// Approve mueve el video de PENDING_REVIEW a PUBLISHED, lo inscribe en el concurso cuya
+	// ventana de postulacion contiene su fecha de subida (si existe) y registra la decision.
+	// Returns domain.ErrNotFound if the video does not exist and domain.ErrConflict if it is not pending.
+	Approve(ctx context.Context, videoID, moderatorID uint) (*entities.Video, error)
+	// Reject mueve el video de PENDING_REVIEW a REJECTED guardando el motivo, registra la decision
+	// y deja una notificacion para el propietario en la misma transaccion. Same errors as Approve.
+	Reject(ctx context.Context, videoID, moderatorID uint, reason string) (*entities.Video, error)
+}
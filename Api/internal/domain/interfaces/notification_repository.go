@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"api/internal/domain/responses"
+	"context"
+)
+
+// NotificationRepository define la persistencia de las notificaciones de usuario.
+type NotificationRepository interface {
+	// ListByUser devuelve las notificaciones de userID, las mas recientes primero.
+	ListByUser(ctx context.Context, userID uint, page, pageSize int) ([]responses.NotificationResponse, error)
+	// MarkRead marca la notificacion como leida (idempotente).
+	// Returns domain.ErrNotFound if it does not exist or belongs to another user.
+	MarkRead(ctx context.Context, userID, notificationID uint) error
+}
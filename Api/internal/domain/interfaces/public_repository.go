@@ -1,6 +1,7 @@
 package interfaces
 
 import (
+	"api/internal/domain/entities"
 	"api/internal/domain/responses"
 	"context"
 )
@@ -14,6 +15,36 @@ type PublicRepository interface {
 	// city: filtro opcional por nombre de ciudad (case-insensitive). Si nil, no filtra.
 	// page, pageSize: para paginacion.
 	Rankings(ctx context.Context, city *string, page, pageSize int) ([]responses.RankingItem, error)
+	// VideoRankings devuelve el ranking paginado de videos por votos, con el mismo filtro
+	// de ciudad (la del autor) que Rankings.
+	VideoRankings(ctx context.Context, city *string, page, pageSize int) ([]responses.VideoRankingItem, error)
 	// GetUsersBasicByIDs retorna username y ciudad para los userIDs proporcionados.
 	GetUsersBasicByIDs(ctx context.Context, ids []uint) ([]responses.UserBasic, error)
 }
+
+// PublicRepositoryWithRankingFilters es una extension opcional de PublicRepository
+// que filtra el ranking por pais y por ventana de tiempo ademas de la ciudad.
+type PublicRepositoryWithRankingFilters interface {
+	PublicRepository
+	FilteredRankings(ctx context.Context, filter entities.RankingFilter, page, pageSize int) ([]responses.RankingItem, error)
+}
+
+// PublicRepositoryWithRankLookup es una extension opcional de PublicRepository para ubicar
+// a un usuario dentro del ranking historico.
+type PublicRepositoryWithRankLookup interface {
+	PublicRepository
+	// UserBasicByUsername busca por username sin distinguir mayusculas. domain.ErrNotFound si no existe.
+	UserBasicByUsername(ctx context.Context, username string) (*responses.UserBasic, error)
+	// RankNeighborhood devuelve la fila del usuario y las inmediatamente anterior y siguiente,
+	// ordenadas por posicion. city filtra como en Rankings. Vacio si el usuario no esta rankeado.
+	RankNeighborhood(ctx context.Context, userID uint, city *string) ([]responses.RankedUserItem, error)
+}
+
+// PublicRepositoryWithGallery es una extension opcional de PublicRepository para la galeria
+// publica con busqueda, filtros y paginacion por cursor.
+type PublicRepositoryWithGallery interface {
+	PublicRepository
+	// SearchPublicVideos devuelve hasta q.Limit videos publicos en el orden de q.Sort,
+	// posteriores a q.After (si no es nil). Los empates se resuelven por video_id descendente.
+	SearchPublicVideos(ctx context.Context, q entities.GalleryQuery) ([]responses.GalleryVideo, error)
+}
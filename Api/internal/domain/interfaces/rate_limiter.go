@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"api/internal/domain/entities"
+	"context"
+)
+
+// RateLimiter consume solicitudes de un RateLimit por clave (usuario o IP).
+// Implementado en infra (Redis compartido entre instancias o memoria local).
+type RateLimiter interface {
+	// Allow registra una solicitud para key y devuelve si se permite y el estado del limite.
+	Allow(ctx context.Context, key string, limit entities.RateLimit) (entities.RateLimitDecision, error)
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"context"
+)
+
+// ReportRepository define la persistencia de denuncias sobre videos publicados.
+type ReportRepository interface {
+	// Create registra la denuncia y oculta el video (hidden_reason REPORTS) cuando las
+	// denuncias abiertas alcanzan hideThreshold. Devuelve true si el video quedo oculto por esta denuncia.
+	// Returns domain.ErrConflict if the user already reported the video.
+	Create(ctx context.Context, report *entities.VideoReport, hideThreshold int) (bool, error)
+	// ListOpen devuelve las denuncias abiertas, priorizando los videos con mas denuncias.
+	ListOpen(ctx context.Context, page, pageSize int) ([]responses.ReportResponse, error)
+	// Dismiss descarta una denuncia abierta. Si el video estaba oculto automaticamente y quedan
+	// menos de hideThreshold denuncias abiertas, vuelve a ser visible.
+	// Returns domain.ErrNotFound for unknown reports and domain.ErrConflict if already resolved.
+	Dismiss(ctx context.Context, reportID, adminID uint, hideThreshold int) error
+	// TakeDown retira el video denunciado (hidden_reason TAKEDOWN) y resuelve todas sus denuncias abiertas.
+	// Same errors as Dismiss. Returns the affected video id.
+	TakeDown(ctx context.Context, reportID, adminID uint) (uint, error)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"api/internal/domain/entities"
+	"context"
+	"time"
+)
+
+// VoteEventOutbox expone los eventos de voto escritos junto con cada voto o retiro.
+type VoteEventOutbox interface {
+	// ClaimPending reserva hasta limit mensajes sin publicar durante lease, en orden de creacion.
+	// Los mensajes reservados por otra instancia se omiten hasta que su lease venza.
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]entities.OutboxMessage, error)
+	MarkPublished(ctx context.Context, ids []uint64) error
+	// MarkFailed libera la reserva y registra el error para reintentar en el siguiente ciclo.
+	MarkFailed(ctx context.Context, id uint64, reason string) error
+	// DeletePublishedBefore borra hasta limit mensajes publicados antes de before y devuelve cuantos borro.
+	DeletePublishedBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"api/internal/domain/responses"
+	"context"
+)
+
+// VoteQuarantineRepository define la revision de votos marcados por las reglas antifraude.
+type VoteQuarantineRepository interface {
+	// ListQuarantined devuelve los votos en cuarentena, los mas antiguos primero.
+	ListQuarantined(ctx context.Context, page, pageSize int) ([]responses.QuarantinedVote, error)
+	// Clear libera el voto: vuelve a contar en rankings.
+	// Returns domain.ErrNotFound if the vote does not exist and domain.ErrConflict if it is not quarantined.
+	Clear(ctx context.Context, voteID, reviewerID uint) error
+	// Discard elimina el voto y lo archiva en vote_history con el revisor como autor. Same errors as Clear.
+	Discard(ctx context.Context, voteID, reviewerID uint) error
+}
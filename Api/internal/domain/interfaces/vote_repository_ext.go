@@ -1,6 +1,11 @@
 package interfaces
 
-import "context"
+import (
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"context"
+	"time"
+)
 
 // VoteRepositoryWithEvent es una extension opcional de VoteRepository
 // que permite persistir un eventID unico para idempotencia fuerte.
@@ -8,3 +13,41 @@ type VoteRepositoryWithEvent interface {
 	VoteRepository
 	CreateWithEvent(ctx context.Context, videoID, userID uint, eventID *string) error
 }
+
+// VoteRepositoryWithRetract es una extension opcional que permite retirar un voto.
+// El voto retirado se conserva en vote_history para auditoria.
+// Errores: domain.ErrConflict si el usuario no tiene voto en el video,
+// domain.ErrIdempotent si eventID ya fue procesado.
+type VoteRepositoryWithRetract interface {
+	VoteRepository
+	Retract(ctx context.Context, videoID, userID uint, eventID *string) error
+}
+
+// VoteRepositoryWithBudget es una extension opcional que aplica presupuestos de votos.
+type VoteRepositoryWithBudget interface {
+	VoteRepository
+	// CreateWithBudget inserta el voto como CreateWithEvent, verificando en la misma transaccion
+	// (serializada por usuario) que ninguna politica quede excedida.
+	// Returns domain.ErrVoteBudgetExceeded when a policy has no remaining votes.
+	CreateWithBudget(ctx context.Context, videoID, userID uint, eventID *string, policies []entities.VoteBudgetPolicy) error
+	// CountVotes cuenta los votos vigentes del usuario emitidos desde since (si no es nil)
+	// y sobre videos del concurso contestID (si no es nil).
+	CountVotes(ctx context.Context, userID uint, since *time.Time, contestID *uint) (int, error)
+	// ListByUser devuelve los votos vigentes y retirados del usuario, los mas recientes primero.
+	ListByUser(ctx context.Context, userID uint, page, pageSize int) ([]responses.UserVoteItem, error)
+}
+
+// VoteRepositoryWithAudit es una extension opcional que guarda los metadatos antifraude del voto.
+// Si audit.Quarantined() el voto se inserta en cuarentena y no cuenta en rankings.
+// Aplica las mismas reglas de idempotencia y presupuesto que CreateWithBudget (policies puede ser nil).
+type VoteRepositoryWithAudit interface {
+	VoteRepository
+	CreateWithAudit(ctx context.Context, videoID, userID uint, eventID *string, audit entities.VoteAudit, policies []entities.VoteBudgetPolicy) error
+}
+
+// VoteRepositoryWithVotedLookup es una extension opcional para marcar en una sola consulta
+// cuales de videoIDs ya voto el usuario (incluye votos en cuarentena).
+type VoteRepositoryWithVotedLookup interface {
+	VoteRepository
+	VotedVideoIDs(ctx context.Context, userID uint, videoIDs []uint) (map[uint]bool, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// VoteSignalReader expone las senales que consultan las reglas antifraude de votos.
+type VoteSignalReader interface {
+	// AccountCreatedAt devuelve la fecha de alta del usuario.
+	AccountCreatedAt(ctx context.Context, userID uint) (time.Time, error)
+	// CountUserVotesSince cuenta los votos emitidos por el usuario desde since, incluidos los retirados.
+	CountUserVotesSince(ctx context.Context, userID uint, since time.Time) (int, error)
+	// CountOtherAccountsByIPSince cuenta las cuentas distintas de userID que votaron desde ip desde since.
+	CountOtherAccountsByIPSince(ctx context.Context, ip string, userID uint, since time.Time) (int, error)
+}
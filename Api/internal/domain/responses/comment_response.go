@@ -0,0 +1,20 @@
+package responses
+
+import "time"
+
+// CommentResponse representa un comentario en /api/public/videos/:video_id/comments
+type CommentResponse struct {
+	CommentID  uint       `json:"comment_id" gorm:"column:comment_id"`
+	ParentID   *uint      `json:"parent_id" gorm:"column:parent_id"`
+	Author     string     `json:"author" gorm:"column:author"`
+	Body       string     `json:"body" gorm:"column:body"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty" gorm:"column:updated_at"`
+	ReplyCount int        `json:"reply_count" gorm:"column:reply_count"`
+}
+
+// CommentPage es una pagina de comentarios; NextCursor es nil cuando no hay mas resultados.
+type CommentPage struct {
+	Items      []CommentResponse `json:"items"`
+	NextCursor *string           `json:"next_cursor"`
+}
@@ -0,0 +1,16 @@
+package responses
+
+import "time"
+
+// ContestResponse representa un concurso en /api/public/contests y /api/admin/contests
+type ContestResponse struct {
+	ContestID          uint       `json:"contest_id"`
+	Name               string     `json:"name"`
+	SubmissionStartsAt time.Time  `json:"submission_starts_at"`
+	SubmissionEndsAt   time.Time  `json:"submission_ends_at"`
+	VotingStartsAt     time.Time  `json:"voting_starts_at"`
+	VotingEndsAt       time.Time  `json:"voting_ends_at"`
+	ClosedAt           *time.Time `json:"closed_at,omitempty"`
+	// VotingOpen indica si el concurso acepta votos en este momento
+	VotingOpen bool `json:"voting_open"`
+}
@@ -0,0 +1,14 @@
+package responses
+
+import "time"
+
+// ModerationQueueItem representa un video pendiente de revision para /api/moderation/videos
+type ModerationQueueItem struct {
+	VideoID       uint       `json:"video_id" gorm:"column:video_id"`
+	Title         string     `json:"title" gorm:"column:title"`
+	OwnerUserID   uint       `json:"owner_user_id" gorm:"column:owner_user_id"`
+	OwnerUsername string     `json:"owner_username" gorm:"column:owner_username"`
+	ProcessedURL  *string    `json:"processed_url" gorm:"column:processed_url"`
+	UploadedAt    time.Time  `json:"uploaded_at" gorm:"column:uploaded_at"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty" gorm:"column:processed_at"`
+}
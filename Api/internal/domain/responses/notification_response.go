@@ -0,0 +1,15 @@
+package responses
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// NotificationResponse representa una notificacion en /api/me/notifications
+type NotificationResponse struct {
+	NotificationID uint            `json:"notification_id"`
+	Type           string          `json:"type"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"created_at"`
+	ReadAt         *time.Time      `json:"read_at,omitempty"`
+}
@@ -0,0 +1,14 @@
+package responses
+
+// PlayerProfileResponse is the public API schema for /api/public/users/:username.
+// GlobalRank and CityRank are null while the player has no public videos in the ranking;
+// NextCursor pages through Videos like X-Next-Cursor in /api/public/videos.
+type PlayerProfileResponse struct {
+	Username   string                `json:"username"`
+	City       *string               `json:"city"`
+	Votes      int                   `json:"votes"`
+	GlobalRank *int                  `json:"global_rank"`
+	CityRank   *int                  `json:"city_rank"`
+	Videos     []PublicVideoResponse `json:"videos"`
+	NextCursor *string               `json:"next_cursor"`
+}
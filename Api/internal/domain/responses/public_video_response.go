@@ -1,5 +1,7 @@
 package responses
 
+import "time"
+
 // PublicVideoResponse representa el esquema de salida para /api/public/videos
 type PublicVideoResponse struct {
 	VideoID      uint    `json:"video_id"`
@@ -7,5 +9,29 @@ type PublicVideoResponse struct {
 	ProcessedURL *string `json:"processed_url"`
 	City         *string `json:"city"`
 	Votes        int     `json:"votes"`
+	Comments     int     `json:"comments" gorm:"column:comments"`
 	OwnerUserID  uint    `json:"-" gorm:"column:owner_user_id"`
+	// PublishedAt es la fecha de aprobacion del video (o de procesamiento si no paso por moderacion)
+	PublishedAt *time.Time `json:"published_at,omitempty" gorm:"column:published_at"`
+	// VotedByMe solo se informa a usuarios autenticados
+	VotedByMe *bool `json:"voted_by_me,omitempty" gorm:"-"`
+}
+
+// PublicVideoDetail representa el esquema de salida para /api/public/videos/:video_id
+type PublicVideoDetail struct {
+	PublicVideoResponse
+	OwnerUsername string `json:"owner_username"`
+}
+
+// GalleryVideo es un video de la galeria con las columnas de orden usadas para el cursor.
+type GalleryVideo struct {
+	PublicVideoResponse
+	UploadedAt    time.Time `json:"-" gorm:"column:uploaded_at"`
+	TrendingVotes int       `json:"-" gorm:"column:trending_votes"`
+}
+
+// PublicVideoPage es una pagina de la galeria; NextCursor es nil cuando no hay mas resultados.
+type PublicVideoPage struct {
+	Items      []PublicVideoResponse `json:"items"`
+	NextCursor *string               `json:"next_cursor"`
 }
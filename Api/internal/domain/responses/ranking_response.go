@@ -16,3 +16,49 @@ type RankingItem struct {
 	City     *string `json:"city,omitempty"`
 	Votes    int     `json:"votes"`
 }
+
+// VideoRankingEntry is the public API schema for /api/public/rankings/videos
+// Note: position is assigned per returned page starting at 1.
+type VideoRankingEntry struct {
+	Position int     `json:"position"`
+	VideoID  uint    `json:"video_id"`
+	Title    string  `json:"title"`
+	Username string  `json:"username"`
+	City     *string `json:"city,omitempty"`
+	Votes    int     `json:"votes"`
+}
+
+// VideoRankingItem is the internal representation of a top-videos row (without position).
+type VideoRankingItem struct {
+	VideoID  uint    `json:"video_id"`
+	Title    string  `json:"title"`
+	Username string  `json:"username"`
+	City     *string `json:"city,omitempty"`
+	Votes    int     `json:"votes"`
+}
+
+// RankedUserItem is a ranking row with its absolute position, used by rank lookups.
+type RankedUserItem struct {
+	UserID   uint    `json:"user_id"`
+	Position int     `json:"position"`
+	Username string  `json:"username"`
+	City     *string `json:"city,omitempty"`
+	Votes    int     `json:"votes"`
+}
+
+// RankStanding is the user's position in one ranking scope and the entries next to it.
+type RankStanding struct {
+	Position int           `json:"position"`
+	Above    *RankingEntry `json:"above,omitempty"`
+	Below    *RankingEntry `json:"below,omitempty"`
+}
+
+// UserRankResponse is the public API schema for /api/public/rankings/users/:username and /api/me/rank.
+// Global and CityRank are null while the user has no public videos in the ranking.
+type UserRankResponse struct {
+	Username string        `json:"username"`
+	City     *string       `json:"city,omitempty"`
+	Votes    int           `json:"votes"`
+	Global   *RankStanding `json:"global"`
+	CityRank *RankStanding `json:"city_rank"`
+}
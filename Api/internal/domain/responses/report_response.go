@@ -0,0 +1,18 @@
+package responses
+
+import "time"
+
+// ReportResponse representa una denuncia abierta en /api/admin/reports
+type ReportResponse struct {
+	ReportID         uint      `json:"report_id" gorm:"column:report_id"`
+	VideoID          uint      `json:"video_id" gorm:"column:video_id"`
+	VideoTitle       string    `json:"video_title" gorm:"column:video_title"`
+	ReporterUsername string    `json:"reporter_username" gorm:"column:reporter_username"`
+	Category         string    `json:"category" gorm:"column:category"`
+	Details          *string   `json:"details,omitempty" gorm:"column:details"`
+	Status           string    `json:"status" gorm:"column:status"`
+	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at"`
+	// OpenReports es el total de denuncias abiertas sobre el mismo video
+	OpenReports int  `json:"open_reports" gorm:"column:open_reports"`
+	VideoHidden bool `json:"video_hidden" gorm:"column:video_hidden"`
+}
@@ -11,4 +11,6 @@ type VideoResponse struct {
 	ProcessedAt  *time.Time `json:"processed_at,omitempty"`
 	OriginalURL  *string    `json:"original_url,omitempty"`
 	ProcessedURL *string    `json:"processed_url,omitempty"`
+	// RejectionReason solo se informa cuando status es "rejected"
+	RejectionReason *string `json:"rejection_reason,omitempty"`
 }
@@ -0,0 +1,45 @@
+package responses
+
+import "time"
+
+// VoteBudgetStatus es el consumo de un presupuesto de votos del usuario.
+type VoteBudgetStatus struct {
+	Scope string `json:"scope"`
+	// ContestID solo aplica al alcance "contest"
+	ContestID *uint `json:"contest_id,omitempty"`
+	Limit     int   `json:"limit"`
+	Used      int   `json:"used"`
+	Remaining int   `json:"remaining"`
+	// ResetsAt solo aplica al alcance "day"
+	ResetsAt *time.Time `json:"resets_at,omitempty"`
+}
+
+// UserVoteItem es un voto del usuario, vigente o retirado.
+type UserVoteItem struct {
+	VideoID     uint       `json:"video_id" gorm:"column:video_id"`
+	Title       string     `json:"title" gorm:"column:title"`
+	ContestID   *uint      `json:"contest_id,omitempty" gorm:"column:contest_id"`
+	VotedAt     time.Time  `json:"voted_at" gorm:"column:voted_at"`
+	RetractedAt *time.Time `json:"retracted_at,omitempty" gorm:"column:retracted_at"`
+}
+
+// MyVotesResponse es la respuesta de GET /api/me/votes
+type MyVotesResponse struct {
+	Budgets []VoteBudgetStatus `json:"budgets"`
+	Votes   []UserVoteItem     `json:"votes"`
+}
+
+// QuarantinedVote es un voto pendiente de revision en /api/admin/votes/quarantine
+type QuarantinedVote struct {
+	VoteID            uint      `json:"vote_id" gorm:"column:vote_id"`
+	VideoID           uint      `json:"video_id" gorm:"column:video_id"`
+	VideoTitle        string    `json:"video_title" gorm:"column:video_title"`
+	VoterID           uint      `json:"voter_id" gorm:"column:voter_id"`
+	VoterUsername     string    `json:"voter_username" gorm:"column:voter_username"`
+	IP                *string   `json:"ip,omitempty" gorm:"column:ip"`
+	AccountAgeSeconds *int64    `json:"account_age_seconds,omitempty" gorm:"column:account_age_seconds"`
+	Flags             []string  `json:"flags" gorm:"-"`
+	RawFlags          string    `json:"-" gorm:"column:quarantine_flags"`
+	VotedAt           time.Time `json:"voted_at" gorm:"column:voted_at"`
+	QuarantinedAt     time.Time `json:"quarantined_at" gorm:"column:quarantined_at"`
+}
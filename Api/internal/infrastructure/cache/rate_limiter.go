@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRateLimitFallbackCooldown es cuanto tiempo se usa el limitador de respaldo
+// antes de volver a intentar con Redis tras un error.
+const DefaultRateLimitFallbackCooldown = 5 * time.Second
+
+// gcraScript implementa GCRA sobre una sola clave con el reloj de Redis, de modo que
+// todas las instancias de la API comparten el mismo estado. Tiempos en microsegundos.
+// KEYS[1] = clave, ARGV[1] = intervalo de emision, ARGV[2] = tolerancia (periodo).
+// Devuelve {permitido, restantes, reset_after, retry_after}.
+var gcraScript = redis.NewScript(`
+local emission = tonumber(ARGV[1])
+local tolerance = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if not tat or tat < now then
+  tat = now
+end
+local new_tat = tat + emission
+local diff = now - (new_tat - tolerance)
+if diff < 0 then
+  return {0, 0, tat - now, -diff}
+end
+redis.call('SET', KEYS[1], string.format('%.0f', new_tat), 'PX', math.ceil((new_tat - now) / 1000))
+return {1, math.floor(diff / emission), new_tat - now, 0}
+`)
+
+// RedisRateLimiter implements interfaces.RateLimiter con GCRA en Redis.
+// Con WithFallback, los errores de Redis se absorben usando el limitador de respaldo
+// (normalmente MemoryRateLimiter) durante un periodo de enfriamiento.
+type RedisRateLimiter struct {
+	rdb       *redis.Client
+	prefix    string
+	fallback  interfaces.RateLimiter
+	cooldown  time.Duration
+	downUntil atomic.Int64
+}
+
+// NewRedisRateLimiter builds a limiter storing its state under prefix.
+func NewRedisRateLimiter(rdb *redis.Client, prefix string) *RedisRateLimiter {
+	return &RedisRateLimiter{rdb: rdb, prefix: prefix, cooldown: DefaultRateLimitFallbackCooldown}
+}
+
+// WithFallback configura el limitador usado mientras Redis no responde.
+func (l *RedisRateLimiter) WithFallback(fallback interfaces.RateLimiter) *RedisRateLimiter {
+	l.fallback = fallback
+	return l
+}
+
+// WithFallbackCooldown ajusta cuanto se evita Redis tras un error; <= 0 reintenta siempre.
+func (l *RedisRateLimiter) WithFallbackCooldown(d time.Duration) *RedisRateLimiter {
+	l.cooldown = d
+	return l
+}
+
+// Close cierra el cliente Redis del limitador.
+func (l *RedisRateLimiter) Close() error {
+	return l.rdb.Close()
+}
+
+// Allow implements interfaces.RateLimiter.
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string, limit entities.RateLimit) (entities.RateLimitDecision, error) {
+	if !limit.Enabled() {
+		return entities.RateLimitDecision{Allowed: true}, nil
+	}
+	if l.fallback != nil && time.Now().UnixNano() < l.downUntil.Load() {
+		return l.fallback.Allow(ctx, key, limit)
+	}
+
+	decision, err := l.allow(ctx, key, limit)
+	if err == nil || l.fallback == nil {
+		return decision, err
+	}
+	if l.downUntil.Swap(time.Now().Add(l.cooldown).UnixNano()) == 0 {
+		slog.WarnContext(ctx, "rate limiter: redis unavailable, using in-memory limits", "err", err)
+	}
+	return l.fallback.Allow(ctx, key, limit)
+}
+
+func (l *RedisRateLimiter) allow(ctx context.Context, key string, limit entities.RateLimit) (entities.RateLimitDecision, error) {
+	res, err := gcraScript.Run(ctx, l.rdb, []string{l.prefix + key},
+		limit.EmissionInterval().Microseconds(), limit.Period.Microseconds()).Int64Slice()
+	if err != nil {
+		return entities.RateLimitDecision{}, err
+	}
+	if len(res) != 4 {
+		return entities.RateLimitDecision{}, fmt.Errorf("rate limiter: unexpected script reply %v", res)
+	}
+	// Redis respondio: se vuelve a usar como fuente de verdad
+	if l.downUntil.Swap(0) != 0 {
+		slog.InfoContext(ctx, "rate limiter: redis available again")
+	}
+	return entities.RateLimitDecision{
+		Allowed:    res[0] == 1,
+		Limit:      limit.Limit,
+		Remaining:  int(res[1]),
+		ResetAfter: time.Duration(res[2]) * time.Microsecond,
+		RetryAfter: time.Duration(res[3]) * time.Microsecond,
+	}, nil
+}
+
+// memorySweepInterval es cada cuanto MemoryRateLimiter descarta claves ya recuperadas.
+const memorySweepInterval = time.Minute
+
+// MemoryRateLimiter implements interfaces.RateLimiter con GCRA en memoria del proceso.
+// Los limites son por instancia, por lo que sirve como respaldo de RedisRateLimiter.
+type MemoryRateLimiter struct {
+	mu        sync.Mutex
+	tats      map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimiter builds an empty in-memory limiter.
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{tats: map[string]time.Time{}, lastSweep: time.Now()}
+}
+
+// Allow implements interfaces.RateLimiter.
+func (l *MemoryRateLimiter) Allow(_ context.Context, key string, limit entities.RateLimit) (entities.RateLimitDecision, error) {
+	if !limit.Enabled() {
+		return entities.RateLimitDecision{Allowed: true}, nil
+	}
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	emission := limit.EmissionInterval()
+	tat := l.tats[key]
+	if tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(emission)
+	diff := now.Sub(newTat.Add(-limit.Period))
+	if diff < 0 {
+		return entities.RateLimitDecision{
+			Limit:      limit.Limit,
+			ResetAfter: tat.Sub(now),
+			RetryAfter: -diff,
+		}, nil
+	}
+	l.tats[key] = newTat
+	return entities.RateLimitDecision{
+		Allowed:    true,
+		Limit:      limit.Limit,
+		Remaining:  int(diff / emission),
+		ResetAfter: newTat.Sub(now),
+	}, nil
+}
+
+// sweep elimina las claves cuyo TAT ya paso (capacidad completa); requiere l.mu.
+func (l *MemoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < memorySweepInterval {
+		return
+	}
+	for key, tat := range l.tats {
+		if !tat.After(now) {
+			delete(l.tats, key)
+		}
+	}
+	l.lastSweep = now
+}
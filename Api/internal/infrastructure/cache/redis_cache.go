@@ -1,7 +1,10 @@
 package cache
 
 import (
+	"api/internal/domain/responses"
 	"context"
+	"errors"
+	"strconv"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -22,14 +25,98 @@ func MustRedisClient(addr string) *redis.Client {
 	return r
 }
 
+// NewRedisClient creates a redis client without checking connectivity, for
+// components that must keep working (degraded) while Redis is down.
+func NewRedisClient(addr string) *redis.Client {
+	return redis.NewClient(&redis.Options{Addr: addr})
+}
+
 // NewRedisCache builds a cache with a given client and key prefix.
 func NewRedisCache(rdb *redis.Client, prefix string) *RedisCache {
 	return &RedisCache{rdb: rdb, prefix: prefix}
 }
 
+// Ready hace PING a Redis.
+func (c *RedisCache) Ready(ctx context.Context) error {
+	return c.rdb.Ping(ctx).Err()
+}
+
+// Close cierra el cliente Redis de la cache.
+func (c *RedisCache) Close() error {
+	return c.rdb.Close()
+}
+
 func (c *RedisCache) key(k string) string { return c.prefix + k }
 
 // GetBytes fetches a key and returns its raw bytes.
 func (c *RedisCache) GetBytes(ctx context.Context, key string) ([]byte, error) {
 	return c.rdb.Get(ctx, c.key(key)).Bytes()
 }
+
+// leaderboardTieBits replica la codificacion de AdminCache: votos * 2^31 + (2^31 - 1 - user_id).
+const leaderboardTieBits = 31
+
+// DecodeLeaderboardVotes extrae los votos de un puntaje del leaderboard.
+func DecodeLeaderboardVotes(score float64) int {
+	return int(int64(score) >> leaderboardTieBits)
+}
+
+// LeaderboardNeighborhood implements interfaces.LeaderboardCache.
+func (c *RedisCache) LeaderboardNeighborhood(ctx context.Context, boardKey, usersKey string, userID uint) ([]responses.RankedUserItem, error) {
+	board := c.key(boardKey)
+	member := strconv.FormatUint(uint64(userID), 10)
+
+	rank, err := c.rdb.ZRevRank(ctx, board, member).Result()
+	if errors.Is(err, redis.Nil) {
+		exists, err := c.rdb.Exists(ctx, board).Result()
+		if err != nil {
+			return nil, err
+		}
+		if exists == 0 {
+			return nil, redis.Nil
+		}
+		return []responses.RankedUserItem{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	start := rank - 1
+	if start < 0 {
+		start = 0
+	}
+	zs, err := c.rdb.ZRevRangeWithScores(ctx, board, start, rank+1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]responses.RankedUserItem, 0, len(zs))
+	members := make([]string, 0, len(zs))
+	for i, z := range zs {
+		m, _ := z.Member.(string)
+		id, err := strconv.ParseUint(m, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, responses.RankedUserItem{
+			UserID:   uint(id),
+			Position: int(start) + i + 1,
+			Votes:    DecodeLeaderboardVotes(z.Score),
+		})
+		members = append(members, m)
+	}
+	if len(members) == 0 {
+		return items, nil
+	}
+
+	names, err := c.rdb.HMGet(ctx, c.key(usersKey), members...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range names {
+		if v, ok := name.(string); ok {
+			items[i].Username = v
+		}
+	}
+	return items, nil
+}
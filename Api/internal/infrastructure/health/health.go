@@ -0,0 +1,102 @@
+// Package health evalua las dependencias de la API para GET /readyz. Cada chequeo corre en
+// paralelo con su propio timeout y reporta estado y latencia, con el mismo formato que los
+// workers.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout acota cada chequeo cuando HEALTH_CHECK_TIMEOUT_MS no esta definido.
+const DefaultTimeout = 2 * time.Second
+
+// Estados de cada chequeo y del reporte completo.
+const (
+	StatusOK = "ok"
+	// StatusDegraded indica que solo fallan dependencias opcionales: la API sigue lista.
+	StatusDegraded = "degraded"
+	StatusFail     = "fail"
+)
+
+// Check prueba una dependencia; Run devuelve nil si se puede usar. Un chequeo Optional que
+// falla degrada el reporte pero no saca a la API de rotacion (p.ej. Redis tiene fallback
+// en memoria).
+type Check struct {
+	Name     string
+	Optional bool
+	Run      func(ctx context.Context) error
+}
+
+// Result es el resultado de un chequeo.
+type Result struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+	Optional  bool    `json:"optional,omitempty"`
+}
+
+// Report es el cuerpo de /readyz: el estado global y el resultado de cada chequeo.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Ready indica si la API debe recibir trafico.
+func (r Report) Ready() bool {
+	return r.Status != StatusFail
+}
+
+// Evaluate corre los chequeos en paralelo, cada uno con su propio timeout; uno que no
+// responde a tiempo cuenta como fallido aunque ignore ctx.
+func Evaluate(ctx context.Context, timeout time.Duration, checks ...Check) Report {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c Check) {
+			defer wg.Done()
+			res := run(ctx, timeout, c)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.Name] = res
+			switch {
+			case res.Status == StatusOK:
+			case c.Optional:
+				if report.Status == StatusOK {
+					report.Status = StatusDegraded
+				}
+			default:
+				report.Status = StatusFail
+			}
+		}(c)
+	}
+	wg.Wait()
+	return report
+}
+
+func run(ctx context.Context, timeout time.Duration, c Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	res := Result{Status: StatusOK, LatencyMs: float64(time.Since(start).Microseconds()) / 1000, Optional: c.Optional}
+	if err != nil {
+		res.Status = StatusFail
+		res.Error = err.Error()
+	}
+	return res
+}
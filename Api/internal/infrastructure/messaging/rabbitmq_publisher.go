@@ -1,11 +1,19 @@
 package messaging
 
 import (
+    "context"
     "encoding/json"
-    "log"
+    "errors"
+    "fmt"
+    "log/slog"
+    "sync"
     "time"
 
+    "api/internal/infrastructure/tracing"
+
     "github.com/streadway/amqp"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
 )
 
 // AMQPChannel abstracts the subset of methods used from amqp.Channel.
@@ -17,6 +25,16 @@ type AMQPChannel interface {
     Close() error
 }
 
+// confirmChannel is implemented by channels that support publisher confirms (amqp.Channel).
+// Checked by type assertion so test stubs of AMQPChannel keep working without it.
+type confirmChannel interface {
+    Confirm(noWait bool) error
+    NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation
+}
+
+// defaultConfirmTimeout bounds how long PublishEvent waits for the broker ack.
+const defaultConfirmTimeout = 5 * time.Second
+
 // AMQPConnection abstracts the subset of methods used from amqp.Connection.
 type AMQPConnection interface {
     Channel() (AMQPChannel, error)
@@ -59,6 +77,10 @@ func (r *realChannel) QueueBind(name, key, exchange string, noWait bool, args am
     return r.ch.QueueBind(name, key, exchange, noWait, args)
 }
 func (r *realChannel) Close() error { return r.ch.Close() }
+func (r *realChannel) Confirm(noWait bool) error { return r.ch.Confirm(noWait) }
+func (r *realChannel) NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation {
+    return r.ch.NotifyPublish(confirm)
+}
 
 // RabbitMQPublisher publishes messages to RabbitMQ queues.
 type RabbitMQPublisher struct {
@@ -66,6 +88,14 @@ type RabbitMQPublisher struct {
     channel AMQPChannel
     url     string
     dial    amqpDialer
+
+    // Publisher confirms (see EnableConfirms); mu serializes PublishEvent so each
+    // confirmation read matches the message just published.
+    mu             sync.Mutex
+    confirms       bool
+    confirmCh      chan amqp.Confirmation
+    confirmTimeout time.Duration
+    exchanges      map[string]bool
 }
 
 // NewRabbitMQPublisher constructs a publisher using the real AMQP dialer.
@@ -104,13 +134,66 @@ func (p *RabbitMQPublisher) connect() error {
     }
     p.conn = conn
     p.channel = ch
+    if p.confirms {
+        if err := p.setupConfirms(); err != nil {
+            p.dropConnection()
+            return err
+        }
+    }
+    return nil
+}
+
+// EnableConfirms puts the channel in publisher-confirm mode (re-applied on reconnect) so
+// PublishEvent only succeeds once the broker has taken responsibility for the message.
+// Use a dedicated publisher for confirmed events: Publish does not read confirmations.
+func (p *RabbitMQPublisher) EnableConfirms() error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.confirms = true
+    if err := p.setupConfirms(); err != nil {
+        p.dropConnection()
+        return err
+    }
+    return nil
+}
+
+func (p *RabbitMQPublisher) setupConfirms() error {
+    cc, ok := p.channel.(confirmChannel)
+    if !ok {
+        return errors.New("rabbitmq: channel does not support publisher confirms")
+    }
+    if err := cc.Confirm(false); err != nil {
+        return err
+    }
+    p.confirmCh = cc.NotifyPublish(make(chan amqp.Confirmation, 1))
     return nil
 }
 
+// dropConnection discards the current connection so the next publish reconnects.
+func (p *RabbitMQPublisher) dropConnection() {
+    if p.conn != nil {
+        _ = p.conn.Close()
+    }
+    p.conn = nil
+    p.channel = nil
+    p.confirmCh = nil
+}
+
 func (p *RabbitMQPublisher) isConnected() bool {
     return p.conn != nil && !p.conn.IsClosed() && p.channel != nil
 }
 
+// Ready reports whether the publisher holds an open connection. It does not reconnect:
+// Publish and PublishEvent do that on their next call.
+func (p *RabbitMQPublisher) Ready(context.Context) error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if !p.isConnected() {
+        return errors.New("rabbitmq connection closed")
+    }
+    return nil
+}
+
 // EnsureQueue declares a durable queue with optional DLX and max length.
 // It is safe to call multiple times; server will keep existing settings when compatible.
 func (p *RabbitMQPublisher) EnsureQueue(queueName string, maxLen int, withDLQ bool) error {
@@ -148,7 +231,11 @@ func (p *RabbitMQPublisher) EnsureQueue(queueName string, maxLen int, withDLQ bo
 }
 
 // Publish sends a message with persistent delivery mode and application/json content-type.
-func (p *RabbitMQPublisher) Publish(queueName string, body []byte) error {
+// The trace context of ctx travels in the message headers so consumers continue the trace.
+func (p *RabbitMQPublisher) Publish(ctx context.Context, queueName string, body []byte) error {
+    ctx, span := startPublishSpan(ctx, queueName)
+    defer span.End()
+    headers := tracing.InjectAMQP(ctx, nil)
     retries := 3
     for i := 0; i < retries; i++ {
         if !p.isConnected() {
@@ -161,6 +248,7 @@ func (p *RabbitMQPublisher) Publish(queueName string, body []byte) error {
             }
         }
         err := p.channel.Publish("", queueName, false, false, amqp.Publishing{
+            Headers:      headers,
             DeliveryMode: amqp.Persistent,
             ContentType:  "application/json",
             Body:         body,
@@ -175,19 +263,94 @@ func (p *RabbitMQPublisher) Publish(queueName string, body []byte) error {
     return nil // Don't fail the operation if messaging fails
 }
 
+// PublishEvent publishes a persistent message to a durable topic exchange (declared on first use).
+// Unlike Publish it reports every failure, and with EnableConfirms it waits for the broker ack,
+// so callers such as the vote outbox relay can retry without losing events.
+func (p *RabbitMQPublisher) PublishEvent(ctx context.Context, exchange, routingKey, messageID string, body []byte) (err error) {
+    ctx, span := startPublishSpan(ctx, exchange,
+        attribute.String("messaging.rabbitmq.destination.routing_key", routingKey),
+        attribute.String("messaging.message.id", messageID),
+    )
+    defer func() { tracing.End(span, err) }()
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if !p.isConnected() {
+        if err := p.connect(); err != nil {
+            return err
+        }
+    }
+    if p.exchanges == nil {
+        p.exchanges = map[string]bool{}
+    }
+    if !p.exchanges[exchange] {
+        if err := p.channel.ExchangeDeclare(exchange, "topic", true, false, false, false, nil); err != nil {
+            p.dropConnection()
+            return err
+        }
+        p.exchanges[exchange] = true
+    }
+    err = p.channel.Publish(exchange, routingKey, false, false, amqp.Publishing{
+        Headers:      tracing.InjectAMQP(ctx, nil),
+        DeliveryMode: amqp.Persistent,
+        ContentType:  "application/json",
+        MessageId:    messageID,
+        Type:         routingKey,
+        Timestamp:    time.Now().UTC(),
+        Body:         body,
+    })
+    if err != nil {
+        p.dropConnection()
+        return err
+    }
+    if p.confirmCh == nil {
+        return nil
+    }
+    timeout := p.confirmTimeout
+    if timeout <= 0 {
+        timeout = defaultConfirmTimeout
+    }
+    select {
+    case c, ok := <-p.confirmCh:
+        if !ok {
+            p.dropConnection()
+            return errors.New("rabbitmq: channel closed before publisher confirm")
+        }
+        if !c.Ack {
+            return fmt.Errorf("rabbitmq: broker nacked message %s", messageID)
+        }
+        return nil
+    case <-time.After(timeout):
+        // A late ack would be matched to the next message; start over on a new channel.
+        p.dropConnection()
+        return errors.New("rabbitmq: timed out waiting for publisher confirm")
+    }
+}
+
 // PublishJSON marshals v to JSON and publishes it.
-func (p *RabbitMQPublisher) PublishJSON(queueName string, v interface{}) error {
+func (p *RabbitMQPublisher) PublishJSON(ctx context.Context, queueName string, v interface{}) error {
     b, err := json.Marshal(v)
     if err != nil {
         return err
     }
-    return p.Publish(queueName, b)
+    return p.Publish(ctx, queueName, b)
+}
+
+// startPublishSpan opens a producer span for a message sent to destination (queue or exchange).
+func startPublishSpan(ctx context.Context, destination string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+    attrs = append(attrs,
+        attribute.String("messaging.system", "rabbitmq"),
+        attribute.String("messaging.destination.name", destination),
+    )
+    return tracing.Tracer().Start(ctx, destination+" publish",
+        trace.WithSpanKind(trace.SpanKindProducer),
+        trace.WithAttributes(attrs...),
+    )
 }
 
 func (p *RabbitMQPublisher) Close() error {
     if p.channel != nil {
         if err := p.channel.Close(); err != nil {
-            log.Printf("rabbitmq publisher channel close: %v", err)
+            slog.Warn("rabbitmq publisher channel close", "err", err)
         }
     }
     if p.conn != nil {
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Resultados de la cache de rankings.
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+)
+
+// Acciones sobre votos.
+const (
+	VoteCast    = "vote"
+	VoteRetract = "retract"
+)
+
+// Metrics agrupa las metricas Prometheus de la API en un registro propio, de modo que
+// cada instancia (y cada prueba) expone solo las suyas. Un *Metrics nil no registra nada.
+type Metrics struct {
+	registry        *prometheus.Registry
+	requestDuration *prometheus.HistogramVec
+	uploads         *prometheus.CounterVec
+	votes           *prometheus.CounterVec
+	rankingCache    *prometheus.CounterVec
+}
+
+// New crea las metricas junto con las del proceso y del runtime de Go.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "api",
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		uploads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "api",
+			Name:      "video_uploads_total",
+			Help:      "Video uploads by result (accepted, rejected, failed).",
+		}, []string{"result"}),
+		votes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "api",
+			Name:      "votes_total",
+			Help:      "Votes and retractions by action and result (accepted, rejected, failed).",
+		}, []string{"action", "result"}),
+		rankingCache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "api",
+			Name:      "ranking_cache_requests_total",
+			Help:      "Ranking cache lookups by result (hit, miss, error).",
+		}, []string{"result"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewGoCollector(),
+		m.requestDuration, m.uploads, m.votes, m.rankingCache,
+	)
+	return m
+}
+
+// Handler sirve las metricas en el formato de exposicion de Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest registra la duracion de una solicitud. route es la plantilla de la ruta
+// ("/api/videos/:video_id") para acotar la cardinalidad.
+func (m *Metrics) ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	if m == nil {
+		return
+	}
+	m.requestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(elapsed.Seconds())
+}
+
+// ObserveUpload cuenta una subida segun el status con el que se respondio.
+func (m *Metrics) ObserveUpload(status int) {
+	if m == nil {
+		return
+	}
+	m.uploads.WithLabelValues(result(status)).Inc()
+}
+
+// ObserveVote cuenta un voto o un retiro (VoteCast, VoteRetract) segun el status de la respuesta.
+func (m *Metrics) ObserveVote(action string, status int) {
+	if m == nil {
+		return
+	}
+	m.votes.WithLabelValues(action, result(status)).Inc()
+}
+
+// ObserveRankingCache cuenta una consulta a la cache de rankings (CacheHit, CacheMiss, CacheError).
+func (m *Metrics) ObserveRankingCache(outcome string) {
+	if m == nil {
+		return
+	}
+	m.rankingCache.WithLabelValues(outcome).Inc()
+}
+
+// result resume un status HTTP: 2xx/3xx accepted, 4xx rejected, 5xx failed.
+func result(status int) string {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return "failed"
+	case status >= http.StatusBadRequest:
+		return "rejected"
+	default:
+		return "accepted"
+	}
+}
@@ -0,0 +1,2 @@
+-- No-op: las URLs publicas originales no se pueden reconstruir a partir de la clave.
+SELECT 1;
@@ -0,0 +1,5 @@
+-- processed_file debe almacenar solo la clave del objeto; la API genera URLs firmadas al responder.
+-- Normaliza filas antiguas que guardaban la URL publica completa.
+UPDATE video
+SET processed_file = regexp_replace(processed_file, '^.*/', '')
+WHERE processed_file ~ '^https?://';
@@ -0,0 +1,4 @@
+-- Move moderation statuses back to PROCESSED so rows remain valid after rollback
+UPDATE video SET status = 'PROCESSED' WHERE status IN ('PENDING_REVIEW','REJECTED');
+
+-- Note: PostgreSQL does not support dropping an enum value easily; we keep 'PENDING_REVIEW' and 'REJECTED' present.
@@ -0,0 +1,21 @@
+-- Add enum values for the moderation step between PROCESSED and PUBLISHED.
+-- Kept in its own migration: new enum values cannot be used in the same transaction that adds them.
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM pg_type t
+        JOIN pg_enum e ON t.oid = e.enumtypid
+        WHERE t.typname = 'video_status' AND e.enumlabel = 'PENDING_REVIEW'
+    ) THEN
+        ALTER TYPE video_status ADD VALUE 'PENDING_REVIEW';
+    END IF;
+    IF NOT EXISTS (
+        SELECT 1
+        FROM pg_type t
+        JOIN pg_enum e ON t.oid = e.enumtypid
+        WHERE t.typname = 'video_status' AND e.enumlabel = 'REJECTED'
+    ) THEN
+        ALTER TYPE video_status ADD VALUE 'REJECTED';
+    END IF;
+END $$;
@@ -0,0 +1,13 @@
+DELETE FROM user_role WHERE role_id IN (SELECT role_id FROM role WHERE name = 'moderator');
+DELETE FROM role WHERE name = 'moderator';
+DELETE FROM privilege WHERE name = 'videos:moderate';
+
+DROP TABLE IF EXISTS video_moderation;
+
+ALTER TABLE video DROP COLUMN IF EXISTS rejection_reason;
+
+UPDATE video SET status = 'PROCESSED' WHERE status IN ('PENDING_REVIEW','REJECTED');
+ALTER TABLE video DROP CONSTRAINT IF EXISTS ck_processed_at;
+ALTER TABLE video
+    ADD CONSTRAINT ck_processed_at
+    CHECK (processed_at IS NULL OR status IN ('PROCESSED','PUBLISHED'));
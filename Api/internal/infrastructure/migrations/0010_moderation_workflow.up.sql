@@ -0,0 +1,42 @@
+-- processed_at is kept through the moderation step
+ALTER TABLE video DROP CONSTRAINT IF EXISTS ck_processed_at;
+ALTER TABLE video
+    ADD CONSTRAINT ck_processed_at
+    CHECK (processed_at IS NULL OR status IN ('PROCESSED','PENDING_REVIEW','PUBLISHED','REJECTED'));
+
+ALTER TABLE video ADD COLUMN IF NOT EXISTS rejection_reason VARCHAR(500);
+
+-- Historial de decisiones de moderacion
+CREATE TABLE IF NOT EXISTS video_moderation (
+    moderation_id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    video_id      INTEGER NOT NULL REFERENCES video(video_id) ON DELETE CASCADE,
+    moderator_id  INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    decision      VARCHAR(16) NOT NULL CHECK (decision IN ('APPROVED','REJECTED')),
+    reason        VARCHAR(500),
+    decided_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_video_moderation_video ON video_moderation (video_id);
+
+-- Privilegio y rol de moderador
+INSERT INTO privilege (name, description) VALUES
+    ('videos:moderate','Aprobar o rechazar videos pendientes de revisión')
+    ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role (name, description) VALUES
+    ('moderator','Revisa videos antes de su publicación')
+    ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_privilege (role_id, privilege_id)
+SELECT r.role_id, p.privilege_id
+FROM role r
+JOIN privilege p ON p.name = 'videos:moderate'
+WHERE r.name = 'moderator'
+ON CONFLICT (role_id, privilege_id) DO NOTHING;
+
+INSERT INTO user_role (user_id, role_id)
+SELECT u.user_id, r.role_id
+FROM users u
+CROSS JOIN role r
+WHERE r.name = 'moderator'
+  AND u.email IN ('admin@videorank.com','moderador@videorank.com')
+ON CONFLICT (user_id, role_id) DO NOTHING;
@@ -0,0 +1,10 @@
+DELETE FROM user_role WHERE role_id IN (SELECT role_id FROM role WHERE name = 'admin');
+DELETE FROM role WHERE name = 'admin';
+DELETE FROM privilege WHERE name = 'reports:triage';
+
+DROP TABLE IF EXISTS video_report;
+
+DROP INDEX IF EXISTS ix_video_published_visible;
+ALTER TABLE video DROP CONSTRAINT IF EXISTS ck_video_hidden_reason;
+ALTER TABLE video DROP COLUMN IF EXISTS hidden_reason;
+ALTER TABLE video DROP COLUMN IF EXISTS hidden_at;
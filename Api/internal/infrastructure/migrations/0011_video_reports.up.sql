@@ -0,0 +1,53 @@
+-- Ocultamiento de videos publicados (denuncias o retiro por un administrador)
+ALTER TABLE video ADD COLUMN IF NOT EXISTS hidden_at     TIMESTAMPTZ;
+ALTER TABLE video ADD COLUMN IF NOT EXISTS hidden_reason VARCHAR(16);
+ALTER TABLE video DROP CONSTRAINT IF EXISTS ck_video_hidden_reason;
+ALTER TABLE video
+    ADD CONSTRAINT ck_video_hidden_reason
+    CHECK (hidden_reason IS NULL OR hidden_reason IN ('REPORTS','TAKEDOWN'));
+
+-- Listados publicos y rankings filtran por videos visibles
+CREATE INDEX IF NOT EXISTS ix_video_published_visible
+    ON video (user_id)
+    WHERE status = 'PUBLISHED' AND hidden_at IS NULL;
+
+-- Denuncias de usuarios sobre videos publicados
+CREATE TABLE IF NOT EXISTS video_report (
+    report_id   INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    video_id    INTEGER NOT NULL REFERENCES video(video_id) ON DELETE CASCADE,
+    reporter_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    category    VARCHAR(16) NOT NULL
+        CHECK (category IN ('SPAM','ABUSE','COPYRIGHT','SEXUAL','VIOLENCE','OTHER')),
+    details     VARCHAR(500),
+    status      VARCHAR(16) NOT NULL DEFAULT 'OPEN'
+        CHECK (status IN ('OPEN','DISMISSED','ACTIONED')),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved_at TIMESTAMPTZ,
+    resolved_by INTEGER REFERENCES users(user_id) ON DELETE SET NULL,
+    CONSTRAINT unique_report_user_video UNIQUE (reporter_id, video_id)
+);
+CREATE INDEX IF NOT EXISTS idx_video_report_open ON video_report (video_id) WHERE status = 'OPEN';
+
+-- Privilegio y rol de administrador para el triage de denuncias
+INSERT INTO privilege (name, description) VALUES
+    ('reports:triage','Descartar denuncias o retirar videos publicados')
+    ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role (name, description) VALUES
+    ('admin','Administra denuncias y contenido publicado')
+    ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_privilege (role_id, privilege_id)
+SELECT r.role_id, p.privilege_id
+FROM role r
+JOIN privilege p ON p.name = 'reports:triage'
+WHERE r.name = 'admin'
+ON CONFLICT (role_id, privilege_id) DO NOTHING;
+
+INSERT INTO user_role (user_id, role_id)
+SELECT u.user_id, r.role_id
+FROM users u
+CROSS JOIN role r
+WHERE r.name = 'admin'
+  AND u.email = 'admin@videorank.com'
+ON CONFLICT (user_id, role_id) DO NOTHING;
@@ -0,0 +1,20 @@
+-- Comentarios sobre videos publicos con un nivel de respuestas
+CREATE TABLE IF NOT EXISTS comment (
+    comment_id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    video_id   INTEGER NOT NULL REFERENCES video(video_id) ON DELETE CASCADE,
+    user_id    INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    -- Respuesta a un comentario de primer nivel; al borrar el padre se borran sus respuestas
+    parent_id  INTEGER REFERENCES comment(comment_id) ON DELETE CASCADE,
+    body       VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT ck_comment_body_not_blank CHECK (length(btrim(body)) > 0)
+);
+
+-- Paginacion por cursor (comment_id) de comentarios de primer nivel y de respuestas
+CREATE INDEX IF NOT EXISTS idx_comment_video_top
+    ON comment (video_id, comment_id DESC)
+    WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_comment_parent ON comment (parent_id, comment_id);
+-- Limite de publicacion por usuario
+CREATE INDEX IF NOT EXISTS idx_comment_user_created ON comment (user_id, created_at DESC);
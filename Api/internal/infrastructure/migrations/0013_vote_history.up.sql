@@ -0,0 +1,23 @@
+-- Historial de votos retirados (auditoria). El voto se borra de vote y se copia aqui.
+CREATE TABLE IF NOT EXISTS vote_history (
+    history_id       INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    vote_id          INTEGER NOT NULL,
+    user_id          INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    video_id         INTEGER NOT NULL REFERENCES video(video_id) ON DELETE CASCADE,
+    voted_at         TIMESTAMPTZ NOT NULL,
+    -- event_id del voto original: evita que reenviar el POST recree un voto retirado
+    vote_event_id    TEXT,
+    retracted_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    -- X-Event-Id del DELETE para idempotencia
+    retract_event_id TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_vote_history_retract_event
+    ON vote_history (retract_event_id)
+    WHERE retract_event_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_vote_history_vote_event
+    ON vote_history (vote_event_id)
+    WHERE vote_event_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_vote_history_user_video ON vote_history (user_id, video_id);
+-- AdminCache consulta MAX(retracted_at) para refrescar rankings
+CREATE INDEX IF NOT EXISTS idx_vote_history_retracted_at ON vote_history (retracted_at DESC);
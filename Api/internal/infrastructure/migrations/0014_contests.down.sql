@@ -0,0 +1,9 @@
+DELETE FROM role_privilege WHERE privilege_id IN (SELECT privilege_id FROM privilege WHERE name = 'contests:manage');
+DELETE FROM privilege WHERE name = 'contests:manage';
+
+DROP TABLE IF EXISTS contest_standing;
+
+DROP INDEX IF EXISTS idx_video_contest;
+ALTER TABLE video DROP COLUMN IF EXISTS contest_id;
+
+DROP TABLE IF EXISTS contest;
@@ -0,0 +1,47 @@
+-- Concursos por temporada con ventanas de postulacion y de votacion
+CREATE TABLE IF NOT EXISTS contest (
+    contest_id           INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name                 VARCHAR(120) NOT NULL,
+    submission_starts_at TIMESTAMPTZ NOT NULL,
+    submission_ends_at   TIMESTAMPTZ NOT NULL,
+    voting_starts_at     TIMESTAMPTZ NOT NULL,
+    voting_ends_at       TIMESTAMPTZ NOT NULL,
+    -- Al cerrar la ronda se congela la clasificacion en contest_standing
+    closed_at            TIMESTAMPTZ,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT ck_contest_submission_window CHECK (submission_starts_at < submission_ends_at),
+    CONSTRAINT ck_contest_voting_window CHECK (
+        voting_starts_at < voting_ends_at AND voting_starts_at >= submission_starts_at
+    )
+);
+CREATE INDEX IF NOT EXISTS idx_contest_submission_open
+    ON contest (submission_starts_at, submission_ends_at)
+    WHERE closed_at IS NULL;
+
+-- Inscripcion del video al publicarse (concurso cuya ventana de postulacion contiene uploaded_at)
+ALTER TABLE video ADD COLUMN IF NOT EXISTS contest_id INTEGER REFERENCES contest(contest_id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_video_contest ON video (contest_id) WHERE contest_id IS NOT NULL;
+
+-- Clasificacion final congelada al cerrar un concurso
+CREATE TABLE IF NOT EXISTS contest_standing (
+    contest_id INTEGER NOT NULL REFERENCES contest(contest_id) ON DELETE CASCADE,
+    position   INTEGER NOT NULL,
+    user_id    INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    username   VARCHAR(255) NOT NULL,
+    city       VARCHAR(100),
+    votes      INTEGER NOT NULL,
+    PRIMARY KEY (contest_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_contest_standing_position ON contest_standing (contest_id, position);
+
+-- Privilegio para crear y cerrar concursos
+INSERT INTO privilege (name, description) VALUES
+    ('contests:manage','Crear concursos y cerrar rondas')
+    ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_privilege (role_id, privilege_id)
+SELECT r.role_id, p.privilege_id
+FROM role r
+JOIN privilege p ON p.name = 'contests:manage'
+WHERE r.name = 'admin'
+ON CONFLICT (role_id, privilege_id) DO NOTHING;
@@ -0,0 +1,18 @@
+DELETE FROM role_privilege WHERE privilege_id IN (SELECT privilege_id FROM privilege WHERE name = 'votes:review');
+DELETE FROM privilege WHERE name = 'votes:review';
+
+ALTER TABLE vote_history DROP COLUMN IF EXISTS quarantine_flags;
+ALTER TABLE vote_history DROP COLUMN IF EXISTS retracted_by;
+
+DROP INDEX IF EXISTS idx_vote_reviewed_at;
+DROP INDEX IF EXISTS idx_vote_quarantined;
+DROP INDEX IF EXISTS idx_vote_ip_voted_at;
+DROP INDEX IF EXISTS idx_vote_user_voted_at;
+
+ALTER TABLE vote DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE vote DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE vote DROP COLUMN IF EXISTS quarantine_flags;
+ALTER TABLE vote DROP COLUMN IF EXISTS quarantined_at;
+ALTER TABLE vote DROP COLUMN IF EXISTS account_age_seconds;
+ALTER TABLE vote DROP COLUMN IF EXISTS user_agent_hash;
+ALTER TABLE vote DROP COLUMN IF EXISTS ip;
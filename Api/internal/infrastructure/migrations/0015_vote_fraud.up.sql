@@ -0,0 +1,33 @@
+-- Metadatos de la solicitud guardados con cada voto para deteccion de fraude
+ALTER TABLE vote ADD COLUMN IF NOT EXISTS ip                  VARCHAR(45);
+ALTER TABLE vote ADD COLUMN IF NOT EXISTS user_agent_hash     CHAR(64);
+ALTER TABLE vote ADD COLUMN IF NOT EXISTS account_age_seconds BIGINT;
+-- Cuarentena: el voto no cuenta en rankings ni en los totales publicos hasta que se revise
+ALTER TABLE vote ADD COLUMN IF NOT EXISTS quarantined_at   TIMESTAMPTZ;
+ALTER TABLE vote ADD COLUMN IF NOT EXISTS quarantine_flags VARCHAR(255);
+ALTER TABLE vote ADD COLUMN IF NOT EXISTS reviewed_at      TIMESTAMPTZ;
+ALTER TABLE vote ADD COLUMN IF NOT EXISTS reviewed_by      INTEGER REFERENCES users(user_id) ON DELETE SET NULL;
+
+-- Reglas de velocidad y de cuentas por IP
+CREATE INDEX IF NOT EXISTS idx_vote_user_voted_at ON vote (user_id, voted_at DESC);
+CREATE INDEX IF NOT EXISTS idx_vote_ip_voted_at ON vote (ip, voted_at DESC) WHERE ip IS NOT NULL;
+-- Cola de revision
+CREATE INDEX IF NOT EXISTS idx_vote_quarantined ON vote (quarantined_at) WHERE quarantined_at IS NOT NULL;
+-- AdminCache refresca rankings cuando un revisor libera votos
+CREATE INDEX IF NOT EXISTS idx_vote_reviewed_at ON vote (reviewed_at DESC) WHERE reviewed_at IS NOT NULL;
+
+-- Votos descartados por un revisor quedan en el historial con su autor
+ALTER TABLE vote_history ADD COLUMN IF NOT EXISTS retracted_by INTEGER REFERENCES users(user_id) ON DELETE SET NULL;
+ALTER TABLE vote_history ADD COLUMN IF NOT EXISTS quarantine_flags VARCHAR(255);
+
+-- Privilegio para revisar votos en cuarentena
+INSERT INTO privilege (name, description) VALUES
+    ('votes:review','Liberar o descartar votos en cuarentena')
+    ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_privilege (role_id, privilege_id)
+SELECT r.role_id, p.privilege_id
+FROM role r
+JOIN privilege p ON p.name = 'votes:review'
+WHERE r.name IN ('admin','moderator')
+ON CONFLICT (role_id, privilege_id) DO NOTHING;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_vote_event_outbox_published;
+DROP INDEX IF EXISTS idx_vote_event_outbox_pending;
+DROP TABLE IF EXISTS vote_event_outbox;
@@ -0,0 +1,19 @@
+-- Outbox de eventos de voto: se escribe en la misma transaccion que el voto y un relay
+-- lo publica en el exchange topic de RabbitMQ (entrega al menos una vez).
+CREATE TABLE IF NOT EXISTS vote_event_outbox (
+    outbox_id     BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    routing_key   VARCHAR(64) NOT NULL,
+    payload       JSONB NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at  TIMESTAMPTZ,
+    -- Lease del relay que reclamo el mensaje; vencido, otra instancia puede reintentarlo
+    locked_until  TIMESTAMPTZ,
+    attempts      INTEGER NOT NULL DEFAULT 0,
+    last_error    VARCHAR(500)
+);
+
+CREATE INDEX IF NOT EXISTS idx_vote_event_outbox_pending
+    ON vote_event_outbox (outbox_id) WHERE published_at IS NULL;
+-- Limpieza de mensajes ya publicados (VoteEventRelay.PruneOnce)
+CREATE INDEX IF NOT EXISTS idx_vote_event_outbox_published
+    ON vote_event_outbox (published_at) WHERE published_at IS NOT NULL;
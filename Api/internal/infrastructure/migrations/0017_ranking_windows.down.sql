@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_video_ranked_user;
+DROP INDEX IF EXISTS idx_users_city;
+DROP INDEX IF EXISTS idx_vote_video_voted_at_counted;
@@ -0,0 +1,11 @@
+-- Rankings por ventana de tiempo (window=day|week|month): votos contables de cada video
+-- desde el inicio del periodo sin recorrer el historico completo
+CREATE INDEX IF NOT EXISTS idx_vote_video_voted_at_counted
+    ON vote (video_id, voted_at DESC) WHERE quarantined_at IS NULL;
+
+-- Rankings por pais/ciudad: usuarios de las ciudades filtradas
+CREATE INDEX IF NOT EXISTS idx_users_city ON users (city_id);
+
+-- Videos que cuentan en rankings, por autor
+CREATE INDEX IF NOT EXISTS idx_video_ranked_user
+    ON video (user_id) WHERE status = 'PUBLISHED' AND processed_file IS NOT NULL AND hidden_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_video_public_newest;
+DROP INDEX IF EXISTS idx_video_title_search;
@@ -0,0 +1,10 @@
+-- Galeria publica: busqueda por titulo sin tildes ni mayusculas (GET /api/public/videos?q=).
+-- La expresion debe coincidir con galleryTitleDocument del repositorio para que se use el indice.
+CREATE INDEX IF NOT EXISTS idx_video_title_search
+    ON video USING GIN (to_tsvector('simple', public.immutable_unaccent(LOWER(title))))
+    WHERE status = 'PUBLISHED' AND processed_file IS NOT NULL AND hidden_at IS NULL;
+
+-- Orden newest con paginacion por cursor (uploaded_at, video_id)
+CREATE INDEX IF NOT EXISTS idx_video_public_newest
+    ON video (uploaded_at DESC, video_id DESC)
+    WHERE status = 'PUBLISHED' AND processed_file IS NOT NULL AND hidden_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ux_users_lower_username;
+ALTER TABLE users DROP COLUMN IF EXISTS username;
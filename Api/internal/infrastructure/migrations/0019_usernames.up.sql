@@ -0,0 +1,38 @@
+-- Username publico como columna propia (antes se derivaba con split_part(email,'@',1)).
+ALTER TABLE users ADD COLUMN IF NOT EXISTS username VARCHAR(255);
+
+-- Backfill: parte local del email.
+UPDATE users SET username = split_part(email, '@', 1) WHERE username IS NULL;
+
+-- Deduplicacion (sin distinguir mayusculas) antes de crear el indice unico. En cada
+-- colision conserva el nombre quien lo tiene sin sufijo (o, entre iguales, el usuario mas
+-- antiguo) y el resto recibe el sufijo _<user_id>, igual que en el registro. El sufijo
+-- puede chocar con un nombre literal (p.ej. "ana_57"), asi que se repite hasta que no
+-- quedan duplicados; cada vuelta alarga los nombres renombrados, por lo que termina.
+DO $$
+DECLARE
+    renamed INT;
+BEGIN
+    LOOP
+        WITH ranked AS (
+            SELECT user_id,
+                   ROW_NUMBER() OVER (
+                       PARTITION BY LOWER(username)
+                       ORDER BY (LOWER(username) <> LOWER(split_part(email, '@', 1))), user_id
+                   ) AS rn
+            FROM users
+        )
+        UPDATE users u
+        SET username = u.username || '_' || u.user_id
+        FROM ranked r
+        WHERE r.user_id = u.user_id AND r.rn > 1;
+
+        GET DIAGNOSTICS renamed = ROW_COUNT;
+        EXIT WHEN renamed = 0;
+    END LOOP;
+END $$;
+
+ALTER TABLE users ALTER COLUMN username SET NOT NULL;
+
+-- Unicidad sin distinguir mayusculas; tambien sirve a /api/public/users/{username}
+CREATE UNIQUE INDEX IF NOT EXISTS ux_users_lower_username ON users (LOWER(username));
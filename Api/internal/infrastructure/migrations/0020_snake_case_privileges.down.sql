@@ -0,0 +1,4 @@
+UPDATE privilege SET name = 'videos:moderate' WHERE name = 'moderate_videos';
+UPDATE privilege SET name = 'reports:triage' WHERE name = 'triage_reports';
+UPDATE privilege SET name = 'contests:manage' WHERE name = 'manage_contests';
+UPDATE privilege SET name = 'votes:review' WHERE name = 'review_votes';
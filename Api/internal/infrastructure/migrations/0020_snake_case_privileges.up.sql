@@ -0,0 +1,5 @@
+-- Los privilegios nuevos usan snake_case como los del seed (moderate_content, upload_video).
+UPDATE privilege SET name = 'moderate_videos' WHERE name = 'videos:moderate';
+UPDATE privilege SET name = 'triage_reports' WHERE name = 'reports:triage';
+UPDATE privilege SET name = 'manage_contests' WHERE name = 'contests:manage';
+UPDATE privilege SET name = 'review_votes' WHERE name = 'votes:review';
@@ -0,0 +1,13 @@
+-- Notificaciones para el usuario (p.ej. video rechazado en moderacion). Se escriben en la
+-- misma transaccion que la decision y se consultan en GET /api/me/notifications.
+CREATE TABLE IF NOT EXISTS notification (
+    notification_id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id         INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    type            VARCHAR(64) NOT NULL,
+    payload         JSONB NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    read_at         TIMESTAMPTZ
+);
+
+-- Bandeja del usuario, las mas recientes primero
+CREATE INDEX IF NOT EXISTS idx_notification_user ON notification (user_id, notification_id DESC);
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) interfaces.CommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *entities.Comment) error {
+	return r.db.WithContext(ctx).Create(comment).Error
+}
+
+func (r *commentRepository) GetByID(ctx context.Context, id uint) (*entities.Comment, error) {
+	var c entities.Comment
+	if err := r.db.WithContext(ctx).First(&c, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *commentRepository) List(ctx context.Context, videoID uint, parentID *uint, afterID uint, limit int) ([]responses.CommentResponse, error) {
+	q := r.db.WithContext(ctx).
+		Table("comment cm").
+		Select(`cm.comment_id, cm.parent_id, u.username AS author,
+			cm.body, cm.created_at, cm.updated_at,
+			(SELECT COUNT(*) FROM comment rp WHERE rp.parent_id = cm.comment_id) AS reply_count`).
+		Joins("JOIN users u ON u.user_id = cm.user_id").
+		Where("cm.video_id = ?", videoID).
+		Limit(limit)
+
+	if parentID == nil {
+		q = q.Where("cm.parent_id IS NULL").Order("cm.comment_id DESC")
+		if afterID > 0 {
+			q = q.Where("cm.comment_id < ?", afterID)
+		}
+	} else {
+		q = q.Where("cm.parent_id = ?", *parentID).Order("cm.comment_id ASC")
+		if afterID > 0 {
+			q = q.Where("cm.comment_id > ?", afterID)
+		}
+	}
+
+	var out []responses.CommentResponse
+	if err := q.Scan(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *commentRepository) UpdateBody(ctx context.Context, id uint, body string) error {
+	res := r.db.WithContext(ctx).Model(&entities.Comment{}).
+		Where("comment_id = ?", id).
+		Updates(map[string]any{"body": body, "updated_at": time.Now().UTC()})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *commentRepository) Delete(ctx context.Context, id uint) error {
+	res := r.db.WithContext(ctx).Delete(&entities.Comment{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// commentRateLockSpace separa los advisory locks del limite de comentarios de otros usos.
+const commentRateLockSpace = 29
+
+// CreateWithinLimit toma un advisory lock por usuario durante la transaccion: sin el, dos
+// solicitudes concurrentes podrian contar a la vez y superar el limite.
+func (r *commentRepository) CreateWithinLimit(ctx context.Context, comment *entities.Comment, max int, since time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?::int, ?::int)", commentRateLockSpace, comment.UserID).Error; err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&entities.Comment{}).
+			Where("user_id = ? AND created_at >= ?", comment.UserID, since).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= int64(max) {
+			return domain.ErrRateLimited
+		}
+		return tx.Create(comment).Error
+	})
+}
+
+func (r *commentRepository) VideoOwnerID(ctx context.Context, videoID uint) (uint, error) {
+	var owners []uint
+	if err := r.db.WithContext(ctx).Table("video").
+		Where("video_id = ?", videoID).
+		Pluck("user_id", &owners).Error; err != nil {
+		return 0, err
+	}
+	if len(owners) == 0 {
+		return 0, domain.ErrNotFound
+	}
+	return owners[0], nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// contestStandingSQL calcula la clasificacion en vivo de un concurso con las mismas
+// reglas que Rankings (videos publicados, procesados y visibles) restringidas a sus videos.
+const contestStandingSQL = `
+SELECT u.user_id,
+       u.username,
+       c.name AS city,
+       COUNT(vt.vote_id) AS votes
+FROM users u
+JOIN city c ON c.city_id = u.city_id
+JOIN video v ON v.user_id = u.user_id
+` + leftJoinVoteOnVideo + `
+WHERE v.contest_id = ? AND ` + publicVideoFilter + `
+GROUP BY u.user_id, u.username, c.name`
+
+type contestRepository struct {
+	db *gorm.DB
+}
+
+func NewContestRepository(db *gorm.DB) interfaces.ContestRepository {
+	return &contestRepository{db: db}
+}
+
+func (r *contestRepository) Create(ctx context.Context, contest *entities.Contest) error {
+	return r.db.WithContext(ctx).Create(contest).Error
+}
+
+func (r *contestRepository) GetByID(ctx context.Context, contestID uint) (*entities.Contest, error) {
+	var contest entities.Contest
+	if err := r.db.WithContext(ctx).First(&contest, contestID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &contest, nil
+}
+
+func (r *contestRepository) List(ctx context.Context) ([]entities.Contest, error) {
+	var contests []entities.Contest
+	if err := r.db.WithContext(ctx).
+		Order("voting_starts_at DESC, contest_id DESC").
+		Find(&contests).Error; err != nil {
+		return nil, err
+	}
+	return contests, nil
+}
+
+func (r *contestRepository) GetForVideo(ctx context.Context, videoID uint) (*entities.Contest, error) {
+	var contests []entities.Contest
+	err := r.db.WithContext(ctx).
+		Table("contest ct").
+		Select("ct.*").
+		Joins("JOIN video v ON v.contest_id = ct.contest_id").
+		Where("v.video_id = ?", videoID).
+		Limit(1).
+		Find(&contests).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(contests) == 0 {
+		return nil, nil
+	}
+	return &contests[0], nil
+}
+
+func (r *contestRepository) Close(ctx context.Context, contestID uint) (*entities.Contest, error) {
+	var contest entities.Contest
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entities.Contest{}).
+			Where("contest_id = ? AND closed_at IS NULL", contestID).
+			Update("closed_at", time.Now().UTC())
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&entities.Contest{}).Where("contest_id = ?", contestID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return domain.ErrNotFound
+			}
+			return domain.ErrConflict
+		}
+		// Congelar la clasificacion final; desempate estable por user_id como en Rankings.
+		if err := tx.Exec(`
+			INSERT INTO contest_standing (contest_id, position, user_id, username, city, votes)
+			SELECT ?, ROW_NUMBER() OVER (ORDER BY s.votes DESC, s.user_id ASC), s.user_id, s.username, s.city, s.votes
+			FROM (`+contestStandingSQL+`) s`,
+			contestID, contestID, "PUBLISHED").Error; err != nil {
+			return err
+		}
+		return tx.First(&contest, contestID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &contest, nil
+}
+
+func (r *contestRepository) Rankings(ctx context.Context, contestID uint, city *string, page, pageSize int) ([]responses.RankingItem, error) {
+	type row struct {
+		Username string  `gorm:"column:username"`
+		City     *string `gorm:"column:city"`
+		Votes    int     `gorm:"column:votes"`
+	}
+
+	var closed int64
+	if err := r.db.WithContext(ctx).Model(&entities.Contest{}).
+		Where("contest_id = ? AND closed_at IS NOT NULL", contestID).
+		Count(&closed).Error; err != nil {
+		return nil, err
+	}
+
+	var q *gorm.DB
+	if closed > 0 {
+		q = r.db.WithContext(ctx).
+			Table("contest_standing s").
+			Select("s.username, s.city, s.votes").
+			Where("s.contest_id = ?", contestID).
+			Order("s.position ASC")
+	} else {
+		q = r.db.WithContext(ctx).
+			Table("(?) s", r.db.Raw(contestStandingSQL, contestID, "PUBLISHED")).
+			Select("s.username, s.city, s.votes").
+			Order("s.votes DESC, s.user_id ASC")
+	}
+	if city != nil && *city != "" {
+		q = q.Where("immutable_unaccent(LOWER(s.city)) = immutable_unaccent(LOWER(?))", *city)
+	}
+
+	var rows []row
+	if err := q.Limit(pageSize).Offset((page - 1) * pageSize).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]responses.RankingItem, 0, len(rows))
+	for _, rr := range rows {
+		items = append(items, responses.RankingItem{Username: rr.Username, City: rr.City, Votes: rr.Votes})
+	}
+	return items, nil
+}
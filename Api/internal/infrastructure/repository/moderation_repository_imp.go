@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	moderationApproved = "APPROVED"
+	moderationRejected = "REJECTED"
+)
+
+// enrollContestExpr inscribe el video al publicarse en el concurso abierto cuya ventana
+// de postulacion contiene la fecha de subida; NULL si no hay ninguno.
+const enrollContestExpr = `(
+	SELECT ct.contest_id FROM contest ct
+	WHERE ct.closed_at IS NULL
+	  AND video.uploaded_at >= ct.submission_starts_at
+	  AND video.uploaded_at < ct.submission_ends_at
+	ORDER BY ct.submission_starts_at DESC, ct.contest_id DESC
+	LIMIT 1)`
+
+type moderationRepository struct {
+	db *gorm.DB
+}
+
+func NewModerationRepository(db *gorm.DB) interfaces.ModerationRepository {
+	return &moderationRepository{db: db}
+}
+
+func (r *moderationRepository) ListPending(ctx context.Context, page, pageSize int) ([]responses.ModerationQueueItem, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	var out []responses.ModerationQueueItem
+	err := r.db.WithContext(ctx).
+		Table("video v").
+		Select(`v.video_id, v.title, v.user_id AS owner_user_id,
+			u.username AS owner_username,
+			v.processed_file AS processed_url, v.uploaded_at, v.processed_at`).
+		Joins("JOIN users u ON u.user_id = v.user_id").
+		Where("v.status = ?", string(entities.StatusPendingReview)).
+		Order("v.processed_at ASC NULLS LAST, v.video_id ASC").
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Scan(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *moderationRepository) Approve(ctx context.Context, videoID, moderatorID uint) (*entities.Video, error) {
+	return r.decide(ctx, videoID, moderatorID, entities.StatusPublished, moderationApproved, nil)
+}
+
+func (r *moderationRepository) Reject(ctx context.Context, videoID, moderatorID uint, reason string) (*entities.Video, error) {
+	return r.decide(ctx, videoID, moderatorID, entities.StatusRejected, moderationRejected, &reason)
+}
+
+// decide aplica la transicion solo si el video sigue en PENDING_REVIEW, de modo que
+// dos moderadores concurrentes no puedan decidir sobre el mismo video.
+func (r *moderationRepository) decide(ctx context.Context, videoID, moderatorID uint, to entities.VideoStatus, decision string, reason *string) (*entities.Video, error) {
+	type moderationRow struct {
+		VideoID     uint      `gorm:"column:video_id"`
+		ModeratorID uint      `gorm:"column:moderator_id"`
+		Decision    string    `gorm:"column:decision"`
+		Reason      *string   `gorm:"column:reason"`
+		DecidedAt   time.Time `gorm:"column:decided_at"`
+	}
+
+	var video entities.Video
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]any{
+			"status":           string(to),
+			"rejection_reason": reason,
+		}
+		if to == entities.StatusPublished {
+			updates["contest_id"] = gorm.Expr(enrollContestExpr)
+		}
+		res := tx.Model(&entities.Video{}).
+			Where("video_id = ? AND status = ?", videoID, string(entities.StatusPendingReview)).
+			Updates(updates)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&entities.Video{}).Where("video_id = ?", videoID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return domain.ErrNotFound
+			}
+			return domain.ErrConflict
+		}
+		row := moderationRow{
+			VideoID:     videoID,
+			ModeratorID: moderatorID,
+			Decision:    decision,
+			Reason:      reason,
+			DecidedAt:   time.Now().UTC(),
+		}
+		if err := tx.Table("video_moderation").Create(&row).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&video, videoID).Error; err != nil {
+			return err
+		}
+		if to == entities.StatusRejected {
+			return insertVideoRejectedNotification(tx, &video, *reason)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &video, nil
+}
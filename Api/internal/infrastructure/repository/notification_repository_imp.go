@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) interfaces.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) ListByUser(ctx context.Context, userID uint, page, pageSize int) ([]responses.NotificationResponse, error) {
+	type row struct {
+		NotificationID uint       `gorm:"column:notification_id"`
+		Type           string     `gorm:"column:type"`
+		Payload        string     `gorm:"column:payload"`
+		CreatedAt      time.Time  `gorm:"column:created_at"`
+		ReadAt         *time.Time `gorm:"column:read_at"`
+	}
+	var rows []row
+	err := r.db.WithContext(ctx).
+		Table("notification").
+		Select("notification_id, type, payload::text AS payload, created_at, read_at").
+		Where("user_id = ?", userID).
+		Order("notification_id DESC").
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]responses.NotificationResponse, 0, len(rows))
+	for _, n := range rows {
+		out = append(out, responses.NotificationResponse{
+			NotificationID: n.NotificationID,
+			Type:           n.Type,
+			Payload:        json.RawMessage(n.Payload),
+			CreatedAt:      n.CreatedAt,
+			ReadAt:         n.ReadAt,
+		})
+	}
+	return out, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, notificationID uint) error {
+	res := r.db.WithContext(ctx).Model(&entities.Notification{}).
+		Where("notification_id = ? AND user_id = ?", notificationID, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, now())"))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// insertVideoRejectedNotification avisa al propietario dentro de la transaccion de la decision.
+func insertVideoRejectedNotification(tx *gorm.DB, video *entities.Video, reason string) error {
+	payload, err := json.Marshal(entities.VideoRejectedPayload{VideoID: video.VideoID, Title: video.Title, Reason: reason})
+	if err != nil {
+		return err
+	}
+	return tx.Create(&entities.Notification{
+		UserID:  video.UserID,
+		Type:    entities.NotificationVideoRejected,
+		Payload: string(payload),
+	}).Error
+}
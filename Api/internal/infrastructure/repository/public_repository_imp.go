@@ -2,15 +2,30 @@ package repository
 
 import (
 	"api/internal/domain"
+	"api/internal/domain/entities"
 	"api/internal/domain/interfaces"
 	"api/internal/domain/responses"
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 const joinCityOnUser = "JOIN city c ON c.city_id = u.city_id"
-const leftJoinVoteOnVideo = "LEFT JOIN vote vt ON vt.video_id = v.video_id"
+
+// leftJoinVoteOnVideo solo une votos contables: los votos en cuarentena no suman
+// en rankings ni en los totales publicos hasta que un revisor los libere.
+const leftJoinVoteOnVideo = "LEFT JOIN vote vt ON vt.video_id = v.video_id AND vt.quarantined_at IS NULL"
+
+// commentCountColumn cuenta comentarios y respuestas sin multiplicar las filas del JOIN de votos.
+const commentCountColumn = "(SELECT COUNT(*) FROM comment cm WHERE cm.video_id = v.video_id) AS comments"
+
+// publishedAtColumn toma la ultima aprobacion de moderacion; los videos publicados antes
+// del flujo de moderacion usan la fecha de procesamiento o de subida.
+const publishedAtColumn = "COALESCE((SELECT MAX(vm.decided_at) FROM video_moderation vm WHERE vm.video_id = v.video_id AND vm.decision = 'APPROVED'), v.processed_at, v.uploaded_at) AS published_at"
+
+// publicVideoFilter limita a videos publicados y no retirados (denuncias o takedown).
+const publicVideoFilter = "v.status = ? AND v.processed_file IS NOT NULL AND v.hidden_at IS NULL"
 
 type publicRepository struct {
 	db *gorm.DB
@@ -24,11 +39,11 @@ func (r *publicRepository) ListPublicVideos(ctx context.Context) ([]responses.Pu
 	var results []responses.PublicVideoResponse
 	q := r.db.WithContext(ctx).
 		Table("video v").
-		Select("v.video_id AS video_id, v.title, v.processed_file AS processed_url, c.name AS city, COUNT(vt.vote_id) AS votes, u.user_id AS owner_user_id").
+		Select("v.video_id AS video_id, v.title, v.processed_file AS processed_url, c.name AS city, COUNT(vt.vote_id) AS votes, "+commentCountColumn+", u.user_id AS owner_user_id, "+publishedAtColumn).
 		Joins("JOIN users u ON u.user_id = v.user_id").
 		Joins(joinCityOnUser).
 		Joins(leftJoinVoteOnVideo).
-		Where("v.status = ? AND v.processed_file IS NOT NULL", "PUBLISHED").
+		Where(publicVideoFilter, "PUBLISHED").
 		Group("v.video_id, v.title, v.processed_file, c.name, u.user_id")
 
 	if err := q.Scan(&results).Error; err != nil {
@@ -37,15 +52,102 @@ func (r *publicRepository) ListPublicVideos(ctx context.Context) ([]responses.Pu
 	return results, nil
 }
 
+// galleryTitleDocument es la expresion indexada por idx_video_title_search (migracion 0018).
+const galleryTitleDocument = "to_tsvector('simple', public.immutable_unaccent(LOWER(v.title)))"
+
+// galleryVotesColumn cuenta votos contables con subconsultas correlacionadas: evita el
+// GROUP BY sobre todos los videos y solo se evalua para las filas que se devuelven
+// cuando el orden no depende de los votos.
+const galleryVotesColumn = "(SELECT COUNT(*) FROM vote vt WHERE vt.video_id = v.video_id AND vt.quarantined_at IS NULL) AS votes"
+
+// galleryVideos arma la consulta de videos publicos con los filtros de la galeria.
+// Se llama una vez por consulta porque los *gorm.DB encadenados se mutan.
+func galleryVideos(db *gorm.DB, q entities.GalleryQuery) *gorm.DB {
+	tx := db.Table("video v").
+		Joins("JOIN users u ON u.user_id = v.user_id").
+		Joins(joinCityOnUser).
+		Where(publicVideoFilter, "PUBLISHED")
+
+	if q.Search != "" {
+		tx = tx.Where(galleryTitleDocument+" @@ plainto_tsquery('simple', public.immutable_unaccent(LOWER(?)))", q.Search)
+	}
+	if q.OwnerUserID != nil {
+		tx = tx.Where("v.user_id = ?", *q.OwnerUserID)
+	}
+	if city := q.City; city != nil && *city != "" {
+		tx = tx.Where("immutable_unaccent(LOWER(c.name)) = immutable_unaccent(LOWER(?))", *city)
+	}
+	if country := q.Country; country != nil && *country != "" {
+		tx = tx.Joins("JOIN country co ON co.country_id = c.country_id").
+			Where("(immutable_unaccent(LOWER(co.name)) = immutable_unaccent(LOWER(?)) OR co.iso_code = UPPER(?))", *country, *country)
+	}
+	return tx
+}
+
+// SearchPublicVideos implementa la galeria publica con paginacion keyset.
+// newest recorre idx_video_public_newest y se detiene en q.Limit filas; most_voted y
+// trending agregan solo los votos de los videos que pasan los filtros (ciudad, pais,
+// autor, busqueda) y paginan sobre el resultado. Trending cuenta desde q.TrendingSince,
+// fijo para todas las paginas de un mismo recorrido.
+func (r *publicRepository) SearchPublicVideos(ctx context.Context, q entities.GalleryQuery) ([]responses.GalleryVideo, error) {
+	const commonColumns = "v.video_id AS video_id, v.title, v.processed_file AS processed_url, c.name AS city, " +
+		commentCountColumn + ", u.user_id AS owner_user_id, v.uploaded_at AS uploaded_at, " + publishedAtColumn
+
+	var stmt *gorm.DB
+	switch q.Sort {
+	case entities.GallerySortMostVoted, entities.GallerySortTrending:
+		db := r.db.WithContext(ctx)
+		ids := galleryVideos(db, q).Select("v.video_id")
+		counts := db.Table("vote vt").
+			Where("vt.quarantined_at IS NULL AND vt.video_id IN (?)", ids).
+			Group("vt.video_id")
+		columns := commonColumns + ", COALESCE(vc.votes, 0) AS votes"
+		key := "g.votes"
+		if q.Sort == entities.GallerySortTrending {
+			since := q.TrendingSince
+			if since.IsZero() {
+				since = time.Now().Add(-entities.GalleryTrendingWindow)
+			}
+			counts = counts.Select("vt.video_id, COUNT(*) AS votes, COUNT(*) FILTER (WHERE vt.voted_at >= ?) AS trending_votes", since)
+			columns += ", COALESCE(vc.trending_votes, 0) AS trending_votes"
+			key = "g.trending_votes"
+		} else {
+			counts = counts.Select("vt.video_id, COUNT(*) AS votes")
+		}
+		ranked := galleryVideos(db, q).
+			Select(columns).
+			Joins("LEFT JOIN (?) AS vc ON vc.video_id = v.video_id", counts)
+
+		stmt = db.Table("(?) AS g", ranked).Select("g.*")
+		if q.After != nil {
+			stmt = stmt.Where("("+key+", g.video_id) < (?, ?)", q.After.Key, q.After.VideoID)
+		}
+		stmt = stmt.Order(key + " DESC, g.video_id DESC")
+	default:
+		stmt = galleryVideos(r.db.WithContext(ctx), q).Select(commonColumns + ", " + galleryVotesColumn)
+		if q.After != nil {
+			stmt = stmt.Where("(v.uploaded_at, v.video_id) < (?, ?)", time.UnixMicro(q.After.Key).UTC(), q.After.VideoID)
+		}
+		stmt = stmt.Order("v.uploaded_at DESC, v.video_id DESC")
+	}
+
+	var results []responses.GalleryVideo
+	if err := stmt.Limit(q.Limit).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 func (r *publicRepository) GetPublicByID(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
 	var result responses.PublicVideoResponse
 	q := r.db.WithContext(ctx).
 		Table("video v").
-		Select("v.video_id AS video_id, v.title, v.processed_file AS processed_url, c.name AS city, COUNT(vt.vote_id) AS votes, u.user_id AS owner_user_id").
+		Select("v.video_id AS video_id, v.title, v.processed_file AS processed_url, c.name AS city, COUNT(vt.vote_id) AS votes, "+commentCountColumn+", u.user_id AS owner_user_id, "+publishedAtColumn).
 		Joins("JOIN users u ON u.user_id = v.user_id").
 		Joins(joinCityOnUser).
 		Joins(leftJoinVoteOnVideo).
-		Where("v.video_id = ? AND v.status = ? AND v.processed_file IS NOT NULL", id, "PUBLISHED").
+		Where("v.video_id = ?", id).
+		Where(publicVideoFilter, "PUBLISHED").
 		Group("v.video_id, v.title, v.processed_file, c.name, u.user_id")
 
 	if err := q.Take(&result).Error; err != nil {
@@ -60,6 +162,24 @@ func (r *publicRepository) GetPublicByID(ctx context.Context, id uint) (*respons
 // Rankings agrega votos por usuario (solo sobre videos públicos procesados) y ordena por votos desc.
 // Desempate TBD: se aplica orden estable por user_id (no expuesto).
 func (r *publicRepository) Rankings(ctx context.Context, city *string, page, pageSize int) ([]responses.RankingItem, error) {
+	return r.FilteredRankings(ctx, entities.RankingFilter{City: city}, page, pageSize)
+}
+
+// rankingWindowStart devuelve el inicio del periodo calendario (UTC) de la ventana,
+// calculado en la base de datos; vacio para el ranking historico.
+func rankingWindowStart(w entities.RankingWindow) string {
+	switch w {
+	case entities.RankingWindowDay, entities.RankingWindowWeek, entities.RankingWindowMonth:
+		return "date_trunc('" + string(w) + "', now() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'"
+	default:
+		return ""
+	}
+}
+
+// FilteredRankings es Rankings con filtro opcional de pais y ventana de tiempo.
+// Con ventana solo cuentan los votos emitidos desde el inicio del periodo; los usuarios
+// con videos publicos siguen apareciendo con 0 votos, igual que en el ranking historico.
+func (r *publicRepository) FilteredRankings(ctx context.Context, filter entities.RankingFilter, page, pageSize int) ([]responses.RankingItem, error) {
 	type row struct {
 		Username string  `gorm:"column:username"`
 		City     *string `gorm:"column:city"`
@@ -68,22 +188,32 @@ func (r *publicRepository) Rankings(ctx context.Context, city *string, page, pag
 
 	offset := (page - 1) * pageSize
 
+	voteJoin := leftJoinVoteOnVideo
+	if start := rankingWindowStart(filter.Window); start != "" {
+		voteJoin += " AND vt.voted_at >= " + start
+	}
+
 	q := r.db.WithContext(ctx).
 		Table("users u").
-		Select("split_part(u.email, '@', 1) AS username, c.name AS city, COUNT(vt.vote_id) AS votes").
+		Select("u.username, c.name AS city, COUNT(vt.vote_id) AS votes").
 		Joins(joinCityOnUser).
 		Joins("JOIN video v ON v.user_id = u.user_id").
-		Joins(leftJoinVoteOnVideo).
-		Where("v.status = ? AND v.processed_file IS NOT NULL", "PUBLISHED").
-		Group("u.user_id, u.email, c.name").
+		Joins(voteJoin).
+		Where(publicVideoFilter, "PUBLISHED").
+		Group("u.user_id, u.username, c.name").
 		Order("votes DESC, u.user_id ASC"). // desempate interno estable (TBD)
 		Limit(pageSize).
 		Offset(offset)
 
-	if city != nil && *city != "" {
+	if city := filter.City; city != nil && *city != "" {
 		// Filtro de ciudad sin tildes ni mayúsculas/minúsculas, usando wrapper inmutable para que el planificador use el índice
 		q = q.Where("immutable_unaccent(LOWER(c.name)) = immutable_unaccent(LOWER(?))", *city)
 	}
+	if country := filter.Country; country != nil && *country != "" {
+		// Nombre del pais (mismo criterio que la ciudad) o codigo ISO
+		q = q.Joins("JOIN country co ON co.country_id = c.country_id").
+			Where("(immutable_unaccent(LOWER(co.name)) = immutable_unaccent(LOWER(?)) OR co.iso_code = UPPER(?))", *country, *country)
+	}
 
 	var rows []row
 	if err := q.Scan(&rows).Error; err != nil {
@@ -101,6 +231,133 @@ func (r *publicRepository) Rankings(ctx context.Context, city *string, page, pag
 	return items, nil
 }
 
+// VideoRankings agrega votos por video publico con los mismos filtros que Rankings.
+// Desempate estable por video_id.
+func (r *publicRepository) VideoRankings(ctx context.Context, city *string, page, pageSize int) ([]responses.VideoRankingItem, error) {
+	type row struct {
+		VideoID  uint    `gorm:"column:video_id"`
+		Title    string  `gorm:"column:title"`
+		Username string  `gorm:"column:username"`
+		City     *string `gorm:"column:city"`
+		Votes    int     `gorm:"column:votes"`
+	}
+
+	offset := (page - 1) * pageSize
+
+	q := r.db.WithContext(ctx).
+		Table("video v").
+		Select("v.video_id, v.title, u.username, c.name AS city, COUNT(vt.vote_id) AS votes").
+		Joins("JOIN users u ON u.user_id = v.user_id").
+		Joins(joinCityOnUser).
+		Joins(leftJoinVoteOnVideo).
+		Where(publicVideoFilter, "PUBLISHED").
+		Group("v.video_id, v.title, u.username, c.name").
+		Order("votes DESC, v.video_id ASC").
+		Limit(pageSize).
+		Offset(offset)
+
+	if city != nil && *city != "" {
+		q = q.Where("immutable_unaccent(LOWER(c.name)) = immutable_unaccent(LOWER(?))", *city)
+	}
+
+	var rows []row
+	if err := q.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]responses.VideoRankingItem, 0, len(rows))
+	for _, rrow := range rows {
+		items = append(items, responses.VideoRankingItem{
+			VideoID:  rrow.VideoID,
+			Title:    rrow.Title,
+			Username: rrow.Username,
+			City:     rrow.City,
+			Votes:    rrow.Votes,
+		})
+	}
+	return items, nil
+}
+
+// UserBasicByUsername busca un usuario por username sin distinguir mayusculas (ux_users_lower_username).
+func (r *publicRepository) UserBasicByUsername(ctx context.Context, username string) (*responses.UserBasic, error) {
+	type row struct {
+		UserID   uint    `gorm:"column:user_id"`
+		Username string  `gorm:"column:username"`
+		City     *string `gorm:"column:city"`
+	}
+	var rows []row
+	err := r.db.WithContext(ctx).
+		Table("users u").
+		Select("u.user_id, u.username, c.name AS city").
+		Joins(joinCityOnUser).
+		Where("LOWER(u.username) = LOWER(?)", username).
+		Limit(1).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &responses.UserBasic{UserID: rows[0].UserID, Username: rows[0].Username, City: rows[0].City}, nil
+}
+
+// rankNeighborhoodSQL numera el ranking historico (mismos filtros y orden que Rankings)
+// y devuelve la fila del usuario con sus vecinos inmediatos.
+const rankNeighborhoodSQL = `
+WITH ranked AS (
+  SELECT u.user_id, u.username, c.name AS city, COUNT(vt.vote_id) AS votes,
+         ROW_NUMBER() OVER (ORDER BY COUNT(vt.vote_id) DESC, u.user_id ASC) AS position
+  FROM users u
+  ` + joinCityOnUser + `
+  JOIN video v ON v.user_id = u.user_id
+  ` + leftJoinVoteOnVideo + `
+  WHERE ` + publicVideoFilter + `
+    AND (? = '' OR immutable_unaccent(LOWER(c.name)) = immutable_unaccent(LOWER(?)))
+  GROUP BY u.user_id, u.username, c.name
+), me AS (
+  SELECT position FROM ranked WHERE user_id = ?
+)
+SELECT ranked.user_id, ranked.username, ranked.city, ranked.votes, ranked.position
+FROM ranked, me
+WHERE ranked.position BETWEEN me.position - 1 AND me.position + 1
+ORDER BY ranked.position`
+
+// RankNeighborhood ubica al usuario en el ranking historico, global o de una ciudad.
+func (r *publicRepository) RankNeighborhood(ctx context.Context, userID uint, city *string) ([]responses.RankedUserItem, error) {
+	type row struct {
+		UserID   uint    `gorm:"column:user_id"`
+		Username string  `gorm:"column:username"`
+		City     *string `gorm:"column:city"`
+		Votes    int     `gorm:"column:votes"`
+		Position int     `gorm:"column:position"`
+	}
+
+	cityName := ""
+	if city != nil {
+		cityName = *city
+	}
+
+	var rows []row
+	if err := r.db.WithContext(ctx).
+		Raw(rankNeighborhoodSQL, "PUBLISHED", cityName, cityName, userID).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]responses.RankedUserItem, 0, len(rows))
+	for _, rr := range rows {
+		items = append(items, responses.RankedUserItem{
+			UserID:   rr.UserID,
+			Position: rr.Position,
+			Username: rr.Username,
+			City:     rr.City,
+			Votes:    rr.Votes,
+		})
+	}
+	return items, nil
+}
+
 // GetUsersBasicByIDs retorna username y ciudad para los IDs provistos.
 func (r *publicRepository) GetUsersBasicByIDs(ctx context.Context, ids []uint) ([]responses.UserBasic, error) {
 	if len(ids) == 0 {
@@ -114,7 +371,7 @@ func (r *publicRepository) GetUsersBasicByIDs(ctx context.Context, ids []uint) (
 	var rows []row
 	q := r.db.WithContext(ctx).
 		Table("users u").
-		Select("u.user_id, split_part(u.email, '@', 1) AS username, c.name AS city").
+		Select("u.user_id, u.username, c.name AS city").
 		Joins(joinCityOnUser).
 		Where("u.user_id IN ?", ids)
 	if err := q.Scan(&rows).Error; err != nil {
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) interfaces.ReportRepository {
+	return &reportRepository{db: db}
+}
+
+func (r *reportRepository) Create(ctx context.Context, report *entities.VideoReport, hideThreshold int) (bool, error) {
+	hidden := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bloquea el video para que el conteo contra el umbral sea consistente entre denuncias concurrentes
+		if err := lockVideo(tx, report.VideoID); err != nil {
+			return err
+		}
+		if err := tx.Create(report).Error; err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return domain.ErrConflict
+			}
+			return err
+		}
+		if hideThreshold <= 0 {
+			return nil
+		}
+		open, err := countOpenReports(tx, report.VideoID)
+		if err != nil {
+			return err
+		}
+		if open < int64(hideThreshold) {
+			return nil
+		}
+		res := tx.Model(&entities.Video{}).
+			Where("video_id = ? AND hidden_at IS NULL", report.VideoID).
+			Updates(map[string]any{"hidden_at": time.Now().UTC(), "hidden_reason": entities.HiddenByReports})
+		if res.Error != nil {
+			return res.Error
+		}
+		hidden = res.RowsAffected > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return hidden, nil
+}
+
+func (r *reportRepository) ListOpen(ctx context.Context, page, pageSize int) ([]responses.ReportResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	var out []responses.ReportResponse
+	err := r.db.WithContext(ctx).
+		Table("video_report vr").
+		Select(`vr.report_id, vr.video_id, v.title AS video_title,
+			u.username AS reporter_username,
+			vr.category, vr.details, vr.status, vr.created_at,
+			COUNT(*) OVER (PARTITION BY vr.video_id) AS open_reports,
+			(v.hidden_at IS NOT NULL) AS video_hidden`).
+		Joins("JOIN video v ON v.video_id = vr.video_id").
+		Joins("JOIN users u ON u.user_id = vr.reporter_id").
+		Where("vr.status = ?", string(entities.ReportOpen)).
+		Order("open_reports DESC, vr.created_at ASC, vr.report_id ASC").
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Scan(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *reportRepository) Dismiss(ctx context.Context, reportID, adminID uint, hideThreshold int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		report, err := lockVideoAndOpenReport(tx, reportID)
+		if err != nil {
+			return err
+		}
+		if err := resolveReports(tx, entities.ReportDismissed, adminID, "report_id = ?", reportID); err != nil {
+			return err
+		}
+		open, err := countOpenReports(tx, report.VideoID)
+		if err != nil {
+			return err
+		}
+		if hideThreshold > 0 && open >= int64(hideThreshold) {
+			return nil
+		}
+		// Solo se restaura el ocultamiento automatico; un retiro del administrador es definitivo
+		return tx.Model(&entities.Video{}).
+			Where("video_id = ? AND hidden_reason = ?", report.VideoID, entities.HiddenByReports).
+			Updates(map[string]any{"hidden_at": nil, "hidden_reason": nil}).Error
+	})
+}
+
+func (r *reportRepository) TakeDown(ctx context.Context, reportID, adminID uint) (uint, error) {
+	var videoID uint
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		report, err := lockVideoAndOpenReport(tx, reportID)
+		if err != nil {
+			return err
+		}
+		videoID = report.VideoID
+		if err := tx.Model(&entities.Video{}).
+			Where("video_id = ?", videoID).
+			Updates(map[string]any{
+				"hidden_at":     gorm.Expr("COALESCE(hidden_at, ?)", time.Now().UTC()),
+				"hidden_reason": entities.HiddenByTakedown,
+			}).Error; err != nil {
+			return err
+		}
+		return resolveReports(tx, entities.ReportActioned, adminID,
+			"video_id = ? AND status = ?", videoID, string(entities.ReportOpen))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return videoID, nil
+}
+
+func lockVideo(tx *gorm.DB, videoID uint) error {
+	var v entities.Video
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("video_id").
+		Where("video_id = ?", videoID).
+		Take(&v).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// lockVideoAndOpenReport bloquea el video de la denuncia y luego la denuncia, en el mismo
+// orden que Create: asi Dismiss y TakeDown concurrentes sobre un mismo video se serializan
+// en el video en vez de bloquearse mutuamente (deadlock). El video_id de una denuncia no
+// cambia, por eso se puede leer sin bloqueo antes de tomar los locks.
+func lockVideoAndOpenReport(tx *gorm.DB, reportID uint) (*entities.VideoReport, error) {
+	var ref entities.VideoReport
+	err := tx.Select("video_id").Where("report_id = ?", reportID).Take(&ref).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	if err := lockVideo(tx, ref.VideoID); err != nil {
+		return nil, err
+	}
+	return lockOpenReport(tx, reportID)
+}
+
+// lockOpenReport obtiene la denuncia bloqueandola; ErrConflict si ya fue resuelta.
+func lockOpenReport(tx *gorm.DB, reportID uint) (*entities.VideoReport, error) {
+	var report entities.VideoReport
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("report_id = ?", reportID).
+		Take(&report).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	if report.Status != string(entities.ReportOpen) {
+		return nil, domain.ErrConflict
+	}
+	return &report, nil
+}
+
+func resolveReports(tx *gorm.DB, status entities.ReportStatus, adminID uint, query string, args ...any) error {
+	return tx.Model(&entities.VideoReport{}).
+		Where(query, args...).
+		Updates(map[string]any{
+			"status":      string(status),
+			"resolved_at": time.Now().UTC(),
+			"resolved_by": adminID,
+		}).Error
+}
+
+func countOpenReports(tx *gorm.DB, videoID uint) (int64, error) {
+	var count int64
+	err := tx.Model(&entities.VideoReport{}).
+		Where("video_id = ? AND status = ?", videoID, string(entities.ReportOpen)).
+		Count(&count).Error
+	return count, err
+}
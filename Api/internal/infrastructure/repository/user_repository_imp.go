@@ -6,7 +6,9 @@ import (
 	"api/internal/domain/interfaces"
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
@@ -18,12 +20,17 @@ func NewUserRepository(db *gorm.DB) interfaces.UserRepository {
 	return &userRepository{db: db}
 }
 
+// maxUsernameAttempts acota los candidatos probados al asignar el username
+// (base, base_<id>, base_<id>_2, ...) antes de rendirse con ErrConflict.
+const maxUsernameAttempts = 5
+
 func (r *userRepository) Create(ctx context.Context, user *entities.User) error {
 	// Create user and assign default 'player' role in a single transaction
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.
-			Select("FirstName", "LastName", "Email", "PasswordHash", "CityID").
-			Create(user).Error; err != nil {
+		if user.Username == "" {
+			user.Username = entities.UsernameFromEmail(user.Email)
+		}
+		if err := insertUser(tx, user); err != nil {
 			return err
 		}
 
@@ -49,6 +56,69 @@ func (r *userRepository) Create(ctx context.Context, user *entities.User) error
 	})
 }
 
+// insertUser inserta el usuario probando candidatos de username hasta encontrar
+// uno libre. La consulta previa evita la mayoria de choques, pero dos registros
+// concurrentes pueden pasarla a la vez: la violacion de ux_users_lower_username
+// se deshace con un savepoint y se prueba el siguiente candidato.
+func insertUser(tx *gorm.DB, user *entities.User) error {
+	columns := []string{"FirstName", "LastName", "Email", "PasswordHash", "CityID", "Username"}
+	base := user.Username
+	for attempt := 0; attempt < maxUsernameAttempts; attempt++ {
+		if attempt > 0 {
+			if user.UserID == 0 {
+				// Mismo criterio que el backfill de la migracion 0019: sufijo _<user_id>,
+				// reservando el id antes del insert
+				var id int
+				if err := tx.Raw("SELECT nextval(pg_get_serial_sequence('users', 'user_id'))").Scan(&id).Error; err != nil {
+					return err
+				}
+				user.UserID = id
+				columns = append(columns, "UserID")
+			}
+			user.Username = fmt.Sprintf("%s_%d", base, user.UserID)
+			if attempt > 1 {
+				user.Username = fmt.Sprintf("%s_%d_%d", base, user.UserID, attempt)
+			}
+		}
+
+		taken, err := usernameTaken(tx, user.Username)
+		if err != nil {
+			return err
+		}
+		if taken {
+			continue
+		}
+
+		if err := tx.SavePoint("username").Error; err != nil {
+			return err
+		}
+		err = tx.Select(columns).Create(user).Error
+		if err == nil {
+			return nil
+		}
+		if !isUsernameConflict(err) {
+			return err
+		}
+		if err := tx.RollbackTo("username").Error; err != nil {
+			return err
+		}
+	}
+	return domain.ErrConflict
+}
+
+func isUsernameConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "ux_users_lower_username"
+}
+
+func usernameTaken(tx *gorm.DB, username string) (bool, error) {
+	var count int64
+	if err := tx.Table("users").Where("LOWER(username) = LOWER(?)", username).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var user entities.User
 	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxOutboxErrorLength coincide con vote_event_outbox.last_error VARCHAR(500).
+const maxOutboxErrorLength = 500
+
+type voteEventOutbox struct {
+	db *gorm.DB
+}
+
+func NewVoteEventOutbox(db *gorm.DB) interfaces.VoteEventOutbox {
+	return &voteEventOutbox{db: db}
+}
+
+// ClaimPending uses FOR UPDATE SKIP LOCKED so several API instances can relay concurrently.
+func (r *voteEventOutbox) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]entities.OutboxMessage, error) {
+	if limit < 1 {
+		limit = 100
+	}
+	type row struct {
+		OutboxID   uint64 `gorm:"column:outbox_id"`
+		RoutingKey string `gorm:"column:routing_key"`
+		Payload    string `gorm:"column:payload"`
+		Attempts   int    `gorm:"column:attempts"`
+	}
+	var rows []row
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE vote_event_outbox o
+		SET locked_until = now() + make_interval(secs => ?), attempts = o.attempts + 1
+		WHERE o.outbox_id IN (
+			SELECT outbox_id FROM vote_event_outbox
+			WHERE published_at IS NULL AND (locked_until IS NULL OR locked_until < now())
+			ORDER BY outbox_id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING o.outbox_id, o.routing_key, o.payload::text AS payload, o.attempts`,
+		lease.Seconds(), limit).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entities.OutboxMessage, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, entities.OutboxMessage{ID: r.OutboxID, RoutingKey: r.RoutingKey, Payload: []byte(r.Payload), Attempts: r.Attempts})
+	}
+	// RETURNING does not preserve the subquery order.
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (r *voteEventOutbox) MarkPublished(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Exec(`
+		UPDATE vote_event_outbox SET published_at = now(), locked_until = NULL, last_error = NULL
+		WHERE outbox_id IN ?`, ids).Error
+}
+
+func (r *voteEventOutbox) MarkFailed(ctx context.Context, id uint64, reason string) error {
+	if runes := []rune(reason); len(runes) > maxOutboxErrorLength {
+		reason = string(runes[:maxOutboxErrorLength])
+	}
+	return r.db.WithContext(ctx).Exec(`
+		UPDATE vote_event_outbox SET locked_until = NULL, last_error = ?
+		WHERE outbox_id = ?`, reason, id).Error
+}
+
+// DeletePublishedBefore borra por lotes usando idx_vote_event_outbox_published.
+func (r *voteEventOutbox) DeletePublishedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit < 1 {
+		limit = 1000
+	}
+	res := r.db.WithContext(ctx).Exec(`
+		DELETE FROM vote_event_outbox
+		WHERE outbox_id IN (
+			SELECT outbox_id FROM vote_event_outbox
+			WHERE published_at IS NOT NULL AND published_at < ?
+			ORDER BY published_at
+			LIMIT ?
+		)`, before, limit)
+	return res.RowsAffected, res.Error
+}
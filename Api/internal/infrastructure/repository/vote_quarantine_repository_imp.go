@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type voteQuarantineRepository struct {
+	db *gorm.DB
+}
+
+func NewVoteQuarantineRepository(db *gorm.DB) interfaces.VoteQuarantineRepository {
+	return &voteQuarantineRepository{db: db}
+}
+
+func (r *voteQuarantineRepository) ListQuarantined(ctx context.Context, page, pageSize int) ([]responses.QuarantinedVote, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	var out []responses.QuarantinedVote
+	err := r.db.WithContext(ctx).
+		Table("vote vt").
+		Select(`vt.vote_id, vt.video_id, v.title AS video_title,
+			vt.user_id AS voter_id, u.username AS voter_username,
+			vt.ip, vt.account_age_seconds, vt.quarantine_flags, vt.voted_at, vt.quarantined_at`).
+		Joins("JOIN video v ON v.video_id = vt.video_id").
+		Joins("JOIN users u ON u.user_id = vt.user_id").
+		Where("vt.quarantined_at IS NOT NULL").
+		Order("vt.quarantined_at ASC, vt.vote_id ASC").
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Scan(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range out {
+		out[i].Flags = splitFlags(out[i].RawFlags)
+	}
+	return out, nil
+}
+
+func (r *voteQuarantineRepository) Clear(ctx context.Context, voteID, reviewerID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Exec(`
+			UPDATE vote SET quarantined_at = NULL, reviewed_at = now(), reviewed_by = ?
+			WHERE vote_id = ? AND quarantined_at IS NOT NULL`, reviewerID, voteID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return voteMissingOrNotQuarantined(tx, voteID)
+		}
+		// The vote starts counting now: consumers see it as cast with quarantined=false.
+		return enqueueVoteEvent(tx, entities.VoteEventCast, "now()", "vt.vote_id = ?", voteID)
+	})
+}
+
+func (r *voteQuarantineRepository) Discard(ctx context.Context, voteID, reviewerID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Published with quarantined=true, so consumers that never counted it ignore it.
+		if err := enqueueVoteEvent(tx, entities.VoteEventRetracted, "now()", "vt.vote_id = ? AND vt.quarantined_at IS NOT NULL", voteID); err != nil {
+			return err
+		}
+		res := tx.Exec(`
+			WITH removed AS (
+				DELETE FROM vote WHERE vote_id = ? AND quarantined_at IS NOT NULL
+				RETURNING vote_id, user_id, video_id, voted_at, event_id, quarantine_flags
+			)
+			INSERT INTO vote_history (vote_id, user_id, video_id, voted_at, vote_event_id, retracted_by, quarantine_flags)
+			SELECT vote_id, user_id, video_id, voted_at, event_id, ?, quarantine_flags FROM removed`,
+			voteID, reviewerID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return voteMissingOrNotQuarantined(tx, voteID)
+		}
+		return nil
+	})
+}
+
+func voteMissingOrNotQuarantined(tx *gorm.DB, voteID uint) error {
+	var count int64
+	if err := tx.Table("vote").Where("vote_id = ?", voteID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return domain.ErrNotFound
+	}
+	return domain.ErrConflict
+}
+
+func splitFlags(raw string) []string {
+	flags := []string{}
+	for _, f := range strings.Split(raw, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			flags = append(flags, f)
+		}
+	}
+	return flags
+}
@@ -2,14 +2,55 @@ package repository
 
 import (
 	"api/internal/domain"
+	"api/internal/domain/entities"
 	"api/internal/domain/interfaces"
+	"api/internal/domain/responses"
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+// voteBudgetLockClass namespaces the per-user advisory lock taken while checking vote budgets.
+const voteBudgetLockClass = 7301
+
+// voteEventSQL writes a vote event to the outbox from the vote row still present in the
+// transaction. %s is the occurred_at expression; the WHERE clause selects the vote.
+const voteEventSQL = `
+	INSERT INTO vote_event_outbox (routing_key, payload)
+	SELECT ?, jsonb_build_object(
+		'type', ?::text,
+		'vote_id', vt.vote_id,
+		'video_id', v.video_id,
+		'owner_id', v.user_id,
+		'owner_username', o.username,
+		'owner_city_id', oc.city_id,
+		'owner_city', oc.name,
+		'voter_id', vt.user_id,
+		'voter_city_id', vc.city_id,
+		'voter_city', vc.name,
+		'contest_id', v.contest_id,
+		'quarantined', vt.quarantined_at IS NOT NULL,
+		'occurred_at', %s)
+	FROM vote vt
+	JOIN video v ON v.video_id = vt.video_id
+	JOIN users o ON o.user_id = v.user_id
+	JOIN city oc ON oc.city_id = o.city_id
+	JOIN users u ON u.user_id = vt.user_id
+	JOIN city vc ON vc.city_id = u.city_id
+	WHERE %s`
+
+// enqueueVoteEvent adds the event to vote_event_outbox inside tx, so it is published
+// if and only if the vote change commits.
+func enqueueVoteEvent(tx *gorm.DB, eventType, occurredAt, where string, args ...interface{}) error {
+	params := append([]interface{}{eventType, eventType}, args...)
+	return tx.Exec(fmt.Sprintf(voteEventSQL, occurredAt, where), params...).Error
+}
+
 type voteRepository struct {
 	db *gorm.DB
 }
@@ -28,20 +69,90 @@ func (r *voteRepository) HasUserVoted(ctx context.Context, videoID, userID uint)
 	return count > 0, nil
 }
 
+// VotedVideoIDs devuelve los videos de videoIDs con voto vigente del usuario.
+func (r *voteRepository) VotedVideoIDs(ctx context.Context, userID uint, videoIDs []uint) (map[uint]bool, error) {
+	voted := make(map[uint]bool, len(videoIDs))
+	if len(videoIDs) == 0 {
+		return voted, nil
+	}
+	var ids []uint
+	if err := r.db.WithContext(ctx).Table("vote").
+		Where("user_id = ? AND video_id IN ?", userID, videoIDs).
+		Pluck("video_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		voted[id] = true
+	}
+	return voted, nil
+}
+
 func (r *voteRepository) Create(ctx context.Context, videoID, userID uint) error {
 	return r.CreateWithEvent(ctx, videoID, userID, nil)
 }
 
 func (r *voteRepository) CreateWithEvent(ctx context.Context, videoID, userID uint, eventID *string) error {
+	return r.create(ctx, videoID, userID, eventID, nil, nil)
+}
+
+// CreateWithBudget serializes the user's votes with a transaction-scoped advisory lock so
+// concurrent requests cannot both pass the budget check.
+func (r *voteRepository) CreateWithBudget(ctx context.Context, videoID, userID uint, eventID *string, policies []entities.VoteBudgetPolicy) error {
+	return r.create(ctx, videoID, userID, eventID, policies, nil)
+}
+
+// CreateWithAudit stores the anti-fraud metadata; flagged votes are inserted already quarantined.
+func (r *voteRepository) CreateWithAudit(ctx context.Context, videoID, userID uint, eventID *string, audit entities.VoteAudit, policies []entities.VoteBudgetPolicy) error {
+	return r.create(ctx, videoID, userID, eventID, policies, &audit)
+}
+
+func (r *voteRepository) create(ctx context.Context, videoID, userID uint, eventID *string, policies []entities.VoteBudgetPolicy, audit *entities.VoteAudit) error {
 	type voteRow struct {
-		UserID  uint    `gorm:"column:user_id"`
-		VideoID uint    `gorm:"column:video_id"`
-		EventID *string `gorm:"column:event_id"`
+		UserID            uint       `gorm:"column:user_id"`
+		VideoID           uint       `gorm:"column:video_id"`
+		EventID           *string    `gorm:"column:event_id"`
+		IP                *string    `gorm:"column:ip"`
+		UserAgentHash     *string    `gorm:"column:user_agent_hash"`
+		AccountAgeSeconds *int64     `gorm:"column:account_age_seconds"`
+		QuarantinedAt     *time.Time `gorm:"column:quarantined_at"`
+		QuarantineFlags   *string    `gorm:"column:quarantine_flags"`
 	}
 
 	// Allow the database identity column to generate vote_id automatically.
 	v := voteRow{UserID: userID, VideoID: videoID, EventID: eventID}
-	if err := r.db.WithContext(ctx).Table("vote").Create(&v).Error; err != nil {
+	if audit != nil {
+		v.IP = nonEmpty(audit.Metadata.IP)
+		v.UserAgentHash = nonEmpty(audit.Metadata.UserAgentHash)
+		age := audit.AccountAgeSeconds
+		v.AccountAgeSeconds = &age
+		if audit.Quarantined() {
+			now := time.Now().UTC()
+			v.QuarantinedAt = &now
+			v.QuarantineFlags = nonEmpty(strings.Join(audit.Flags, ","))
+		}
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A replayed event of a vote that was later retracted must not recreate it.
+		if eventID != nil {
+			var replayed int64
+			if err := tx.Table("vote_history").Where("vote_event_id = ?", *eventID).Count(&replayed).Error; err != nil {
+				return err
+			}
+			if replayed > 0 {
+				return domain.ErrIdempotent
+			}
+		}
+		if len(policies) > 0 {
+			if err := r.checkBudget(tx, videoID, userID, eventID, policies); err != nil {
+				return err
+			}
+		}
+		if err := tx.Table("vote").Create(&v).Error; err != nil {
+			return err
+		}
+		return enqueueVoteEvent(tx, entities.VoteEventCast, "vt.voted_at", "vt.video_id = ? AND vt.user_id = ?", videoID, userID)
+	})
+	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			switch pgErr.ConstraintName {
@@ -58,3 +169,147 @@ func (r *voteRepository) CreateWithEvent(ctx context.Context, videoID, userID ui
 	}
 	return nil
 }
+
+func nonEmpty(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// checkBudget runs inside the vote transaction. Replays and duplicate votes are reported
+// as such before the budget so clients keep the usual idempotency semantics.
+func (r *voteRepository) checkBudget(tx *gorm.DB, videoID, userID uint, eventID *string, policies []entities.VoteBudgetPolicy) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", voteBudgetLockClass, userID).Error; err != nil {
+		return err
+	}
+	var existing int64
+	q := tx.Table("vote").Where("video_id = ? AND user_id = ?", videoID, userID)
+	if eventID != nil {
+		q = tx.Table("vote").Where("(video_id = ? AND user_id = ?) OR event_id = ?", videoID, userID, *eventID)
+	}
+	if err := q.Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 {
+		// Let the insert report ErrConflict or ErrIdempotent through the unique constraints.
+		return nil
+	}
+
+	for _, p := range policies {
+		if p.Limit <= 0 {
+			continue
+		}
+		var since *time.Time
+		var contestID *uint
+		switch p.Scope {
+		case entities.VoteBudgetDaily:
+			start := time.Now().UTC().Truncate(24 * time.Hour)
+			since = &start
+		case entities.VoteBudgetContest:
+			var ids []uint
+			if err := tx.Table("video").Where("video_id = ? AND contest_id IS NOT NULL", videoID).
+				Pluck("contest_id", &ids).Error; err != nil {
+				return err
+			}
+			if len(ids) == 0 {
+				// Videos outside a contest do not consume contest budgets.
+				continue
+			}
+			contestID = &ids[0]
+		default:
+			continue
+		}
+		used, err := countVotes(tx, userID, since, contestID)
+		if err != nil {
+			return err
+		}
+		if used >= p.Limit {
+			return domain.ErrVoteBudgetExceeded
+		}
+	}
+	return nil
+}
+
+func (r *voteRepository) CountVotes(ctx context.Context, userID uint, since *time.Time, contestID *uint) (int, error) {
+	return countVotes(r.db.WithContext(ctx), userID, since, contestID)
+}
+
+func countVotes(db *gorm.DB, userID uint, since *time.Time, contestID *uint) (int, error) {
+	q := db.Table("vote vt").Where("vt.user_id = ?", userID)
+	if since != nil {
+		q = q.Where("vt.voted_at >= ?", *since)
+	}
+	if contestID != nil {
+		q = q.Joins("JOIN video v ON v.video_id = vt.video_id").Where("v.contest_id = ?", *contestID)
+	}
+	var count int64
+	if err := q.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *voteRepository) ListByUser(ctx context.Context, userID uint, page, pageSize int) ([]responses.UserVoteItem, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	var out []responses.UserVoteItem
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT h.video_id, v.title, v.contest_id, h.voted_at, h.retracted_at
+		FROM (
+			SELECT video_id, voted_at, NULL::timestamptz AS retracted_at FROM vote WHERE user_id = ?
+			UNION ALL
+			SELECT video_id, voted_at, retracted_at FROM vote_history WHERE user_id = ?
+		) h
+		JOIN video v ON v.video_id = h.video_id
+		ORDER BY h.voted_at DESC, h.video_id DESC
+		LIMIT ? OFFSET ?`,
+		userID, userID, pageSize, (page-1)*pageSize).Scan(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Retract deletes the user's vote and archives it in vote_history within one transaction.
+func (r *voteRepository) Retract(ctx context.Context, videoID, userID uint, eventID *string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if eventID != nil {
+			var seen int64
+			if err := tx.Table("vote_history").Where("retract_event_id = ?", *eventID).Count(&seen).Error; err != nil {
+				return err
+			}
+			if seen > 0 {
+				return domain.ErrIdempotent
+			}
+		}
+
+		// Written before the delete so the event can read the vote; rolled back with ErrConflict.
+		if err := enqueueVoteEvent(tx, entities.VoteEventRetracted, "now()", "vt.video_id = ? AND vt.user_id = ?", videoID, userID); err != nil {
+			return err
+		}
+		res := tx.Exec(`
+			WITH removed AS (
+				DELETE FROM vote WHERE video_id = ? AND user_id = ?
+				RETURNING vote_id, user_id, video_id, voted_at, event_id, quarantine_flags
+			)
+			INSERT INTO vote_history (vote_id, user_id, video_id, voted_at, vote_event_id, retract_event_id, quarantine_flags)
+			SELECT vote_id, user_id, video_id, voted_at, event_id, ?, quarantine_flags FROM removed`,
+			videoID, userID, eventID)
+		if res.Error != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(res.Error, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "ux_vote_history_retract_event" {
+				return domain.ErrIdempotent
+			}
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.ErrConflict
+		}
+		return nil
+	})
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"api/internal/domain"
+	"api/internal/domain/interfaces"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type voteSignalReader struct {
+	db *gorm.DB
+}
+
+func NewVoteSignalReader(db *gorm.DB) interfaces.VoteSignalReader {
+	return &voteSignalReader{db: db}
+}
+
+func (r *voteSignalReader) AccountCreatedAt(ctx context.Context, userID uint) (time.Time, error) {
+	var createdAt []time.Time
+	if err := r.db.WithContext(ctx).Table("users").
+		Where("user_id = ?", userID).
+		Pluck("created_at", &createdAt).Error; err != nil {
+		return time.Time{}, err
+	}
+	if len(createdAt) == 0 {
+		return time.Time{}, domain.ErrNotFound
+	}
+	return createdAt[0], nil
+}
+
+// CountUserVotesSince suma los votos vigentes y los retirados (vote_history): contar solo
+// la tabla vote permitiria esquivar la regla de velocidad retirando y volviendo a votar.
+func (r *voteSignalReader) CountUserVotesSince(ctx context.Context, userID uint, since time.Time) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT (SELECT COUNT(*) FROM vote WHERE user_id = ? AND voted_at >= ?)
+		     + (SELECT COUNT(*) FROM vote_history WHERE user_id = ? AND voted_at >= ?)`,
+		userID, since, userID, since).
+		Scan(&count).Error
+	return int(count), err
+}
+
+func (r *voteSignalReader) CountOtherAccountsByIPSince(ctx context.Context, ip string, userID uint, since time.Time) (int, error) {
+	if ip == "" {
+		return 0, errors.New("ip is required")
+	}
+	var count int64
+	err := r.db.WithContext(ctx).Table("vote").
+		Where("ip = ? AND user_id <> ? AND voted_at >= ?", ip, userID, since).
+		Distinct("user_id").
+		Count(&count).Error
+	return int(count), err
+}
@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/internal/domain/interfaces"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Supported signing modes for MediaSignerConfig.Mode.
+const (
+	MediaSignerModeS3         = "s3"
+	MediaSignerModeSecureLink = "secure_link"
+)
+
+const defaultMediaURLTTL = 15 * time.Minute
+
+// MediaSignerConfig holds the settings needed to build a MediaURLSigner.
+// Mode selects the implementation; an empty mode disables signing.
+type MediaSignerConfig struct {
+	Mode string
+	TTL  time.Duration
+
+	// S3 presign mode: S3.Bucket is ignored, buckets are chosen per media kind.
+	S3              S3Config
+	OriginalBucket  string
+	ProcessedBucket string
+
+	// nginx secure_link mode (stock ngx_http_secure_link_module, secure_link_md5).
+	OriginalBaseURL  string
+	ProcessedBaseURL string
+	Secret           string
+}
+
+// NewMediaURLSigner builds the signer selected by cfg.Mode.
+// Returns (nil, nil) when signing is disabled.
+func NewMediaURLSigner(cfg MediaSignerConfig) (interfaces.MediaURLSigner, error) {
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = defaultMediaURLTTL
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.Mode)) {
+	case "":
+		return nil, nil
+	case MediaSignerModeS3:
+		signer, err := NewS3PresignSigner(cfg.S3, cfg.OriginalBucket, cfg.ProcessedBucket, ttl)
+		if err != nil {
+			return nil, err
+		}
+		return signer, nil
+	case MediaSignerModeSecureLink:
+		signer, err := NewSecureLinkSigner(cfg.OriginalBaseURL, cfg.ProcessedBaseURL, cfg.Secret, ttl)
+		if err != nil {
+			return nil, err
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unknown media signer mode %q", cfg.Mode)
+	}
+}
+
+// S3PresignSigner issues presigned GetObject URLs.
+type S3PresignSigner struct {
+	presign *s3.PresignClient
+	buckets map[interfaces.MediaKind]string
+	ttl     time.Duration
+}
+
+// NewS3PresignSigner creates a signer that presigns objects from the raw and processed buckets.
+func NewS3PresignSigner(cfg S3Config, originalBucket, processedBucket string, ttl time.Duration) (*S3PresignSigner, error) {
+	if originalBucket == "" || processedBucket == "" {
+		return nil, fmt.Errorf("s3 presign requires original and processed buckets")
+	}
+	client, err := newS3Client(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &S3PresignSigner{
+		presign: s3.NewPresignClient(client),
+		buckets: map[interfaces.MediaKind]string{
+			interfaces.MediaOriginal:  originalBucket,
+			interfaces.MediaProcessed: processedBucket,
+		},
+		ttl: ttl,
+	}, nil
+}
+
+// SignURL returns a presigned URL valid for the configured TTL.
+func (s *S3PresignSigner) SignURL(ctx context.Context, kind interfaces.MediaKind, objectKey string) (string, error) {
+	bucket, ok := s.buckets[kind]
+	if !ok {
+		return "", fmt.Errorf("unknown media kind %q", kind)
+	}
+	key := normalizeObjectKey(objectKey)
+	if key == "" {
+		return "", fmt.Errorf("object key is required")
+	}
+	req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(s.ttl))
+	if err != nil {
+		return "", err
+	}
+	return req.URL, nil
+}
+
+// SecureLinkSigner issues URLs validated by nginx (see the media server in nginx.conf) using
+// secure_link $arg_md5,$arg_expires and
+// secure_link_md5 "$secure_link_expires$uri <secret>".
+type SecureLinkSigner struct {
+	bases  map[interfaces.MediaKind]*url.URL
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewSecureLinkSigner creates a signer for media served by nginx.
+func NewSecureLinkSigner(originalBaseURL, processedBaseURL, secret string, ttl time.Duration) (*SecureLinkSigner, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("secure link secret is required")
+	}
+	bases := make(map[interfaces.MediaKind]*url.URL, 2)
+	for kind, raw := range map[interfaces.MediaKind]string{
+		interfaces.MediaOriginal:  originalBaseURL,
+		interfaces.MediaProcessed: processedBaseURL,
+	} {
+		if raw == "" {
+			continue
+		}
+		u, err := url.Parse(strings.TrimRight(raw, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s base url: %w", kind, err)
+		}
+		bases[kind] = u
+	}
+	if len(bases) == 0 {
+		return nil, fmt.Errorf("secure link requires at least one base url")
+	}
+	return &SecureLinkSigner{bases: bases, secret: []byte(secret), ttl: ttl, now: time.Now}, nil
+}
+
+// WithClock overrides the time source (useful for tests).
+func (s *SecureLinkSigner) WithClock(now func() time.Time) *SecureLinkSigner {
+	s.now = now
+	return s
+}
+
+// SignURL returns base/key?md5=...&expires=... valid for the configured TTL.
+// expires is an absolute unix time; md5 is the unpadded base64url MD5 of
+// expires + path + " " + secret, as secure_link_md5 computes it.
+func (s *SecureLinkSigner) SignURL(_ context.Context, kind interfaces.MediaKind, objectKey string) (string, error) {
+	base, ok := s.bases[kind]
+	if !ok {
+		return "", fmt.Errorf("no base url configured for media kind %q", kind)
+	}
+	key := normalizeObjectKey(objectKey)
+	if key == "" {
+		return "", fmt.Errorf("object key is required")
+	}
+
+	u := *base
+	u.Path = base.Path + "/" + key
+	u.RawPath = ""
+
+	expires := strconv.FormatInt(s.now().Add(s.ttl).Unix(), 10)
+	sum := md5.Sum([]byte(expires + u.Path + " " + string(s.secret)))
+
+	q := url.Values{}
+	q.Set("md5", base64.RawURLEncoding.EncodeToString(sum[:]))
+	q.Set("expires", expires)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// normalizeObjectKey accepts bare object keys as well as legacy rows that stored
+// a full public URL, returning only the object key.
+func normalizeObjectKey(stored string) string {
+	stored = strings.TrimSpace(stored)
+	if stored == "" {
+		return ""
+	}
+	if strings.Contains(stored, "://") {
+		u, err := url.Parse(stored)
+		if err != nil {
+			return ""
+		}
+		base := path.Base(u.Path)
+		if base == "/" || base == "." {
+			return ""
+		}
+		return base
+	}
+	return strings.TrimLeft(stored, "/")
+}
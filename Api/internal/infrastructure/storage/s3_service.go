@@ -6,11 +6,14 @@ import (
 	"io"
 
 	"api/internal/domain/interfaces"
+	"api/internal/infrastructure/tracing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // S3Config holds the necessary configuration for connecting to Amazon S3 or an S3-compatible service.
@@ -30,12 +33,21 @@ type videoStorage struct {
 
 // NewS3VideoStorage creates a new VideoStorage backed by Amazon S3.
 func NewS3VideoStorage(cfg S3Config) (interfaces.VideoStorage, error) {
-	if cfg.Region == "" {
-		return nil, fmt.Errorf("s3 region is required")
-	}
 	if cfg.Bucket == "" {
 		return nil, fmt.Errorf("s3 bucket is required")
 	}
+	client, err := newS3Client(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &videoStorage{client: client, bucket: cfg.Bucket}, nil
+}
+
+// newS3Client builds the SDK client shared by storage and URL presigning.
+func newS3Client(cfg S3Config) (*s3.Client, error) {
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("s3 region is required")
+	}
 
 	loadOpts := []func(*config.LoadOptions) error{
 		config.WithRegion(cfg.Region),
@@ -61,12 +73,27 @@ func NewS3VideoStorage(cfg S3Config) (interfaces.VideoStorage, error) {
 			o.UsePathStyle = true
 		}
 	})
+	return client, nil
+}
 
-	return &videoStorage{client: client, bucket: cfg.Bucket}, nil
+// Ready checks that the bucket exists and the credentials can reach it.
+func (s *videoStorage) Ready(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(s.bucket)})
+	return err
 }
 
 // Save uploads the provided video data to S3 and returns the object key.
-func (s *videoStorage) Save(ctx context.Context, objectName string, reader io.Reader, size int64, contentType string) (string, error) {
+func (s *videoStorage) Save(ctx context.Context, objectName string, reader io.Reader, size int64, contentType string) (_ string, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "s3 PutObject",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("s3.bucket", s.bucket),
+			attribute.String("s3.key", objectName),
+			attribute.Int64("s3.size", size),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	input := &s3.PutObjectInput{
 		Bucket:        aws.String(s.bucket),
 		Key:           aws.String(objectName),
@@ -77,7 +104,7 @@ func (s *videoStorage) Save(ctx context.Context, objectName string, reader io.Re
 		input.ContentType = aws.String(contentType)
 	}
 
-	if _, err := s.client.PutObject(ctx, input); err != nil {
+	if _, err = s.client.PutObject(ctx, input); err != nil {
 		return "", err
 	}
 	return objectName, nil
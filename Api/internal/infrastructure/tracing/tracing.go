@@ -0,0 +1,115 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifica los spans creados por la API.
+const instrumentationName = "api"
+
+// Setup configura el propagador W3C (traceparent/baggage) y, si hay un endpoint OTLP
+// configurado, un exportador OTLP/HTTP. Se configura con las variables estandar de
+// OpenTelemetry (OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_TRACES_ENDPOINT,
+// OTEL_EXPORTER_OTLP_HEADERS, OTEL_SERVICE_NAME, OTEL_TRACES_SAMPLER...);
+// OTEL_TRACES_EXPORTER=none lo desactiva. Sin exportador el contexto de traza se sigue
+// propagando, pero no se registran spans. La funcion devuelta vacia los spans pendientes.
+func Setup(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+	if !exporterEnabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+	// OTEL_SERVICE_NAME, si esta definido, tiene prioridad sobre serviceName.
+	if envRes, err := resource.New(ctx, resource.WithFromEnv()); err == nil {
+		if merged, err := resource.Merge(res, envRes); err == nil {
+			res = merged
+		}
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+func exporterEnabled() bool {
+	if strings.EqualFold(os.Getenv("OTEL_TRACES_EXPORTER"), "none") {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Tracer devuelve el tracer de la API sobre el proveedor global.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// End cierra span marcandolo como error cuando err no es nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// amqpCarrier adapta los headers de un mensaje AMQP a propagation.TextMapCarrier.
+type amqpCarrier amqp.Table
+
+func (c amqpCarrier) Get(key string) string {
+	if v, ok := c[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func (c amqpCarrier) Set(key, value string) { c[key] = value }
+
+func (c amqpCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// InjectAMQP agrega el contexto de traza de ctx a headers y devuelve la tabla resultante
+// (creandola si headers es nil).
+func InjectAMQP(ctx context.Context, headers amqp.Table) amqp.Table {
+	if headers == nil {
+		headers = amqp.Table{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, amqpCarrier(headers))
+	return headers
+}
+
+// ExtractAMQP devuelve ctx con el contexto de traza recibido en headers.
+func ExtractAMQP(ctx context.Context, headers amqp.Table) context.Context {
+	if headers == nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, amqpCarrier(headers))
+}
@@ -0,0 +1,131 @@
+// Package logging configura el logger slog de la API: lineas JSON en stdout, nivel y
+// formato desde el entorno y, en cada registro emitido con un metodo *Context, los campos
+// request_id / video_id / trace_id del contexto. Los nombres de campo son los mismos que
+// usan los workers (Workers/shared/logging), de modo que un grep sigue un video de punta
+// a punta: el request_id de la subida viaja como correlation_id en los mensajes.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Nombres de campo compartidos con los workers.
+const (
+	ServiceKey       = "service"
+	RequestIDKey     = "request_id"
+	VideoIDKey       = "video_id"
+	CorrelationIDKey = "correlation_id"
+	TraceIDKey       = "trace_id"
+)
+
+// Setup construye el logger de service a partir de LOG_LEVEL (debug, info, warn, error;
+// info por defecto) y LOG_FORMAT (json o text; json por defecto), lo instala como logger
+// por defecto de slog (y del paquete log) y lo devuelve.
+func Setup(service string) *slog.Logger {
+	logger := New(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT")).With(ServiceKey, service)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// New construye un logger que escribe en w con el nivel y formato indicados.
+func New(w io.Writer, level, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: ParseLevel(level)}
+	var h slog.Handler
+	if strings.EqualFold(strings.TrimSpace(format), "text") {
+		h = slog.NewTextHandler(w, opts)
+	} else {
+		h = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(contextHandler{h})
+}
+
+// ParseLevel traduce un nombre de nivel; vacio o desconocido equivale a info.
+func ParseLevel(s string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+type ctxKey int
+
+const (
+	requestIDCtxKey ctxKey = iota
+	videoIDCtxKey
+)
+
+// WithRequestID devuelve ctx etiquetado con el ID de la solicitud HTTP.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDCtxKey, requestID)
+}
+
+// RequestID devuelve el ID de solicitud guardado en ctx, o "". Es el valor que se envia
+// como correlation_id en los mensajes para los workers.
+func RequestID(ctx context.Context) string {
+	v, _ := ctx.Value(requestIDCtxKey).(string)
+	return v
+}
+
+// WithVideoID devuelve ctx etiquetado con el video afectado por la operacion.
+func WithVideoID(ctx context.Context, videoID string) context.Context {
+	if videoID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, videoIDCtxKey, videoID)
+}
+
+// VideoID devuelve el video guardado en ctx, o "".
+func VideoID(ctx context.Context) string {
+	v, _ := ctx.Value(videoIDCtxKey).(string)
+	return v
+}
+
+// contextHandler agrega los campos de correlacion presentes en el contexto del registro.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if v := RequestID(ctx); v != "" {
+			r.AddAttrs(slog.String(RequestIDKey, v))
+		}
+		if v := VideoID(ctx); v != "" {
+			r.AddAttrs(slog.String(VideoIDKey, v))
+		}
+		if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+			r.AddAttrs(slog.String(TraceIDKey, sc.TraceID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// Fatal registra msg como error con el logger por defecto y termina el proceso.
+// Solo para errores de arranque.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
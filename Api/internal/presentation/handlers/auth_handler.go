@@ -3,6 +3,7 @@ package handlers
 import (
 	"api/internal/application/useCase"
 	"api/internal/domain/requests"
+	"api/internal/presentation/problem"
 	"net/http"
 	"strings"
 
@@ -20,12 +21,12 @@ func NewAuthHandlers(service *useCase.AuthService) *AuthHandlers {
 func (handler *AuthHandlers) Login(context *gin.Context) {
 	var request requests.LoginRequest
 	if err := context.ShouldBindJSON(&request); err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": err.Error()})
+		problem.Abort(context, http.StatusBadRequest, problem.CodeInvalidBody)
 		return
 	}
 	token, expiresIn, err := handler.service.Login(context.Request.Context(), request.Email, request.Password)
 	if err != nil {
-		context.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": err.Error()})
+		problem.Abort(context, http.StatusUnauthorized, problem.CodeInvalidCredentials)
 		return
 	}
 	context.JSON(http.StatusOK, gin.H{
@@ -39,16 +40,16 @@ func (handler *AuthHandlers) Logout(context *gin.Context) {
 	header := context.GetHeader("Authorization")
 	const prefix = "Bearer "
 	if header == "" || !strings.HasPrefix(header, prefix) {
-		context.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": "invalid authorization header"})
+		problem.Abort(context, http.StatusUnauthorized, problem.CodeTokenRequired)
 		return
 	}
 	token := strings.TrimSpace(header[len(prefix):])
 	if token == "" {
-		context.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": "invalid token"})
+		problem.Abort(context, http.StatusUnauthorized, problem.CodeInvalidToken)
 		return
 	}
 	if err := handler.service.Logout(token); err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error", "message": err.Error()})
+		problem.Internal(context, err)
 		return
 	}
 	context.Status(http.StatusNoContent)
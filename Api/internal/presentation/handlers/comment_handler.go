@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/presentation/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CommentHandlers maneja comentarios sobre videos publicos.
+type CommentHandlers struct {
+	uc *useCase.CommentUseCase
+}
+
+func NewCommentHandlers(uc *useCase.CommentUseCase) *CommentHandlers {
+	return &CommentHandlers{uc: uc}
+}
+
+type commentRequest struct {
+	Body     string `json:"body"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+// ListComments maneja GET /api/public/videos/:video_id/comments
+// Query: cursor (opaco), limit (1-100, default 20), parent_id (lista respuestas de ese comentario).
+func (h *CommentHandlers) ListComments(c *gin.Context) {
+	videoID, ok := parseVideoIDOrAbort(c)
+	if !ok {
+		return
+	}
+	limit := 20
+	if ls := strings.TrimSpace(c.Query("limit")); ls != "" {
+		v, err := strconv.Atoi(ls)
+		if err != nil || v < 1 || v > 100 {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return
+		}
+		limit = v
+	}
+	var parentID *uint
+	if ps := strings.TrimSpace(c.Query("parent_id")); ps != "" {
+		v, err := strconv.ParseUint(ps, 10, 64)
+		if err != nil || v == 0 {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return
+		}
+		pid := uint(v)
+		parentID = &pid
+	}
+
+	page, err := h.uc.List(c.Request.Context(), videoID, parentID, c.Query("cursor"), limit)
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// CreateComment maneja POST /api/public/videos/:video_id/comments
+func (h *CommentHandlers) CreateComment(c *gin.Context) {
+	userID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	videoID, ok := parseVideoIDOrAbort(c)
+	if !ok {
+		return
+	}
+	var req commentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidBody)
+		return
+	}
+	created, err := h.uc.Create(c.Request.Context(), userID, videoID, req.ParentID, req.Body)
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "Comentario publicado.",
+		"comment_id": created.CommentID,
+		"parent_id":  created.ParentID,
+	})
+}
+
+// UpdateComment maneja PATCH /api/public/videos/:video_id/comments/:comment_id (solo el autor)
+func (h *CommentHandlers) UpdateComment(c *gin.Context) {
+	userID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	videoID, ok := parseVideoIDOrAbort(c)
+	if !ok {
+		return
+	}
+	commentID, ok := parseCommentIDOrAbort(c)
+	if !ok {
+		return
+	}
+	var req commentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidBody)
+		return
+	}
+	if err := h.uc.Update(c.Request.Context(), userID, videoID, commentID, req.Body); err != nil {
+		writeCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Comentario actualizado.", "comment_id": commentID})
+}
+
+// DeleteComment maneja DELETE /api/public/videos/:video_id/comments/:comment_id
+// Permitido al autor, al propietario del video y a moderadores (PrivilegeModerateVideos).
+func (h *CommentHandlers) DeleteComment(c *gin.Context) {
+	userID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	videoID, ok := parseVideoIDOrAbort(c)
+	if !ok {
+		return
+	}
+	commentID, ok := parseCommentIDOrAbort(c)
+	if !ok {
+		return
+	}
+	perms, _ := c.Get("permissions")
+	granted, _ := perms.([]string)
+	canModerate := slices.Contains(granted, PrivilegeModerateVideos)
+
+	if err := h.uc.Delete(c.Request.Context(), userID, videoID, commentID, canModerate); err != nil {
+		writeCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Comentario eliminado.", "comment_id": commentID})
+}
+
+// parseCommentIDOrAbort validates path param "comment_id" and returns it as uint.
+func parseCommentIDOrAbort(c *gin.Context) (uint, bool) {
+	parsed, err := strconv.ParseUint(c.Param("comment_id"), 10, 64)
+	if err != nil || parsed == 0 {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
+		return 0, false
+	}
+	return uint(parsed), true
+}
+
+func writeCommentError(c *gin.Context, err error) {
+	problem.AbortWithError(c, err,
+		problem.On(domain.ErrInvalid, http.StatusBadRequest, problem.CodeInvalidComment),
+		problem.On(domain.ErrNotFound, http.StatusNotFound, problem.CodeCommentNotFound),
+		problem.On(domain.ErrRateLimited, http.StatusTooManyRequests, problem.CodeCommentRateLimited),
+	)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"api/internal/presentation/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContestHandlers maneja la consulta y administracion de concursos.
+// Las rutas /api/admin/contests deben protegerse con RequirePermission(PrivilegeManageContests).
+type ContestHandlers struct {
+	uc *useCase.ContestUseCase
+}
+
+func NewContestHandlers(uc *useCase.ContestUseCase) *ContestHandlers {
+	return &ContestHandlers{uc: uc}
+}
+
+type createContestRequest struct {
+	Name               string `json:"name"`
+	SubmissionStartsAt string `json:"submission_starts_at"`
+	SubmissionEndsAt   string `json:"submission_ends_at"`
+	VotingStartsAt     string `json:"voting_starts_at"`
+	VotingEndsAt       string `json:"voting_ends_at"`
+}
+
+// ListContests maneja GET /api/public/contests
+func (h *ContestHandlers) ListContests(c *gin.Context) {
+	contests, err := h.uc.List(c.Request.Context())
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	now := time.Now()
+	out := make([]responses.ContestResponse, 0, len(contests))
+	for _, ct := range contests {
+		out = append(out, toContestResponse(ct, now))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// GetContest maneja GET /api/public/contests/:contest_id
+func (h *ContestHandlers) GetContest(c *gin.Context) {
+	contestID, ok := parseContestIDOrAbort(c)
+	if !ok {
+		return
+	}
+	contest, err := h.uc.Get(c.Request.Context(), contestID)
+	if err != nil {
+		writeContestError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toContestResponse(*contest, time.Now()))
+}
+
+// CreateContest maneja POST /api/admin/contests
+// Las fechas se reciben en RFC 3339.
+func (h *ContestHandlers) CreateContest(c *gin.Context) {
+	var req createContestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidBody)
+		return
+	}
+	in := useCase.ContestInput{Name: req.Name}
+	for _, f := range []struct {
+		raw string
+		dst *time.Time
+	}{
+		{req.SubmissionStartsAt, &in.SubmissionStartsAt},
+		{req.SubmissionEndsAt, &in.SubmissionEndsAt},
+		{req.VotingStartsAt, &in.VotingStartsAt},
+		{req.VotingEndsAt, &in.VotingEndsAt},
+	} {
+		parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(f.raw))
+		if err != nil {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidContestDates)
+			return
+		}
+		*f.dst = parsed
+	}
+
+	contest, err := h.uc.Create(c.Request.Context(), in)
+	if err != nil {
+		writeContestError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toContestResponse(*contest, time.Now()))
+}
+
+// CloseContest maneja POST /api/admin/contests/:contest_id/close
+func (h *ContestHandlers) CloseContest(c *gin.Context) {
+	contestID, ok := parseContestIDOrAbort(c)
+	if !ok {
+		return
+	}
+	contest, err := h.uc.Close(c.Request.Context(), contestID)
+	if err != nil {
+		writeContestError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toContestResponse(*contest, time.Now()))
+}
+
+func toContestResponse(ct entities.Contest, now time.Time) responses.ContestResponse {
+	return responses.ContestResponse{
+		ContestID:          ct.ContestID,
+		Name:               ct.Name,
+		SubmissionStartsAt: ct.SubmissionStartsAt,
+		SubmissionEndsAt:   ct.SubmissionEndsAt,
+		VotingStartsAt:     ct.VotingStartsAt,
+		VotingEndsAt:       ct.VotingEndsAt,
+		ClosedAt:           ct.ClosedAt,
+		VotingOpen:         ct.VotingOpen(now),
+	}
+}
+
+// parseContestIDOrAbort validates path param "contest_id" and returns it as uint.
+func parseContestIDOrAbort(c *gin.Context) (uint, bool) {
+	parsed, err := strconv.ParseUint(c.Param("contest_id"), 10, 64)
+	if err != nil || parsed == 0 {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
+		return 0, false
+	}
+	return uint(parsed), true
+}
+
+func writeContestError(c *gin.Context, err error) {
+	problem.AbortWithError(c, err,
+		problem.On(domain.ErrInvalid, http.StatusBadRequest, problem.CodeInvalidContest),
+		problem.On(domain.ErrNotFound, http.StatusNotFound, problem.CodeContestNotFound),
+		problem.On(domain.ErrConflict, http.StatusConflict, problem.CodeContestClosed),
+	)
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"api/internal/infrastructure/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandlers sirve las sondas de Kubernetes/Docker: /livez solo confirma que el
+// proceso responde y /readyz evalua cada dependencia.
+type HealthHandlers struct {
+	timeout time.Duration
+	checks  []health.Check
+}
+
+// NewHealthHandlers crea los handlers; timeout <= 0 usa health.DefaultTimeout.
+func NewHealthHandlers(timeout time.Duration, checks ...health.Check) *HealthHandlers {
+	return &HealthHandlers{timeout: timeout, checks: checks}
+}
+
+// Livez maneja GET /livez
+func (h *HealthHandlers) Livez(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+// Readyz maneja GET /readyz: 503 si falla una dependencia critica; las opcionales solo
+// degradan el reporte.
+func (h *HealthHandlers) Readyz(c *gin.Context) {
+	report := health.Evaluate(c.Request.Context(), h.timeout, h.checks...)
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, report)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"api/internal/presentation/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Valores del header X-Cache.
+const (
+	cacheStatusFresh = "fresh"
+	cacheStatusStale = "stale"
+	cacheStatusMiss  = "miss"
+)
+
+// rankingCacheHit describe una respuesta servida desde una entrada de AdminCache.
+type rankingCacheHit struct {
+	status     string
+	freshUntil time.Time
+	staleUntil time.Time
+}
+
+// writeCacheableJSON responde body con ETag, Cache-Control y X-Cache; hit nil indica que
+// la respuesta salio de la base de datos. Si If-None-Match coincide con el ETag responde 304.
+//   - fresh: cacheable hasta FreshUntil
+//   - stale: debe revalidarse, servible mientras AdminCache refresca hasta StaleUntil
+//   - miss: debe revalidarse siempre
+func writeCacheableJSON(c *gin.Context, body any, hit *rankingCacheHit) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+
+	sum := sha256.Sum256(payload)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	status := cacheStatusMiss
+	cacheControl := "public, no-cache"
+	if hit != nil {
+		now := time.Now()
+		status = hit.status
+		switch hit.status {
+		case cacheStatusFresh:
+			cacheControl = fmt.Sprintf("public, max-age=%d", secondsUntil(now, hit.freshUntil))
+		case cacheStatusStale:
+			cacheControl = fmt.Sprintf("public, max-age=0, stale-while-revalidate=%d", secondsUntil(now, hit.staleUntil))
+		}
+	}
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", cacheControl)
+	c.Header("X-Cache", status)
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", payload)
+}
+
+func secondsUntil(now, t time.Time) int {
+	if !t.After(now) {
+		return 0
+	}
+	return int(math.Ceil(t.Sub(now).Seconds()))
+}
+
+// etagMatches evalua If-None-Match con comparacion debil (RFC 9110): acepta "*",
+// listas separadas por comas y validadores W/.
+func etagMatches(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,17 @@
 package handlers
 
-const badRequest = "Bad Request"
+import (
+	"net/http"
 
-// Common localized messages
-const invalidTokenExpiredMsg = "Token inválido o expirado."
+	"api/internal/domain"
+	"api/internal/presentation/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeVideoError traduce los errores de las operaciones sobre un video: rules primero,
+// luego ErrNotFound como video_not_found y el resto de errores de dominio por defecto.
+func writeVideoError(c *gin.Context, err error, rules ...problem.Rule) {
+	rules = append(rules, problem.On(domain.ErrNotFound, http.StatusNotFound, problem.CodeVideoNotFound))
+	problem.AbortWithError(c, err, rules...)
+}
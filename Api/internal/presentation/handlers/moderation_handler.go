@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/presentation/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ModerationHandlers expone la cola de revision previa a la publicacion.
+// Las rutas deben protegerse con JWTMiddleware y RequirePermission(PrivilegeModerateVideos).
+type ModerationHandlers struct {
+	uc    *useCase.ModerationUseCase
+	media *useCase.MediaURLService
+}
+
+func NewModerationHandlers(uc *useCase.ModerationUseCase, media *useCase.MediaURLService) *ModerationHandlers {
+	return &ModerationHandlers{uc: uc, media: media}
+}
+
+type rejectVideoRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ListPending maneja GET /api/moderation/videos
+func (h *ModerationHandlers) ListPending(c *gin.Context) {
+	page := 1
+	pageSize := 20
+	if ps := strings.TrimSpace(c.Query("page")); ps != "" {
+		v, err := strconv.Atoi(ps)
+		if err != nil || v < 1 {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return
+		}
+		page = v
+	}
+	if pss := strings.TrimSpace(c.Query("pageSize")); pss != "" {
+		v, err := strconv.Atoi(pss)
+		if err != nil || v < 1 || v > 100 {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return
+		}
+		pageSize = v
+	}
+
+	items, err := h.uc.ListPending(c.Request.Context(), page, pageSize)
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	if h.media != nil {
+		for i := range items {
+			items[i].ProcessedURL = h.media.ReviewURL(c.Request.Context(), items[i].ProcessedURL)
+		}
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// Approve maneja POST /api/moderation/videos/:video_id/approve
+func (h *ModerationHandlers) Approve(c *gin.Context) {
+	moderatorID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	videoID, ok := parseVideoIDOrAbort(c)
+	if !ok {
+		return
+	}
+	if err := h.uc.Approve(c.Request.Context(), moderatorID, videoID); err != nil {
+		writeModerationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Video publicado exitosamente.", "video_id": c.Param("video_id"), "status": "published"})
+}
+
+// Reject maneja POST /api/moderation/videos/:video_id/reject
+func (h *ModerationHandlers) Reject(c *gin.Context) {
+	moderatorID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	videoID, ok := parseVideoIDOrAbort(c)
+	if !ok {
+		return
+	}
+	var req rejectVideoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidBody)
+		return
+	}
+	if err := h.uc.Reject(c.Request.Context(), moderatorID, videoID, req.Reason); err != nil {
+		writeModerationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Video rechazado.", "video_id": c.Param("video_id"), "status": "rejected"})
+}
+
+func writeModerationError(c *gin.Context, err error) {
+	writeVideoError(c, err,
+		problem.On(domain.ErrInvalid, http.StatusBadRequest, problem.CodeInvalidRejectionReason),
+		problem.On(domain.ErrConflict, http.StatusConflict, problem.CodeVideoNotPendingReview),
+	)
+}
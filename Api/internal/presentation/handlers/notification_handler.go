@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/presentation/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandlers expone la bandeja de notificaciones del usuario autenticado.
+type NotificationHandlers struct {
+	uc *useCase.NotificationUseCase
+}
+
+func NewNotificationHandlers(uc *useCase.NotificationUseCase) *NotificationHandlers {
+	return &NotificationHandlers{uc: uc}
+}
+
+// ListNotifications maneja GET /api/me/notifications (page, pageSize).
+func (h *NotificationHandlers) ListNotifications(c *gin.Context) {
+	userID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	page, pageSize, ok := parsePageQueryOrAbort(c)
+	if !ok {
+		return
+	}
+	items, err := h.uc.List(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// MarkNotificationRead maneja POST /api/me/notifications/:notification_id/read
+func (h *NotificationHandlers) MarkNotificationRead(c *gin.Context) {
+	userID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseUint(c.Param("notification_id"), 10, 64)
+	if err != nil || parsed == 0 {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
+		return
+	}
+	if err := h.uc.MarkRead(c.Request.Context(), userID, uint(parsed)); err != nil {
+		problem.AbortWithError(c, err,
+			problem.On(domain.ErrNotFound, http.StatusNotFound, problem.CodeNotificationNotFound),
+		)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Notificación marcada como leída.", "notification_id": c.Param("notification_id")})
+}
@@ -0,0 +1,9 @@
+package handlers
+
+// Privilegios (tabla privilege) que exigen las rutas de administracion y los handlers.
+const (
+	PrivilegeModerateVideos = "moderate_videos"
+	PrivilegeTriageReports  = "triage_reports"
+	PrivilegeManageContests = "manage_contests"
+	PrivilegeReviewVotes    = "review_votes"
+)
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"api/internal/domain"
+	domainresponses "api/internal/domain/responses"
+	"api/internal/presentation/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserProfile maneja GET /api/public/users/:username
+// Publico. Devuelve ciudad, votos, posicion global y en su ciudad, y sus videos publicados
+// paginados por cursor (sort, cursor y limit como en /api/public/videos).
+func (h *PublicHandlers) UserProfile(c *gin.Context) {
+	username := strings.TrimSpace(c.Param("username"))
+	if username == "" {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
+		return
+	}
+	q, ok := parseGalleryPageOrAbort(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, err := h.service.UserBasicByUsername(ctx, username)
+	if err != nil {
+		problem.AbortWithError(c, err, problem.On(domain.ErrNotFound, http.StatusNotFound, problem.CodeUserNotFound))
+		return
+	}
+
+	q.OwnerUserID = &user.UserID
+	page, err := h.service.SearchPublicVideos(ctx, q, c.Query("cursor"))
+	if err != nil {
+		problem.AbortWithError(c, err, problem.On(domain.ErrInvalid, http.StatusBadRequest, problem.CodeInvalidQuery))
+		return
+	}
+	if err := h.service.MarkVotedByMe(ctx, c.GetUint("userID"), page.Items); err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	h.resolveMediaURLs(ctx, page.Items)
+
+	global, city, votes, err := h.userStandings(ctx, *user)
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	resp := domainresponses.PlayerProfileResponse{
+		Username:   user.Username,
+		City:       user.City,
+		Votes:      votes,
+		Videos:     page.Items,
+		NextCursor: page.NextCursor,
+	}
+	if global != nil {
+		resp.GlobalRank = &global.Position
+	}
+	if city != nil {
+		resp.CityRank = &city.Position
+	}
+	c.JSON(http.StatusOK, resp)
+}
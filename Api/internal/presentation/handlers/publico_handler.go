@@ -13,8 +13,11 @@ import (
 
 	"api/internal/application/useCase"
 	"api/internal/domain"
+	"api/internal/domain/entities"
 	"api/internal/domain/interfaces"
 	domainresponses "api/internal/domain/responses"
+	"api/internal/infrastructure/metrics"
+	"api/internal/presentation/problem"
 
 	"github.com/gin-gonic/gin"
 	redis "github.com/redis/go-redis/v9"
@@ -25,6 +28,9 @@ type PublicHandlers struct {
 	service            *useCase.PublicService
 	cache              interfaces.Cache
 	cacheSchemaVersion string
+	media              *useCase.MediaURLService
+	contests           *useCase.ContestUseCase
+	metrics            *metrics.Metrics
 }
 
 // NewPublicHandlers mantiene compatibilidad para tests y uso sin cache.
@@ -37,83 +43,322 @@ func NewPublicHandlersWithCache(service *useCase.PublicService, cache interfaces
 	return &PublicHandlers{service: service, cache: cache, cacheSchemaVersion: strings.TrimSpace(schemaVersion)}
 }
 
+// WithMediaURLs configura la resolucion de claves de objeto a URLs (firmadas si aplica).
+func (h *PublicHandlers) WithMediaURLs(media *useCase.MediaURLService) *PublicHandlers {
+	h.media = media
+	return h
+}
+
+// WithContests habilita las clasificaciones por concurso.
+func (h *PublicHandlers) WithContests(contests *useCase.ContestUseCase) *PublicHandlers {
+	h.contests = contests
+	return h
+}
+
+// WithMetrics cuenta votos, retiros y consultas a la cache de rankings; nil no cuenta nada.
+func (h *PublicHandlers) WithMetrics(m *metrics.Metrics) *PublicHandlers {
+	h.metrics = m
+	return h
+}
+
 // ListPublicVideos maneja GET /api/public/videos
+// Query: q (busqueda por titulo), city, country, sort (newest|most_voted|trending),
+// cursor (opaco), limit (1-100, default 20). El cursor de la pagina siguiente va en X-Next-Cursor.
 func (h *PublicHandlers) ListPublicVideos(c *gin.Context) {
-	results, err := h.service.ListPublicVideos(c.Request.Context())
+	q, ok := parseGalleryPageOrAbort(c)
+	if !ok {
+		return
+	}
+	q.Search = c.Query("q")
+	if v := strings.TrimSpace(c.Query("city")); v != "" {
+		q.City = &v
+	}
+	if v := strings.TrimSpace(c.Query("country")); v != "" {
+		q.Country = &v
+	}
+
+	page, err := h.service.SearchPublicVideos(c.Request.Context(), q, c.Query("cursor"))
+	if err != nil {
+		problem.AbortWithError(c, err, problem.On(domain.ErrInvalid, http.StatusBadRequest, problem.CodeInvalidQuery))
+		return
+	}
+	// Con token valido (OptionalJWTMiddleware) se marca voted_by_me con una sola consulta
+	if err := h.service.MarkVotedByMe(c.Request.Context(), c.GetUint("userID"), page.Items); err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	h.resolveMediaURLs(c.Request.Context(), page.Items)
+	if page.NextCursor != nil {
+		c.Header("X-Next-Cursor", *page.NextCursor)
+	}
+	c.JSON(http.StatusOK, page.Items)
+}
+
+// parseGalleryPageOrAbort lee sort y limit (1-100, default 20) de la galeria.
+func parseGalleryPageOrAbort(c *gin.Context) (entities.GalleryQuery, bool) {
+	sort, ok := entities.ParseGallerySort(c.Query("sort"))
+	if !ok {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+		return entities.GalleryQuery{}, false
+	}
+	q := entities.GalleryQuery{Sort: sort, Limit: useCase.DefaultGalleryLimit}
+	if ls := strings.TrimSpace(c.Query("limit")); ls != "" {
+		v, err := strconv.Atoi(ls)
+		if err != nil || v < 1 || v > useCase.MaxGalleryLimit {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return entities.GalleryQuery{}, false
+		}
+		q.Limit = v
+	}
+	return q, true
+}
+
+// GetPublicVideo maneja GET /api/public/videos/:video_id
+// Publico; con token valido incluye voted_by_me.
+func (h *PublicHandlers) GetPublicVideo(c *gin.Context) {
+	videoID, ok := parseVideoIDOrAbort(c)
+	if !ok {
+		return
+	}
+	detail, err := h.service.PublicVideoDetail(c.Request.Context(), videoID, c.GetUint("userID"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error", "message": err.Error()})
+		writeVideoError(c, err)
+		return
+	}
+	if h.media != nil {
+		detail.ProcessedURL = h.media.PublicURL(c.Request.Context(), detail.ProcessedURL)
+	}
+	c.JSON(http.StatusOK, detail)
+}
+
+// resolveMediaURLs reemplaza la clave almacenada por la URL entregable de cada video.
+func (h *PublicHandlers) resolveMediaURLs(ctx context.Context, videos []domainresponses.PublicVideoResponse) {
+	if h.media == nil {
 		return
 	}
-	c.JSON(http.StatusOK, results)
+	for i := range videos {
+		videos[i].ProcessedURL = h.media.PublicURL(ctx, videos[i].ProcessedURL)
+	}
 }
 
 // VotePublicVideo maneja POST /api/public/videos/:video_id/vote
 func (h *PublicHandlers) VotePublicVideo(c *gin.Context) {
+	defer func() { h.metrics.ObserveVote(metrics.VoteCast, c.Writer.Status()) }()
+
 	// 1) Auth: extraer userID del contexto
 	userID := c.GetUint("userID")
 	if userID == 0 {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": invalidTokenExpiredMsg})
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeUnauthorized)
 		return
 	}
 
 	// 2) Path param
 	v := c.Param("video_id")
 	if v == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": badRequest, "message": "Parametros invalidos."})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
 		return
 	}
 	vid64, err := strconv.ParseUint(v, 10, 64)
 	if err != nil || vid64 == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": badRequest, "message": "Parametros invalidos."})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
 		return
 	}
 	videoID := uint(vid64)
 
 	// 3) Idempotencia opcional: X-Event-Id (header) o query param "eventId"
-	var eventIDPtr *string
-	if evt := strings.TrimSpace(c.GetHeader("X-Event-Id")); evt != "" {
-		eventIDPtr = &[]string{evt}[0]
-	} else if evtq := strings.TrimSpace(c.Query("eventId")); evtq != "" {
-		eventIDPtr = &[]string{evtq}[0]
-	}
+	eventIDPtr := voteEventID(c)
 
-	// 4) Logica de voto via servicio (incluye verificacion de existencia y unicidad)
-	if eventIDPtr != nil {
-		err = h.service.VotePublicVideoWithEvent(c.Request.Context(), videoID, userID, eventIDPtr)
-	} else {
-		err = h.service.VotePublicVideo(c.Request.Context(), videoID, userID)
-	}
+	// 4) Logica de voto via servicio (incluye verificacion de existencia, unicidad y reglas antifraude).
+	// Un voto sospechoso se acepta igual pero queda en cuarentena; la respuesta no lo revela.
+	// ClientIP solo lee X-Forwarded-For de los proxies en TRUSTED_PROXIES (ver main).
+	meta := useCase.NewVoteMetadata(c.ClientIP(), c.GetHeader("User-Agent"))
+	err = h.service.VotePublicVideoWithMetadata(c.Request.Context(), videoID, userID, eventIDPtr, meta)
 	if err != nil {
-		// Mapear errores comunes
-		if errors.Is(err, domain.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Not Found", "message": "Video no encontrado."})
-			return
-		}
 		if errors.Is(err, domain.ErrIdempotent) {
 			c.JSON(http.StatusOK, gin.H{"message": "Voto registrado exitosamente."})
 			return
 		}
-		if errors.Is(err, domain.ErrConflict) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": badRequest, "message": "Ya has votado por este video."})
+		writeVoteError(c, err, problem.CodeAlreadyVoted, problem.CodeVoteFailed)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Voto registrado exitosamente."})
+}
+
+// RetractVote maneja DELETE /api/public/videos/:video_id/vote
+// Mismas reglas de idempotencia que el voto (X-Event-Id o query param "eventId").
+func (h *PublicHandlers) RetractVote(c *gin.Context) {
+	defer func() { h.metrics.ObserveVote(metrics.VoteRetract, c.Writer.Status()) }()
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeUnauthorized)
+		return
+	}
+	vid64, err := strconv.ParseUint(c.Param("video_id"), 10, 64)
+	if err != nil || vid64 == 0 {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
+		return
+	}
+
+	err = h.service.RetractVote(c.Request.Context(), uint(vid64), userID, voteEventID(c))
+	if err != nil {
+		if errors.Is(err, domain.ErrIdempotent) {
+			c.JSON(http.StatusOK, gin.H{"message": "Voto retirado exitosamente."})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error", "message": "No se pudo registrar el voto."})
+		writeVoteError(c, err, problem.CodeNotVoted, problem.CodeVoteRetractFailed)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Voto registrado exitosamente."})
+	c.JSON(http.StatusOK, gin.H{"message": "Voto retirado exitosamente."})
+}
+
+// writeVoteError traduce los errores de votar y retirar el voto: ErrConflict responde 400 con
+// conflictCode y los errores no reconocidos 500 con failedCode.
+func writeVoteError(c *gin.Context, err error, conflictCode, failedCode problem.Code) {
+	r, ok := problem.Match(err,
+		problem.On(domain.ErrNotFound, http.StatusNotFound, problem.CodeVideoNotFound),
+		problem.On(domain.ErrConflict, http.StatusBadRequest, conflictCode),
+	)
+	if !ok {
+		problem.InternalWithCode(c, err, failedCode)
+		return
+	}
+	problem.Abort(c, r.Status, r.Code)
+}
+
+// MyVotes maneja GET /api/me/votes
+// Devuelve el presupuesto de votos restante y el historial de votos paginado (page, pageSize).
+func (h *PublicHandlers) MyVotes(c *gin.Context) {
+	userID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	page, pageSize, ok := parsePageQueryOrAbort(c)
+	if !ok {
+		return
+	}
+	out, err := h.service.MyVotes(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// voteEventID extrae el identificador de idempotencia: header X-Event-Id o query param "eventId".
+func voteEventID(c *gin.Context) *string {
+	if evt := strings.TrimSpace(c.GetHeader("X-Event-Id")); evt != "" {
+		return &evt
+	}
+	if evtq := strings.TrimSpace(c.Query("eventId")); evtq != "" {
+		return &evtq
+	}
+	return nil
 }
 
 // ListRankings maneja GET /api/public/rankings
 // Publico, sin autenticacion. Devuelve un array de RankingEntry.
+// Ademas de city acepta window=day|week|month|all (default all) y country (nombre o codigo ISO).
 func (h *PublicHandlers) ListRankings(c *gin.Context) {
-	// Validacion de parametros de consulta
+	city, page, pageSize, ok := parseRankingQueryOrAbort(c)
+	if !ok {
+		return
+	}
+	window, country, ok := parseRankingFiltersOrAbort(c)
+	if !ok {
+		return
+	}
+	filter := entities.RankingFilter{City: city, Country: country, Window: window}
+
+	if cached, hit, err := h.filteredRankingsFromCache(c.Request.Context(), filter, page, pageSize); err == nil && hit != nil {
+		writeCacheableJSON(c, cached, hit)
+		return
+	}
+
+	items, err := h.service.FilteredRankings(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	writeCacheableJSON(c, toRankingEntries(items), nil)
+}
+
+// ListContestRankings maneja GET /api/public/contests/:contest_id/rankings
+// Mismos parametros que ListRankings; en concursos cerrados devuelve la clasificacion congelada.
+func (h *PublicHandlers) ListContestRankings(c *gin.Context) {
+	contestID, ok := parseContestIDOrAbort(c)
+	if !ok {
+		return
+	}
+	city, page, pageSize, ok := parseRankingQueryOrAbort(c)
+	if !ok {
+		return
+	}
+
+	if cached, hit, err := h.rankingsFromCache(c.Request.Context(), contestID, city, page, pageSize); err == nil && hit != nil {
+		writeCacheableJSON(c, cached, hit)
+		return
+	}
+
+	items, err := h.contests.Rankings(c.Request.Context(), contestID, city, page, pageSize)
+	if err != nil {
+		writeContestError(c, err)
+		return
+	}
+	writeCacheableJSON(c, toRankingEntries(items), nil)
+}
+
+// ListVideoRankings maneja GET /api/public/rankings/videos
+// Mismos parametros que ListRankings; la ciudad es la del autor del video.
+func (h *PublicHandlers) ListVideoRankings(c *gin.Context) {
+	city, page, pageSize, ok := parseRankingQueryOrAbort(c)
+	if !ok {
+		return
+	}
+
+	if cached, hit, err := h.videoRankingsFromCache(c.Request.Context(), city, page, pageSize); err == nil && hit != nil {
+		writeCacheableJSON(c, cached, hit)
+		return
+	}
+
+	items, err := h.service.VideoRankings(c.Request.Context(), city, page, pageSize)
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	writeCacheableJSON(c, toVideoRankingEntries(items), nil)
+}
+
+// parseRankingQueryOrAbort valida city, page (default 1) y pageSize (default 20, max 100).
+func parseRankingQueryOrAbort(c *gin.Context) (*string, int, int, bool) {
 	cityParam := strings.TrimSpace(c.Query("city"))
 	var city *string
 	if cityParam != "" {
 		city = &cityParam
 	}
+	page, pageSize, ok := parsePaginationOrAbort(c)
+	if !ok {
+		return nil, 0, 0, false
+	}
+	return city, page, pageSize, true
+}
 
+// parsePageQueryOrAbort es la paginacion de los listados personales (/api/me/...): solo
+// acepta page y pageSize y rechaza cualquier otro parametro, como filtros de ranking.
+func parsePageQueryOrAbort(c *gin.Context) (int, int, bool) {
+	for key := range c.Request.URL.Query() {
+		if key != "page" && key != "pageSize" {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return 0, 0, false
+		}
+	}
+	return parsePaginationOrAbort(c)
+}
+
+// parsePaginationOrAbort valida page (default 1) y pageSize (default 20, max 100).
+func parsePaginationOrAbort(c *gin.Context) (int, int, bool) {
 	// Defaults
 	page := 1
 	pageSize := 20
@@ -121,30 +366,37 @@ func (h *PublicHandlers) ListRankings(c *gin.Context) {
 	if ps := strings.TrimSpace(c.Query("page")); ps != "" {
 		v, err := strconv.Atoi(ps)
 		if err != nil || v < 1 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": badRequest, "message": "Parametro invalido en la consulta"})
-			return
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return 0, 0, false
 		}
 		page = v
 	}
 	if pss := strings.TrimSpace(c.Query("pageSize")); pss != "" {
 		v, err := strconv.Atoi(pss)
 		if err != nil || v < 1 || v > 100 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": badRequest, "message": "Parametro invalido en la consulta"})
-			return
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return 0, 0, false
 		}
 		pageSize = v
 	}
+	return page, pageSize, true
+}
 
-	if cached, ok, err := h.rankingsFromCache(c.Request.Context(), city); err == nil && ok {
-		c.JSON(http.StatusOK, cached)
-		return
+// parseRankingFiltersOrAbort valida window (day|week|month|all) y country.
+func parseRankingFiltersOrAbort(c *gin.Context) (entities.RankingWindow, *string, bool) {
+	window, ok := entities.ParseRankingWindow(c.Query("window"))
+	if !ok {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+		return "", nil, false
 	}
-
-	items, err := h.service.Rankings(c.Request.Context(), city, page, pageSize)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error", "message": err.Error()})
-		return
+	var country *string
+	if v := strings.TrimSpace(c.Query("country")); v != "" {
+		country = &v
 	}
+	return window, country, true
+}
+
+func toRankingEntries(items []domainresponses.RankingItem) []domainresponses.RankingEntry {
 	resp := make([]domainresponses.RankingEntry, 0, len(items))
 	for i, it := range items {
 		resp = append(resp, domainresponses.RankingEntry{
@@ -154,93 +406,336 @@ func (h *PublicHandlers) ListRankings(c *gin.Context) {
 			Votes:    it.Votes,
 		})
 	}
-	c.JSON(http.StatusOK, resp)
+	return resp
+}
+
+func toVideoRankingEntries(items []domainresponses.VideoRankingItem) []domainresponses.VideoRankingEntry {
+	resp := make([]domainresponses.VideoRankingEntry, 0, len(items))
+	for i, it := range items {
+		resp = append(resp, domainresponses.VideoRankingEntry{
+			Position: i + 1,
+			VideoID:  it.VideoID,
+			Title:    it.Title,
+			Username: it.Username,
+			City:     it.City,
+			Votes:    it.Votes,
+		})
+	}
+	return resp
 }
 
-func (h *PublicHandlers) rankingsFromCache(ctx context.Context, city *string) ([]domainresponses.RankingEntry, bool, error) {
+// rankingCacheKey replica las claves escritas por AdminCache.
+// contestID 0 corresponde al ranking historico; citySlug vacio al alcance global.
+func rankingCacheKey(contestID uint, citySlug, schemaVersion string) string {
+	prefix := "rank"
+	if contestID != 0 {
+		prefix = fmt.Sprintf("rank:contest:%d", contestID)
+	}
+	if citySlug != "" {
+		return fmt.Sprintf("%s:city:%s:%s", prefix, citySlug, schemaVersion)
+	}
+	return fmt.Sprintf("%s:global:%s", prefix, schemaVersion)
+}
+
+// filteredRankingCacheKey replica las claves por ventana y pais escritas por AdminCache:
+// la ventana all usa las claves historicas (rankingCacheKey).
+func filteredRankingCacheKey(window entities.RankingWindow, scope, slug, schemaVersion string) string {
+	prefix := "rank"
+	if window != "" && window != entities.RankingWindowAll {
+		prefix = fmt.Sprintf("rank:window:%s", window)
+	}
+	if scope == cacheScopeGlobal {
+		return fmt.Sprintf("%s:global:%s", prefix, schemaVersion)
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", prefix, scope, slug, schemaVersion)
+}
+
+// videoRankingCacheKey replica las claves del ranking de videos escritas por AdminCache.
+func videoRankingCacheKey(citySlug, schemaVersion string) string {
+	if citySlug != "" {
+		return fmt.Sprintf("rank:videos:city:%s:%s", citySlug, schemaVersion)
+	}
+	return fmt.Sprintf("rank:videos:global:%s", schemaVersion)
+}
+
+// rankingCacheScope devuelve la version de esquema y el slug de ciudad a consultar;
+// ok=false si la cache no esta configurada o la ciudad no produce un slug valido.
+func (h *PublicHandlers) rankingCacheScope(city *string) (schemaVersion, citySlug string, ok bool) {
 	if h.cache == nil {
-		return nil, false, nil
+		return "", "", false
 	}
 
-	schemaVersion := strings.TrimSpace(h.cacheSchemaVersion)
+	schemaVersion = strings.TrimSpace(h.cacheSchemaVersion)
 	if schemaVersion == "" {
-		return nil, false, nil
+		return "", "", false
 	}
 
-	key := fmt.Sprintf("rank:global:%s", schemaVersion)
-	var citySlug string
 	if city != nil {
 		trimmed := strings.TrimSpace(*city)
 		if trimmed != "" {
 			citySlug = slugCity(trimmed)
 			if citySlug == "" {
-				return nil, false, nil
+				return "", "", false
 			}
-			key = fmt.Sprintf("rank:city:%s:%s", citySlug, schemaVersion)
 		}
 	}
+	return schemaVersion, citySlug, true
+}
+
+// Los lectores de cache devuelven hit nil (sin error) cuando la pagina no puede servirse
+// desde cache y debe consultarse la base de datos. Cada lector registra su resultado con
+// observeRankingCache.
+
+func (h *PublicHandlers) observeRankingCache(hit *rankingCacheHit, err error) {
+	switch {
+	case err != nil:
+		h.metrics.ObserveRankingCache(metrics.CacheError)
+	case hit == nil:
+		h.metrics.ObserveRankingCache(metrics.CacheMiss)
+	default:
+		h.metrics.ObserveRankingCache(metrics.CacheHit)
+	}
+}
+
+func (h *PublicHandlers) rankingsFromCache(ctx context.Context, contestID uint, city *string, page, pageSize int) (entries []domainresponses.RankingEntry, hit *rankingCacheHit, err error) {
+	defer func() { h.observeRankingCache(hit, err) }()
+	schemaVersion, citySlug, ok := h.rankingCacheScope(city)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	target := rankingCacheTarget{key: rankingCacheKey(contestID, citySlug, schemaVersion), contestID: contestID}
+	if citySlug != "" {
+		target.scope, target.slug = cacheScopeCity, citySlug
+	}
+	return h.rankingEntriesFromCache(ctx, schemaVersion, target, page, pageSize)
+}
+
+// filteredRankingsFromCache sirve el ranking historico o por ventana, global, por ciudad o por pais.
+// Con ciudad y pais a la vez no hay clave cacheada y se consulta la base de datos.
+func (h *PublicHandlers) filteredRankingsFromCache(ctx context.Context, filter entities.RankingFilter, page, pageSize int) (entries []domainresponses.RankingEntry, hit *rankingCacheHit, err error) {
+	defer func() { h.observeRankingCache(hit, err) }()
+	if filter.City != nil && filter.Country != nil {
+		return nil, nil, nil
+	}
+	place := filter.City
+	scope := cacheScopeCity
+	if filter.Country != nil {
+		place, scope = filter.Country, cacheScopeCountry
+	}
+	schemaVersion, slug, ok := h.rankingCacheScope(place)
+	if !ok {
+		return nil, nil, nil
+	}
+	if slug == "" {
+		scope = cacheScopeGlobal
+	}
+
+	target := rankingCacheTarget{
+		key:    filteredRankingCacheKey(filter.Window, scope, slug, schemaVersion),
+		window: string(filter.Window),
+	}
+	if scope != cacheScopeGlobal {
+		target.scope, target.slug = scope, slug
+	}
+	return h.rankingEntriesFromCache(ctx, schemaVersion, target, page, pageSize)
+}
+
+func (h *PublicHandlers) rankingEntriesFromCache(ctx context.Context, schemaVersion string, target rankingCacheTarget, page, pageSize int) ([]domainresponses.RankingEntry, *rankingCacheHit, error) {
+	entry, hit, err := h.readRankingCache(ctx, schemaVersion, target)
+	if err != nil || hit == nil {
+		return nil, nil, err
+	}
+
+	items := make([]rankingCacheItem, 0, len(entry.Items))
+	for _, item := range entry.Items {
+		if item.Username != "" {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return nil, nil, fmt.Errorf("cache entry without items")
+	}
+	start, end, ok := cachedPageBounds(len(items), page, pageSize, entry.Complete)
+	if !ok {
+		return nil, nil, nil
+	}
+	items = items[start:end]
 
-	raw, err := h.cache.GetBytes(ctx, key)
+	userMeta, err := h.userMetaFor(ctx, items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Igual que en la base de datos, la posicion se numera dentro de la pagina.
+	resp := make([]domainresponses.RankingEntry, 0, len(items))
+	for i, item := range items {
+		resp = append(resp, domainresponses.RankingEntry{
+			Position: i + 1,
+			Username: item.Username,
+			City:     cityFromSources(item.UserID, userMeta, entry.City),
+			Votes:    int(item.Score),
+		})
+	}
+	return resp, hit, nil
+}
+
+func (h *PublicHandlers) videoRankingsFromCache(ctx context.Context, city *string, page, pageSize int) (entries []domainresponses.VideoRankingEntry, hit *rankingCacheHit, err error) {
+	defer func() { h.observeRankingCache(hit, err) }()
+	schemaVersion, citySlug, ok := h.rankingCacheScope(city)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	target := rankingCacheTarget{key: videoRankingCacheKey(citySlug, schemaVersion)}
+	if citySlug != "" {
+		target.scope, target.slug = cacheScopeCity, citySlug
+	}
+	entry, hit, err := h.readRankingCache(ctx, schemaVersion, target)
+	if err != nil || hit == nil {
+		return nil, nil, err
+	}
+
+	items := make([]rankingCacheItem, 0, len(entry.Items))
+	for _, item := range entry.Items {
+		if item.VideoID > 0 {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return nil, nil, fmt.Errorf("cache entry without items")
+	}
+	start, end, ok := cachedPageBounds(len(items), page, pageSize, entry.Complete)
+	if !ok {
+		return nil, nil, nil
+	}
+	items = items[start:end]
+
+	userMeta, err := h.userMetaFor(ctx, items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp := make([]domainresponses.VideoRankingEntry, 0, len(items))
+	for i, item := range items {
+		resp = append(resp, domainresponses.VideoRankingEntry{
+			Position: i + 1,
+			VideoID:  uint(item.VideoID),
+			Title:    item.Title,
+			Username: item.Username,
+			City:     cityFromSources(item.UserID, userMeta, entry.City),
+			Votes:    int(item.Score),
+		})
+	}
+	return resp, hit, nil
+}
+
+// cachedPageBounds devuelve el rango [start, end) de la pagina dentro de n elementos cacheados.
+// Si la entrada solo tiene el top-N, la pagina tiene que caber entera en el; ok=false hace que
+// el handler la lea del repositorio, para que la respuesta no dependa del estado de la cache.
+func cachedPageBounds(n, page, pageSize int, complete bool) (start, end int, ok bool) {
+	start = (page - 1) * pageSize
+	if start+pageSize > n && !complete {
+		return 0, 0, false
+	}
+	if start > n {
+		start = n
+	}
+	end = start + pageSize
+	if end > n {
+		end = n
+	}
+	return start, end, true
+}
+
+// rankingCacheTarget describe la entrada de cache esperada; scope vacio es el alcance global
+// y window vacia el ranking historico.
+type rankingCacheTarget struct {
+	key       string
+	contestID uint
+	scope     string
+	slug      string
+	window    string
+}
+
+// readRankingCache lee y valida una entrada de ranking (esquema, concurso, alcance, ventana y vigencia).
+// La entrada es fresh hasta FreshUntil y stale hasta StaleUntil; vencida se descarta.
+func (h *PublicHandlers) readRankingCache(ctx context.Context, schemaVersion string, target rankingCacheTarget) (*rankingCacheEntry, *rankingCacheHit, error) {
+	raw, err := h.cache.GetBytes(ctx, target.key)
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
-			return nil, false, nil
+			return nil, nil, nil
 		}
-		return nil, false, err
+		return nil, nil, err
 	}
 
 	var entry rankingCacheEntry
 	if err := json.Unmarshal(raw, &entry); err != nil {
-		return nil, false, err
+		return nil, nil, err
 	}
 
 	if entry.SchemaVersion != "" && entry.SchemaVersion != schemaVersion {
-		return nil, false, fmt.Errorf("schema version mismatch: cache=%s expected=%s", entry.SchemaVersion, schemaVersion)
+		return nil, nil, fmt.Errorf("schema version mismatch: cache=%s expected=%s", entry.SchemaVersion, schemaVersion)
 	}
 
-	if citySlug != "" {
+	if entry.ContestID != int64(target.contestID) {
+		return nil, nil, fmt.Errorf("contest mismatch: cache=%d expected=%d", entry.ContestID, target.contestID)
+	}
+
+	switch target.scope {
+	case cacheScopeCity:
 		if entry.Scope != cacheScopeCity {
-			return nil, false, fmt.Errorf("unexpected scope %s for city cache", entry.Scope)
+			return nil, nil, fmt.Errorf("unexpected scope %s for city cache", entry.Scope)
+		}
+		if entry.CitySlug != "" && entry.CitySlug != target.slug {
+			return nil, nil, fmt.Errorf("city slug mismatch: cache=%s expected=%s", entry.CitySlug, target.slug)
 		}
-		if entry.CitySlug != "" && entry.CitySlug != citySlug {
-			return nil, false, fmt.Errorf("city slug mismatch: cache=%s expected=%s", entry.CitySlug, citySlug)
+	case cacheScopeCountry:
+		if entry.Scope != cacheScopeCountry {
+			return nil, nil, fmt.Errorf("unexpected scope %s for country cache", entry.Scope)
 		}
+		if entry.CountrySlug != "" && entry.CountrySlug != target.slug {
+			return nil, nil, fmt.Errorf("country slug mismatch: cache=%s expected=%s", entry.CountrySlug, target.slug)
+		}
+	}
+
+	if windowOrAll(entry.Window) != windowOrAll(target.window) {
+		return nil, nil, fmt.Errorf("window mismatch: cache=%s expected=%s", entry.Window, target.window)
 	}
 
 	now := time.Now().UTC()
-	if entry.StaleUntil.IsZero() || now.After(entry.StaleUntil) {
-		return nil, false, fmt.Errorf("cache entry expired")
+	if entry.StaleUntil.IsZero() || !now.Before(entry.StaleUntil) {
+		return nil, nil, fmt.Errorf("cache entry expired")
 	}
+	hit := &rankingCacheHit{status: cacheStatusStale, freshUntil: entry.FreshUntil, staleUntil: entry.StaleUntil}
+	if now.Before(entry.FreshUntil) {
+		hit.status = cacheStatusFresh
+	}
+	return &entry, hit, nil
+}
 
-	userIDs := collectUserIDs(entry.Items)
-	var userMeta map[uint]domainresponses.UserBasic
-	if len(userIDs) > 0 {
-		basics, err := h.service.UserBasicsByIDs(ctx, userIDs)
-		if err != nil {
-			return nil, false, err
-		}
-		userMeta = make(map[uint]domainresponses.UserBasic, len(basics))
-		for _, ub := range basics {
-			userMeta[ub.UserID] = ub
-		}
+func windowOrAll(w string) string {
+	if w == "" {
+		return string(entities.RankingWindowAll)
 	}
+	return w
+}
 
-	resp := make([]domainresponses.RankingEntry, 0, len(entry.Items))
-	for _, item := range entry.Items {
-		if item.Username == "" {
-			continue
-		}
-		cityPtr := cityFromSources(item.UserID, userMeta, entry.City)
-		resp = append(resp, domainresponses.RankingEntry{
-			Position: item.Rank,
-			Username: item.Username,
-			City:     cityPtr,
-			Votes:    int(item.Score),
-		})
+// userMetaFor resuelve la ciudad actual de los usuarios presentes en los items cacheados.
+func (h *PublicHandlers) userMetaFor(ctx context.Context, items []rankingCacheItem) (map[uint]domainresponses.UserBasic, error) {
+	userIDs := collectUserIDs(items)
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	basics, err := h.service.UserBasicsByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
 	}
-	if len(resp) == 0 {
-		return nil, false, fmt.Errorf("cache entry without items")
+	userMeta := make(map[uint]domainresponses.UserBasic, len(basics))
+	for _, ub := range basics {
+		userMeta[ub.UserID] = ub
 	}
-	return resp, true, nil
+	return userMeta, nil
 }
 
 func collectUserIDs(items []rankingCacheItem) []uint {
@@ -287,25 +782,37 @@ func cloneStringValue(v string) *string {
 }
 
 const (
-	cacheScopeCity = "city"
+	cacheScopeGlobal  = "global"
+	cacheScopeCity    = "city"
+	cacheScopeCountry = "country"
 )
 
+// rankingCacheItem es comun a los rankings de usuarios y de videos;
+// VideoID y Title solo vienen informados en el ranking de videos.
 type rankingCacheItem struct {
 	Rank     int    `json:"rank"`
+	VideoID  int64  `json:"video_id,omitempty"`
+	Title    string `json:"title,omitempty"`
 	UserID   int64  `json:"user_id"`
 	Username string `json:"username"`
 	Score    int64  `json:"score"`
 }
 
 type rankingCacheEntry struct {
-	SchemaVersion string             `json:"schema_version"`
-	Scope         string             `json:"scope"`
-	City          string             `json:"city,omitempty"`
-	CitySlug      string             `json:"city_slug,omitempty"`
-	AsOf          time.Time          `json:"as_of"`
-	FreshUntil    time.Time          `json:"fresh_until"`
-	StaleUntil    time.Time          `json:"stale_until"`
-	Items         []rankingCacheItem `json:"items"`
+	SchemaVersion string `json:"schema_version"`
+	Scope         string `json:"scope"`
+	City          string `json:"city,omitempty"`
+	CitySlug      string `json:"city_slug,omitempty"`
+	Country       string `json:"country,omitempty"`
+	CountrySlug   string `json:"country_slug,omitempty"`
+	Window        string `json:"window,omitempty"`
+	ContestID     int64  `json:"contest_id,omitempty"`
+	// Complete indica que Items contiene el ranking entero (no solo el top cacheado).
+	Complete   bool               `json:"complete,omitempty"`
+	AsOf       time.Time          `json:"as_of"`
+	FreshUntil time.Time          `json:"fresh_until"`
+	StaleUntil time.Time          `json:"stale_until"`
+	Items      []rankingCacheItem `json:"items"`
 }
 
 func slugCity(city string) string {
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"api/internal/domain"
+	"api/internal/domain/interfaces"
+	domainresponses "api/internal/domain/responses"
+	"api/internal/presentation/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserRank maneja GET /api/public/rankings/users/:username
+// Publico. Devuelve la posicion global y en su ciudad, los votos y las entradas vecinas.
+func (h *PublicHandlers) UserRank(c *gin.Context) {
+	username := strings.TrimSpace(c.Param("username"))
+	if username == "" {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
+		return
+	}
+
+	user, err := h.service.UserBasicByUsername(c.Request.Context(), username)
+	if err != nil {
+		problem.AbortWithError(c, err, problem.On(domain.ErrNotFound, http.StatusNotFound, problem.CodeUserNotFound))
+		return
+	}
+	h.writeUserRank(c, *user)
+}
+
+// MyRank maneja GET /api/me/rank
+// Requiere JWT. Misma respuesta que UserRank para el usuario autenticado.
+func (h *PublicHandlers) MyRank(c *gin.Context) {
+	userID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+
+	basics, err := h.service.UserBasicsByIDs(c.Request.Context(), []uint{userID})
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	if len(basics) == 0 {
+		problem.Abort(c, http.StatusNotFound, problem.CodeUserNotFound)
+		return
+	}
+	h.writeUserRank(c, basics[0])
+}
+
+func (h *PublicHandlers) writeUserRank(c *gin.Context, user domainresponses.UserBasic) {
+	resp := domainresponses.UserRankResponse{Username: user.Username, City: user.City}
+	var err error
+	resp.Global, resp.CityRank, resp.Votes, err = h.userStandings(c.Request.Context(), user)
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// userStandings devuelve la posicion global y en la ciudad del usuario (nil si no esta rankeado)
+// y sus votos.
+func (h *PublicHandlers) userStandings(ctx context.Context, user domainresponses.UserBasic) (global, city *domainresponses.RankStanding, votes int, err error) {
+	globalRows, err := h.rankNeighborhood(ctx, user.UserID, nil)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	var localRows []domainresponses.RankedUserItem
+	if user.City != nil && strings.TrimSpace(*user.City) != "" {
+		localRows, err = h.rankNeighborhood(ctx, user.UserID, user.City)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+	if err := h.fillRankedUsers(ctx, globalRows, localRows); err != nil {
+		return nil, nil, 0, err
+	}
+
+	global, votes = rankStanding(globalRows, user.UserID)
+	city, _ = rankStanding(localRows, user.UserID)
+	return global, city, votes, nil
+}
+
+// rankNeighborhood lee el leaderboard incremental de AdminCache y, si no esta disponible,
+// calcula la posicion en la base de datos.
+func (h *PublicHandlers) rankNeighborhood(ctx context.Context, userID uint, city *string) ([]domainresponses.RankedUserItem, error) {
+	if rows, ok := h.neighborhoodFromCache(ctx, userID, city); ok {
+		return rows, nil
+	}
+	return h.service.RankNeighborhood(ctx, userID, city)
+}
+
+func (h *PublicHandlers) neighborhoodFromCache(ctx context.Context, userID uint, city *string) ([]domainresponses.RankedUserItem, bool) {
+	lb, ok := h.cache.(interfaces.LeaderboardCache)
+	if !ok {
+		return nil, false
+	}
+	schemaVersion, citySlug, ok := h.rankingCacheScope(city)
+	if !ok {
+		return nil, false
+	}
+	rows, err := lb.LeaderboardNeighborhood(ctx, leaderboardCacheKey(citySlug, schemaVersion), fmt.Sprintf("lb:users:%s", schemaVersion), userID)
+	if err != nil {
+		return nil, false
+	}
+	return rows, true
+}
+
+// leaderboardCacheKey replica las claves de los leaderboards (ZSET) escritos por AdminCache.
+func leaderboardCacheKey(citySlug, schemaVersion string) string {
+	if citySlug != "" {
+		return fmt.Sprintf("lb:city:%s:%s", citySlug, schemaVersion)
+	}
+	return fmt.Sprintf("lb:global:%s", schemaVersion)
+}
+
+// fillRankedUsers completa username y ciudad de las filas leidas del leaderboard.
+func (h *PublicHandlers) fillRankedUsers(ctx context.Context, groups ...[]domainresponses.RankedUserItem) error {
+	seen := make(map[uint]struct{})
+	var ids []uint
+	for _, rows := range groups {
+		for _, r := range rows {
+			if r.Username != "" && r.City != nil {
+				continue
+			}
+			if _, dup := seen[r.UserID]; dup {
+				continue
+			}
+			seen[r.UserID] = struct{}{}
+			ids = append(ids, r.UserID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	basics, err := h.service.UserBasicsByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uint]domainresponses.UserBasic, len(basics))
+	for _, ub := range basics {
+		byID[ub.UserID] = ub
+	}
+	for _, rows := range groups {
+		for i := range rows {
+			ub, ok := byID[rows[i].UserID]
+			if !ok {
+				continue
+			}
+			if rows[i].Username == "" {
+				rows[i].Username = ub.Username
+			}
+			if rows[i].City == nil {
+				rows[i].City = cloneStringPtr(ub.City)
+			}
+		}
+	}
+	return nil
+}
+
+// rankStanding arma la posicion del usuario con sus vecinos; nil si no esta en rows.
+func rankStanding(rows []domainresponses.RankedUserItem, userID uint) (*domainresponses.RankStanding, int) {
+	for i, r := range rows {
+		if r.UserID != userID {
+			continue
+		}
+		st := &domainresponses.RankStanding{Position: r.Position}
+		if i > 0 {
+			st.Above = rankedEntry(rows[i-1])
+		}
+		if i+1 < len(rows) {
+			st.Below = rankedEntry(rows[i+1])
+		}
+		return st, r.Votes
+	}
+	return nil, 0
+}
+
+func rankedEntry(r domainresponses.RankedUserItem) *domainresponses.RankingEntry {
+	return &domainresponses.RankingEntry{
+		Position: r.Position,
+		Username: r.Username,
+		City:     r.City,
+		Votes:    r.Votes,
+	}
+}
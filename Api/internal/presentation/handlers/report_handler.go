@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/presentation/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportHandlers maneja denuncias de videos publicos y su triage.
+// Las rutas /api/admin/reports deben protegerse con RequirePermission(PrivilegeTriageReports).
+type ReportHandlers struct {
+	uc *useCase.ReportUseCase
+}
+
+func NewReportHandlers(uc *useCase.ReportUseCase) *ReportHandlers {
+	return &ReportHandlers{uc: uc}
+}
+
+type reportVideoRequest struct {
+	Category string `json:"category"`
+	Details  string `json:"details"`
+}
+
+// ReportVideo maneja POST /api/public/videos/:video_id/report
+func (h *ReportHandlers) ReportVideo(c *gin.Context) {
+	userID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	videoID, ok := parseVideoIDOrAbort(c)
+	if !ok {
+		return
+	}
+	var req reportVideoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidBody)
+		return
+	}
+	if err := h.uc.ReportVideo(c.Request.Context(), userID, videoID, req.Category, req.Details); err != nil {
+		writeVideoError(c, err,
+			problem.On(domain.ErrInvalid, http.StatusBadRequest, problem.CodeInvalidReport),
+			problem.On(domain.ErrConflict, http.StatusConflict, problem.CodeAlreadyReported),
+		)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Denuncia registrada. Gracias por avisarnos."})
+}
+
+// ListReports maneja GET /api/admin/reports
+func (h *ReportHandlers) ListReports(c *gin.Context) {
+	page := 1
+	pageSize := 20
+	if ps := strings.TrimSpace(c.Query("page")); ps != "" {
+		v, err := strconv.Atoi(ps)
+		if err != nil || v < 1 {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return
+		}
+		page = v
+	}
+	if pss := strings.TrimSpace(c.Query("pageSize")); pss != "" {
+		v, err := strconv.Atoi(pss)
+		if err != nil || v < 1 || v > 100 {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return
+		}
+		pageSize = v
+	}
+	items, err := h.uc.ListOpen(c.Request.Context(), page, pageSize)
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// DismissReport maneja POST /api/admin/reports/:report_id/dismiss
+func (h *ReportHandlers) DismissReport(c *gin.Context) {
+	adminID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	reportID, ok := parseReportIDOrAbort(c)
+	if !ok {
+		return
+	}
+	if err := h.uc.Dismiss(c.Request.Context(), adminID, reportID); err != nil {
+		writeReportError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Denuncia descartada.", "report_id": c.Param("report_id")})
+}
+
+// TakeDownVideo maneja POST /api/admin/reports/:report_id/takedown
+func (h *ReportHandlers) TakeDownVideo(c *gin.Context) {
+	adminID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	reportID, ok := parseReportIDOrAbort(c)
+	if !ok {
+		return
+	}
+	videoID, err := h.uc.TakeDown(c.Request.Context(), adminID, reportID)
+	if err != nil {
+		writeReportError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Video retirado.", "report_id": c.Param("report_id"), "video_id": strconv.FormatUint(uint64(videoID), 10)})
+}
+
+// parseReportIDOrAbort validates path param "report_id" and returns it as uint.
+func parseReportIDOrAbort(c *gin.Context) (uint, bool) {
+	parsed, err := strconv.ParseUint(c.Param("report_id"), 10, 64)
+	if err != nil || parsed == 0 {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
+		return 0, false
+	}
+	return uint(parsed), true
+}
+
+func writeReportError(c *gin.Context, err error) {
+	problem.AbortWithError(c, err,
+		problem.On(domain.ErrNotFound, http.StatusNotFound, problem.CodeReportNotFound),
+		problem.On(domain.ErrConflict, http.StatusConflict, problem.CodeReportResolved),
+	)
+}
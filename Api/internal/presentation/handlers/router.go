@@ -2,9 +2,13 @@ package handlers
 
 import (
 	"api/internal/application/useCase"
+	"api/internal/domain/entities"
 	"api/internal/domain/interfaces"
+	"api/internal/infrastructure/health"
+	"api/internal/infrastructure/metrics"
 	"api/internal/presentation/middlewares"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,36 +24,158 @@ type RouterConfig struct {
 	Cache              interfaces.Cache
 	CacheSchemaVersion string
 	ProcessedVideoURL  string
+	// MediaSigner firma URLs de corta duracion; nil mantiene URLs publicas sin firma.
+	MediaSigner interfaces.MediaURLSigner
+	// ModerationUC habilita la cola de revision; nil omite las rutas /api/moderation.
+	ModerationUC *useCase.ModerationUseCase
+	// ReportUC habilita denuncias y su triage; nil omite esas rutas.
+	ReportUC *useCase.ReportUseCase
+	// CommentUC habilita los comentarios publicos; nil omite esas rutas.
+	CommentUC *useCase.CommentUseCase
+	// ContestUC habilita concursos y clasificaciones por concurso; nil omite esas rutas.
+	ContestUC *useCase.ContestUseCase
+	// VoteReviewUC habilita la revision de votos en cuarentena; nil omite esas rutas.
+	VoteReviewUC *useCase.VoteReviewUseCase
+	// NotificationUC habilita /api/me/notifications; nil omite esas rutas.
+	NotificationUC *useCase.NotificationUseCase
+	// RateLimiter aplica RateLimits; nil deja las rutas sin limite de frecuencia.
+	RateLimiter interfaces.RateLimiter
+	RateLimits  RateLimitPolicies
+	// OpenAPI valida cada solicitud contra la especificacion; nil omite la validacion.
+	OpenAPI *middlewares.OpenAPIValidator
+	// Metrics mide cada solicitud y expone GET /metrics; nil omite ambas cosas.
+	Metrics *metrics.Metrics
+	// Tracing abre un span OpenTelemetry por solicitud (ver tracing.Setup); false lo omite.
+	Tracing bool
+	// HealthChecks son las dependencias que evalua GET /readyz, cada una acotada por
+	// HealthTimeout (cero usa health.DefaultTimeout).
+	HealthChecks  []health.Check
+	HealthTimeout time.Duration
+}
+
+// RateLimitPolicies define el limite de frecuencia de cada grupo de rutas; un limite
+// cero desactiva el grupo.
+type RateLimitPolicies struct {
+	// Auth cubre signup y login, por IP.
+	Auth entities.RateLimit
+	// Uploads cubre la subida de videos, por usuario.
+	Uploads entities.RateLimit
+	// Votes cubre votar y retirar el voto, por usuario.
+	Votes entities.RateLimit
 }
 
 func NewRouter(router *gin.Engine, cfg RouterConfig) {
 	authHandlers := NewAuthHandlers(cfg.AuthService)
 	userHandlers := NewUserHandlers(cfg.UserService)
-	videoHandlers := NewVideoHandlers(cfg.UploadsUC, cfg.ProcessedVideoURL)
+	mediaURLs := useCase.NewMediaURLService(cfg.MediaSigner, cfg.ProcessedVideoURL)
+	videoHandlers := NewVideoHandlersWithMedia(cfg.UploadsUC, mediaURLs).WithMetrics(cfg.Metrics)
 	// Constructor con cache de solo lectura
-	publicHandlers := NewPublicHandlersWithCache(cfg.PublicService, cfg.Cache, cfg.CacheSchemaVersion)
+	publicHandlers := NewPublicHandlersWithCache(cfg.PublicService, cfg.Cache, cfg.CacheSchemaVersion).
+		WithMediaURLs(mediaURLs).
+		WithMetrics(cfg.Metrics)
 
+	// Metrics va primero para medir tambien las respuestas de la validacion
+	if cfg.Metrics != nil {
+		router.Use(middlewares.Metrics(cfg.Metrics))
+	}
+	if cfg.Tracing {
+		router.Use(middlewares.Tracing())
+	}
+	if cfg.OpenAPI != nil {
+		router.Use(cfg.OpenAPI.Middleware())
+	}
+	if cfg.Metrics != nil {
+		router.GET("/metrics", gin.WrapH(cfg.Metrics.Handler()))
+	}
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
-	router.GET("/api/public/videos", publicHandlers.ListPublicVideos)
+	healthHandlers := NewHealthHandlers(cfg.HealthTimeout, cfg.HealthChecks...)
+	router.GET("/livez", healthHandlers.Livez)
+	router.GET("/readyz", healthHandlers.Readyz)
+	// Rutas publicas que personalizan la respuesta (voted_by_me) si llega un token valido
+	optionalAuth := middlewares.OptionalJWTMiddleware(cfg.AuthService, cfg.JWTSecret)
+	router.GET("/api/public/videos", optionalAuth, publicHandlers.ListPublicVideos)
+	router.GET("/api/public/videos/:video_id", optionalAuth, publicHandlers.GetPublicVideo)
+	router.GET("/api/public/users/:username", optionalAuth, publicHandlers.UserProfile)
 	router.GET("/api/public/rankings", publicHandlers.ListRankings)
+	router.GET("/api/public/rankings/videos", publicHandlers.ListVideoRankings)
+	router.GET("/api/public/rankings/users/:username", publicHandlers.UserRank)
 	// Se eliminaron endpoints basados en poll_id (leaderboard/stats/count)
-	router.POST("/api/auth/signup", userHandlers.Register)
-	router.POST("/api/auth/login", authHandlers.Login)
+	authLimit := middlewares.RateLimit(cfg.RateLimiter, "auth", cfg.RateLimits.Auth)
+	router.POST("/api/auth/signup", authLimit, userHandlers.Register)
+	router.POST("/api/auth/login", authLimit, authHandlers.Login)
 
 	authGroup := router.Group("/")
 	authGroup.Use(middlewares.JWTMiddleware(cfg.AuthService, cfg.JWTSecret))
 	authGroup.POST("/api/auth/logout", authHandlers.Logout)
 	authGroup.GET("/api/me", authHandlers.Me)
+	authGroup.GET("/api/me/votes", publicHandlers.MyVotes)
+	authGroup.GET("/api/me/rank", publicHandlers.MyRank)
 	videoGroup := authGroup.Group("/api/videos")
 	videoGroup.GET("", videoHandlers.ListVideos)
-	videoGroup.POST("/upload", videoHandlers.Upload)
+	videoGroup.POST("/upload", middlewares.RateLimit(cfg.RateLimiter, "upload", cfg.RateLimits.Uploads), videoHandlers.Upload)
 	videoGroup.GET("/:video_id", videoHandlers.GetVideoDetail)
 	videoGroup.DELETE("/:video_id", videoHandlers.DeleteVideo)
 	videoGroup.POST("/:video_id/publish", videoHandlers.PublishVideo)
 
 	// Ruta protegida para votar por un video publico
-	authGroup.POST("/api/public/videos/:video_id/vote", publicHandlers.VotePublicVideo)
+	voteLimit := middlewares.RateLimit(cfg.RateLimiter, "vote", cfg.RateLimits.Votes)
+	authGroup.POST("/api/public/videos/:video_id/vote", voteLimit, publicHandlers.VotePublicVideo)
+	authGroup.DELETE("/api/public/videos/:video_id/vote", voteLimit, publicHandlers.RetractVote)
+
+	if cfg.ModerationUC != nil {
+		moderationHandlers := NewModerationHandlers(cfg.ModerationUC, mediaURLs)
+		moderationGroup := authGroup.Group("/api/moderation")
+		moderationGroup.Use(middlewares.RequirePermission(PrivilegeModerateVideos))
+		moderationGroup.GET("/videos", moderationHandlers.ListPending)
+		moderationGroup.POST("/videos/:video_id/approve", moderationHandlers.Approve)
+		moderationGroup.POST("/videos/:video_id/reject", moderationHandlers.Reject)
+	}
+
+	if cfg.ReportUC != nil {
+		reportHandlers := NewReportHandlers(cfg.ReportUC)
+		authGroup.POST("/api/public/videos/:video_id/report", reportHandlers.ReportVideo)
+		reportsGroup := authGroup.Group("/api/admin/reports")
+		reportsGroup.Use(middlewares.RequirePermission(PrivilegeTriageReports))
+		reportsGroup.GET("", reportHandlers.ListReports)
+		reportsGroup.POST("/:report_id/dismiss", reportHandlers.DismissReport)
+		reportsGroup.POST("/:report_id/takedown", reportHandlers.TakeDownVideo)
+	}
+
+	if cfg.CommentUC != nil {
+		commentHandlers := NewCommentHandlers(cfg.CommentUC)
+		router.GET("/api/public/videos/:video_id/comments", commentHandlers.ListComments)
+		authGroup.POST("/api/public/videos/:video_id/comments", commentHandlers.CreateComment)
+		authGroup.PATCH("/api/public/videos/:video_id/comments/:comment_id", commentHandlers.UpdateComment)
+		authGroup.DELETE("/api/public/videos/:video_id/comments/:comment_id", commentHandlers.DeleteComment)
+	}
+
+	if cfg.ContestUC != nil {
+		contestHandlers := NewContestHandlers(cfg.ContestUC)
+		publicHandlers.WithContests(cfg.ContestUC)
+		router.GET("/api/public/contests", contestHandlers.ListContests)
+		router.GET("/api/public/contests/:contest_id", contestHandlers.GetContest)
+		router.GET("/api/public/contests/:contest_id/rankings", publicHandlers.ListContestRankings)
+		contestsGroup := authGroup.Group("/api/admin/contests")
+		contestsGroup.Use(middlewares.RequirePermission(PrivilegeManageContests))
+		contestsGroup.POST("", contestHandlers.CreateContest)
+		contestsGroup.POST("/:contest_id/close", contestHandlers.CloseContest)
+	}
+
+	if cfg.VoteReviewUC != nil {
+		voteReviewHandlers := NewVoteReviewHandlers(cfg.VoteReviewUC)
+		votesGroup := authGroup.Group("/api/admin/votes")
+		votesGroup.Use(middlewares.RequirePermission(PrivilegeReviewVotes))
+		votesGroup.GET("/quarantine", voteReviewHandlers.ListQuarantined)
+		votesGroup.POST("/:vote_id/clear", voteReviewHandlers.ClearVote)
+		votesGroup.POST("/:vote_id/discard", voteReviewHandlers.DiscardVote)
+	}
+
+	if cfg.NotificationUC != nil {
+		notificationHandlers := NewNotificationHandlers(cfg.NotificationUC)
+		authGroup.GET("/api/me/notifications", notificationHandlers.ListNotifications)
+		authGroup.POST("/api/me/notifications/:notification_id/read", notificationHandlers.MarkNotificationRead)
+	}
 
 }
@@ -2,12 +2,13 @@ package handlers
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 
 	"api/internal/application/useCase"
 	"api/internal/domain"
+	"api/internal/presentation/problem"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -29,7 +30,7 @@ func NewUploadsHandler(uploadsUC *useCase.UploadsUseCase) *UploadsHandlers {
 func (h *UploadsHandlers) UploadVideo(c *gin.Context) {
 	title := c.PostForm("title")
 	if title == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": "title is required"})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeTitleRequired)
 		return
 	}
 	status := c.PostForm("status")
@@ -46,19 +47,19 @@ func (h *UploadsHandlers) UploadVideo(c *gin.Context) {
 		fh, err = c.FormFile("video_file")
 	}
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": "video file is required"})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeVideoFileRequired)
 		return
 	}
 
 	// Extract userID from Gin context
 	uidVal, ok := c.Get("userID")
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": invalidTokenExpiredMsg})
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeUnauthorized)
 		return
 	}
 	userID, ok := uidVal.(uint)
 	if !ok || userID == 0 {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": invalidTokenExpiredMsg})
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeUnauthorized)
 		return
 	}
 
@@ -72,11 +73,7 @@ func (h *UploadsHandlers) UploadVideo(c *gin.Context) {
 		Status:     status,
 	})
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalid) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error", "message": err.Error()})
+		problem.AbortWithError(c, err, problem.On(domain.ErrInvalid, http.StatusBadRequest, problem.CodeInvalidVideo))
 		return
 	}
 
@@ -4,7 +4,7 @@ import (
 	"api/internal/application/useCase"
 	"api/internal/domain"
 	"api/internal/domain/requests"
-	"errors"
+	"api/internal/presentation/problem"
 	"net/http"
 	"strings"
 
@@ -22,22 +22,22 @@ func NewUserHandlers(service *useCase.UserService) *UserHandlers {
 func (handler *UserHandlers) Register(context *gin.Context) {
 	var request requests.RegisterUserRequest
 	if err := context.ShouldBindJSON(&request); err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": badRequest, "message": err.Error()})
+		problem.Abort(context, http.StatusBadRequest, problem.CodeInvalidBody)
 		return
 	}
 
 	email := strings.ToLower(strings.TrimSpace(request.Email))
 
 	if exists, err := handler.service.EmailExists(context.Request.Context(), email); err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error", "message": err.Error()})
+		problem.Internal(context, err)
 		return
 	} else if exists {
-		context.JSON(http.StatusBadRequest, gin.H{"error": badRequest, "message": "email already in use"})
+		problem.Abort(context, http.StatusBadRequest, problem.CodeEmailInUse)
 		return
 	}
 
 	if request.Password1 != request.Password2 {
-		context.JSON(http.StatusBadRequest, gin.H{"error": badRequest, "message": "passwords do not match"})
+		problem.Abort(context, http.StatusBadRequest, problem.CodePasswordMismatch)
 		return
 	}
 
@@ -51,11 +51,11 @@ func (handler *UserHandlers) Register(context *gin.Context) {
 		request.City,
 	)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrInvalid) {
-			context.JSON(http.StatusBadRequest, gin.H{"error": badRequest, "message": "invalid city or country"})
-			return
-		}
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error", "message": err.Error()})
+		problem.AbortWithError(context, err,
+			problem.On(domain.ErrNotFound, http.StatusBadRequest, problem.CodeInvalidLocation),
+			problem.On(domain.ErrInvalid, http.StatusBadRequest, problem.CodeInvalidLocation),
+			problem.On(domain.ErrConflict, http.StatusConflict, problem.CodeUsernameUnavailable),
+		)
 		return
 	}
 
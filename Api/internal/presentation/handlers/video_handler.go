@@ -6,8 +6,9 @@ import (
 	"api/internal/domain"
 	"api/internal/domain/entities"
 	"api/internal/domain/responses"
+	"api/internal/infrastructure/metrics"
+	"api/internal/presentation/problem"
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"slices"
@@ -18,13 +19,26 @@ import (
 	"github.com/google/uuid"
 )
 
-type VideoHandlers struct{ 
+type VideoHandlers struct {
 	uploadsUC *useCase.UploadsUseCase
-	processedVideoURL string
+	media     *useCase.MediaURLService
+	metrics   *metrics.Metrics
 }
 
+// NewVideoHandlers construye URLs sin firma a partir de processedVideoURL (compatibilidad).
 func NewVideoHandlers(uploadsUC *useCase.UploadsUseCase, processedVideoURL string) *VideoHandlers {
-	return &VideoHandlers{uploadsUC: uploadsUC, processedVideoURL: processedVideoURL}
+	return NewVideoHandlersWithMedia(uploadsUC, useCase.NewMediaURLService(nil, processedVideoURL))
+}
+
+// NewVideoHandlersWithMedia permite inyectar el servicio de URLs firmadas.
+func NewVideoHandlersWithMedia(uploadsUC *useCase.UploadsUseCase, media *useCase.MediaURLService) *VideoHandlers {
+	return &VideoHandlers{uploadsUC: uploadsUC, media: media}
+}
+
+// WithMetrics cuenta las subidas segun su resultado; nil no cuenta nada.
+func (h *VideoHandlers) WithMetrics(m *metrics.Metrics) *VideoHandlers {
+	h.metrics = m
+	return h
 }
 
 // ListVideos handles GET /api/videos (authenticated)
@@ -36,33 +50,31 @@ func (h *VideoHandlers) ListVideos(c *gin.Context) {
 
 	videos, err := h.uploadsUC.ListUserVideos(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error", "message": err.Error()})
+		problem.Internal(c, err)
 		return
 	}
 
 	// Map to OAS Video schema
 	out := make([]responses.VideoResponse, 0, len(videos))
 	for _, v := range videos {
-		out = append(out, h.toVideoResponse(v))
+		out = append(out, h.toVideoResponse(c.Request.Context(), userID, v))
 	}
 
 	c.JSON(http.StatusOK, out)
 }
 
 func (h *VideoHandlers) Upload(c *gin.Context) {
+	defer func() { h.metrics.ObserveUpload(c.Writer.Status()) }()
+
 	title := c.PostForm("title")
 	if title == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": "title is required"})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeTitleRequired)
 		return
 	}
 
-	// Acepta el nombre estándar del documento: video_file. Mantiene compatibilidad con "video".
 	file, err := c.FormFile("video_file")
 	if err != nil {
-		file, err = c.FormFile("video")
-	}
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": "video file is required"})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeVideoFileRequired)
 		return
 	}
 
@@ -73,13 +85,13 @@ func (h *VideoHandlers) Upload(c *gin.Context) {
 		mimeFromForm = file.Header.Get("Content-Type")
 	}
 	if mimeFromForm != "" && mimeFromForm != "video/mp4" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": "mimeType must be video/mp4"})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeUnsupportedMediaType)
 		return
 	}
 
 	// Validar tamaño declarado (=100MB)
 	if file.Size > validations.MaxBytes {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": "file too large (max 100MB)"})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeFileTooLarge)
 		return
 	}
 
@@ -90,19 +102,19 @@ func (h *VideoHandlers) Upload(c *gin.Context) {
 
 	permsVal, ok := c.Get("permissions")
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": "permissions missing in context"})
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeInvalidToken)
 		return
 	}
 	perms, ok := permsVal.([]string)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": "invalid permissions in context"})
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeInvalidToken)
 		return
 	}
 	// Align with OpenAPI: expect "videos:upload".
 	// Keep backward compatibility with legacy "upload_video".
 	allowed := slices.Contains(perms, "videos:upload") || slices.Contains(perms, "upload_video")
 	if !allowed {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+		problem.Abort(c, http.StatusForbidden, problem.CodeForbidden)
 		return
 	}
 
@@ -117,11 +129,7 @@ func (h *VideoHandlers) Upload(c *gin.Context) {
 	ctx := context.WithValue(c.Request.Context(), useCase.UserIDContextKey, userID)
 	output, err := h.uploadsUC.UploadMultipart(ctx, input)
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalid) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error", "message": err.Error()})
+		problem.AbortWithError(c, err, problem.On(domain.ErrInvalid, http.StatusBadRequest, problem.CodeInvalidVideo))
 		return
 	}
 
@@ -149,12 +157,11 @@ func (h *VideoHandlers) GetVideoDetail(c *gin.Context) {
 	// Query use case enforcing ownership
 	v, err := h.uploadsUC.GetUserVideoByID(c.Request.Context(), userID, vidUint)
 	if err != nil {
-		if handled := writeStandardDomainError(c, err); handled {
-			return
-		}
+		writeVideoError(c, err)
+		return
 	}
 
-	resp := h.toVideoResponse(v)
+	resp := h.toVideoResponse(c.Request.Context(), userID, v)
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -176,16 +183,9 @@ func (h *VideoHandlers) DeleteVideo(c *gin.Context) {
 	// 3-6) Execute delete with eligibility rules in use case
 	err := h.uploadsUC.DeleteUserVideoIfEligible(c.Request.Context(), userID, vidUint)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrInvalid):
-			// Elegibilidad incumplida (p.ej., publicado para votación o procesado)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": "No se puede eliminar: el video está publicado para votación o ya fue procesado."})
-			return
-		default:
-			if handled := writeStandardDomainError(c, err); handled {
-				return
-			}
-		}
+		// ErrInvalid: elegibilidad incumplida (p.ej., publicado para votación o procesado)
+		writeVideoError(c, err, problem.On(domain.ErrInvalid, http.StatusBadRequest, problem.CodeVideoNotDeletable))
+		return
 	}
 
 	// 7) Success 200 with exact body
@@ -195,27 +195,29 @@ func (h *VideoHandlers) DeleteVideo(c *gin.Context) {
 	})
 }
 
-// PublishVideo handles POST /api/videos/:video_id/publish (moderation action)
+// PublishVideo handles POST /api/videos/:video_id/publish (authenticated, own video only)
+// Sends the processed video to the moderation queue; a moderator makes it public.
 // Requires permission: edit_video or moderate_content
 func (h *VideoHandlers) PublishVideo(c *gin.Context) {
 	// 1) Auth
-	if _, ok := userIDFromContextOrAbort(c); !ok {
+	userID, ok := userIDFromContextOrAbort(c)
+	if !ok {
 		return
 	}
 	// 2) Permissions
 	permsVal, ok := c.Get("permissions")
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "permissions missing in context"})
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeInvalidToken)
 		return
 	}
 	perms, ok := permsVal.([]string)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid permissions in context"})
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeInvalidToken)
 		return
 	}
 	allowed := slices.Contains(perms, "edit_video") || slices.Contains(perms, "moderate_content")
 	if !allowed {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+		problem.Abort(c, http.StatusForbidden, problem.CodeForbidden)
 		return
 	}
 
@@ -225,22 +227,13 @@ func (h *VideoHandlers) PublishVideo(c *gin.Context) {
 		return
 	}
 
-	// 4) Publish via use case
-	if err := h.uploadsUC.PublishVideo(c.Request.Context(), vidUint); err != nil {
-		switch {
-		case errors.Is(err, domain.ErrNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": "Not Found", "message": "Video no encontrado."})
-			return
-		case errors.Is(err, domain.ErrInvalid):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": "El video no está listo para publicarse."})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
-			return
-		}
+	// 4) Submit for review via use case (enforces ownership)
+	if err := h.uploadsUC.PublishVideo(c.Request.Context(), userID, vidUint); err != nil {
+		writeVideoError(c, err, problem.On(domain.ErrInvalid, http.StatusBadRequest, problem.CodeVideoNotReady))
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Video publicado exitosamente.", "video_id": c.Param("video_id")})
+	c.JSON(http.StatusOK, gin.H{"message": "Video enviado a revisión. Se publicará cuando un moderador lo apruebe.", "video_id": c.Param("video_id")})
 }
 
 // --- Helpers to reduce duplication ---
@@ -249,12 +242,12 @@ func (h *VideoHandlers) PublishVideo(c *gin.Context) {
 func userIDFromContextOrAbort(c *gin.Context) (uint, bool) {
 	uidVal, ok := c.Get("userID")
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": invalidTokenExpiredMsg})
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeUnauthorized)
 		return 0, false
 	}
 	userID, ok := uidVal.(uint)
 	if !ok || userID == 0 {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": invalidTokenExpiredMsg})
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeUnauthorized)
 		return 0, false
 	}
 	return userID, true
@@ -264,64 +257,43 @@ func userIDFromContextOrAbort(c *gin.Context) (uint, bool) {
 func parseVideoIDOrAbort(c *gin.Context) (uint, bool) {
 	vidStr := c.Param("video_id")
 	if vidStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": "Parámetro inválido."})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
 		return 0, false
 	}
 	parsed, err := strconv.ParseUint(vidStr, 10, 64)
 	if err != nil || parsed == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad Request", "message": "Parámetro inválido."})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
 		return 0, false
 	}
 	return uint(parsed), true
 }
 
 // toVideoResponse maps an entities.Video to responses.VideoResponse.
-func (h *VideoHandlers) toVideoResponse(v *entities.Video) responses.VideoResponse {
+// Media URLs are resolved per request (signed and short-lived when a signer is configured).
+func (h *VideoHandlers) toVideoResponse(ctx context.Context, userID uint, v *entities.Video) responses.VideoResponse {
 	status := "uploaded"
-	if v.Status == string(entities.StatusPublished) {
+	switch entities.VideoStatus(v.Status) {
+	case entities.StatusPublished:
 		status = "published"
-	} else if v.Status == string(entities.StatusProcessed) {
+	case entities.StatusProcessed:
 		status = "processed"
+	case entities.StatusPendingReview:
+		status = "pending_review"
+	case entities.StatusRejected:
+		status = "rejected"
 	}
-	var originalURL *string
-	if v.OriginalFile != "" {
-		s := v.OriginalFile
-		originalURL = &s
-	}
+	originalURL, processedURL := h.media.OwnerURLs(ctx, userID, v)
 	resp := responses.VideoResponse{
-		VideoID:     fmt.Sprintf("%d", v.VideoID),
-		Title:       v.Title,
-		Status:      status,
-		UploadedAt:  v.UploadedAt,
-		ProcessedAt: v.ProcessedAt,
-		OriginalURL: originalURL,
-	}
-	if v.ProcessedFile != nil && *v.ProcessedFile != "" {
-		// Check if ProcessedFile already contains a full URL
-		if strings.HasPrefix(*v.ProcessedFile, "http://") || strings.HasPrefix(*v.ProcessedFile, "https://") {
-			// ProcessedFile already contains the full URL
-			resp.ProcessedURL = v.ProcessedFile
-		} else {
-			// ProcessedFile contains only the filename, construct the full URL
-			processedURL := fmt.Sprintf(h.processedVideoURL, *v.ProcessedFile)
-			resp.ProcessedURL = &processedURL
-		}
+		VideoID:      fmt.Sprintf("%d", v.VideoID),
+		Title:        v.Title,
+		Status:       status,
+		UploadedAt:   v.UploadedAt,
+		ProcessedAt:  v.ProcessedAt,
+		OriginalURL:  originalURL,
+		ProcessedURL: processedURL,
 	}
-	return resp
-}
-
-// writeStandardDomainError writes common domain error translations.
-// Returns true if it wrote a response.
-func writeStandardDomainError(c *gin.Context, err error) bool {
-	switch {
-	case errors.Is(err, domain.ErrNotFound):
-		c.JSON(http.StatusNotFound, gin.H{"error": "Not Found", "message": "Video no encontrado."})
-		return true
-	case errors.Is(err, domain.ErrForbidden):
-		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden", "message": "Acceso denegado."})
-		return true
-	default:
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error", "message": err.Error()})
-		return true
+	if v.Status == string(entities.StatusRejected) {
+		resp.RejectionReason = v.RejectionReason
 	}
+	return resp
 }
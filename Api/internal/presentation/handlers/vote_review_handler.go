@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/presentation/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VoteReviewHandlers maneja la revision de votos en cuarentena antifraude.
+// Las rutas /api/admin/votes deben protegerse con RequirePermission(PrivilegeReviewVotes).
+type VoteReviewHandlers struct {
+	uc *useCase.VoteReviewUseCase
+}
+
+func NewVoteReviewHandlers(uc *useCase.VoteReviewUseCase) *VoteReviewHandlers {
+	return &VoteReviewHandlers{uc: uc}
+}
+
+// ListQuarantined maneja GET /api/admin/votes/quarantine
+func (h *VoteReviewHandlers) ListQuarantined(c *gin.Context) {
+	page := 1
+	pageSize := 20
+	if ps := strings.TrimSpace(c.Query("page")); ps != "" {
+		v, err := strconv.Atoi(ps)
+		if err != nil || v < 1 {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return
+		}
+		page = v
+	}
+	if pss := strings.TrimSpace(c.Query("pageSize")); pss != "" {
+		v, err := strconv.Atoi(pss)
+		if err != nil || v < 1 || v > 100 {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidQuery)
+			return
+		}
+		pageSize = v
+	}
+	items, err := h.uc.ListQuarantined(c.Request.Context(), page, pageSize)
+	if err != nil {
+		problem.Internal(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// ClearVote maneja POST /api/admin/votes/:vote_id/clear
+func (h *VoteReviewHandlers) ClearVote(c *gin.Context) {
+	reviewerID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	voteID, ok := parseVoteIDOrAbort(c)
+	if !ok {
+		return
+	}
+	if err := h.uc.Clear(c.Request.Context(), reviewerID, voteID); err != nil {
+		writeVoteReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Voto liberado.", "vote_id": c.Param("vote_id")})
+}
+
+// DiscardVote maneja POST /api/admin/votes/:vote_id/discard
+func (h *VoteReviewHandlers) DiscardVote(c *gin.Context) {
+	reviewerID, ok := userIDFromContextOrAbort(c)
+	if !ok {
+		return
+	}
+	voteID, ok := parseVoteIDOrAbort(c)
+	if !ok {
+		return
+	}
+	if err := h.uc.Discard(c.Request.Context(), reviewerID, voteID); err != nil {
+		writeVoteReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Voto descartado.", "vote_id": c.Param("vote_id")})
+}
+
+// parseVoteIDOrAbort validates path param "vote_id" and returns it as uint.
+func parseVoteIDOrAbort(c *gin.Context) (uint, bool) {
+	parsed, err := strconv.ParseUint(c.Param("vote_id"), 10, 64)
+	if err != nil || parsed == 0 {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidParameter)
+		return 0, false
+	}
+	return uint(parsed), true
+}
+
+func writeVoteReviewError(c *gin.Context, err error) {
+	problem.AbortWithError(c, err,
+		problem.On(domain.ErrNotFound, http.StatusNotFound, problem.CodeVoteNotFound),
+		problem.On(domain.ErrConflict, http.StatusConflict, problem.CodeVoteNotQuarantined),
+	)
+}
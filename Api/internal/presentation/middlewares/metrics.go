@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"api/internal/infrastructure/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute agrupa las solicitudes que no coinciden con ninguna ruta, para que las
+// URLs arbitrarias no generen series nuevas.
+const unmatchedRoute = "unmatched"
+
+// Metrics registra la latencia de cada solicitud por metodo, ruta y status. Debe
+// registrarse antes que los demas middlewares para medir tambien sus respuestas.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		m.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -2,8 +2,10 @@ package middlewares
 
 import (
 	"api/internal/application/useCase"
+	"api/internal/presentation/problem"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,33 +22,59 @@ func JWTMiddleware(authService *useCase.AuthService, secret string) gin.HandlerF
 		if !ok {
 			return
 		}
-
-		if abortIfInvalidated(c, authService, tokenStr) {
+		if !authenticate(c, authService, secret, tokenStr) {
 			return
 		}
+		c.Next()
+	}
+}
 
-		claims := &useCase.AuthClaims{}
-		if !parseTokenWithClaims(c, tokenStr, secret, claims) {
+// OptionalJWTMiddleware es JWTMiddleware para rutas publicas: sin header Authorization
+// la solicitud continua como anonima (sin userID); un token presente pero invalido
+// responde 401 igual que en las rutas protegidas.
+func OptionalJWTMiddleware(authService *useCase.AuthService, secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.TrimSpace(c.GetHeader("Authorization")) == "" {
+			c.Next()
 			return
 		}
-
-		if !validateTimeClaims(c, claims) {
+		tokenStr, ok := bearerToken(c)
+		if !ok {
 			return
 		}
-
-		uid, ok := subjectToUserID(c, claims.Subject)
-		if !ok {
+		if !authenticate(c, authService, secret, tokenStr) {
 			return
 		}
+		c.Next()
+	}
+}
 
-		c.Set("userID", uid)
-		c.Set("permissions", claims.Permissions)
-		c.Set("first_name", claims.FirstName)
-		c.Set("last_name", claims.LastName)
-		c.Set("email", claims.Email)
+// authenticate valida tokenStr y coloca sus claims en el contexto; aborta con 401 si no es valido.
+func authenticate(c *gin.Context, authService *useCase.AuthService, secret, tokenStr string) bool {
+	if abortIfInvalidated(c, authService, tokenStr) {
+		return false
+	}
 
-		c.Next()
+	claims := &useCase.AuthClaims{}
+	if !parseTokenWithClaims(c, tokenStr, secret, claims) {
+		return false
+	}
+
+	if !validateTimeClaims(c, claims) {
+		return false
+	}
+
+	uid, ok := subjectToUserID(c, claims.Subject)
+	if !ok {
+		return false
 	}
+
+	c.Set("userID", uid)
+	c.Set("permissions", claims.Permissions)
+	c.Set("first_name", claims.FirstName)
+	c.Set("last_name", claims.LastName)
+	c.Set("email", claims.Email)
+	return true
 }
 
 // bearerToken extrae y valida el token Bearer del header Authorization.
@@ -54,7 +82,7 @@ func bearerToken(c *gin.Context) (string, bool) {
 	auth := c.GetHeader("Authorization")
 	parts := strings.Fields(auth)
 	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
-		abortUnauthorized(c, "token requerido")
+		abortUnauthorized(c, problem.CodeTokenRequired)
 		return "", false
 	}
 	return parts[1], true
@@ -63,7 +91,7 @@ func bearerToken(c *gin.Context) (string, bool) {
 // abortIfInvalidated verifica si el token fue invalidado por el servicio.
 func abortIfInvalidated(c *gin.Context, authService *useCase.AuthService, tokenStr string) bool {
 	if authService.IsTokenInvalid(tokenStr) {
-		abortUnauthorized(c, "token inválido")
+		abortUnauthorized(c, problem.CodeInvalidToken)
 		return true
 	}
 	return false
@@ -78,7 +106,7 @@ func parseTokenWithClaims(c *gin.Context, tokenStr, secret string, claims *useCa
 		return []byte(secret), nil
 	})
 	if err != nil || !token.Valid {
-		abortUnauthorized(c, "token inválido")
+		abortUnauthorized(c, problem.CodeInvalidToken)
 		return false
 	}
 	return true
@@ -88,11 +116,11 @@ func parseTokenWithClaims(c *gin.Context, tokenStr, secret string, claims *useCa
 func validateTimeClaims(c *gin.Context, claims *useCase.AuthClaims) bool {
 	now := time.Now()
 	if claims.ExpiresAt != nil && now.After(claims.ExpiresAt.Time) {
-		abortUnauthorized(c, "token expirado")
+		abortUnauthorized(c, problem.CodeTokenExpired)
 		return false
 	}
 	if claims.NotBefore != nil && now.Before(claims.NotBefore.Time) {
-		abortUnauthorized(c, "token aún no válido")
+		abortUnauthorized(c, problem.CodeTokenNotYetValid)
 		return false
 	}
 	return true
@@ -101,18 +129,42 @@ func validateTimeClaims(c *gin.Context, claims *useCase.AuthClaims) bool {
 // subjectToUserID valida y convierte el Subject a userID.
 func subjectToUserID(c *gin.Context, sub string) (uint, bool) {
 	if sub == "" {
-		abortUnauthorized(c, "sub faltante")
+		abortUnauthorized(c, problem.CodeInvalidToken)
 		return 0, false
 	}
 	uid64, err := strconv.ParseUint(sub, 10, 64)
 	if err != nil || uid64 == 0 {
-		abortUnauthorized(c, "sub inválido")
+		abortUnauthorized(c, problem.CodeInvalidToken)
 		return 0, false
 	}
 	return uint(uid64), true
 }
 
-// abortUnauthorized centraliza la respuesta 401 con mensaje de error.
-func abortUnauthorized(c *gin.Context, msg string) {
-	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": msg})
+// abortUnauthorized centraliza la respuesta 401.
+func abortUnauthorized(c *gin.Context, code problem.Code) {
+	problem.Abort(c, http.StatusUnauthorized, code)
+}
+
+// RequirePermission exige que el token incluya al menos uno de los privilegios indicados.
+// Debe registrarse despues de JWTMiddleware, que coloca "permissions" en el contexto.
+func RequirePermission(perms ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		val, ok := c.Get("permissions")
+		if !ok {
+			abortUnauthorized(c, problem.CodeInvalidToken)
+			return
+		}
+		granted, ok := val.([]string)
+		if !ok {
+			abortUnauthorized(c, problem.CodeInvalidToken)
+			return
+		}
+		for _, p := range perms {
+			if slices.Contains(granted, p) {
+				c.Next()
+				return
+			}
+		}
+		problem.Abort(c, http.StatusForbidden, problem.CodeForbidden)
+	}
 }
@@ -0,0 +1,175 @@
+package middlewares
+
+import (
+	"api/internal/presentation/problem"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultOpenAPIMaxBodyBytes es el tamaño maximo de cuerpo que se valida contra la
+// especificacion; los cuerpos mayores (p. ej. la subida de videos) solo validan parametros.
+const DefaultOpenAPIMaxBodyBytes = 1 << 20
+
+// OpenAPIValidator valida las solicitudes contra la especificacion OpenAPI antes de que
+// lleguen a los handlers. Con WithResponseValidation (pensado para pruebas de contrato)
+// tambien compara cada respuesta con la operacion documentada.
+type OpenAPIValidator struct {
+	doc *openapi3.T
+	// paths traduce la ruta de gin ("/api/videos/:video_id") a la de la especificacion.
+	paths      map[string]string
+	maxBody    int64
+	onResponse func(c *gin.Context, err error)
+}
+
+// NewOpenAPIValidator carga y valida la especificacion spec (YAML o JSON).
+func NewOpenAPIValidator(spec []byte) (*OpenAPIValidator, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: load spec: %w", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("openapi: invalid spec: %w", err)
+	}
+	paths := make(map[string]string, doc.Paths.Len())
+	for path := range doc.Paths.Map() {
+		paths[ginPath(path)] = path
+	}
+	return &OpenAPIValidator{doc: doc, paths: paths, maxBody: DefaultOpenAPIMaxBodyBytes}, nil
+}
+
+// WithMaxBodyBytes ajusta el tamaño maximo de cuerpo validado; <= 0 valida siempre el cuerpo.
+func (v *OpenAPIValidator) WithMaxBodyBytes(n int64) *OpenAPIValidator {
+	v.maxBody = n
+	return v
+}
+
+// WithResponseValidation valida tambien las respuestas y reporta cada discrepancia
+// (status no documentado, headers o cuerpo fuera del schema, ruta sin documentar) a report.
+// La respuesta se entrega al cliente sin cambios.
+func (v *OpenAPIValidator) WithResponseValidation(report func(c *gin.Context, err error)) *OpenAPIValidator {
+	v.onResponse = report
+	return v
+}
+
+// Middleware devuelve el handler que aplica la validacion. Debe registrarse antes que las
+// rutas. Las rutas que no estan en la especificacion pasan sin validar.
+func (v *OpenAPIValidator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, ok := v.route(c)
+		if !ok {
+			if v.onResponse != nil && c.FullPath() != "" {
+				v.onResponse(c, fmt.Errorf("openapi: %s %s is not documented", c.Request.Method, c.FullPath()))
+			}
+			c.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: make(map[string]string, len(c.Params)),
+			Route:      route,
+			Options: &openapi3filter.Options{
+				AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+				SkipSettingDefaults: true,
+				ExcludeRequestBody:  v.maxBody > 0 && (c.Request.ContentLength < 0 || c.Request.ContentLength > v.maxBody),
+			},
+		}
+		for _, p := range c.Params {
+			input.PathParams[p.Key] = p.Value
+		}
+		if v.onResponse != nil {
+			recorder := &bodyRecorder{ResponseWriter: c.Writer}
+			c.Writer = recorder
+			defer v.validateResponse(c, input, recorder)
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			problem.Abort(c, http.StatusBadRequest, requestErrorCode(err))
+			return
+		}
+		c.Next()
+	}
+}
+
+// validateResponse compara la respuesta grabada por recorder con la operacion de input.
+func (v *OpenAPIValidator) validateResponse(c *gin.Context, input *openapi3filter.RequestValidationInput, recorder *bodyRecorder) {
+	err := openapi3filter.ValidateResponse(context.WithoutCancel(c.Request.Context()), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 recorder.Status(),
+		Header:                 recorder.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	})
+	if err != nil {
+		v.onResponse(c, err)
+	}
+}
+
+// route busca la operacion documentada para la ruta que gin resolvio.
+func (v *OpenAPIValidator) route(c *gin.Context) (*routers.Route, bool) {
+	path, ok := v.paths[c.FullPath()]
+	if !ok {
+		return nil, false
+	}
+	item := v.doc.Paths.Value(path)
+	op := item.GetOperation(c.Request.Method)
+	if op == nil {
+		return nil, false
+	}
+	return &routers.Route{Spec: v.doc, Path: path, PathItem: item, Method: c.Request.Method, Operation: op}, true
+}
+
+// requestErrorCode elige el codigo de problema segun la parte de la solicitud que fallo.
+func requestErrorCode(err error) problem.Code {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return problem.CodeInvalidInput
+	}
+	switch {
+	case reqErr.Parameter == nil:
+		return problem.CodeInvalidBody
+	case reqErr.Parameter.In == openapi3.ParameterInPath:
+		return problem.CodeInvalidParameter
+	case reqErr.Parameter.In == openapi3.ParameterInQuery:
+		return problem.CodeInvalidQuery
+	default:
+		return problem.CodeInvalidInput
+	}
+}
+
+// ginPath convierte "/api/videos/{video_id}" en "/api/videos/:video_id".
+func ginPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			segments[i] = ":" + s[1:len(s)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// bodyRecorder copia el cuerpo de la respuesta para validarlo despues de los handlers.
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+	"api/internal/presentation/problem"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit limita la frecuencia de solicitudes del grupo scope segun limit.
+// La clave es el userID si JWTMiddleware ya autentico la solicitud, o la IP del cliente.
+// Responde con los headers RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset y
+// RateLimit-Policy, y con 429 + Retry-After al exceder el limite. Si el limitador
+// falla la solicitud continua (fail open) para no tumbar la API.
+func RateLimit(limiter interfaces.RateLimiter, scope string, limit entities.RateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limiter == nil || !limit.Enabled() {
+			c.Next()
+			return
+		}
+		decision, err := limiter.Allow(c.Request.Context(), rateLimitKey(c, scope), limit)
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "rate limit check failed", "scope", scope, "err", err)
+			c.Next()
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+		h.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.ResetAfter)))
+		h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Limit, ceilSeconds(limit.Period)))
+		if !decision.Allowed {
+			h.Set("Retry-After", strconv.Itoa(max(1, ceilSeconds(decision.RetryAfter))))
+			problem.Abort(c, http.StatusTooManyRequests, problem.CodeRateLimited)
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimitKey identifica al cliente dentro de scope.
+func rateLimitKey(c *gin.Context, scope string) string {
+	if v, ok := c.Get("userID"); ok {
+		if uid, ok := v.(uint); ok && uid != 0 {
+			return scope + ":user:" + strconv.FormatUint(uint64(uid), 10)
+		}
+	}
+	return scope + ":ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"api/internal/logging"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader transporta el ID de la solicitud en ambos sentidos.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength limita el ID aceptado del cliente; uno mas largo se reemplaza.
+const maxRequestIDLength = 128
+
+// RequestID reutiliza el X-Request-ID recibido (si es razonable) o genera uno nuevo, lo
+// devuelve en la respuesta y lo guarda en c.Request.Context() para los logs y para el
+// correlation_id de los mensajes que se publiquen durante la solicitud. Debe registrarse
+// antes que cualquier middleware que escriba logs.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// validRequestID acepta solo caracteres que no permiten inyectar lineas en los logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// AccessLog registra una linea por solicitud con metodo, ruta, status y latencia; el
+// request_id y el trace_id salen del contexto de la solicitud.
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		if status >= 500 {
+			level = slog.LevelError
+		}
+		slog.Log(c.Request.Context(), level, "http request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"route", c.FullPath(),
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+		)
+	}
+}
@@ -0,0 +1,204 @@
+package problem
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Idiomas con catalogo de mensajes.
+const (
+	LangSpanish = "es"
+	LangEnglish = "en"
+)
+
+// DefaultLanguage se usa sin Accept-Language o si ningun idioma pedido tiene catalogo.
+const DefaultLanguage = LangSpanish
+
+// titles traduce el titulo de cada status (resumen del tipo de problema).
+var titles = map[string]map[int]string{
+	LangSpanish: {
+		http.StatusBadRequest:          "Solicitud inválida",
+		http.StatusUnauthorized:        "No autenticado",
+		http.StatusForbidden:           "Acceso denegado",
+		http.StatusNotFound:            "No encontrado",
+		http.StatusConflict:            "Conflicto",
+		http.StatusTooManyRequests:     "Demasiadas solicitudes",
+		http.StatusInternalServerError: "Error interno",
+	},
+	LangEnglish: {
+		http.StatusBadRequest:          "Bad Request",
+		http.StatusUnauthorized:        "Unauthorized",
+		http.StatusForbidden:           "Forbidden",
+		http.StatusNotFound:            "Not Found",
+		http.StatusConflict:            "Conflict",
+		http.StatusTooManyRequests:     "Too Many Requests",
+		http.StatusInternalServerError: "Internal Server Error",
+	},
+}
+
+// messages traduce el detalle de cada codigo.
+var messages = map[string]map[Code]string{
+	LangSpanish: {
+		CodeInvalidInput:     "Los datos enviados no son válidos.",
+		CodeInvalidBody:      "Cuerpo inválido.",
+		CodeInvalidParameter: "Parámetro inválido.",
+		CodeInvalidQuery:     "Parámetro inválido en la consulta.",
+		CodeUnauthorized:     "Token inválido o expirado.",
+		CodeForbidden:        "Acceso denegado.",
+		CodeNotFound:         "Recurso no encontrado.",
+		CodeConflict:         "La solicitud entra en conflicto con el estado actual del recurso.",
+		CodeDuplicateRequest: "La solicitud ya fue procesada.",
+		CodeRateLimited:      "Demasiadas solicitudes. Intenta de nuevo más tarde.",
+		CodeInternal:         "Ocurrió un error inesperado. Intenta de nuevo más tarde.",
+
+		CodeTokenRequired:      "Se requiere un token Bearer.",
+		CodeInvalidToken:       "Token inválido.",
+		CodeTokenExpired:       "Token expirado.",
+		CodeTokenNotYetValid:   "Token aún no válido.",
+		CodeInvalidCredentials: "Email o contraseña incorrectos.",
+
+		CodeEmailInUse:          "El email ya está registrado.",
+		CodePasswordMismatch:    "Las contraseñas no coinciden.",
+		CodeInvalidLocation:     "Ciudad o país inválidos.",
+		CodeUserNotFound:        "Usuario no encontrado.",
+		CodeUsernameUnavailable: "No se pudo asignar un nombre de usuario libre; intenta de nuevo.",
+
+		CodeVideoNotFound:          "Video no encontrado.",
+		CodeTitleRequired:          "El título es obligatorio.",
+		CodeVideoFileRequired:      "El archivo de video es obligatorio.",
+		CodeUnsupportedMediaType:   "El video debe ser video/mp4.",
+		CodeFileTooLarge:           "El archivo supera el tamaño máximo de 100MB.",
+		CodeInvalidVideo:           "El archivo de video no es válido.",
+		CodeVideoNotDeletable:      "No se puede eliminar: el video está publicado para votación o ya fue procesado.",
+		CodeVideoNotReady:          "El video no está listo para publicarse.",
+		CodeVideoNotPendingReview:  "El video no está pendiente de revisión.",
+		CodeInvalidRejectionReason: "Debe indicar un motivo de rechazo (máximo 500 caracteres).",
+
+		CodeAlreadyVoted:       "Ya has votado por este video.",
+		CodeNotVoted:           "No has votado por este video.",
+		CodeVotingClosed:       "La votación de este concurso no está abierta.",
+		CodeVoteBudgetExceeded: "Has agotado tu presupuesto de votos.",
+		CodeVoteNotFound:       "Voto no encontrado.",
+		CodeVoteNotQuarantined: "El voto no está en cuarentena.",
+		CodeVoteFailed:         "No se pudo registrar el voto.",
+		CodeVoteRetractFailed:  "No se pudo retirar el voto.",
+
+		CodeCommentNotFound:    "Video o comentario no encontrado.",
+		CodeInvalidComment:     "Comentario, respuesta o cursor inválido.",
+		CodeCommentRateLimited: "Estás comentando demasiado rápido. Intenta de nuevo en un momento.",
+		CodeInvalidReport:      "Categoría o detalle de la denuncia inválidos.",
+		CodeAlreadyReported:    "Ya has denunciado este video.",
+		CodeReportNotFound:     "Denuncia no encontrada.",
+		CodeReportResolved:     "La denuncia ya fue resuelta.",
+
+		CodeNotificationNotFound: "Notificación no encontrada.",
+
+		CodeContestNotFound:     "Concurso no encontrado.",
+		CodeInvalidContest:      "Datos del concurso inválidos.",
+		CodeInvalidContestDates: "Fechas inválidas; use RFC 3339.",
+		CodeContestClosed:       "El concurso ya fue cerrado.",
+	},
+	LangEnglish: {
+		CodeInvalidInput:     "The submitted data is not valid.",
+		CodeInvalidBody:      "Invalid request body.",
+		CodeInvalidParameter: "Invalid path parameter.",
+		CodeInvalidQuery:     "Invalid query parameter.",
+		CodeUnauthorized:     "Invalid or expired token.",
+		CodeForbidden:        "Access denied.",
+		CodeNotFound:         "Resource not found.",
+		CodeConflict:         "The request conflicts with the current state of the resource.",
+		CodeDuplicateRequest: "The request was already processed.",
+		CodeRateLimited:      "Too many requests. Try again later.",
+		CodeInternal:         "An unexpected error occurred. Try again later.",
+
+		CodeTokenRequired:      "A Bearer token is required.",
+		CodeInvalidToken:       "Invalid token.",
+		CodeTokenExpired:       "Token expired.",
+		CodeTokenNotYetValid:   "Token not valid yet.",
+		CodeInvalidCredentials: "Incorrect email or password.",
+
+		CodeEmailInUse:          "The email is already registered.",
+		CodePasswordMismatch:    "Passwords do not match.",
+		CodeUsernameUnavailable: "No free username could be assigned; try again.",
+		CodeInvalidLocation:     "Invalid city or country.",
+		CodeUserNotFound:        "User not found.",
+
+		CodeVideoNotFound:          "Video not found.",
+		CodeTitleRequired:          "The title is required.",
+		CodeVideoFileRequired:      "The video file is required.",
+		CodeUnsupportedMediaType:   "The video must be video/mp4.",
+		CodeFileTooLarge:           "The file exceeds the 100MB maximum size.",
+		CodeInvalidVideo:           "The video file is not valid.",
+		CodeVideoNotDeletable:      "Cannot delete: the video is published for voting or was already processed.",
+		CodeVideoNotReady:          "The video is not ready to be published.",
+		CodeVideoNotPendingReview:  "The video is not pending review.",
+		CodeInvalidRejectionReason: "A rejection reason is required (500 characters max).",
+
+		CodeAlreadyVoted:       "You already voted for this video.",
+		CodeNotVoted:           "You have not voted for this video.",
+		CodeVotingClosed:       "Voting for this contest is not open.",
+		CodeVoteBudgetExceeded: "You have used up your vote budget.",
+		CodeVoteNotFound:       "Vote not found.",
+		CodeVoteNotQuarantined: "The vote is not quarantined.",
+		CodeVoteFailed:         "The vote could not be recorded.",
+		CodeVoteRetractFailed:  "The vote could not be retracted.",
+
+		CodeCommentNotFound:    "Video or comment not found.",
+		CodeInvalidComment:     "Invalid comment, reply or cursor.",
+		CodeCommentRateLimited: "You are commenting too fast. Try again in a moment.",
+		CodeInvalidReport:      "Invalid report category or details.",
+		CodeAlreadyReported:    "You already reported this video.",
+		CodeReportNotFound:     "Report not found.",
+		CodeReportResolved:     "The report was already resolved.",
+
+		CodeNotificationNotFound: "Notification not found.",
+
+		CodeContestNotFound:     "Contest not found.",
+		CodeInvalidContest:      "Invalid contest data.",
+		CodeInvalidContestDates: "Invalid dates; use RFC 3339.",
+		CodeContestClosed:       "The contest is already closed.",
+	},
+}
+
+// Title devuelve el titulo localizado de status, o el texto estandar en ingles.
+func Title(lang string, status int) string {
+	if t, ok := titles[lang][status]; ok {
+		return t
+	}
+	return http.StatusText(status)
+}
+
+// Message devuelve el detalle localizado de code; sin traduccion usa DefaultLanguage.
+func Message(lang string, code Code) string {
+	if m, ok := messages[lang][code]; ok {
+		return m
+	}
+	return messages[DefaultLanguage][code]
+}
+
+// NegotiateLanguage elige el idioma con catalogo de mayor peso en un header Accept-Language
+// ("en-US,en;q=0.9,es;q=0.8"). Solo compara la etiqueta primaria; "*" acepta DefaultLanguage.
+func NegotiateLanguage(acceptLanguage string) string {
+	best, bestQ := DefaultLanguage, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if primary == "*" {
+			primary = DefaultLanguage
+		}
+		if _, ok := messages[primary]; !ok || q <= bestQ {
+			continue
+		}
+		best, bestQ = primary, q
+	}
+	return best
+}
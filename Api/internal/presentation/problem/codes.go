@@ -0,0 +1,88 @@
+package problem
+
+// Code identifica de forma estable el tipo de error; los clientes deben decidir por el
+// codigo y no por el texto, que depende del idioma.
+type Code string
+
+// Codigos genericos, usados por defecto para cada error de dominio.
+const (
+	CodeInvalidInput     Code = "invalid_input"
+	CodeInvalidBody      Code = "invalid_body"
+	CodeInvalidParameter Code = "invalid_parameter"
+	CodeInvalidQuery     Code = "invalid_query"
+	CodeUnauthorized     Code = "unauthorized"
+	CodeForbidden        Code = "forbidden"
+	CodeNotFound         Code = "not_found"
+	CodeConflict         Code = "conflict"
+	CodeDuplicateRequest Code = "duplicate_request"
+	CodeRateLimited      Code = "rate_limited"
+	CodeInternal         Code = "internal_error"
+)
+
+// Autenticacion.
+const (
+	CodeTokenRequired      Code = "token_required"
+	CodeInvalidToken       Code = "invalid_token"
+	CodeTokenExpired       Code = "token_expired"
+	CodeTokenNotYetValid   Code = "token_not_yet_valid"
+	CodeInvalidCredentials Code = "invalid_credentials"
+)
+
+// Registro de usuarios.
+const (
+	CodeEmailInUse          Code = "email_in_use"
+	CodePasswordMismatch    Code = "password_mismatch"
+	CodeInvalidLocation     Code = "invalid_location"
+	CodeUserNotFound        Code = "user_not_found"
+	CodeUsernameUnavailable Code = "username_unavailable"
+)
+
+// Videos y moderacion.
+const (
+	CodeVideoNotFound          Code = "video_not_found"
+	CodeTitleRequired          Code = "title_required"
+	CodeVideoFileRequired      Code = "video_file_required"
+	CodeUnsupportedMediaType   Code = "unsupported_media_type"
+	CodeFileTooLarge           Code = "file_too_large"
+	CodeInvalidVideo           Code = "invalid_video"
+	CodeVideoNotDeletable      Code = "video_not_deletable"
+	CodeVideoNotReady          Code = "video_not_ready"
+	CodeVideoNotPendingReview  Code = "video_not_pending_review"
+	CodeInvalidRejectionReason Code = "invalid_rejection_reason"
+)
+
+// Votos.
+const (
+	CodeAlreadyVoted       Code = "already_voted"
+	CodeNotVoted           Code = "not_voted"
+	CodeVotingClosed       Code = "voting_closed"
+	CodeVoteBudgetExceeded Code = "vote_budget_exceeded"
+	CodeVoteNotFound       Code = "vote_not_found"
+	CodeVoteNotQuarantined Code = "vote_not_quarantined"
+	CodeVoteFailed         Code = "vote_failed"
+	CodeVoteRetractFailed  Code = "vote_retract_failed"
+)
+
+// Comentarios y denuncias.
+const (
+	CodeCommentNotFound    Code = "comment_not_found"
+	CodeInvalidComment     Code = "invalid_comment"
+	CodeCommentRateLimited Code = "comment_rate_limited"
+	CodeInvalidReport      Code = "invalid_report"
+	CodeAlreadyReported    Code = "already_reported"
+	CodeReportNotFound     Code = "report_not_found"
+	CodeReportResolved     Code = "report_resolved"
+)
+
+// Notificaciones.
+const (
+	CodeNotificationNotFound Code = "notification_not_found"
+)
+
+// Concursos.
+const (
+	CodeContestNotFound     Code = "contest_not_found"
+	CodeInvalidContest      Code = "invalid_contest"
+	CodeInvalidContestDates Code = "invalid_contest_dates"
+	CodeContestClosed       Code = "contest_closed"
+)
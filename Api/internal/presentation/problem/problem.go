@@ -0,0 +1,119 @@
+// Package problem centraliza las respuestas de error HTTP con el formato RFC 7807
+// (application/problem+json), codigos estables y mensajes en español o ingles segun
+// Accept-Language.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType es el media type de las respuestas de error.
+const ContentType = "application/problem+json"
+
+// TypePrefix antecede al codigo en el campo type de cada problema.
+const TypePrefix = "urn:videorank:error:"
+
+// Problem es el cuerpo de error (RFC 7807) con la extension code.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     Code   `json:"code"`
+}
+
+// New arma el problema code con status en el idioma lang.
+func New(lang string, status int, code Code) Problem {
+	return Problem{
+		Type:   TypePrefix + string(code),
+		Title:  Title(lang, status),
+		Status: status,
+		Detail: Message(lang, code),
+		Code:   code,
+	}
+}
+
+// Abort responde el problema code con status en el idioma pedido por el cliente y corta
+// la cadena de handlers.
+func Abort(c *gin.Context, status int, code Code) {
+	lang := NegotiateLanguage(c.GetHeader("Accept-Language"))
+	p := New(lang, status, code)
+	p.Instance = c.Request.URL.Path
+
+	c.Header("Content-Language", lang)
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	payload, err := json.Marshal(p)
+	if err != nil {
+		c.AbortWithStatus(status)
+		return
+	}
+	c.Abort()
+	c.Data(status, ContentType+"; charset=utf-8", payload)
+}
+
+// Rule asocia un error de dominio con el status y codigo que debe producir.
+type Rule struct {
+	Err    error
+	Status int
+	Code   Code
+}
+
+// On crea una Rule para AbortWithError.
+func On(err error, status int, code Code) Rule {
+	return Rule{Err: err, Status: status, Code: code}
+}
+
+// domainRules es la traduccion por defecto de los errores de dominio.
+var domainRules = []Rule{
+	On(domain.ErrInvalid, http.StatusBadRequest, CodeInvalidInput),
+	On(domain.ErrNotFound, http.StatusNotFound, CodeNotFound),
+	On(domain.ErrForbidden, http.StatusForbidden, CodeForbidden),
+	On(domain.ErrConflict, http.StatusConflict, CodeConflict),
+	On(domain.ErrIdempotent, http.StatusConflict, CodeDuplicateRequest),
+	On(domain.ErrRateLimited, http.StatusTooManyRequests, CodeRateLimited),
+	On(domain.ErrVotingClosed, http.StatusForbidden, CodeVotingClosed),
+	On(domain.ErrVoteBudgetExceeded, http.StatusTooManyRequests, CodeVoteBudgetExceeded),
+}
+
+// Match devuelve la primera regla que aplica a err, buscando en rules (en orden) y luego en
+// la traduccion por defecto de los errores de dominio.
+func Match(err error, rules ...Rule) (Rule, bool) {
+	for _, set := range [][]Rule{rules, domainRules} {
+		for _, r := range set {
+			if errors.Is(err, r.Err) {
+				return r, true
+			}
+		}
+	}
+	return Rule{}, false
+}
+
+// AbortWithError responde el problema de la regla que aplica a err (ver Match). Cualquier
+// otro error se registra en el log y se responde como 500 internal_error, sin exponer el
+// mensaje original.
+func AbortWithError(c *gin.Context, err error, rules ...Rule) {
+	if r, ok := Match(err, rules...); ok {
+		Abort(c, r.Status, r.Code)
+		return
+	}
+	Internal(c, err)
+}
+
+// Internal registra err y responde 500 internal_error.
+func Internal(c *gin.Context, err error) {
+	InternalWithCode(c, err, CodeInternal)
+}
+
+// InternalWithCode registra err y responde 500 con un codigo propio de la operacion.
+func InternalWithCode(c *gin.Context, err error, code Code) {
+	slog.ErrorContext(c.Request.Context(), "internal error", "method", c.Request.Method, "path", c.Request.URL.Path, "code", code, "err", err)
+	Abort(c, http.StatusInternalServerError, code)
+}
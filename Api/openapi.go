@@ -0,0 +1,9 @@
+// Package api expone los artefactos del modulo que se compilan dentro del binario.
+package api
+
+import _ "embed"
+
+// OpenAPISpec es el contrato HTTP de la API (openapi.yaml), usado para validar solicitudes.
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
@@ -0,0 +1,462 @@
+// Package contract_test ejercita cada ruta de NewRouter con fakes en memoria y falla
+// cuando la respuesta (status, headers o cuerpo) no coincide con openapi.yaml.
+package contract_test
+
+import (
+	"api"
+	"api/internal/application/useCase"
+	"api/internal/domain/entities"
+	"api/internal/infrastructure/cache"
+	"api/internal/infrastructure/health"
+	"api/internal/infrastructure/metrics"
+	"api/internal/presentation/handlers"
+	"api/internal/presentation/middlewares"
+	"api/tests/mocks"
+	"api/tests/testdata"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// contractCase es una solicitud contra el router y el status que debe producir.
+type contractCase struct {
+	name   string
+	method string
+	target string
+	// as es el usuario autenticado ("admin", "player", "viewer") o vacio para solicitudes anonimas
+	as     string
+	body   string
+	upload map[string]string
+	header map[string]string
+	status int
+}
+
+type contractEnv struct {
+	engine     *gin.Engine
+	doc        *openapi3.T
+	tokens     map[string]string
+	violations []string
+	exercised  map[string]bool
+}
+
+// newContractEnv arma el router completo; configure ajusta la configuracion antes de crearlo.
+func newContractEnv(t *testing.T, configure ...func(*handlers.RouterConfig)) *contractEnv {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	users := newUserRepo(string(hash))
+	videos := newVideoRepo()
+	publicRepository := publicRepo{}
+	contests := newContestRepo()
+	publisher := &mocks.MockMessagePublisher{}
+	storage := &mocks.MockVideoStorage{
+		SaveFunc: func(_ context.Context, objectName string, _ io.Reader, _ int64, _ string) (string, error) {
+			return objectName, nil
+		},
+	}
+
+	const secret = "contract-secret"
+	authService := useCase.NewAuthService(users, secret)
+	env := &contractEnv{engine: gin.New(), tokens: map[string]string{}, exercised: map[string]bool{}}
+	for _, name := range []string{"admin", "player", "viewer"} {
+		email := name + "@example.com"
+		token, _, err := authService.Login(context.Background(), email, password)
+		require.NoError(t, err)
+		env.tokens[name] = token
+	}
+
+	validator, err := middlewares.NewOpenAPIValidator(api.OpenAPISpec)
+	require.NoError(t, err)
+	validator.WithResponseValidation(func(c *gin.Context, err error) {
+		env.violations = append(env.violations, err.Error())
+	})
+	env.doc, err = openapi3.NewLoader().LoadFromData(api.OpenAPISpec)
+	require.NoError(t, err)
+
+	env.engine.Use(func(c *gin.Context) {
+		env.exercised[c.Request.Method+" "+c.FullPath()] = true
+	})
+	cfg := handlers.RouterConfig{
+		AuthService:    authService,
+		UserService:    useCase.NewUserService(users, locationRepo{}),
+		UploadsUC:      useCase.NewUploadsUseCase(videos, storage, publisher, "states_machine_queue"),
+		PublicService:  useCase.NewPublicService(publicRepository, newVoteRepo()).WithContests(contests),
+		StatusService:  useCase.NewStatusService(),
+		ModerationUC:   useCase.NewModerationUseCase(newModerationRepo()),
+		ReportUC:       useCase.NewReportUseCase(publicRepository, newReportRepo(), useCase.DefaultReportHideThreshold),
+		CommentUC:      useCase.NewCommentUseCase(publicRepository, newCommentRepo()),
+		ContestUC:      useCase.NewContestUseCase(contests),
+		VoteReviewUC:   useCase.NewVoteReviewUseCase(newQuarantineRepo()),
+		NotificationUC: useCase.NewNotificationUseCase(newNotificationRepo()),
+		JWTSecret:      secret,
+		// Formato de URL procesada de produccion (ver cmd/api)
+		ProcessedVideoURL: "http://localhost:8084/processed-videos/%s",
+		OpenAPI:           validator,
+		Metrics:           metrics.New(),
+	}
+	for _, fn := range configure {
+		fn(&cfg)
+	}
+	handlers.NewRouter(env.engine, cfg)
+	return env
+}
+
+// do ejecuta tc y devuelve la respuesta junto con las discrepancias contra la especificacion.
+func (env *contractEnv) do(t *testing.T, tc contractCase) (*httptest.ResponseRecorder, []string) {
+	t.Helper()
+	var body io.Reader
+	contentType := ""
+	switch {
+	case tc.upload != nil:
+		buf := &bytes.Buffer{}
+		w := multipart.NewWriter(buf)
+		for k, v := range tc.upload {
+			require.NoError(t, w.WriteField(k, v))
+		}
+		part, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Disposition": {`form-data; name="video_file"; filename="jugada.mp4"`},
+			"Content-Type":        {"video/mp4"},
+		})
+		require.NoError(t, err)
+		_, err = part.Write(testdata.CreateValidMP4())
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		body, contentType = buf, w.FormDataContentType()
+	case tc.body != "":
+		body, contentType = strings.NewReader(tc.body), "application/json"
+	}
+
+	req := httptest.NewRequest(tc.method, tc.target, body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if tc.as != "" {
+		req.Header.Set("Authorization", "Bearer "+env.tokens[tc.as])
+	}
+	for k, v := range tc.header {
+		req.Header.Set(k, v)
+	}
+
+	env.violations = nil
+	w := httptest.NewRecorder()
+	env.engine.ServeHTTP(w, req)
+	return w, append(env.violations, env.undocumentedParams(req, tc)...)
+}
+
+// undocumentedParams detecta parametros de query o headers propios que la operacion no
+// declara; el validador de solicitudes los acepta sin revisarlos.
+func (env *contractEnv) undocumentedParams(req *http.Request, tc contractCase) []string {
+	path, item := env.findPath(req.URL.Path)
+	if item == nil || item.GetOperation(req.Method) == nil {
+		return nil
+	}
+	params := item.GetOperation(req.Method).Parameters
+	var out []string
+	check := func(in, name string) {
+		if params.GetByInAndName(in, name) == nil && item.Parameters.GetByInAndName(in, name) == nil {
+			out = append(out, fmt.Sprintf("%s %s: %s parameter %q is not documented", req.Method, path, in, name))
+		}
+	}
+	for name := range req.URL.Query() {
+		check(openapi3.ParameterInQuery, name)
+	}
+	for name := range tc.header {
+		check(openapi3.ParameterInHeader, http.CanonicalHeaderKey(name))
+	}
+	return out
+}
+
+// findPath busca la ruta de la especificacion que corresponde a un path concreto.
+func (env *contractEnv) findPath(path string) (string, *openapi3.PathItem) {
+	segments := strings.Split(path, "/")
+	for specPath, item := range env.doc.Paths.Map() {
+		specSegments := strings.Split(specPath, "/")
+		if len(specSegments) != len(segments) {
+			continue
+		}
+		match := true
+		for i, s := range specSegments {
+			if !strings.HasPrefix(s, "{") && s != segments[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return specPath, item
+		}
+	}
+	return "", nil
+}
+
+func contractCases() []contractCase {
+	vote := fmt.Sprintf("/api/public/videos/%d/vote", videoID)
+	comments := fmt.Sprintf("/api/public/videos/%d/comments", videoID)
+	return []contractCase{
+		{name: "health", method: http.MethodGet, target: "/health", status: http.StatusOK},
+		{name: "metrics", method: http.MethodGet, target: "/metrics", status: http.StatusOK},
+		{name: "livez", method: http.MethodGet, target: "/livez", status: http.StatusOK},
+		{name: "readyz", method: http.MethodGet, target: "/readyz", status: http.StatusOK},
+
+		// Autenticacion
+		{name: "signup", method: http.MethodPost, target: "/api/auth/signup", status: http.StatusCreated,
+			body: `{"first_name":"Lina","last_name":"Ruiz","email":"lina@example.com","password1":"Clave123","password2":"Clave123","country":"Colombia","city":"Bogotá"}`},
+		{name: "signup with mismatched passwords", method: http.MethodPost, target: "/api/auth/signup", status: http.StatusBadRequest,
+			body: `{"first_name":"Lina","last_name":"Ruiz","email":"otra@example.com","password1":"Clave123","password2":"Otra123","country":"Colombia","city":"Bogotá"}`},
+		{name: "signup missing fields", method: http.MethodPost, target: "/api/auth/signup", status: http.StatusBadRequest,
+			body: `{"email":"lina@example.com"}`},
+		{name: "login", method: http.MethodPost, target: "/api/auth/login", status: http.StatusOK,
+			body: `{"email":"player@example.com","password":"` + password + `"}`},
+		{name: "login with wrong password", method: http.MethodPost, target: "/api/auth/login", status: http.StatusUnauthorized,
+			body: `{"email":"player@example.com","password":"incorrecta"}`},
+		{name: "me", method: http.MethodGet, target: "/api/me", as: "player", status: http.StatusOK},
+		{name: "me without token", method: http.MethodGet, target: "/api/me", status: http.StatusUnauthorized},
+		{name: "my votes", method: http.MethodGet, target: "/api/me/votes", as: "player", status: http.StatusOK},
+		{name: "my notifications", method: http.MethodGet, target: "/api/me/notifications?page=1&pageSize=10", as: "player", status: http.StatusOK},
+		{name: "my notifications invalid page", method: http.MethodGet, target: "/api/me/notifications?page=0", as: "player", status: http.StatusBadRequest},
+		{name: "mark notification read", method: http.MethodPost, target: "/api/me/notifications/1/read", as: "player", status: http.StatusOK},
+		{name: "mark notification of another user", method: http.MethodPost, target: "/api/me/notifications/1/read", as: "admin", status: http.StatusNotFound},
+		{name: "mark notification invalid id", method: http.MethodPost, target: "/api/me/notifications/abc/read", as: "player", status: http.StatusBadRequest},
+		{name: "my rank", method: http.MethodGet, target: "/api/me/rank", as: "admin", status: http.StatusOK},
+		{name: "my rank while unranked", method: http.MethodGet, target: "/api/me/rank", as: "player", status: http.StatusOK},
+
+		// Videos del usuario
+		{name: "list my videos", method: http.MethodGet, target: "/api/videos", as: "admin", status: http.StatusOK},
+		{name: "upload", method: http.MethodPost, target: "/api/videos/upload", as: "player", status: http.StatusCreated,
+			upload: map[string]string{"title": "Mi mejor jugada"}},
+		{name: "upload declaring another media type", method: http.MethodPost, target: "/api/videos/upload", as: "player", status: http.StatusBadRequest,
+			upload: map[string]string{"title": "Mi jugada", "mimeType": "video/webm"}},
+		{name: "upload without permission", method: http.MethodPost, target: "/api/videos/upload", as: "viewer", status: http.StatusForbidden,
+			upload: map[string]string{"title": "Mi jugada"}},
+		{name: "upload without title", method: http.MethodPost, target: "/api/videos/upload", as: "player", status: http.StatusBadRequest,
+			upload: map[string]string{}},
+		{name: "video detail", method: http.MethodGet, target: fmt.Sprintf("/api/videos/%d", videoID), as: "admin", status: http.StatusOK},
+		{name: "video detail of another user", method: http.MethodGet, target: fmt.Sprintf("/api/videos/%d", videoID), as: "player", status: http.StatusForbidden},
+		{name: "video detail not found", method: http.MethodGet, target: "/api/videos/999", as: "admin", status: http.StatusNotFound},
+		{name: "video detail invalid id", method: http.MethodGet, target: "/api/videos/abc", as: "admin", status: http.StatusBadRequest},
+		{name: "publish", method: http.MethodPost, target: fmt.Sprintf("/api/videos/%d/publish", processedID), as: "admin", status: http.StatusOK},
+		{name: "publish unprocessed video", method: http.MethodPost, target: fmt.Sprintf("/api/videos/%d/publish", uploadID), as: "admin", status: http.StatusBadRequest},
+		{name: "delete published video", method: http.MethodDelete, target: fmt.Sprintf("/api/videos/%d", videoID), as: "admin", status: http.StatusBadRequest},
+		{name: "delete video of another user", method: http.MethodDelete, target: fmt.Sprintf("/api/videos/%d", uploadID), as: "player", status: http.StatusForbidden},
+		{name: "delete missing video", method: http.MethodDelete, target: "/api/videos/999", as: "admin", status: http.StatusNotFound},
+		{name: "publish without permission", method: http.MethodPost, target: fmt.Sprintf("/api/videos/%d/publish", processedID), as: "viewer", status: http.StatusForbidden},
+		{name: "publish missing video", method: http.MethodPost, target: "/api/videos/999/publish", as: "admin", status: http.StatusNotFound},
+		{name: "delete", method: http.MethodDelete, target: fmt.Sprintf("/api/videos/%d", uploadID), as: "admin", status: http.StatusOK},
+
+		// Galeria y perfiles publicos
+		{name: "public videos", method: http.MethodGet, target: "/api/public/videos", status: http.StatusOK},
+		{name: "public videos filtered", method: http.MethodGet, target: "/api/public/videos?q=triple&city=Bogot%C3%A1&sort=most_voted&limit=5", as: "player", status: http.StatusOK},
+		{name: "public videos invalid sort", method: http.MethodGet, target: "/api/public/videos?sort=random", status: http.StatusBadRequest},
+		{name: "public video", method: http.MethodGet, target: fmt.Sprintf("/api/public/videos/%d", videoID), as: "player", status: http.StatusOK},
+		{name: "public video not found", method: http.MethodGet, target: "/api/public/videos/999", status: http.StatusNotFound},
+		{name: "player profile", method: http.MethodGet, target: "/api/public/users/admin", status: http.StatusOK},
+		{name: "unranked player profile", method: http.MethodGet, target: "/api/public/users/player", status: http.StatusOK},
+		{name: "player profile not found", method: http.MethodGet, target: "/api/public/users/nadie", status: http.StatusNotFound},
+
+		// Votos
+		{name: "vote", method: http.MethodPost, target: vote, as: "player", header: map[string]string{"X-Event-Id": "evt-1"}, status: http.StatusOK},
+		{name: "vote twice", method: http.MethodPost, target: vote, as: "player", status: http.StatusBadRequest},
+		{name: "vote without token", method: http.MethodPost, target: vote, status: http.StatusUnauthorized},
+		{name: "vote for missing video", method: http.MethodPost, target: "/api/public/videos/999/vote", as: "player", status: http.StatusNotFound},
+		{name: "retract vote", method: http.MethodDelete, target: vote, as: "player", status: http.StatusOK},
+		{name: "retract missing vote", method: http.MethodDelete, target: vote, as: "player", status: http.StatusBadRequest},
+
+		// Denuncias y comentarios
+		{name: "report", method: http.MethodPost, target: fmt.Sprintf("/api/public/videos/%d/report", videoID), as: "admin", status: http.StatusCreated,
+			body: `{"category":"spam","details":"Publicidad"}`},
+		{name: "report twice", method: http.MethodPost, target: fmt.Sprintf("/api/public/videos/%d/report", videoID), as: "admin", status: http.StatusConflict,
+			body: `{"category":"spam"}`},
+		{name: "list comments", method: http.MethodGet, target: comments + "?limit=10", status: http.StatusOK},
+		{name: "list comments of missing video", method: http.MethodGet, target: "/api/public/videos/999/comments", status: http.StatusNotFound},
+		{name: "report missing video", method: http.MethodPost, target: "/api/public/videos/999/report", as: "admin", status: http.StatusNotFound,
+			body: `{"category":"spam"}`},
+		{name: "comment", method: http.MethodPost, target: comments, as: "player", status: http.StatusCreated, body: `{"body":"Gran video"}`},
+		{name: "reply", method: http.MethodPost, target: comments, as: "player", status: http.StatusCreated, body: `{"body":"Gracias","parent_id":1}`},
+		{name: "empty comment", method: http.MethodPost, target: comments, as: "player", status: http.StatusBadRequest, body: `{"body":""}`},
+		{name: "edit comment", method: http.MethodPatch, target: comments + "/1", as: "player", status: http.StatusOK, body: `{"body":"¡Qué jugadón!"}`},
+		{name: "edit comment of another user", method: http.MethodPatch, target: comments + "/1", as: "admin", status: http.StatusForbidden, body: `{"body":"No"}`},
+		{name: "delete comment", method: http.MethodDelete, target: comments + "/1", as: "player", status: http.StatusOK},
+		{name: "delete missing comment", method: http.MethodDelete, target: comments + "/999", as: "player", status: http.StatusNotFound},
+
+		// Rankings
+		{name: "rankings", method: http.MethodGet, target: "/api/public/rankings?city=Bogot%C3%A1&page=1&pageSize=10", status: http.StatusOK},
+		{name: "rankings invalid page", method: http.MethodGet, target: "/api/public/rankings?page=0", status: http.StatusBadRequest},
+		{name: "video rankings", method: http.MethodGet, target: "/api/public/rankings/videos?pageSize=5", status: http.StatusOK},
+		{name: "video rankings invalid page size", method: http.MethodGet, target: "/api/public/rankings/videos?pageSize=0", status: http.StatusBadRequest},
+		{name: "user rank", method: http.MethodGet, target: "/api/public/rankings/users/admin", status: http.StatusOK},
+		{name: "user rank not found", method: http.MethodGet, target: "/api/public/rankings/users/nadie", status: http.StatusNotFound},
+
+		// Concursos
+		{name: "contests", method: http.MethodGet, target: "/api/public/contests", status: http.StatusOK},
+		{name: "contest", method: http.MethodGet, target: fmt.Sprintf("/api/public/contests/%d", contestID), status: http.StatusOK},
+		{name: "contest not found", method: http.MethodGet, target: "/api/public/contests/999", status: http.StatusNotFound},
+		{name: "contest rankings", method: http.MethodGet, target: fmt.Sprintf("/api/public/contests/%d/rankings", contestID), status: http.StatusOK},
+		{name: "rankings of missing contest", method: http.MethodGet, target: "/api/public/contests/999/rankings", status: http.StatusNotFound},
+		{name: "create contest", method: http.MethodPost, target: "/api/admin/contests", as: "admin", status: http.StatusCreated,
+			body: `{"name":"Liga 2026","submission_starts_at":"2026-01-01T00:00:00Z","submission_ends_at":"2026-02-01T00:00:00Z","voting_starts_at":"2026-02-01T00:00:00Z","voting_ends_at":"2026-03-01T00:00:00Z"}`},
+		{name: "create contest with invalid dates", method: http.MethodPost, target: "/api/admin/contests", as: "admin", status: http.StatusBadRequest,
+			body: `{"name":"Liga","submission_starts_at":"ayer","submission_ends_at":"hoy","voting_starts_at":"hoy","voting_ends_at":"mañana"}`},
+		{name: "create contest without permission", method: http.MethodPost, target: "/api/admin/contests", as: "player", status: http.StatusForbidden,
+			body: `{"name":"Liga","submission_starts_at":"2026-01-01T00:00:00Z","submission_ends_at":"2026-02-01T00:00:00Z","voting_starts_at":"2026-02-01T00:00:00Z","voting_ends_at":"2026-03-01T00:00:00Z"}`},
+		{name: "close contest", method: http.MethodPost, target: fmt.Sprintf("/api/admin/contests/%d/close", contestID), as: "admin", status: http.StatusOK},
+		{name: "close contest twice", method: http.MethodPost, target: fmt.Sprintf("/api/admin/contests/%d/close", contestID), as: "admin", status: http.StatusConflict},
+		{name: "close missing contest", method: http.MethodPost, target: "/api/admin/contests/999/close", as: "admin", status: http.StatusNotFound},
+		{name: "vote after the contest closed", method: http.MethodPost, target: vote, as: "viewer", status: http.StatusForbidden},
+
+		// Moderacion
+		{name: "moderation queue", method: http.MethodGet, target: "/api/moderation/videos?page=1&pageSize=20", as: "admin", status: http.StatusOK},
+		{name: "moderation queue without permission", method: http.MethodGet, target: "/api/moderation/videos", as: "player", status: http.StatusForbidden},
+		{name: "approve", method: http.MethodPost, target: fmt.Sprintf("/api/moderation/videos/%d/approve", approveID), as: "admin", status: http.StatusOK},
+		{name: "approve missing video", method: http.MethodPost, target: "/api/moderation/videos/999/approve", as: "admin", status: http.StatusNotFound},
+		{name: "approve twice", method: http.MethodPost, target: fmt.Sprintf("/api/moderation/videos/%d/approve", approveID), as: "admin", status: http.StatusConflict},
+		{name: "reject", method: http.MethodPost, target: fmt.Sprintf("/api/moderation/videos/%d/reject", rejectID), as: "admin", status: http.StatusOK,
+			body: `{"reason":"Contenido fuera de tema"}`},
+		{name: "reject without reason", method: http.MethodPost, target: fmt.Sprintf("/api/moderation/videos/%d/reject", rejectID), as: "admin", status: http.StatusBadRequest,
+			body: `{"reason":""}`},
+
+		// Triage de denuncias
+		{name: "open reports", method: http.MethodGet, target: "/api/admin/reports", as: "admin", status: http.StatusOK},
+		{name: "open reports without permission", method: http.MethodGet, target: "/api/admin/reports", as: "player", status: http.StatusForbidden},
+		{name: "dismiss report", method: http.MethodPost, target: "/api/admin/reports/1/dismiss", as: "admin", status: http.StatusOK},
+		{name: "dismiss resolved report", method: http.MethodPost, target: "/api/admin/reports/1/dismiss", as: "admin", status: http.StatusConflict},
+		{name: "take down", method: http.MethodPost, target: "/api/admin/reports/2/takedown", as: "admin", status: http.StatusOK},
+		{name: "take down missing report", method: http.MethodPost, target: "/api/admin/reports/999/takedown", as: "admin", status: http.StatusNotFound},
+
+		// Revision de votos
+		{name: "quarantine", method: http.MethodGet, target: "/api/admin/votes/quarantine", as: "admin", status: http.StatusOK},
+		{name: "quarantine without permission", method: http.MethodGet, target: "/api/admin/votes/quarantine", as: "player", status: http.StatusForbidden},
+		{name: "clear vote", method: http.MethodPost, target: "/api/admin/votes/1/clear", as: "admin", status: http.StatusOK},
+		{name: "clear reviewed vote", method: http.MethodPost, target: "/api/admin/votes/1/clear", as: "admin", status: http.StatusConflict},
+		{name: "discard vote", method: http.MethodPost, target: "/api/admin/votes/2/discard", as: "admin", status: http.StatusOK},
+		{name: "discard missing vote", method: http.MethodPost, target: "/api/admin/votes/999/discard", as: "admin", status: http.StatusNotFound},
+
+		// Al final: invalida el token del jugador
+		{name: "logout", method: http.MethodPost, target: "/api/auth/logout", as: "player", status: http.StatusNoContent},
+		{name: "token after logout", method: http.MethodGet, target: "/api/me", as: "player", status: http.StatusUnauthorized},
+	}
+}
+
+func TestContract_ResponsesMatchSpec(t *testing.T) {
+	env := newContractEnv(t)
+	for _, tc := range contractCases() {
+		// Los casos comparten estado (votos, denuncias...) y se ejecutan en orden
+		w, violations := env.do(t, tc)
+		assert.Equalf(t, tc.status, w.Code, "%s: %s %s: %s", tc.name, tc.method, tc.target, w.Body.String())
+		for _, v := range violations {
+			t.Errorf("%s: %s", tc.name, v)
+		}
+	}
+
+	var missing []string
+	for _, r := range env.engine.Routes() {
+		if !env.exercised[r.Method+" "+r.Path] {
+			missing = append(missing, r.Method+" "+r.Path)
+		}
+	}
+	sort.Strings(missing)
+	assert.Empty(t, missing, "routes without contract cases")
+}
+
+func TestContract_SpecOperationsAreRouted(t *testing.T) {
+	env := newContractEnv(t)
+	routed := map[string]bool{}
+	for _, r := range env.engine.Routes() {
+		routed[r.Method+" "+r.Path] = true
+	}
+
+	var undocumented, unrouted []string
+	for path, item := range env.doc.Paths.Map() {
+		ginPath := path
+		for _, seg := range strings.Split(path, "/") {
+			if strings.HasPrefix(seg, "{") {
+				ginPath = strings.Replace(ginPath, seg, ":"+strings.Trim(seg, "{}"), 1)
+			}
+		}
+		for method := range item.Operations() {
+			key := method + " " + ginPath
+			if !routed[key] {
+				unrouted = append(unrouted, key)
+			}
+			delete(routed, key)
+		}
+	}
+	for key := range routed {
+		undocumented = append(undocumented, key)
+	}
+	sort.Strings(undocumented)
+	sort.Strings(unrouted)
+	assert.Empty(t, undocumented, "routes missing from openapi.yaml")
+	assert.Empty(t, unrouted, "openapi.yaml operations without a route")
+}
+
+func TestContract_RateLimitedResponses(t *testing.T) {
+	env := newContractEnv(t, func(cfg *handlers.RouterConfig) {
+		cfg.RateLimiter = cache.NewMemoryRateLimiter()
+		once := entities.RateLimit{Limit: 1, Period: time.Minute}
+		cfg.RateLimits = handlers.RateLimitPolicies{Auth: once, Uploads: once, Votes: once}
+	})
+	login := `{"email":"player@example.com","password":"` + password + `"}`
+	for _, tc := range []contractCase{
+		{name: "login", method: http.MethodPost, target: "/api/auth/login", body: login, status: http.StatusOK},
+		{name: "login over the limit", method: http.MethodPost, target: "/api/auth/login", body: login, status: http.StatusTooManyRequests},
+		{name: "vote", method: http.MethodPost, target: fmt.Sprintf("/api/public/videos/%d/vote", videoID), as: "player", status: http.StatusOK},
+		{name: "retract over the limit", method: http.MethodDelete, target: fmt.Sprintf("/api/public/videos/%d/vote", videoID), as: "player", status: http.StatusTooManyRequests},
+	} {
+		w, violations := env.do(t, tc)
+		assert.Equalf(t, tc.status, w.Code, "%s: %s", tc.name, w.Body.String())
+		for _, v := range violations {
+			t.Errorf("%s: %s", tc.name, v)
+		}
+	}
+}
+
+func TestContract_ReadinessResponses(t *testing.T) {
+	down := func(context.Context) error { return errors.New("connection refused") }
+	for _, tc := range []struct {
+		name   string
+		check  health.Check
+		status int
+	}{
+		{name: "optional dependency down", check: health.Check{Name: "redis", Optional: true, Run: down}, status: http.StatusOK},
+		{name: "critical dependency down", check: health.Check{Name: "postgres", Run: down}, status: http.StatusServiceUnavailable},
+	} {
+		env := newContractEnv(t, func(cfg *handlers.RouterConfig) {
+			cfg.HealthChecks = []health.Check{tc.check}
+		})
+		w, violations := env.do(t, contractCase{name: tc.name, method: http.MethodGet, target: "/readyz", status: tc.status})
+		assert.Equalf(t, tc.status, w.Code, "%s: %s", tc.name, w.Body.String())
+		for _, v := range violations {
+			t.Errorf("%s: %s", tc.name, v)
+		}
+	}
+}
+
+func TestContract_RequestValidation(t *testing.T) {
+	env := newContractEnv(t)
+	for _, tc := range []contractCase{
+		{name: "non numeric path parameter", method: http.MethodGet, target: "/api/public/videos/abc", status: http.StatusBadRequest},
+		{name: "query parameter out of range", method: http.MethodGet, target: "/api/public/videos?limit=1000", status: http.StatusBadRequest},
+		{name: "body of the wrong type", method: http.MethodPost, target: "/api/auth/login", status: http.StatusBadRequest, body: `{"email":1}`},
+	} {
+		w, _ := env.do(t, tc)
+		assert.Equalf(t, tc.status, w.Code, "%s: %s", tc.name, w.Body.String())
+		assert.Containsf(t, w.Header().Get("Content-Type"), "application/problem+json", tc.name)
+	}
+}
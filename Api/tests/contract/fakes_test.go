@@ -0,0 +1,673 @@
+package contract_test
+
+import (
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Datos fijos del escenario: el usuario 1 es el administrador (todos los permisos) y autor
+// de los videos 1 (publicado), 2 (subido, sin procesar) y 3 (procesado); el usuario 2 es un
+// jugador con videos 4 y 5 pendientes de revision y el 3 un espectador sin permisos.
+const (
+	adminID     uint = 1
+	playerID    uint = 2
+	viewerID    uint = 3
+	password         = "Secreta123"
+	videoID     uint = 1
+	uploadID    uint = 2
+	processedID uint = 3
+	approveID   uint = 4
+	rejectID    uint = 5
+	contestID   uint = 1
+)
+
+var (
+	city      = "Bogotá"
+	published = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func strPtr(s string) *string { return &s }
+
+// userRepo implements interfaces.UserRepository.
+type userRepo struct {
+	mu    sync.Mutex
+	users map[string]*entities.User
+	perms map[uint][]string
+}
+
+func newUserRepo(hash string) *userRepo {
+	return &userRepo{
+		users: map[string]*entities.User{
+			"admin@example.com":  {UserID: int(adminID), FirstName: "Ana", LastName: "Admin", Email: "admin@example.com", Username: "admin", PasswordHash: hash, CityID: 1},
+			"player@example.com": {UserID: int(playerID), FirstName: "Pedro", LastName: "Player", Email: "player@example.com", Username: "player", PasswordHash: hash, CityID: 1},
+			"viewer@example.com": {UserID: int(viewerID), FirstName: "Vera", LastName: "Viewer", Email: "viewer@example.com", Username: "viewer", PasswordHash: hash, CityID: 1},
+		},
+		perms: map[uint][]string{
+			adminID:  {"upload_video", "edit_video", "moderate_videos", "triage_reports", "manage_contests", "review_votes"},
+			playerID: {"upload_video", "edit_video"},
+		},
+	}
+}
+
+func (r *userRepo) Create(_ context.Context, user *entities.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user.UserID = 100 + len(r.users)
+	r.users[user.Email] = user
+	return nil
+}
+
+func (r *userRepo) GetByEmail(_ context.Context, email string) (*entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[email]; ok {
+		return u, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *userRepo) GetPermissions(_ context.Context, userID uint) ([]string, error) {
+	return r.perms[userID], nil
+}
+
+// locationRepo implements interfaces.LocationRepository.
+type locationRepo struct{}
+
+func (locationRepo) GetCityID(_ context.Context, country, cityName string) (int, error) {
+	if country == "Colombia" && cityName == city {
+		return 1, nil
+	}
+	return 0, domain.ErrNotFound
+}
+
+// videoRepo implements interfaces.VideoRepository.
+type videoRepo struct {
+	mu     sync.Mutex
+	videos map[uint]*entities.Video
+}
+
+func newVideoRepo() *videoRepo {
+	return &videoRepo{videos: map[uint]*entities.Video{
+		videoID: {VideoID: videoID, UserID: adminID, Title: "Triple en la final", OriginalFile: "raw/1.mp4",
+			ProcessedFile: strPtr("processed/1.mp4"), Status: string(entities.StatusPublished), UploadedAt: published, ProcessedAt: &published},
+		uploadID: {VideoID: uploadID, UserID: adminID, Title: "Entrenamiento", OriginalFile: "raw/2.mp4",
+			Status: string(entities.StatusUploaded), UploadedAt: published},
+		processedID: {VideoID: processedID, UserID: adminID, Title: "Bandeja", OriginalFile: "raw/3.mp4",
+			ProcessedFile: strPtr("processed/3.mp4"), Status: string(entities.StatusProcessed), UploadedAt: published, ProcessedAt: &published},
+	}}
+}
+
+func (r *videoRepo) Create(_ context.Context, video *entities.Video) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	video.VideoID = uint(len(r.videos) + 1)
+	r.videos[video.VideoID] = video
+	return nil
+}
+
+func (r *videoRepo) GetByID(_ context.Context, id uint) (*entities.Video, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v, ok := r.videos[id]; ok {
+		return v, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *videoRepo) List(ctx context.Context) ([]*entities.Video, error) {
+	return r.ListByUser(ctx, 0)
+}
+
+func (r *videoRepo) ListByUser(_ context.Context, userID uint) ([]*entities.Video, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*entities.Video
+	for _, v := range r.videos {
+		if userID == 0 || v.UserID == userID {
+			out = append(out, v)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].VideoID > out[j].VideoID })
+	return out, nil
+}
+
+func (r *videoRepo) GetByIDAndUser(ctx context.Context, id, userID uint) (*entities.Video, error) {
+	v, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if v.UserID != userID {
+		return nil, domain.ErrForbidden
+	}
+	return v, nil
+}
+
+func (r *videoRepo) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.videos[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.videos, id)
+	return nil
+}
+
+func (r *videoRepo) UpdateStatus(_ context.Context, id uint, status entities.VideoStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.videos[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	v.Status = string(status)
+	return nil
+}
+
+// publicRepo implements interfaces.PublicRepository and its ranking, rank lookup and
+// gallery extensions over a single published video.
+type publicRepo struct{}
+
+func publicVideo() responses.PublicVideoResponse {
+	return responses.PublicVideoResponse{
+		VideoID:      videoID,
+		Title:        "Triple en la final",
+		ProcessedURL: strPtr("processed/1.mp4"),
+		City:         &city,
+		Votes:        3,
+		Comments:     1,
+		OwnerUserID:  adminID,
+		PublishedAt:  &published,
+	}
+}
+
+func (publicRepo) ListPublicVideos(context.Context) ([]responses.PublicVideoResponse, error) {
+	return []responses.PublicVideoResponse{publicVideo()}, nil
+}
+
+func (publicRepo) GetPublicByID(_ context.Context, id uint) (*responses.PublicVideoResponse, error) {
+	if id != videoID {
+		return nil, domain.ErrNotFound
+	}
+	v := publicVideo()
+	return &v, nil
+}
+
+func (publicRepo) Rankings(context.Context, *string, int, int) ([]responses.RankingItem, error) {
+	return []responses.RankingItem{{Username: "admin", City: &city, Votes: 3}}, nil
+}
+
+func (publicRepo) VideoRankings(context.Context, *string, int, int) ([]responses.VideoRankingItem, error) {
+	return []responses.VideoRankingItem{{VideoID: videoID, Title: "Triple en la final", Username: "admin", City: &city, Votes: 3}}, nil
+}
+
+func (publicRepo) GetUsersBasicByIDs(_ context.Context, ids []uint) ([]responses.UserBasic, error) {
+	out := make([]responses.UserBasic, 0, len(ids))
+	for _, id := range ids {
+		switch id {
+		case adminID:
+			out = append(out, responses.UserBasic{UserID: adminID, Username: "admin", City: &city})
+		case playerID:
+			out = append(out, responses.UserBasic{UserID: playerID, Username: "player", City: &city})
+		}
+	}
+	return out, nil
+}
+
+func (r publicRepo) FilteredRankings(ctx context.Context, filter entities.RankingFilter, page, pageSize int) ([]responses.RankingItem, error) {
+	return r.Rankings(ctx, filter.City, page, pageSize)
+}
+
+func (publicRepo) UserBasicByUsername(_ context.Context, username string) (*responses.UserBasic, error) {
+	switch strings.ToLower(username) {
+	case "admin":
+		return &responses.UserBasic{UserID: adminID, Username: "admin", City: &city}, nil
+	case "player":
+		return &responses.UserBasic{UserID: playerID, Username: "player", City: &city}, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (publicRepo) RankNeighborhood(_ context.Context, userID uint, _ *string) ([]responses.RankedUserItem, error) {
+	if userID != adminID {
+		return nil, nil
+	}
+	return []responses.RankedUserItem{{UserID: adminID, Position: 1, Username: "admin", City: &city, Votes: 3}}, nil
+}
+
+func (publicRepo) SearchPublicVideos(_ context.Context, q entities.GalleryQuery) ([]responses.GalleryVideo, error) {
+	if q.OwnerUserID != nil && *q.OwnerUserID != adminID {
+		return nil, nil
+	}
+	return []responses.GalleryVideo{{PublicVideoResponse: publicVideo(), UploadedAt: published, TrendingVotes: 1}}, nil
+}
+
+// voteRepo implements interfaces.VoteRepository and its event, retract, budget, audit and
+// voted lookup extensions.
+type voteRepo struct {
+	mu    sync.Mutex
+	votes map[[2]uint]time.Time
+}
+
+func newVoteRepo() *voteRepo {
+	return &voteRepo{votes: map[[2]uint]time.Time{}}
+}
+
+func (r *voteRepo) HasUserVoted(_ context.Context, video, user uint) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.votes[[2]uint{video, user}]
+	return ok, nil
+}
+
+func (r *voteRepo) Create(_ context.Context, video, user uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := [2]uint{video, user}
+	if _, ok := r.votes[key]; ok {
+		return domain.ErrConflict
+	}
+	r.votes[key] = time.Now()
+	return nil
+}
+
+func (r *voteRepo) CreateWithEvent(ctx context.Context, video, user uint, _ *string) error {
+	return r.Create(ctx, video, user)
+}
+
+func (r *voteRepo) CreateWithBudget(ctx context.Context, video, user uint, _ *string, _ []entities.VoteBudgetPolicy) error {
+	return r.Create(ctx, video, user)
+}
+
+func (r *voteRepo) CreateWithAudit(ctx context.Context, video, user uint, _ *string, _ entities.VoteAudit, _ []entities.VoteBudgetPolicy) error {
+	return r.Create(ctx, video, user)
+}
+
+func (r *voteRepo) Retract(_ context.Context, video, user uint, _ *string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := [2]uint{video, user}
+	if _, ok := r.votes[key]; !ok {
+		return domain.ErrConflict
+	}
+	delete(r.votes, key)
+	return nil
+}
+
+func (r *voteRepo) CountVotes(_ context.Context, user uint, _ *time.Time, _ *uint) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for key := range r.votes {
+		if key[1] == user {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *voteRepo) ListByUser(_ context.Context, user uint, _, _ int) ([]responses.UserVoteItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := []responses.UserVoteItem{}
+	for key, at := range r.votes {
+		if key[1] == user {
+			cid := contestID
+			out = append(out, responses.UserVoteItem{VideoID: key[0], Title: "Triple en la final", ContestID: &cid, VotedAt: at})
+		}
+	}
+	return out, nil
+}
+
+func (r *voteRepo) VotedVideoIDs(_ context.Context, user uint, ids []uint) (map[uint]bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := map[uint]bool{}
+	for _, id := range ids {
+		if _, ok := r.votes[[2]uint{id, user}]; ok {
+			out[id] = true
+		}
+	}
+	return out, nil
+}
+
+// commentRepo implements interfaces.CommentRepository.
+type commentRepo struct {
+	mu       sync.Mutex
+	comments map[uint]*entities.Comment
+}
+
+func newCommentRepo() *commentRepo {
+	return &commentRepo{comments: map[uint]*entities.Comment{
+		1: {CommentID: 1, VideoID: videoID, UserID: playerID, Body: "¡Qué jugada!", CreatedAt: published},
+	}}
+}
+
+func (r *commentRepo) Create(_ context.Context, comment *entities.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	comment.CommentID = uint(len(r.comments) + 1)
+	comment.CreatedAt = time.Now()
+	r.comments[comment.CommentID] = comment
+	return nil
+}
+
+func (r *commentRepo) GetByID(_ context.Context, id uint) (*entities.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.comments[id]; ok {
+		return c, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *commentRepo) List(_ context.Context, video uint, parentID *uint, afterID uint, limit int) ([]responses.CommentResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := []responses.CommentResponse{}
+	for _, c := range r.comments {
+		sameParent := (parentID == nil && c.ParentID == nil) || (parentID != nil && c.ParentID != nil && *parentID == *c.ParentID)
+		if c.VideoID != video || !sameParent || (afterID != 0 && c.CommentID <= afterID) {
+			continue
+		}
+		out = append(out, responses.CommentResponse{CommentID: c.CommentID, ParentID: c.ParentID, Author: "player",
+			Body: c.Body, CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CommentID < out[j].CommentID })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (r *commentRepo) UpdateBody(_ context.Context, id uint, body string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.comments[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	now := time.Now()
+	c.Body, c.UpdatedAt = body, &now
+	return nil
+}
+
+func (r *commentRepo) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.comments[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.comments, id)
+	return nil
+}
+
+func (r *commentRepo) CreateWithinLimit(ctx context.Context, c *entities.Comment, _ int, _ time.Time) error {
+	return r.Create(ctx, c)
+}
+
+func (r *commentRepo) VideoOwnerID(context.Context, uint) (uint, error) {
+	return 0, domain.ErrNotFound
+}
+
+// contestRepo implements interfaces.ContestRepository.
+type contestRepo struct {
+	mu       sync.Mutex
+	contests map[uint]*entities.Contest
+}
+
+func newContestRepo() *contestRepo {
+	now := time.Now().UTC().Truncate(time.Second)
+	return &contestRepo{contests: map[uint]*entities.Contest{
+		contestID: {ContestID: contestID, Name: "Liga 2025", SubmissionStartsAt: now.AddDate(0, -1, 0),
+			SubmissionEndsAt: now.AddDate(0, 0, -7), VotingStartsAt: now.AddDate(0, 0, -7), VotingEndsAt: now.AddDate(0, 0, 7)},
+	}}
+}
+
+func (r *contestRepo) Create(_ context.Context, contest *entities.Contest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	contest.ContestID = uint(len(r.contests) + 1)
+	r.contests[contest.ContestID] = contest
+	return nil
+}
+
+func (r *contestRepo) GetByID(_ context.Context, id uint) (*entities.Contest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.contests[id]; ok {
+		copy := *c
+		return &copy, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *contestRepo) List(context.Context) ([]entities.Contest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]entities.Contest, 0, len(r.contests))
+	for _, c := range r.contests {
+		out = append(out, *c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ContestID > out[j].ContestID })
+	return out, nil
+}
+
+func (r *contestRepo) GetForVideo(ctx context.Context, video uint) (*entities.Contest, error) {
+	if video != videoID {
+		return nil, nil
+	}
+	return r.GetByID(ctx, contestID)
+}
+
+func (r *contestRepo) Close(_ context.Context, id uint) (*entities.Contest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.contests[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	if c.ClosedAt != nil {
+		return nil, domain.ErrConflict
+	}
+	now := time.Now().UTC()
+	c.ClosedAt = &now
+	copy := *c
+	return &copy, nil
+}
+
+func (r *contestRepo) Rankings(context.Context, uint, *string, int, int) ([]responses.RankingItem, error) {
+	return []responses.RankingItem{{Username: "admin", City: &city, Votes: 3}}, nil
+}
+
+// moderationRepo implements interfaces.ModerationRepository.
+type moderationRepo struct {
+	mu      sync.Mutex
+	pending map[uint]bool
+}
+
+func newModerationRepo() *moderationRepo {
+	return &moderationRepo{pending: map[uint]bool{approveID: true, rejectID: true}}
+}
+
+func (r *moderationRepo) ListPending(context.Context, int, int) ([]responses.ModerationQueueItem, error) {
+	return []responses.ModerationQueueItem{{VideoID: approveID, Title: "Clavada", OwnerUserID: playerID,
+		OwnerUsername: "player", ProcessedURL: strPtr("processed/4.mp4"), UploadedAt: published, ProcessedAt: &published}}, nil
+}
+
+func (r *moderationRepo) Approve(_ context.Context, video, _ uint) (*entities.Video, error) {
+	return r.decide(video, entities.StatusPublished)
+}
+
+func (r *moderationRepo) Reject(_ context.Context, video, _ uint, reason string) (*entities.Video, error) {
+	v, err := r.decide(video, entities.StatusRejected)
+	if err == nil {
+		v.RejectionReason = &reason
+	}
+	return v, err
+}
+
+func (r *moderationRepo) decide(video uint, status entities.VideoStatus) (*entities.Video, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending, ok := r.pending[video]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	if !pending {
+		return nil, domain.ErrConflict
+	}
+	r.pending[video] = false
+	return &entities.Video{VideoID: video, UserID: playerID, Title: "Clavada", OriginalFile: "raw/4.mp4",
+		Status: string(status), UploadedAt: published}, nil
+}
+
+// reportRepo implements interfaces.ReportRepository.
+type reportRepo struct {
+	mu      sync.Mutex
+	reports map[uint]*entities.VideoReport
+}
+
+func newReportRepo() *reportRepo {
+	return &reportRepo{reports: map[uint]*entities.VideoReport{
+		1: {ReportID: 1, VideoID: videoID, ReporterID: playerID, Category: string(entities.ReportSpam), Status: string(entities.ReportOpen), CreatedAt: published},
+		2: {ReportID: 2, VideoID: videoID, ReporterID: playerID, Category: string(entities.ReportAbuse), Status: string(entities.ReportOpen), CreatedAt: published},
+	}}
+}
+
+func (r *reportRepo) Create(_ context.Context, report *entities.VideoReport, _ int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.reports {
+		if existing.VideoID == report.VideoID && existing.ReporterID == report.ReporterID {
+			return false, domain.ErrConflict
+		}
+	}
+	report.ReportID = uint(len(r.reports) + 1)
+	report.Status = string(entities.ReportOpen)
+	r.reports[report.ReportID] = report
+	return false, nil
+}
+
+func (r *reportRepo) ListOpen(context.Context, int, int) ([]responses.ReportResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := []responses.ReportResponse{}
+	for _, rep := range r.reports {
+		if rep.Status == string(entities.ReportOpen) {
+			out = append(out, responses.ReportResponse{ReportID: rep.ReportID, VideoID: rep.VideoID, VideoTitle: "Triple en la final",
+				ReporterUsername: "player", Category: rep.Category, Details: rep.Details, Status: rep.Status, CreatedAt: rep.CreatedAt, OpenReports: 1})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ReportID < out[j].ReportID })
+	return out, nil
+}
+
+func (r *reportRepo) Dismiss(ctx context.Context, reportID, _ uint, _ int) error {
+	_, err := r.resolve(reportID, entities.ReportDismissed)
+	return err
+}
+
+func (r *reportRepo) TakeDown(_ context.Context, reportID, _ uint) (uint, error) {
+	return r.resolve(reportID, entities.ReportActioned)
+}
+
+func (r *reportRepo) resolve(reportID uint, status entities.ReportStatus) (uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rep, ok := r.reports[reportID]
+	if !ok {
+		return 0, domain.ErrNotFound
+	}
+	if rep.Status != string(entities.ReportOpen) {
+		return 0, domain.ErrConflict
+	}
+	rep.Status = string(status)
+	return rep.VideoID, nil
+}
+
+// quarantineRepo implements interfaces.VoteQuarantineRepository.
+type quarantineRepo struct {
+	mu      sync.Mutex
+	pending map[uint]bool
+}
+
+func newQuarantineRepo() *quarantineRepo {
+	return &quarantineRepo{pending: map[uint]bool{1: true, 2: true}}
+}
+
+func (r *quarantineRepo) ListQuarantined(context.Context, int, int) ([]responses.QuarantinedVote, error) {
+	age := int64(3600)
+	return []responses.QuarantinedVote{{VoteID: 1, VideoID: videoID, VideoTitle: "Triple en la final", VoterID: playerID,
+		VoterUsername: "player", IP: strPtr("203.0.113.7"), AccountAgeSeconds: &age, Flags: []string{"new_account"},
+		VotedAt: published, QuarantinedAt: published}}, nil
+}
+
+func (r *quarantineRepo) Clear(_ context.Context, voteID, _ uint) error {
+	return r.review(voteID)
+}
+
+func (r *quarantineRepo) Discard(_ context.Context, voteID, _ uint) error {
+	return r.review(voteID)
+}
+
+func (r *quarantineRepo) review(voteID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending, ok := r.pending[voteID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	if !pending {
+		return domain.ErrConflict
+	}
+	r.pending[voteID] = false
+	return nil
+}
+
+// notificationRepo implements interfaces.NotificationRepository.
+type notificationRepo struct {
+	mu    sync.Mutex
+	items map[uint]*responses.NotificationResponse
+	owner map[uint]uint
+}
+
+func newNotificationRepo() *notificationRepo {
+	return &notificationRepo{
+		items: map[uint]*responses.NotificationResponse{1: {NotificationID: 1, Type: entities.NotificationVideoRejected,
+			Payload: json.RawMessage(`{"video_id":6,"title":"Clip","reason":"Contenido ajeno"}`), CreatedAt: published}},
+		owner: map[uint]uint{1: playerID},
+	}
+}
+
+func (r *notificationRepo) ListByUser(_ context.Context, userID uint, _, _ int) ([]responses.NotificationResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []responses.NotificationResponse
+	for id, n := range r.items {
+		if r.owner[id] == userID {
+			out = append(out, *n)
+		}
+	}
+	return out, nil
+}
+
+func (r *notificationRepo) MarkRead(_ context.Context, userID, notificationID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n, ok := r.items[notificationID]
+	if !ok || r.owner[notificationID] != userID {
+		return domain.ErrNotFound
+	}
+	if n.ReadAt == nil {
+		read := published
+		n.ReadAt = &read
+	}
+	return nil
+}
@@ -34,6 +34,10 @@ func (f *fakePublicRepoList) Rankings(ctx context.Context, city *string, page, p
 	return nil, nil
 }
 
+func (f *fakePublicRepoList) VideoRankings(ctx context.Context, city *string, page, pageSize int) ([]responses.VideoRankingItem, error) {
+	return nil, nil
+}
+
 func (f *fakePublicRepoList) GetUsersBasicByIDs(ctx context.Context, ids []uint) ([]responses.UserBasic, error) {
 	return nil, nil
 }
@@ -1,5 +1,7 @@
 package mocks
 
+import "context"
+
 type MockMessagePublisher struct {
 	PublishFunc func(queue string, body []byte) error
 	CloseFunc   func() error
@@ -11,7 +13,7 @@ type PublishedMessage struct {
 	Body  []byte
 }
 
-func (m *MockMessagePublisher) Publish(queue string, body []byte) error {
+func (m *MockMessagePublisher) Publish(_ context.Context, queue string, body []byte) error {
 	m.Messages = append(m.Messages, PublishedMessage{Queue: queue, Body: body})
 	if m.PublishFunc != nil {
 		return m.PublishFunc(queue, body)
@@ -0,0 +1,218 @@
+package application_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCommentRepo struct {
+	comments  map[uint]*entities.Comment
+	listed    []responses.CommentResponse
+	lastAfter uint
+	lastLimit int
+	recent    int64
+	deletedID uint
+	updatedID uint
+	lastSince time.Time
+	lastMax   int
+	nextID    uint
+	// videoOwner responde VideoOwnerID; 0 simula un video inexistente
+	videoOwner uint
+}
+
+func newFakeCommentRepo() *fakeCommentRepo {
+	return &fakeCommentRepo{comments: map[uint]*entities.Comment{}, nextID: 100}
+}
+
+func (f *fakeCommentRepo) Create(ctx context.Context, c *entities.Comment) error {
+	f.nextID++
+	c.CommentID = f.nextID
+	f.comments[c.CommentID] = c
+	return nil
+}
+
+func (f *fakeCommentRepo) GetByID(ctx context.Context, id uint) (*entities.Comment, error) {
+	if c, ok := f.comments[id]; ok {
+		return c, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (f *fakeCommentRepo) List(ctx context.Context, videoID uint, parentID *uint, afterID uint, limit int) ([]responses.CommentResponse, error) {
+	f.lastAfter = afterID
+	f.lastLimit = limit
+	if len(f.listed) > limit {
+		return f.listed[:limit], nil
+	}
+	return f.listed, nil
+}
+
+func (f *fakeCommentRepo) UpdateBody(ctx context.Context, id uint, body string) error {
+	f.updatedID = id
+	return nil
+}
+
+func (f *fakeCommentRepo) Delete(ctx context.Context, id uint) error {
+	f.deletedID = id
+	return nil
+}
+
+func (f *fakeCommentRepo) CreateWithinLimit(ctx context.Context, c *entities.Comment, max int, since time.Time) error {
+	f.lastSince, f.lastMax = since, max
+	if f.recent >= int64(max) {
+		return domain.ErrRateLimited
+	}
+	return f.Create(ctx, c)
+}
+
+func (f *fakeCommentRepo) VideoOwnerID(ctx context.Context, videoID uint) (uint, error) {
+	if f.videoOwner == 0 {
+		return 0, domain.ErrNotFound
+	}
+	return f.videoOwner, nil
+}
+
+func ownedVideoRepo(owner uint) *mockPublicRepo {
+	return &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+		return &responses.PublicVideoResponse{VideoID: id, OwnerUserID: owner}, nil
+	}}
+}
+
+func uintPtr(v uint) *uint { return &v }
+
+func TestCommentUseCase_List_ReturnsNextCursor(t *testing.T) {
+	repo := newFakeCommentRepo()
+	for i := 5; i >= 1; i-- {
+		repo.listed = append(repo.listed, responses.CommentResponse{CommentID: uint(i)})
+	}
+	uc := useCase.NewCommentUseCase(ownedVideoRepo(1), repo)
+
+	page, err := uc.List(context.Background(), 1, nil, "", 2)
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, 3, repo.lastLimit)
+	if assert.NotNil(t, page.NextCursor) {
+		_, err := uc.List(context.Background(), 1, nil, *page.NextCursor, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4), repo.lastAfter)
+	}
+}
+
+func TestCommentUseCase_List_LastPageHasNoCursor(t *testing.T) {
+	repo := newFakeCommentRepo()
+	repo.listed = []responses.CommentResponse{{CommentID: 1}}
+	uc := useCase.NewCommentUseCase(ownedVideoRepo(1), repo)
+
+	page, err := uc.List(context.Background(), 1, nil, "", 20)
+	assert.NoError(t, err)
+	assert.Nil(t, page.NextCursor)
+}
+
+func TestCommentUseCase_List_InvalidCursor(t *testing.T) {
+	uc := useCase.NewCommentUseCase(ownedVideoRepo(1), newFakeCommentRepo())
+
+	_, err := uc.List(context.Background(), 1, nil, "%%%", 20)
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+}
+
+func TestCommentUseCase_Create_OneLevelOfReplies(t *testing.T) {
+	repo := newFakeCommentRepo()
+	repo.comments[1] = &entities.Comment{CommentID: 1, VideoID: 9}
+	repo.comments[2] = &entities.Comment{CommentID: 2, VideoID: 9, ParentID: uintPtr(1)}
+	repo.comments[3] = &entities.Comment{CommentID: 3, VideoID: 8}
+	uc := useCase.NewCommentUseCase(ownedVideoRepo(1), repo)
+
+	reply, err := uc.Create(context.Background(), 7, 9, uintPtr(1), " hola ")
+	assert.NoError(t, err)
+	assert.Equal(t, "hola", reply.Body)
+
+	_, err = uc.Create(context.Background(), 7, 9, uintPtr(2), "reply to reply")
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	_, err = uc.Create(context.Background(), 7, 9, uintPtr(3), "other video")
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	_, err = uc.Create(context.Background(), 7, 9, uintPtr(99), "missing parent")
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+}
+
+func TestCommentUseCase_Create_RateLimited(t *testing.T) {
+	repo := newFakeCommentRepo()
+	repo.recent = 3
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	uc := useCase.NewCommentUseCase(ownedVideoRepo(1), repo).
+		WithRateLimit(3, 30*time.Second).
+		WithClock(func() time.Time { return now })
+
+	_, err := uc.Create(context.Background(), 7, 9, nil, "spam")
+	assert.ErrorIs(t, err, domain.ErrRateLimited)
+	assert.Equal(t, now.Add(-30*time.Second), repo.lastSince)
+	assert.Equal(t, 3, repo.lastMax)
+	assert.Empty(t, repo.comments)
+}
+
+func TestCommentUseCase_Create_RejectsBlankAndLongBodies(t *testing.T) {
+	uc := useCase.NewCommentUseCase(ownedVideoRepo(1), newFakeCommentRepo())
+
+	_, err := uc.Create(context.Background(), 7, 9, nil, "   ")
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	long := fmt.Sprintf("%0*d", useCase.MaxCommentLength+1, 0)
+	_, err = uc.Create(context.Background(), 7, 9, nil, long)
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+}
+
+func TestCommentUseCase_Update_AuthorOnly(t *testing.T) {
+	repo := newFakeCommentRepo()
+	repo.comments[1] = &entities.Comment{CommentID: 1, VideoID: 9, UserID: 7}
+	uc := useCase.NewCommentUseCase(ownedVideoRepo(1), repo)
+
+	assert.ErrorIs(t, uc.Update(context.Background(), 8, 9, 1, "edit"), domain.ErrForbidden)
+	assert.NoError(t, uc.Update(context.Background(), 7, 9, 1, "edit"))
+	assert.Equal(t, uint(1), repo.updatedID)
+	assert.ErrorIs(t, uc.Update(context.Background(), 7, 10, 1, "edit"), domain.ErrNotFound)
+}
+
+func TestCommentUseCase_Delete_Permissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      uint
+		canModerate bool
+		wantErr     error
+	}{
+		{"author", 7, false, nil},
+		{"video owner", 1, false, nil},
+		{"moderator", 50, true, nil},
+		{"other user", 50, false, domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeCommentRepo()
+			repo.comments[1] = &entities.Comment{CommentID: 1, VideoID: 9, UserID: 7}
+			repo.videoOwner = 1
+			// El video ya no es publico (p.ej. retirado): el propietario igual puede borrar
+			hidden := &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+				return nil, domain.ErrNotFound
+			}}
+			uc := useCase.NewCommentUseCase(hidden, repo)
+
+			err := uc.Delete(context.Background(), tt.userID, 9, 1, tt.canModerate)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Zero(t, repo.deletedID)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), repo.deletedID)
+		})
+	}
+}
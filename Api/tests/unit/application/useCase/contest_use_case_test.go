@@ -0,0 +1,177 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	usecase "api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeContestRepo struct {
+	contests map[uint]*entities.Contest
+	// videoContest asigna videos a concursos para GetForVideo
+	videoContest map[uint]uint
+	created      *entities.Contest
+	rankingCalls int
+}
+
+func newFakeContestRepo() *fakeContestRepo {
+	return &fakeContestRepo{contests: map[uint]*entities.Contest{}, videoContest: map[uint]uint{}}
+}
+
+func (f *fakeContestRepo) Create(ctx context.Context, contest *entities.Contest) error {
+	contest.ContestID = uint(len(f.contests) + 1)
+	f.contests[contest.ContestID] = contest
+	f.created = contest
+	return nil
+}
+
+func (f *fakeContestRepo) GetByID(ctx context.Context, contestID uint) (*entities.Contest, error) {
+	if ct, ok := f.contests[contestID]; ok {
+		return ct, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (f *fakeContestRepo) List(ctx context.Context) ([]entities.Contest, error) {
+	out := make([]entities.Contest, 0, len(f.contests))
+	for _, ct := range f.contests {
+		out = append(out, *ct)
+	}
+	return out, nil
+}
+
+func (f *fakeContestRepo) GetForVideo(ctx context.Context, videoID uint) (*entities.Contest, error) {
+	id, ok := f.videoContest[videoID]
+	if !ok {
+		return nil, nil
+	}
+	return f.contests[id], nil
+}
+
+func (f *fakeContestRepo) Close(ctx context.Context, contestID uint) (*entities.Contest, error) {
+	ct, ok := f.contests[contestID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	if ct.ClosedAt != nil {
+		return nil, domain.ErrConflict
+	}
+	now := time.Now()
+	ct.ClosedAt = &now
+	return ct, nil
+}
+
+func (f *fakeContestRepo) Rankings(ctx context.Context, contestID uint, city *string, page, pageSize int) ([]responses.RankingItem, error) {
+	f.rankingCalls++
+	return []responses.RankingItem{{Username: "ana", Votes: 3}}, nil
+}
+
+func validContestInput() usecase.ContestInput {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	return usecase.ContestInput{
+		Name:               "  Temporada 1 ",
+		SubmissionStartsAt: start,
+		SubmissionEndsAt:   start.AddDate(0, 0, 14),
+		VotingStartsAt:     start.AddDate(0, 0, 7),
+		VotingEndsAt:       start.AddDate(0, 0, 21),
+	}
+}
+
+func TestContestUseCase_Create(t *testing.T) {
+	repo := newFakeContestRepo()
+	uc := usecase.NewContestUseCase(repo)
+
+	contest, err := uc.Create(context.Background(), validContestInput())
+	require.NoError(t, err)
+	assert.Equal(t, "Temporada 1", contest.Name)
+	assert.Equal(t, uint(1), contest.ContestID)
+}
+
+func TestContestUseCase_Create_InvalidWindows(t *testing.T) {
+	tests := map[string]func(in *usecase.ContestInput){
+		"blank name":                 func(in *usecase.ContestInput) { in.Name = "  " },
+		"submission ends first":      func(in *usecase.ContestInput) { in.SubmissionEndsAt = in.SubmissionStartsAt },
+		"voting ends first":          func(in *usecase.ContestInput) { in.VotingEndsAt = in.VotingStartsAt.Add(-time.Hour) },
+		"voting before submissions":  func(in *usecase.ContestInput) { in.VotingStartsAt = in.SubmissionStartsAt.Add(-time.Hour) },
+		"voting ends before entries": func(in *usecase.ContestInput) { in.VotingEndsAt = in.SubmissionEndsAt.Add(-time.Hour) },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := newFakeContestRepo()
+			in := validContestInput()
+			mutate(&in)
+			_, err := usecase.NewContestUseCase(repo).Create(context.Background(), in)
+			assert.ErrorIs(t, err, domain.ErrInvalid)
+			assert.Nil(t, repo.created)
+		})
+	}
+}
+
+func TestContestUseCase_Rankings_UnknownContest(t *testing.T) {
+	repo := newFakeContestRepo()
+	_, err := usecase.NewContestUseCase(repo).Rankings(context.Background(), 9, nil, 1, 20)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Zero(t, repo.rankingCalls)
+}
+
+func TestContestUseCase_Close_Twice(t *testing.T) {
+	repo := newFakeContestRepo()
+	uc := usecase.NewContestUseCase(repo)
+	_, err := uc.Create(context.Background(), validContestInput())
+	require.NoError(t, err)
+
+	_, err = uc.Close(context.Background(), 1)
+	require.NoError(t, err)
+	_, err = uc.Close(context.Background(), 1)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
+func TestPublicService_VotePublicVideo_VotingWindow(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	closedAt := now.Add(-time.Hour)
+	contests := newFakeContestRepo()
+	contests.contests[1] = &entities.Contest{ContestID: 1, VotingStartsAt: now.Add(-time.Hour), VotingEndsAt: now.Add(time.Hour)}
+	contests.contests[2] = &entities.Contest{ContestID: 2, VotingStartsAt: now.Add(time.Hour), VotingEndsAt: now.Add(2 * time.Hour)}
+	contests.contests[3] = &entities.Contest{ContestID: 3, VotingStartsAt: now.Add(-2 * time.Hour), VotingEndsAt: now}
+	contests.contests[4] = &entities.Contest{ContestID: 4, VotingStartsAt: now.Add(-time.Hour), VotingEndsAt: now.Add(time.Hour), ClosedAt: &closedAt}
+	contests.videoContest = map[uint]uint{10: 1, 20: 2, 30: 3, 40: 4}
+
+	repo := &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+		return &responses.PublicVideoResponse{VideoID: id}, nil
+	}}
+	svc := usecase.NewPublicService(repo, &mockVoteRepo{}).
+		WithContests(contests).
+		WithClock(func() time.Time { return now })
+
+	tests := []struct {
+		name    string
+		videoID uint
+		want    error
+	}{
+		{"no contest", 5, nil},
+		{"window open", 10, nil},
+		{"not started", 20, domain.ErrVotingClosed},
+		{"ended (exclusive end)", 30, domain.ErrVotingClosed},
+		{"closed early", 40, domain.ErrVotingClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.VotePublicVideo(context.Background(), tt.videoID, 1)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.want)
+			evt := "evt"
+			assert.ErrorIs(t, svc.VotePublicVideoWithEvent(context.Background(), tt.videoID, 1, &evt), tt.want)
+		})
+	}
+}
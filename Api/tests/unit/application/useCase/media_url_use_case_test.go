@@ -0,0 +1,80 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	usecase "api/internal/application/useCase"
+	"api/internal/domain/entities"
+	"api/internal/domain/interfaces"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMediaSigner struct {
+	err   error
+	calls []string
+}
+
+func (f *fakeMediaSigner) SignURL(ctx context.Context, kind interfaces.MediaKind, objectKey string) (string, error) {
+	f.calls = append(f.calls, string(kind)+":"+objectKey)
+	if f.err != nil {
+		return "", f.err
+	}
+	return "https://signed/" + string(kind) + "/" + objectKey + "?sig=x", nil
+}
+
+func TestMediaURLService_OwnerURLs_SignsForOwner(t *testing.T) {
+	signer := &fakeMediaSigner{}
+	svc := usecase.NewMediaURLService(signer, "")
+	processed := "final.mp4"
+	v := &entities.Video{UserID: 7, OriginalFile: "raw.mp4", ProcessedFile: &processed, Status: string(entities.StatusPublished)}
+
+	orig, proc := svc.OwnerURLs(context.Background(), 7, v)
+	if assert.NotNil(t, orig) && assert.NotNil(t, proc) {
+		assert.Equal(t, "https://signed/original/raw.mp4?sig=x", *orig)
+		assert.Equal(t, "https://signed/processed/final.mp4?sig=x", *proc)
+	}
+}
+
+func TestMediaURLService_OwnerURLs_DeniesOtherUsers(t *testing.T) {
+	signer := &fakeMediaSigner{}
+	svc := usecase.NewMediaURLService(signer, "")
+	v := &entities.Video{UserID: 7, OriginalFile: "raw.mp4"}
+
+	orig, proc := svc.OwnerURLs(context.Background(), 8, v)
+	assert.Nil(t, orig)
+	assert.Nil(t, proc)
+	assert.Empty(t, signer.calls)
+}
+
+func TestMediaURLService_OwnerURLs_HidesProcessedUntilReady(t *testing.T) {
+	svc := usecase.NewMediaURLService(&fakeMediaSigner{}, "")
+	processed := "partial.mp4"
+	v := &entities.Video{UserID: 7, OriginalFile: "raw.mp4", ProcessedFile: &processed, Status: string(entities.StatusAddingWatermark)}
+
+	orig, proc := svc.OwnerURLs(context.Background(), 7, v)
+	assert.NotNil(t, orig)
+	assert.Nil(t, proc)
+}
+
+func TestMediaURLService_SignerErrorOmitsURL(t *testing.T) {
+	svc := usecase.NewMediaURLService(&fakeMediaSigner{err: errors.New("boom")}, "")
+	key := "final.mp4"
+	assert.Nil(t, svc.PublicURL(context.Background(), &key))
+}
+
+func TestMediaURLService_UnsignedFallback(t *testing.T) {
+	svc := usecase.NewMediaURLService(nil, "https://cdn.example.com/%s")
+	key := "final.mp4"
+	legacy := "https://old.example.com/final.mp4"
+
+	if got := svc.PublicURL(context.Background(), &key); assert.NotNil(t, got) {
+		assert.Equal(t, "https://cdn.example.com/final.mp4", *got)
+	}
+	if got := svc.PublicURL(context.Background(), &legacy); assert.NotNil(t, got) {
+		assert.Equal(t, legacy, *got)
+	}
+	assert.Nil(t, svc.PublicURL(context.Background(), nil))
+}
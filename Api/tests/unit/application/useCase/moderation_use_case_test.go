@@ -0,0 +1,114 @@
+package application_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"api/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeModerationRepo struct {
+	video       *entities.Video
+	err         error
+	rejectCalls int
+	lastReason  string
+}
+
+func (f *fakeModerationRepo) ListPending(ctx context.Context, page, pageSize int) ([]responses.ModerationQueueItem, error) {
+	return []responses.ModerationQueueItem{{VideoID: 1, Title: "t"}}, f.err
+}
+
+func (f *fakeModerationRepo) Approve(ctx context.Context, videoID, moderatorID uint) (*entities.Video, error) {
+	return f.video, f.err
+}
+
+func (f *fakeModerationRepo) Reject(ctx context.Context, videoID, moderatorID uint, reason string) (*entities.Video, error) {
+	f.rejectCalls++
+	f.lastReason = reason
+	return f.video, f.err
+}
+
+func TestModerationUseCase_Reject_TrimsReason(t *testing.T) {
+	repo := &fakeModerationRepo{video: &entities.Video{VideoID: 5, UserID: 9, Title: "Clip"}}
+	uc := useCase.NewModerationUseCase(repo)
+
+	err := uc.Reject(context.Background(), 1, 5, "  contenido inapropiado ")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, repo.rejectCalls)
+	assert.Equal(t, "contenido inapropiado", repo.lastReason)
+}
+
+func TestModerationUseCase_Reject_RequiresReason(t *testing.T) {
+	repo := &fakeModerationRepo{}
+	uc := useCase.NewModerationUseCase(repo)
+
+	err := uc.Reject(context.Background(), 1, 5, "   ")
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	err = uc.Reject(context.Background(), 1, 5, strings.Repeat("x", useCase.MaxRejectionReasonLength+1))
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+	assert.Equal(t, 0, repo.rejectCalls)
+}
+
+func TestModerationUseCase_Reject_NotPending(t *testing.T) {
+	repo := &fakeModerationRepo{err: domain.ErrConflict}
+	uc := useCase.NewModerationUseCase(repo)
+
+	err := uc.Reject(context.Background(), 1, 5, "motivo")
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
+func TestUploadsUseCase_PublishVideo_SubmitsForReview(t *testing.T) {
+	processed := "final.mp4"
+	var updated entities.VideoStatus
+	repo := &mocks.MockVideoRepository{
+		GetByIDAndUserFunc: func(ctx context.Context, id, userID uint) (*entities.Video, error) {
+			return &entities.Video{VideoID: id, UserID: userID, Status: string(entities.StatusProcessed), ProcessedFile: &processed}, nil
+		},
+		UpdateStatusFunc: func(ctx context.Context, id uint, status entities.VideoStatus) error {
+			updated = status
+			return nil
+		},
+	}
+	uc := useCase.NewUploadsUseCase(repo, &mocks.MockVideoStorage{}, nil, "")
+
+	assert.NoError(t, uc.PublishVideo(context.Background(), 10, 1))
+	assert.Equal(t, entities.StatusPendingReview, updated)
+}
+
+func TestUploadsUseCase_PublishVideo_RejectsNonOwner(t *testing.T) {
+	repo := &mocks.MockVideoRepository{
+		GetByIDAndUserFunc: func(ctx context.Context, id, userID uint) (*entities.Video, error) {
+			return nil, domain.ErrForbidden
+		},
+		UpdateStatusFunc: func(ctx context.Context, id uint, status entities.VideoStatus) error {
+			t.Fatal("status must not change for non-owners")
+			return nil
+		},
+	}
+	uc := useCase.NewUploadsUseCase(repo, &mocks.MockVideoStorage{}, nil, "")
+
+	assert.ErrorIs(t, uc.PublishVideo(context.Background(), 11, 1), domain.ErrForbidden)
+}
+
+func TestUploadsUseCase_PublishVideo_IdempotentWhenPending(t *testing.T) {
+	repo := &mocks.MockVideoRepository{
+		GetByIDAndUserFunc: func(ctx context.Context, id, userID uint) (*entities.Video, error) {
+			return &entities.Video{VideoID: id, UserID: userID, Status: string(entities.StatusPendingReview)}, nil
+		},
+		UpdateStatusFunc: func(ctx context.Context, id uint, status entities.VideoStatus) error {
+			t.Fatal("status must not change when already pending")
+			return nil
+		},
+	}
+	uc := useCase.NewUploadsUseCase(repo, &mocks.MockVideoStorage{}, nil, "")
+
+	assert.NoError(t, uc.PublishVideo(context.Background(), 10, 1))
+}
@@ -3,10 +3,13 @@ package application_test
 import (
 	usecase "api/internal/application/useCase"
 	"api/internal/domain"
+	"api/internal/domain/entities"
 	"api/internal/domain/responses"
 	"context"
+	"encoding/base64"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,6 +18,7 @@ type mockPublicRepo struct {
 	ListFunc     func(ctx context.Context) ([]responses.PublicVideoResponse, error)
 	GetByIDFunc  func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error)
 	RankingsFunc func(ctx context.Context, city *string, page, pageSize int) ([]responses.RankingItem, error)
+	VideoRanksFn func(ctx context.Context, city *string, page, pageSize int) ([]responses.VideoRankingItem, error)
 }
 
 func (m *mockPublicRepo) ListPublicVideos(ctx context.Context) ([]responses.PublicVideoResponse, error) {
@@ -36,6 +40,13 @@ func (m *mockPublicRepo) Rankings(ctx context.Context, city *string, page, pageS
 	return nil, nil
 }
 
+func (m *mockPublicRepo) VideoRankings(ctx context.Context, city *string, page, pageSize int) ([]responses.VideoRankingItem, error) {
+	if m.VideoRanksFn != nil {
+		return m.VideoRanksFn(ctx, city, page, pageSize)
+	}
+	return nil, nil
+}
+
 // Satisfy new interface method; not used in these unit tests
 func (m *mockPublicRepo) GetUsersBasicByIDs(ctx context.Context, ids []uint) ([]responses.UserBasic, error) {
 	return []responses.UserBasic{}, nil
@@ -150,3 +161,275 @@ func TestPublicService_List_Get_Rankings_Delegation(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, r, 1)
 }
+
+// mockFilteredPublicRepo agrega FilteredRankings a mockPublicRepo.
+type mockFilteredPublicRepo struct {
+	mockPublicRepo
+	filters []entities.RankingFilter
+}
+
+func (m *mockFilteredPublicRepo) FilteredRankings(ctx context.Context, filter entities.RankingFilter, page, pageSize int) ([]responses.RankingItem, error) {
+	m.filters = append(m.filters, filter)
+	return []responses.RankingItem{{Username: "weekly", Votes: 2}}, nil
+}
+
+func TestPublicService_FilteredRankings_AllTimeUsesRankings(t *testing.T) {
+	var gotCity *string
+	repo := &mockFilteredPublicRepo{mockPublicRepo: mockPublicRepo{
+		RankingsFunc: func(ctx context.Context, city *string, page, pageSize int) ([]responses.RankingItem, error) {
+			gotCity = city
+			return []responses.RankingItem{{Username: "alice", Votes: 3}}, nil
+		},
+	}}
+	svc := usecase.NewPublicService(repo, nil)
+
+	city := "Cali"
+	r, err := svc.FilteredRankings(context.Background(), entities.RankingFilter{City: &city, Window: entities.RankingWindowAll}, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", r[0].Username)
+	assert.Equal(t, &city, gotCity)
+	assert.Empty(t, repo.filters)
+}
+
+func TestPublicService_FilteredRankings_WindowAndCountry(t *testing.T) {
+	repo := &mockFilteredPublicRepo{}
+	svc := usecase.NewPublicService(repo, nil)
+
+	country := "Colombia"
+	r, err := svc.FilteredRankings(context.Background(), entities.RankingFilter{Country: &country, Window: entities.RankingWindowWeek}, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "weekly", r[0].Username)
+	if assert.Len(t, repo.filters, 1) {
+		assert.Equal(t, entities.RankingWindowWeek, repo.filters[0].Window)
+		assert.Equal(t, &country, repo.filters[0].Country)
+	}
+}
+
+func TestPublicService_FilteredRankings_NotSupported(t *testing.T) {
+	svc := usecase.NewPublicService(&mockPublicRepo{}, nil)
+	_, err := svc.FilteredRankings(context.Background(), entities.RankingFilter{Window: entities.RankingWindowDay}, 1, 10)
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+}
+
+// mockRetractVoteRepo agrega Retract a mockVoteRepo.
+type mockRetractVoteRepo struct {
+	mockVoteRepo
+	RetractFunc func(ctx context.Context, videoID, userID uint, eventID *string) error
+}
+
+func (m *mockRetractVoteRepo) Retract(ctx context.Context, videoID, userID uint, eventID *string) error {
+	return m.RetractFunc(ctx, videoID, userID, eventID)
+}
+
+func TestPublicService_RetractVote_NotSupported(t *testing.T) {
+	svc := usecase.NewPublicService(&mockPublicRepo{}, &mockVoteRepo{})
+	err := svc.RetractVote(context.Background(), 1, 2, nil)
+	assert.Error(t, err)
+}
+
+func TestPublicService_RetractVote_NotFound(t *testing.T) {
+	repo := &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+		return nil, domain.ErrNotFound
+	}}
+	votes := &mockRetractVoteRepo{RetractFunc: func(ctx context.Context, videoID, userID uint, eventID *string) error {
+		t.Fatal("Retract should not be called for a missing video")
+		return nil
+	}}
+	svc := usecase.NewPublicService(repo, votes)
+
+	err := svc.RetractVote(context.Background(), 1, 2, nil)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestPublicService_RetractVote_DelegatesWithEvent(t *testing.T) {
+	repo := &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+		return &responses.PublicVideoResponse{VideoID: id}, nil
+	}}
+	var gotEvent *string
+	votes := &mockRetractVoteRepo{RetractFunc: func(ctx context.Context, videoID, userID uint, eventID *string) error {
+		assert.Equal(t, uint(10), videoID)
+		assert.Equal(t, uint(20), userID)
+		gotEvent = eventID
+		return domain.ErrIdempotent
+	}}
+	svc := usecase.NewPublicService(repo, votes)
+
+	evt := "evt-1"
+	err := svc.RetractVote(context.Background(), 10, 20, &evt)
+	assert.ErrorIs(t, err, domain.ErrIdempotent)
+	if assert.NotNil(t, gotEvent) {
+		assert.Equal(t, "evt-1", *gotEvent)
+	}
+}
+
+// mockGalleryPublicRepo agrega SearchPublicVideos a mockPublicRepo; devuelve los videos de
+// rows posteriores a q.After (ya ordenados) hasta q.Limit.
+type mockGalleryPublicRepo struct {
+	mockPublicRepo
+	rows    []responses.GalleryVideo
+	queries []entities.GalleryQuery
+}
+
+func (m *mockGalleryPublicRepo) SearchPublicVideos(ctx context.Context, q entities.GalleryQuery) ([]responses.GalleryVideo, error) {
+	m.queries = append(m.queries, q)
+	out := []responses.GalleryVideo{}
+	for _, r := range m.rows {
+		if q.After != nil && (int64(r.Votes) > q.After.Key || (int64(r.Votes) == q.After.Key && r.VideoID >= q.After.VideoID)) {
+			continue
+		}
+		if len(out) == q.Limit {
+			break
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func galleryRow(id uint, votes int) responses.GalleryVideo {
+	return responses.GalleryVideo{PublicVideoResponse: responses.PublicVideoResponse{VideoID: id, Votes: votes}}
+}
+
+func TestPublicService_SearchPublicVideos_CursorPages(t *testing.T) {
+	repo := &mockGalleryPublicRepo{rows: []responses.GalleryVideo{
+		galleryRow(7, 9), galleryRow(5, 4), galleryRow(3, 4), galleryRow(2, 1),
+	}}
+	svc := usecase.NewPublicService(repo, nil)
+	q := entities.GalleryQuery{Sort: entities.GallerySortMostVoted, Limit: 2}
+
+	first, err := svc.SearchPublicVideos(context.Background(), q, "")
+	assert.NoError(t, err)
+	if assert.Len(t, first.Items, 2) && assert.NotNil(t, first.NextCursor) {
+		assert.Equal(t, uint(5), first.Items[1].VideoID)
+	}
+	// Se pide un elemento extra para detectar la pagina siguiente
+	assert.Equal(t, 3, repo.queries[0].Limit)
+
+	second, err := svc.SearchPublicVideos(context.Background(), q, *first.NextCursor)
+	assert.NoError(t, err)
+	if assert.Len(t, second.Items, 2) {
+		assert.Equal(t, uint(3), second.Items[0].VideoID)
+		assert.Equal(t, uint(2), second.Items[1].VideoID)
+	}
+	assert.Nil(t, second.NextCursor)
+	if assert.NotNil(t, repo.queries[1].After) {
+		assert.Equal(t, int64(4), repo.queries[1].After.Key)
+		assert.Equal(t, uint(5), repo.queries[1].After.VideoID)
+	}
+
+	// El cursor queda atado al orden con el que se emitio
+	_, err = svc.SearchPublicVideos(context.Background(), entities.GalleryQuery{Sort: entities.GallerySortTrending}, *first.NextCursor)
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+	_, err = svc.SearchPublicVideos(context.Background(), q, "%%%")
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+}
+
+func TestPublicService_SearchPublicVideos_TrendingWindowPinnedByCursor(t *testing.T) {
+	rows := []responses.GalleryVideo{galleryRow(7, 9), galleryRow(5, 4), galleryRow(3, 4)}
+	for i := range rows {
+		rows[i].TrendingVotes = rows[i].Votes
+	}
+	repo := &mockGalleryPublicRepo{rows: rows}
+	now := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+	svc := usecase.NewPublicService(repo, nil).WithClock(func() time.Time { return now })
+	q := entities.GalleryQuery{Sort: entities.GallerySortTrending, Limit: 2}
+
+	first, err := svc.SearchPublicVideos(context.Background(), q, "")
+	assert.NoError(t, err)
+	if !assert.NotNil(t, first.NextCursor) {
+		return
+	}
+	start := now.Add(-entities.GalleryTrendingWindow)
+	assert.Equal(t, start, repo.queries[0].TrendingSince)
+
+	// Horas despues la segunda pagina sigue contando desde el inicio de la primera
+	now = now.Add(5 * time.Hour)
+	_, err = svc.SearchPublicVideos(context.Background(), q, *first.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, start, repo.queries[1].TrendingSince)
+	if assert.NotNil(t, repo.queries[1].After) {
+		assert.Equal(t, int64(4), repo.queries[1].After.Key)
+	}
+
+	// Un cursor sin inicio de ventana se rechaza
+	noWindow := base64.RawURLEncoding.EncodeToString([]byte("trending:4:5"))
+	_, err = svc.SearchPublicVideos(context.Background(), q, noWindow)
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+}
+
+func TestPublicService_SearchPublicVideos_NotSupported(t *testing.T) {
+	repo := &mockPublicRepo{ListFunc: func(ctx context.Context) ([]responses.PublicVideoResponse, error) {
+		return []responses.PublicVideoResponse{{VideoID: 1}}, nil
+	}}
+	svc := usecase.NewPublicService(repo, nil)
+
+	page, err := svc.SearchPublicVideos(context.Background(), entities.GalleryQuery{}, "")
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Nil(t, page.NextCursor)
+
+	_, err = svc.SearchPublicVideos(context.Background(), entities.GalleryQuery{Search: "gol"}, "")
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+}
+
+// mockVotedLookupRepo agrega VotedVideoIDs a mockVoteRepo.
+type mockVotedLookupRepo struct {
+	mockVoteRepo
+	voted   map[uint]bool
+	lookups [][]uint
+}
+
+func (m *mockVotedLookupRepo) VotedVideoIDs(ctx context.Context, userID uint, videoIDs []uint) (map[uint]bool, error) {
+	m.lookups = append(m.lookups, videoIDs)
+	return m.voted, nil
+}
+
+func TestPublicService_MarkVotedByMe_SingleLookup(t *testing.T) {
+	votes := &mockVotedLookupRepo{
+		mockVoteRepo: mockVoteRepo{HasUserVotedFunc: func(ctx context.Context, videoID, userID uint) (bool, error) {
+			t.Fatalf("HasUserVoted should not be called when batch lookup is available")
+			return false, nil
+		}},
+		voted: map[uint]bool{2: true},
+	}
+	svc := usecase.NewPublicService(&mockPublicRepo{}, votes)
+
+	videos := []responses.PublicVideoResponse{{VideoID: 1}, {VideoID: 2}, {VideoID: 3}}
+	assert.NoError(t, svc.MarkVotedByMe(context.Background(), 5, videos))
+	assert.Equal(t, [][]uint{{1, 2, 3}}, votes.lookups)
+	for _, v := range videos {
+		if assert.NotNil(t, v.VotedByMe) {
+			assert.Equal(t, v.VideoID == 2, *v.VotedByMe)
+		}
+	}
+
+	// Anonimo: no consulta ni marca
+	anon := []responses.PublicVideoResponse{{VideoID: 1}}
+	assert.NoError(t, svc.MarkVotedByMe(context.Background(), 0, anon))
+	assert.Nil(t, anon[0].VotedByMe)
+	assert.Len(t, votes.lookups, 1)
+}
+
+func TestPublicService_PublicVideoDetail(t *testing.T) {
+	repo := &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+		if id != 4 {
+			return nil, domain.ErrNotFound
+		}
+		return &responses.PublicVideoResponse{VideoID: 4, Title: "Clavada", OwnerUserID: 9}, nil
+	}}
+	votes := &mockVotedLookupRepo{voted: map[uint]bool{4: true}}
+	svc := usecase.NewPublicService(repo, votes)
+
+	d, err := svc.PublicVideoDetail(context.Background(), 4, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "Clavada", d.Title)
+	if assert.NotNil(t, d.VotedByMe) {
+		assert.True(t, *d.VotedByMe)
+	}
+
+	d, err = svc.PublicVideoDetail(context.Background(), 4, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, d.VotedByMe)
+
+	_, err = svc.PublicVideoDetail(context.Background(), 8, 5)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,99 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeReportRepo struct {
+	created   []*entities.VideoReport
+	threshold int
+	createErr error
+	dismissed uint
+}
+
+func (f *fakeReportRepo) Create(ctx context.Context, report *entities.VideoReport, hideThreshold int) (bool, error) {
+	if f.createErr != nil {
+		return false, f.createErr
+	}
+	f.created = append(f.created, report)
+	f.threshold = hideThreshold
+	return len(f.created) >= hideThreshold, nil
+}
+
+func (f *fakeReportRepo) ListOpen(ctx context.Context, page, pageSize int) ([]responses.ReportResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeReportRepo) Dismiss(ctx context.Context, reportID, adminID uint, hideThreshold int) error {
+	f.dismissed = reportID
+	f.threshold = hideThreshold
+	return nil
+}
+
+func (f *fakeReportRepo) TakeDown(ctx context.Context, reportID, adminID uint) (uint, error) {
+	return 42, nil
+}
+
+func publicVideoRepo() *mockPublicRepo {
+	return &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+		return &responses.PublicVideoResponse{VideoID: id}, nil
+	}}
+}
+
+func TestReportUseCase_ReportVideo_NormalizesCategory(t *testing.T) {
+	repo := &fakeReportRepo{}
+	uc := useCase.NewReportUseCase(publicVideoRepo(), repo, 3)
+
+	err := uc.ReportVideo(context.Background(), 7, 1, " copyright ", "  copia de otro canal ")
+	assert.NoError(t, err)
+	if assert.Len(t, repo.created, 1) {
+		r := repo.created[0]
+		assert.Equal(t, string(entities.ReportCopyright), r.Category)
+		assert.Equal(t, uint(7), r.ReporterID)
+		if assert.NotNil(t, r.Details) {
+			assert.Equal(t, "copia de otro canal", *r.Details)
+		}
+	}
+	assert.Equal(t, 3, repo.threshold)
+}
+
+func TestReportUseCase_ReportVideo_InvalidCategory(t *testing.T) {
+	repo := &fakeReportRepo{}
+	uc := useCase.NewReportUseCase(publicVideoRepo(), repo, 0)
+
+	err := uc.ReportVideo(context.Background(), 7, 1, "boring", "")
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+	assert.Empty(t, repo.created)
+}
+
+func TestReportUseCase_ReportVideo_HiddenVideoNotFound(t *testing.T) {
+	public := &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+		return nil, domain.ErrNotFound
+	}}
+	uc := useCase.NewReportUseCase(public, &fakeReportRepo{}, 0)
+
+	assert.ErrorIs(t, uc.ReportVideo(context.Background(), 7, 1, "spam", ""), domain.ErrNotFound)
+}
+
+func TestReportUseCase_ReportVideo_Duplicate(t *testing.T) {
+	uc := useCase.NewReportUseCase(publicVideoRepo(), &fakeReportRepo{createErr: domain.ErrConflict}, 0)
+
+	assert.ErrorIs(t, uc.ReportVideo(context.Background(), 7, 1, "spam", ""), domain.ErrConflict)
+}
+
+func TestReportUseCase_Dismiss_UsesDefaultThreshold(t *testing.T) {
+	repo := &fakeReportRepo{}
+	uc := useCase.NewReportUseCase(publicVideoRepo(), repo, 0)
+
+	assert.NoError(t, uc.Dismiss(context.Background(), 1, 9))
+	assert.Equal(t, uint(9), repo.dismissed)
+	assert.Equal(t, useCase.DefaultReportHideThreshold, repo.threshold)
+}
@@ -20,8 +20,10 @@ func TestStatusService_ListVideoStatuses(t *testing.T) {
 		"REMOVING_AUDIO",
 		"ADDING_INTRO_OUTRO",
 		"PROCESSED",
+		"PENDING_REVIEW",
 		"PUBLISHED",
 		"FAILED",
+		"REJECTED",
 	}
 	assert.Equal(t, expected, got)
 }
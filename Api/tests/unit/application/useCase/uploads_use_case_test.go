@@ -4,6 +4,7 @@ import (
 	"api/internal/application/useCase"
 	"api/internal/domain"
 	"api/internal/domain/entities"
+	"api/internal/logging"
 	"api/tests/mocks"
 	"api/tests/testdata"
 	"bytes"
@@ -136,6 +137,36 @@ func TestUploadsUseCase_UploadMultipart(t *testing.T) {
 	}
 }
 
+func TestUploadsUseCase_UploadMultipart_PublishesCorrelationID(t *testing.T) {
+	repo := &mocks.MockVideoRepository{
+		CreateFunc: func(ctx context.Context, video *entities.Video) error {
+			video.VideoID = 123
+			return nil
+		},
+	}
+	storage := &mocks.MockVideoStorage{
+		SaveFunc: func(ctx context.Context, objectName string, reader io.Reader, size int64, contentType string) (string, error) {
+			return "https://example.com/video.mp4", nil
+		},
+	}
+	publisher := &mocks.MockMessagePublisher{}
+	uc := useCase.NewUploadsUseCase(repo, storage, publisher, "test-queue")
+
+	ctx := context.WithValue(context.Background(), useCase.UserIDContextKey, uint(1))
+	ctx = logging.WithRequestID(ctx, "req-abc")
+	_, err := uc.UploadMultipart(ctx, useCase.UploadVideoInput{
+		Title:      "Test Video",
+		FileHeader: createMockFileHeader("test.mp4", testdata.CreateValidMP4()),
+		Status:     string(entities.StatusUploaded),
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, publisher.Messages, 1) {
+		assert.Equal(t, "test-queue", publisher.Messages[0].Queue)
+		assert.JSONEq(t, `{"videoId":"123","correlation_id":"req-abc"}`, string(publisher.Messages[0].Body))
+	}
+}
+
 func TestUploadsUseCase_DeleteUserVideoIfEligible(t *testing.T) {
 	tests := []struct {
 		name     string
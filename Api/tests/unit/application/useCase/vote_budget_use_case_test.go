@@ -0,0 +1,127 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	usecase "api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBudgetVoteRepo agrega presupuestos e historial a mockVoteRepo.
+type fakeBudgetVoteRepo struct {
+	mockVoteRepo
+	policies  []entities.VoteBudgetPolicy
+	eventID   *string
+	budgetErr error
+	daily     int
+	byContest map[uint]int
+	since     *time.Time
+	history   []responses.UserVoteItem
+}
+
+func (f *fakeBudgetVoteRepo) CreateWithBudget(ctx context.Context, videoID, userID uint, eventID *string, policies []entities.VoteBudgetPolicy) error {
+	f.policies = policies
+	f.eventID = eventID
+	return f.budgetErr
+}
+
+func (f *fakeBudgetVoteRepo) CountVotes(ctx context.Context, userID uint, since *time.Time, contestID *uint) (int, error) {
+	if contestID != nil {
+		return f.byContest[*contestID], nil
+	}
+	f.since = since
+	return f.daily, nil
+}
+
+func (f *fakeBudgetVoteRepo) ListByUser(ctx context.Context, userID uint, page, pageSize int) ([]responses.UserVoteItem, error) {
+	return f.history, nil
+}
+
+func TestParseVoteBudgetPolicies(t *testing.T) {
+	policies, err := usecase.ParseVoteBudgetPolicies(" day:10, CONTEST:3 ,")
+	require.NoError(t, err)
+	assert.Equal(t, []entities.VoteBudgetPolicy{
+		{Scope: entities.VoteBudgetDaily, Limit: 10},
+		{Scope: entities.VoteBudgetContest, Limit: 3},
+	}, policies)
+
+	policies, err = usecase.ParseVoteBudgetPolicies("")
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+
+	for _, bad := range []string{"day", "day:0", "week:5", "day:x"} {
+		_, err := usecase.ParseVoteBudgetPolicies(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestPublicService_VoteWithBudget_UsesAtomicCreate(t *testing.T) {
+	repo := &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+		return &responses.PublicVideoResponse{VideoID: id}, nil
+	}}
+	votes := &fakeBudgetVoteRepo{budgetErr: domain.ErrVoteBudgetExceeded}
+	votes.CreateFunc = func(ctx context.Context, videoID, userID uint) error {
+		t.Fatal("Create must not bypass the budget")
+		return nil
+	}
+	policies := []entities.VoteBudgetPolicy{{Scope: entities.VoteBudgetDaily, Limit: 2}}
+	svc := usecase.NewPublicService(repo, votes).WithVoteBudget(policies)
+
+	evt := "evt-9"
+	err := svc.VotePublicVideoWithEvent(context.Background(), 1, 2, &evt)
+	assert.ErrorIs(t, err, domain.ErrVoteBudgetExceeded)
+	assert.Equal(t, policies, votes.policies)
+	require.NotNil(t, votes.eventID)
+	assert.Equal(t, "evt-9", *votes.eventID)
+
+	err = svc.VotePublicVideo(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, domain.ErrVoteBudgetExceeded)
+	assert.Nil(t, votes.eventID)
+}
+
+func TestPublicService_MyVotes(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC)
+	contests := newFakeContestRepo()
+	contests.contests[1] = &entities.Contest{ContestID: 1, VotingStartsAt: now.Add(-time.Hour), VotingEndsAt: now.Add(time.Hour)}
+	contests.contests[2] = &entities.Contest{ContestID: 2, VotingStartsAt: now.Add(time.Hour), VotingEndsAt: now.Add(2 * time.Hour)}
+	votes := &fakeBudgetVoteRepo{
+		daily:     7,
+		byContest: map[uint]int{1: 2},
+		history:   []responses.UserVoteItem{{VideoID: 4, Title: "Clip"}},
+	}
+	svc := usecase.NewPublicService(&mockPublicRepo{}, votes).
+		WithContests(contests).
+		WithVoteBudget([]entities.VoteBudgetPolicy{
+			{Scope: entities.VoteBudgetDaily, Limit: 5},
+			{Scope: entities.VoteBudgetContest, Limit: 3},
+		}).
+		WithClock(func() time.Time { return now })
+
+	out, err := svc.MyVotes(context.Background(), 9, 1, 20)
+	require.NoError(t, err)
+	require.Len(t, out.Budgets, 2)
+
+	daily := out.Budgets[0]
+	assert.Equal(t, "day", daily.Scope)
+	assert.Equal(t, 7, daily.Used)
+	assert.Equal(t, 0, daily.Remaining)
+	require.NotNil(t, votes.since)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), *votes.since)
+	require.NotNil(t, daily.ResetsAt)
+	assert.Equal(t, time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), *daily.ResetsAt)
+
+	contest := out.Budgets[1]
+	assert.Equal(t, "contest", contest.Scope)
+	require.NotNil(t, contest.ContestID)
+	assert.Equal(t, uint(1), *contest.ContestID)
+	assert.Equal(t, 1, contest.Remaining)
+
+	assert.Len(t, out.Votes, 1)
+}
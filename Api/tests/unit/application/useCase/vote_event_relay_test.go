@@ -0,0 +1,138 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "api/internal/application/useCase"
+	"api/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOutbox struct {
+	pending   []entities.OutboxMessage
+	published []uint64
+	failed    map[uint64]string
+	// prunable simula mensajes publicados vencidos; cutoffs guarda cada corte pedido
+	prunable int64
+	cutoffs  []time.Time
+}
+
+func (f *fakeOutbox) DeletePublishedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	f.cutoffs = append(f.cutoffs, before)
+	n := min(f.prunable, int64(limit))
+	f.prunable -= n
+	return n, nil
+}
+
+func (f *fakeOutbox) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]entities.OutboxMessage, error) {
+	return f.pending, nil
+}
+
+func (f *fakeOutbox) MarkPublished(ctx context.Context, ids []uint64) error {
+	f.published = append(f.published, ids...)
+	return nil
+}
+
+func (f *fakeOutbox) MarkFailed(ctx context.Context, id uint64, reason string) error {
+	if f.failed == nil {
+		f.failed = map[uint64]string{}
+	}
+	f.failed[id] = reason
+	return nil
+}
+
+type sentEvent struct {
+	exchange, key, id string
+}
+
+type fakeEventPublisher struct {
+	sent   []sentEvent
+	failOn string
+}
+
+func (f *fakeEventPublisher) Publish(ctx context.Context, queue string, body []byte) error {
+	return nil
+}
+func (f *fakeEventPublisher) Close() error { return nil }
+
+func (f *fakeEventPublisher) PublishEvent(ctx context.Context, exchange, routingKey, messageID string, body []byte) error {
+	if messageID == f.failOn {
+		return errors.New("broker unavailable")
+	}
+	f.sent = append(f.sent, sentEvent{exchange, routingKey, messageID})
+	return nil
+}
+
+func TestVoteEventRelay_PublishesInOrder(t *testing.T) {
+	outbox := &fakeOutbox{pending: []entities.OutboxMessage{
+		{ID: 7, RoutingKey: entities.VoteEventCast, Payload: []byte(`{}`)},
+		{ID: 8, RoutingKey: entities.VoteEventRetracted, Payload: []byte(`{}`)},
+	}}
+	pub := &fakeEventPublisher{}
+	n, err := usecase.NewVoteEventRelay(outbox, pub, "").RelayOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []sentEvent{
+		{usecase.DefaultVoteEventsExchange, entities.VoteEventCast, "7"},
+		{usecase.DefaultVoteEventsExchange, entities.VoteEventRetracted, "8"},
+	}, pub.sent)
+	assert.Equal(t, []uint64{7, 8}, outbox.published)
+	assert.Empty(t, outbox.failed)
+}
+
+func TestVoteEventRelay_StopsAtFirstFailure(t *testing.T) {
+	outbox := &fakeOutbox{pending: []entities.OutboxMessage{
+		{ID: 1, RoutingKey: entities.VoteEventCast},
+		{ID: 2, RoutingKey: entities.VoteEventCast},
+		{ID: 3, RoutingKey: entities.VoteEventCast},
+	}}
+	pub := &fakeEventPublisher{failOn: "2"}
+	n, err := usecase.NewVoteEventRelay(outbox, pub, "votes").RelayOnce(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []uint64{1}, outbox.published)
+	assert.Contains(t, outbox.failed[2], "broker unavailable")
+	assert.Contains(t, outbox.failed, uint64(3))
+	assert.Len(t, pub.sent, 1)
+}
+
+func TestVoteEventRelay_RunStopsOnCancel(t *testing.T) {
+	outbox := &fakeOutbox{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		usecase.NewVoteEventRelay(outbox, &fakeEventPublisher{}, "").WithPollInterval(time.Millisecond).Run(ctx)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay did not stop after cancel")
+	}
+}
+
+func TestVoteEventRelay_PruneOnceDeletesInBatches(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	outbox := &fakeOutbox{prunable: 2500}
+	n, err := usecase.NewVoteEventRelay(outbox, &fakeEventPublisher{}, "").
+		WithRetention(48 * time.Hour).
+		WithClock(func() time.Time { return now }).
+		PruneOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), n)
+	assert.Zero(t, outbox.prunable)
+	// Lotes de 1000: dos completos y uno parcial con el mismo corte
+	require.Len(t, outbox.cutoffs, 3)
+	for _, c := range outbox.cutoffs {
+		assert.Equal(t, now.Add(-48*time.Hour), c)
+	}
+}
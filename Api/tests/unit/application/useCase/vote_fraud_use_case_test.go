@@ -0,0 +1,157 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeVoteSignals struct {
+	createdAt  time.Time
+	accountErr error
+	userVotes  int
+	ipAccounts int
+	ipErr      error
+	since      time.Time
+}
+
+func (f *fakeVoteSignals) AccountCreatedAt(ctx context.Context, userID uint) (time.Time, error) {
+	return f.createdAt, f.accountErr
+}
+
+func (f *fakeVoteSignals) CountUserVotesSince(ctx context.Context, userID uint, since time.Time) (int, error) {
+	f.since = since
+	return f.userVotes, nil
+}
+
+func (f *fakeVoteSignals) CountOtherAccountsByIPSince(ctx context.Context, ip string, userID uint, since time.Time) (int, error) {
+	return f.ipAccounts, f.ipErr
+}
+
+// fakeAuditVoteRepo agrega CreateWithAudit a fakeBudgetVoteRepo.
+type fakeAuditVoteRepo struct {
+	fakeBudgetVoteRepo
+	audit    *entities.VoteAudit
+	auditErr error
+}
+
+func (f *fakeAuditVoteRepo) CreateWithAudit(ctx context.Context, videoID, userID uint, eventID *string, audit entities.VoteAudit, policies []entities.VoteBudgetPolicy) error {
+	f.audit = &audit
+	f.eventID = eventID
+	f.policies = policies
+	return f.auditErr
+}
+
+var fraudNow = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestVoteFraudDetector_FlagsEachRule(t *testing.T) {
+	signals := &fakeVoteSignals{createdAt: fraudNow.Add(-time.Hour), userVotes: 10, ipAccounts: 3}
+	detector := usecase.NewVoteFraudDetector(signals, usecase.DefaultVoteRules()...)
+
+	meta := entities.VoteMetadata{IP: "10.0.0.1"}
+	audit := detector.Evaluate(context.Background(), 1, 2, meta, fraudNow)
+
+	assert.Equal(t, []string{usecase.VoteRuleVelocity, usecase.VoteRuleSharedIP, usecase.VoteRuleNewAccount}, audit.Flags)
+	assert.True(t, audit.Quarantined())
+	assert.Equal(t, int64(3600), audit.AccountAgeSeconds)
+	assert.Equal(t, meta, audit.Metadata)
+	assert.Equal(t, fraudNow.Add(-usecase.DefaultVoteVelocityWindow), signals.since)
+}
+
+func TestVoteFraudDetector_CleanVote(t *testing.T) {
+	signals := &fakeVoteSignals{createdAt: fraudNow.Add(-30 * 24 * time.Hour), userVotes: 9, ipAccounts: 2}
+	detector := usecase.NewVoteFraudDetector(signals, usecase.DefaultVoteRules()...)
+
+	audit := detector.Evaluate(context.Background(), 1, 2, entities.VoteMetadata{IP: "10.0.0.1"}, fraudNow)
+	assert.Empty(t, audit.Flags)
+	assert.False(t, audit.Quarantined())
+}
+
+func TestVoteFraudDetector_FailsOpenOnSignalErrors(t *testing.T) {
+	signals := &fakeVoteSignals{accountErr: errors.New("db down"), ipErr: errors.New("db down"), ipAccounts: 50}
+	detector := usecase.NewVoteFraudDetector(signals, usecase.DefaultVoteRules()...)
+
+	audit := detector.Evaluate(context.Background(), 1, 2, entities.VoteMetadata{IP: "10.0.0.1"}, fraudNow)
+	assert.Empty(t, audit.Flags)
+	assert.Zero(t, audit.AccountAgeSeconds)
+}
+
+func TestSharedIPRule_IgnoresMissingIP(t *testing.T) {
+	signals := &fakeVoteSignals{ipAccounts: 50}
+	flagged, err := usecase.SharedIPRule{MaxAccounts: 1, Window: time.Hour}.
+		Flag(context.Background(), usecase.VoteRuleInput{UserID: 2, Now: fraudNow}, signals)
+	require.NoError(t, err)
+	assert.False(t, flagged)
+}
+
+func TestNewVoteMetadata_HashesUserAgent(t *testing.T) {
+	meta := usecase.NewVoteMetadata(" 10.0.0.1 ", "curl/8.0")
+	assert.Equal(t, "10.0.0.1", meta.IP)
+	assert.Len(t, meta.UserAgentHash, 64)
+	assert.NotContains(t, meta.UserAgentHash, "curl")
+
+	assert.Empty(t, usecase.NewVoteMetadata("", "  ").UserAgentHash)
+}
+
+func TestPublicService_VoteWithMetadata_StoresAudit(t *testing.T) {
+	repo := &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+		return &responses.PublicVideoResponse{VideoID: id}, nil
+	}}
+	votes := &fakeAuditVoteRepo{}
+	votes.HasUserVotedFunc = func(ctx context.Context, videoID, userID uint) (bool, error) { return false, nil }
+	signals := &fakeVoteSignals{createdAt: fraudNow.Add(-time.Minute)}
+	policies := []entities.VoteBudgetPolicy{{Scope: entities.VoteBudgetDaily, Limit: 5}}
+	svc := usecase.NewPublicService(repo, votes).
+		WithVoteBudget(policies).
+		WithFraudDetector(usecase.NewVoteFraudDetector(signals, usecase.NewAccountRule{MinAge: time.Hour})).
+		WithClock(func() time.Time { return fraudNow })
+
+	meta := usecase.NewVoteMetadata("10.0.0.1", "ua")
+	err := svc.VotePublicVideoWithMetadata(context.Background(), 1, 2, nil, meta)
+	require.NoError(t, err)
+	require.NotNil(t, votes.audit)
+	assert.Equal(t, []string{usecase.VoteRuleNewAccount}, votes.audit.Flags)
+	assert.Equal(t, meta, votes.audit.Metadata)
+	assert.Equal(t, policies, votes.policies)
+}
+
+func TestPublicService_VoteWithMetadata_ConflictSkipsRules(t *testing.T) {
+	repo := &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+		return &responses.PublicVideoResponse{VideoID: id}, nil
+	}}
+	votes := &fakeAuditVoteRepo{}
+	votes.HasUserVotedFunc = func(ctx context.Context, videoID, userID uint) (bool, error) { return true, nil }
+	svc := usecase.NewPublicService(repo, votes).
+		WithFraudDetector(usecase.NewVoteFraudDetector(&fakeVoteSignals{}, usecase.DefaultVoteRules()...))
+
+	err := svc.VotePublicVideoWithMetadata(context.Background(), 1, 2, nil, entities.VoteMetadata{})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	assert.Nil(t, votes.audit)
+}
+
+func TestPublicService_VoteWithMetadata_FallsBackWithoutDetector(t *testing.T) {
+	repo := &mockPublicRepo{GetByIDFunc: func(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+		return &responses.PublicVideoResponse{VideoID: id}, nil
+	}}
+	created := false
+	votes := &fakeAuditVoteRepo{}
+	votes.HasUserVotedFunc = func(ctx context.Context, videoID, userID uint) (bool, error) { return false, nil }
+	votes.CreateFunc = func(ctx context.Context, videoID, userID uint) error {
+		created = true
+		return nil
+	}
+	svc := usecase.NewPublicService(repo, votes)
+
+	require.NoError(t, svc.VotePublicVideoWithMetadata(context.Background(), 1, 2, nil, entities.VoteMetadata{IP: "10.0.0.1"}))
+	assert.True(t, created)
+	assert.Nil(t, votes.audit)
+}
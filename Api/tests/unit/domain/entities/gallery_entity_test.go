@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"api/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGallerySort(t *testing.T) {
+	s, ok := entities.ParseGallerySort("")
+	assert.True(t, ok)
+	assert.Equal(t, entities.GallerySortNewest, s)
+
+	s, ok = entities.ParseGallerySort(" Most_Voted ")
+	assert.True(t, ok)
+	assert.Equal(t, entities.GallerySortMostVoted, s)
+
+	_, ok = entities.ParseGallerySort("oldest")
+	assert.False(t, ok)
+}
+
+func TestGalleryQuery_IsDefault(t *testing.T) {
+	empty := ""
+	city := "Cali"
+	assert.True(t, entities.GalleryQuery{Limit: 20}.IsDefault())
+	assert.True(t, entities.GalleryQuery{City: &empty, Sort: entities.GallerySortNewest}.IsDefault())
+	assert.False(t, entities.GalleryQuery{City: &city}.IsDefault())
+	assert.False(t, entities.GalleryQuery{Sort: entities.GallerySortTrending}.IsDefault())
+	assert.False(t, entities.GalleryQuery{After: &entities.GalleryCursor{VideoID: 1}}.IsDefault())
+}
@@ -0,0 +1,27 @@
+package domain_test
+
+import (
+	"api/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRankingWindow(t *testing.T) {
+	w, ok := entities.ParseRankingWindow("")
+	assert.True(t, ok)
+	assert.Equal(t, entities.RankingWindowAll, w)
+
+	w, ok = entities.ParseRankingWindow(" MONTH ")
+	assert.True(t, ok)
+	assert.Equal(t, entities.RankingWindowMonth, w)
+
+	_, ok = entities.ParseRankingWindow("year")
+	assert.False(t, ok)
+}
+
+func TestRankingFilter_IsAllTime(t *testing.T) {
+	assert.True(t, entities.RankingFilter{}.IsAllTime())
+	assert.True(t, entities.RankingFilter{Window: entities.RankingWindowAll}.IsAllTime())
+	assert.False(t, entities.RankingFilter{Window: entities.RankingWindowDay}.IsAllTime())
+}
@@ -0,0 +1,28 @@
+package domain_test
+
+import (
+	"api/internal/domain/entities"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	l, err := entities.ParseRateLimit(" 10 / 1m ")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.RateLimit{Limit: 10, Period: time.Minute}, l)
+	assert.True(t, l.Enabled())
+	assert.Equal(t, 6*time.Second, l.EmissionInterval())
+
+	for _, spec := range []string{"", "off", "OFF"} {
+		l, err = entities.ParseRateLimit(spec)
+		assert.NoError(t, err)
+		assert.False(t, l.Enabled(), spec)
+	}
+
+	for _, spec := range []string{"10", "0/1m", "x/1m", "10/abc", "10/-1m"} {
+		_, err = entities.ParseRateLimit(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -29,3 +29,9 @@ func TestUser_Creation(t *testing.T) {
 	assert.Equal(t, "User", user.LastName)
 	assert.Equal(t, 123, user.CityID)
 }
+
+func TestUsernameFromEmail(t *testing.T) {
+	assert.Equal(t, "ana.perez", entities.UsernameFromEmail("ana.perez@example.com"))
+	assert.Equal(t, "sin-arroba", entities.UsernameFromEmail("sin-arroba"))
+	assert.Equal(t, "", entities.UsernameFromEmail("@example.com"))
+}
@@ -26,8 +26,10 @@ func TestVideoStatus_Constants(t *testing.T) {
 		{"StatusRemovingAudio", entities.StatusRemovingAudio, "REMOVING_AUDIO"},
 		{"StatusAddingIntroOutro", entities.StatusAddingIntroOutro, "ADDING_INTRO_OUTRO"},
 		{"StatusProcessed", entities.StatusProcessed, "PROCESSED"},
+		{"StatusPendingReview", entities.StatusPendingReview, "PENDING_REVIEW"},
 		{"StatusPublished", entities.StatusPublished, "PUBLISHED"},
 		{"StatusFailed", entities.StatusFailed, "FAILED"},
+		{"StatusRejected", entities.StatusRejected, "REJECTED"},
 	}
 
 	for _, tt := range tests {
@@ -48,12 +50,14 @@ func TestAllVideoStatuses(t *testing.T) {
 		entities.StatusRemovingAudio,
 		entities.StatusAddingIntroOutro,
 		entities.StatusProcessed,
+		entities.StatusPendingReview,
 		entities.StatusPublished,
 		entities.StatusFailed,
+		entities.StatusRejected,
 	}
 
 	assert.Equal(t, expected, statuses)
-	assert.Len(t, statuses, 9)
+	assert.Len(t, statuses, 11)
 }
 
 func TestVideo_Creation(t *testing.T) {
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"api/internal/domain/entities"
+	"api/internal/infrastructure/cache"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRateLimiter_Burst(t *testing.T) {
+	limiter := cache.NewMemoryRateLimiter()
+	limit := entities.RateLimit{Limit: 3, Period: time.Hour}
+	ctx := context.Background()
+
+	for want := 2; want >= 0; want-- {
+		d, err := limiter.Allow(ctx, "k", limit)
+		assert.NoError(t, err)
+		assert.True(t, d.Allowed)
+		assert.Equal(t, 3, d.Limit)
+		assert.Equal(t, want, d.Remaining)
+	}
+
+	d, err := limiter.Allow(ctx, "k", limit)
+	assert.NoError(t, err)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, 0, d.Remaining)
+	// Se recupera una solicitud cada 20 minutos
+	assert.InDelta(t, (20 * time.Minute).Seconds(), d.RetryAfter.Seconds(), 1)
+	assert.InDelta(t, time.Hour.Seconds(), d.ResetAfter.Seconds(), 1)
+
+	// Otra clave tiene su propio presupuesto
+	d, _ = limiter.Allow(ctx, "otra", limit)
+	assert.True(t, d.Allowed)
+}
+
+func TestMemoryRateLimiter_Recovers(t *testing.T) {
+	limiter := cache.NewMemoryRateLimiter()
+	limit := entities.RateLimit{Limit: 1, Period: 50 * time.Millisecond}
+	ctx := context.Background()
+
+	d, _ := limiter.Allow(ctx, "k", limit)
+	assert.True(t, d.Allowed)
+	d, _ = limiter.Allow(ctx, "k", limit)
+	assert.False(t, d.Allowed)
+
+	time.Sleep(60 * time.Millisecond)
+	d, _ = limiter.Allow(ctx, "k", limit)
+	assert.True(t, d.Allowed)
+}
+
+func TestRedisRateLimiter_FallsBackWhenUnreachable(t *testing.T) {
+	rdb := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", DialTimeout: 100 * time.Millisecond, MaxRetries: -1})
+	defer rdb.Close()
+	limit := entities.RateLimit{Limit: 1, Period: time.Hour}
+	ctx := context.Background()
+
+	_, err := cache.NewRedisRateLimiter(rdb, "test:").Allow(ctx, "k", limit)
+	assert.Error(t, err)
+
+	limiter := cache.NewRedisRateLimiter(rdb, "test:").WithFallback(cache.NewMemoryRateLimiter())
+	d, err := limiter.Allow(ctx, "k", limit)
+	assert.NoError(t, err)
+	assert.True(t, d.Allowed)
+	d, err = limiter.Allow(ctx, "k", limit)
+	assert.NoError(t, err)
+	assert.False(t, d.Allowed)
+}
@@ -31,8 +31,35 @@ func TestRedisCache_GetBytes(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestRedisCache_ReadyAndClose(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	redisCache := cache.NewRedisCache(client, "test:")
+
+	assert.Error(t, redisCache.Ready(context.Background()), "no Redis listening")
+	assert.NoError(t, redisCache.Close())
+	assert.ErrorIs(t, redisCache.Ready(context.Background()), redis.ErrClosed)
+}
+
 func TestMustRedisClient_PanicOnFailure(t *testing.T) {
 	assert.Panics(t, func() {
 		cache.MustRedisClient("invalid:6379")
 	})
 }
+
+func TestDecodeLeaderboardVotes(t *testing.T) {
+	// votos * 2^31 + (2^31 - 1 - user_id), como lo escribe AdminCache
+	encode := func(votes, userID int64) float64 {
+		return float64(votes<<31 + (1<<31 - 1 - userID))
+	}
+	assert.Equal(t, 0, cache.DecodeLeaderboardVotes(encode(0, 7)))
+	assert.Equal(t, 12, cache.DecodeLeaderboardVotes(encode(12, 1)))
+	assert.Equal(t, 12, cache.DecodeLeaderboardVotes(encode(12, 1<<31-1)))
+}
+
+func TestRedisCache_LeaderboardNeighborhood_NoConnection(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	redisCache := cache.NewRedisCache(client, "test:")
+
+	_, err := redisCache.LeaderboardNeighborhood(context.Background(), "lb:global:v2", "lb:users:v2", 1)
+	assert.Error(t, err)
+}
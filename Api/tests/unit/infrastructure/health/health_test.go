@@ -0,0 +1,64 @@
+package health
+
+import (
+	"api/internal/infrastructure/health"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ok(context.Context) error { return nil }
+
+func down(context.Context) error { return errors.New("connection refused") }
+
+func TestEvaluate_AllChecksPass(t *testing.T) {
+	report := health.Evaluate(context.Background(), time.Second,
+		health.Check{Name: "postgres", Run: ok},
+		health.Check{Name: "redis", Optional: true, Run: ok},
+	)
+
+	assert.Equal(t, health.StatusOK, report.Status)
+	assert.True(t, report.Ready())
+	assert.Len(t, report.Checks, 2)
+	assert.GreaterOrEqual(t, report.Checks["postgres"].LatencyMs, 0.0)
+}
+
+func TestEvaluate_OptionalFailureDegrades(t *testing.T) {
+	report := health.Evaluate(context.Background(), time.Second,
+		health.Check{Name: "postgres", Run: ok},
+		health.Check{Name: "redis", Optional: true, Run: down},
+	)
+
+	assert.Equal(t, health.StatusDegraded, report.Status)
+	assert.True(t, report.Ready())
+	assert.Equal(t, health.StatusFail, report.Checks["redis"].Status)
+	assert.True(t, report.Checks["redis"].Optional)
+	assert.Equal(t, "connection refused", report.Checks["redis"].Error)
+}
+
+func TestEvaluate_CriticalFailureFails(t *testing.T) {
+	report := health.Evaluate(context.Background(), time.Second,
+		health.Check{Name: "postgres", Run: down},
+		health.Check{Name: "redis", Optional: true, Run: down},
+	)
+
+	assert.Equal(t, health.StatusFail, report.Status)
+	assert.False(t, report.Ready())
+}
+
+func TestEvaluate_TimesOutChecksThatIgnoreContext(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	start := time.Now()
+	report := health.Evaluate(context.Background(), 20*time.Millisecond,
+		health.Check{Name: "s3", Run: func(context.Context) error { <-block; return nil }},
+	)
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, health.StatusFail, report.Status)
+	assert.Contains(t, report.Checks["s3"].Error, "timed out")
+}
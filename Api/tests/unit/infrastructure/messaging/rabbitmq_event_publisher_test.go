@@ -0,0 +1,102 @@
+package messaging_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	infra "api/internal/infrastructure/messaging"
+
+	"github.com/streadway/amqp"
+)
+
+// confirmChannel adds publisher confirms to stubChannel; every publish is acked with ack.
+type confirmChannel struct {
+	*stubChannel
+	ack      bool
+	notify   chan amqp.Confirmation
+	seq      uint64
+	confirms int
+}
+
+func (c *confirmChannel) Confirm(noWait bool) error {
+	c.confirms++
+	return nil
+}
+
+func (c *confirmChannel) NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation {
+	c.notify = confirm
+	return confirm
+}
+
+func (c *confirmChannel) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	if err := c.stubChannel.Publish(exchange, key, mandatory, immediate, msg); err != nil {
+		return err
+	}
+	c.seq++
+	c.notify <- amqp.Confirmation{DeliveryTag: c.seq, Ack: c.ack}
+	return nil
+}
+
+func newConfirmPublisher(t *testing.T, ch infra.AMQPChannel) *infra.RabbitMQPublisher {
+	t.Helper()
+	p, err := infra.NewRabbitMQPublisherWithDialer("amqp://dummy", func(s string) (infra.AMQPConnection, error) {
+		return &stubConn{ch: ch}, nil
+	})
+	if err != nil {
+		t.Fatalf("new publisher with dialer: %v", err)
+	}
+	return p
+}
+
+func TestPublishEvent_DeclaresTopicExchangeOnceAndWaitsForAck(t *testing.T) {
+	ch := &confirmChannel{stubChannel: &stubChannel{}, ack: true}
+	p := newConfirmPublisher(t, ch)
+	if err := p.EnableConfirms(); err != nil {
+		t.Fatalf("EnableConfirms: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := p.PublishEvent(context.Background(), "votes", "vote.cast", "42", []byte(`{}`)); err != nil {
+			t.Fatalf("PublishEvent: %v", err)
+		}
+	}
+	if len(ch.exDeclared) != 1 || ch.exDeclared[0].name != "votes" || ch.exDeclared[0].kind != "topic" {
+		t.Fatalf("expected a single topic exchange declaration, got %#v", ch.exDeclared)
+	}
+	msg := ch.lastPublish.msg
+	if ch.lastPublish.exchange != "votes" || ch.lastPublish.key != "vote.cast" {
+		t.Fatalf("unexpected destination: %s/%s", ch.lastPublish.exchange, ch.lastPublish.key)
+	}
+	if msg.MessageId != "42" || msg.DeliveryMode != amqp.Persistent {
+		t.Fatalf("unexpected message fields: id=%s mode=%d", msg.MessageId, msg.DeliveryMode)
+	}
+}
+
+func TestPublishEvent_NackIsAnError(t *testing.T) {
+	ch := &confirmChannel{stubChannel: &stubChannel{}, ack: false}
+	p := newConfirmPublisher(t, ch)
+	if err := p.EnableConfirms(); err != nil {
+		t.Fatalf("EnableConfirms: %v", err)
+	}
+	if err := p.PublishEvent(context.Background(), "votes", "vote.cast", "1", []byte(`{}`)); err == nil {
+		t.Fatal("expected error on broker nack")
+	}
+}
+
+func TestPublishEvent_ReportsPublishErrorsWithoutRetrying(t *testing.T) {
+	ch := &stubChannel{publishErr: errors.New("boom")}
+	p := newConfirmPublisher(t, ch)
+	if err := p.PublishEvent(context.Background(), "votes", "vote.retracted", "1", []byte(`{}`)); err == nil {
+		t.Fatal("expected publish error")
+	}
+	if ch.publishCalls != 1 {
+		t.Fatalf("expected 1 publish call, got %d", ch.publishCalls)
+	}
+}
+
+func TestEnableConfirms_UnsupportedChannel(t *testing.T) {
+	p := newConfirmPublisher(t, &stubChannel{})
+	if err := p.EnableConfirms(); err == nil {
+		t.Fatal("expected error for channel without confirm support")
+	}
+}
@@ -1,12 +1,17 @@
 package messaging_test
 
 import (
+    "context"
     "errors"
     infra "api/internal/infrastructure/messaging"
     "reflect"
+    "strings"
     "testing"
 
     "github.com/streadway/amqp"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/propagation"
+    "go.opentelemetry.io/otel/trace"
 )
 
 // helper to assert a function panics
@@ -103,7 +108,7 @@ func TestRabbitMQPublisher_Close_NoConn_NoPanic(t *testing.T) {
 func TestRabbitMQPublisher_PublishJSON_MarshalError(t *testing.T) {
     var p infra.RabbitMQPublisher
     // functions are not JSON-marshalable
-    err := p.PublishJSON("queue", func() {})
+    err := p.PublishJSON(context.Background(), "queue", func() {})
     if err == nil {
         t.Fatalf("expected marshal error, got nil")
     }
@@ -184,7 +189,7 @@ func TestPublish_Success_ConnectsIfNeeded(t *testing.T) {
     if err != nil {
         t.Fatalf("new publisher with dialer: %v", err)
     }
-    if err := p.Publish("q", []byte("{}")); err != nil {
+    if err := p.Publish(context.Background(), "q", []byte("{}")); err != nil {
         t.Fatalf("publish returned error: %v", err)
     }
     if ch.publishCalls != 1 {
@@ -202,7 +207,7 @@ func TestPublish_RetryOnPublishError_ThreeAttemptsNoError(t *testing.T) {
     if err != nil {
         t.Fatalf("new publisher with dialer: %v", err)
     }
-    if err := p.Publish("q", []byte("x")); err != nil {
+    if err := p.Publish(context.Background(), "q", []byte("x")); err != nil {
         t.Fatalf("publish returned unexpected error: %v", err)
     }
     if ch.publishCalls != 3 {
@@ -227,7 +232,7 @@ func TestPublish_ReconnectFailure_ReturnsError(t *testing.T) {
     }
     // Simulate connection closed to force reconnect during Publish
     conn.isClosed = true
-    if err := p.Publish("q", []byte("{}")); err == nil {
+    if err := p.Publish(context.Background(), "q", []byte("{}")); err == nil {
         t.Fatalf("expected error on reconnect failure, got nil")
     }
 }
@@ -254,6 +259,23 @@ func TestEnsureQueue_ReconnectFailure_ReturnsError(t *testing.T) {
     }
 }
 
+func TestReady_ReportsClosedConnection(t *testing.T) {
+    conn := &stubConn{ch: &stubChannel{}}
+    p, err := infra.NewRabbitMQPublisherWithDialer("amqp://dummy", func(s string) (infra.AMQPConnection, error) {
+        return conn, nil
+    })
+    if err != nil {
+        t.Fatalf("new publisher with dialer: %v", err)
+    }
+    if err := p.Ready(context.Background()); err != nil {
+        t.Fatalf("expected ready publisher, got %v", err)
+    }
+    conn.isClosed = true
+    if err := p.Ready(context.Background()); err == nil {
+        t.Fatalf("expected error with closed connection, got nil")
+    }
+}
+
 func TestClose_ClosesChannelThenConn_ReturnsConnError(t *testing.T) {
     calls := []string{}
     ch := &stubChannel{calls: &calls}
@@ -284,3 +306,28 @@ func Test_NewRabbitMQPublisher_DialError(t *testing.T) {
         t.Fatal("expected error from NewRabbitMQPublisher with bad URL")
     }
 }
+
+func TestPublish_InjectsTraceContextHeaders(t *testing.T) {
+    prev := otel.GetTextMapPropagator()
+    otel.SetTextMapPropagator(propagation.TraceContext{})
+    t.Cleanup(func() { otel.SetTextMapPropagator(prev) })
+
+    ch := &stubChannel{}
+    conn := &stubConn{ch: ch}
+    p, err := infra.NewRabbitMQPublisherWithDialer("amqp://dummy", func(s string) (infra.AMQPConnection, error) { return conn, nil })
+    if err != nil {
+        t.Fatalf("new publisher with dialer: %v", err)
+    }
+    traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+    spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+    ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+        TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled, Remote: true,
+    }))
+    if err := p.Publish(ctx, "q", []byte("{}")); err != nil {
+        t.Fatalf("publish returned error: %v", err)
+    }
+    got, _ := ch.lastPublish.msg.Headers["traceparent"].(string)
+    if !strings.HasPrefix(got, "00-4bf92f3577b34da6a3ce929d0e0e4736-") {
+        t.Fatalf("expected traceparent for the caller trace, got %q", got)
+    }
+}
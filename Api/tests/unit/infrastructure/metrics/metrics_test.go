@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"api/internal/infrastructure/metrics"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_CountersByResult(t *testing.T) {
+	m := metrics.New()
+	m.ObserveUpload(http.StatusCreated)
+	m.ObserveUpload(http.StatusBadRequest)
+	m.ObserveUpload(http.StatusInternalServerError)
+	m.ObserveVote(metrics.VoteCast, http.StatusOK)
+	m.ObserveVote(metrics.VoteCast, http.StatusOK)
+	m.ObserveVote(metrics.VoteRetract, http.StatusNotFound)
+	m.ObserveRankingCache(metrics.CacheHit)
+	m.ObserveRankingCache(metrics.CacheMiss)
+	m.ObserveRequest(http.MethodGet, "/api/videos/:video_id", http.StatusOK, 20*time.Millisecond)
+
+	out := scrape(t, m)
+	for _, line := range []string{
+		`api_video_uploads_total{result="accepted"} 1`,
+		`api_video_uploads_total{result="rejected"} 1`,
+		`api_video_uploads_total{result="failed"} 1`,
+		`api_votes_total{action="vote",result="accepted"} 2`,
+		`api_votes_total{action="retract",result="rejected"} 1`,
+		`api_ranking_cache_requests_total{result="hit"} 1`,
+		`api_ranking_cache_requests_total{result="miss"} 1`,
+		`api_http_request_duration_seconds_count{method="GET",route="/api/videos/:video_id",status="200"} 1`,
+		`go_goroutines`,
+	} {
+		assert.Contains(t, out, line)
+	}
+}
+
+func TestMetrics_NilIsNoop(t *testing.T) {
+	var m *metrics.Metrics
+	assert.NotPanics(t, func() {
+		m.ObserveUpload(http.StatusCreated)
+		m.ObserveVote(metrics.VoteCast, http.StatusOK)
+		m.ObserveRankingCache(metrics.CacheError)
+		m.ObserveRequest(http.MethodGet, "/health", http.StatusOK, time.Millisecond)
+	})
+}
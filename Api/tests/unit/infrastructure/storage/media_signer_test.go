@@ -0,0 +1,84 @@
+package storage_test
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"api/internal/domain/interfaces"
+	stor "api/internal/infrastructure/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMediaURLSigner_DisabledWhenModeEmpty(t *testing.T) {
+	s, err := stor.NewMediaURLSigner(stor.MediaSignerConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+}
+
+func TestNewMediaURLSigner_UnknownMode(t *testing.T) {
+	_, err := stor.NewMediaURLSigner(stor.MediaSignerConfig{Mode: "cdn"})
+	assert.Error(t, err)
+}
+
+func TestNewMediaURLSigner_S3Presign(t *testing.T) {
+	s, err := stor.NewMediaURLSigner(stor.MediaSignerConfig{
+		Mode:            stor.MediaSignerModeS3,
+		TTL:             time.Minute,
+		S3:              stor.S3Config{Region: "us-east-1", AccessKey: "AKID", SecretKey: "SECRET"},
+		OriginalBucket:  "raw-videos",
+		ProcessedBucket: "processed-videos",
+	})
+	require.NoError(t, err)
+
+	signed, err := s.SignURL(context.Background(), interfaces.MediaProcessed, "clip.mp4")
+	require.NoError(t, err)
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Contains(t, u.Host+u.Path, "processed-videos")
+	assert.True(t, strings.HasSuffix(u.Path, "/clip.mp4"))
+	assert.Equal(t, "60", u.Query().Get("X-Amz-Expires"))
+	assert.NotEmpty(t, u.Query().Get("X-Amz-Signature"))
+}
+
+func TestSecureLinkSigner_SignURL(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s, err := stor.NewSecureLinkSigner("", "https://media.example.com/processed-videos/", "topsecret", 5*time.Minute)
+	require.NoError(t, err)
+	s.WithClock(func() time.Time { return now })
+
+	signed, err := s.SignURL(context.Background(), interfaces.MediaProcessed, "clip.mp4")
+	require.NoError(t, err)
+
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "/processed-videos/clip.mp4", u.Path)
+	assert.Equal(t, "1700000300", u.Query().Get("expires"))
+
+	// Mismo calculo que secure_link_md5 "$secure_link_expires$uri topsecret" en nginx.conf
+	sum := md5.Sum([]byte("1700000300/processed-videos/clip.mp4 topsecret"))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(sum[:]), u.Query().Get("md5"))
+}
+
+func TestSecureLinkSigner_LegacyFullURLAndMissingKind(t *testing.T) {
+	s, err := stor.NewSecureLinkSigner("", "https://media.example.com/p", "k", time.Minute)
+	require.NoError(t, err)
+
+	signed, err := s.SignURL(context.Background(), interfaces.MediaProcessed, "http://nginx:8084/processed-videos/old.mp4")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signed, "https://media.example.com/p/old.mp4?"))
+
+	_, err = s.SignURL(context.Background(), interfaces.MediaOriginal, "raw.mp4")
+	assert.Error(t, err)
+}
+
+func TestNewSecureLinkSigner_RequiresSecret(t *testing.T) {
+	_, err := stor.NewSecureLinkSigner("", "https://media.example.com", "", time.Minute)
+	assert.Error(t, err)
+}
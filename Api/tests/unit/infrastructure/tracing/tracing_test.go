@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"api/internal/infrastructure/tracing"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func remoteSpanContext(t *testing.T) trace.SpanContext {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+}
+
+func TestSetup_WithoutEndpointOnlyPropagates(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	shutdown, err := tracing.Setup(context.Background(), "api-test")
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	ctx := trace.ContextWithSpanContext(context.Background(), remoteSpanContext(t))
+	headers := tracing.InjectAMQP(ctx, nil)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", headers["traceparent"])
+}
+
+func TestAMQPHeaders_RoundTrip(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), "api-test")
+	require.NoError(t, err)
+
+	sc := remoteSpanContext(t)
+	headers := tracing.InjectAMQP(trace.ContextWithSpanContext(context.Background(), sc), amqp.Table{"x-other": int32(1)})
+	assert.Equal(t, int32(1), headers["x-other"])
+
+	got := trace.SpanContextFromContext(tracing.ExtractAMQP(context.Background(), headers))
+	assert.Equal(t, sc.TraceID(), got.TraceID())
+	assert.Equal(t, sc.SpanID(), got.SpanID())
+	assert.True(t, got.IsRemote())
+}
+
+func TestExtractAMQP_NilHeadersKeepsContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, tracing.ExtractAMQP(ctx, nil))
+}
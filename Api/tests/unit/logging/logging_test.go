@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"api/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+	var rec map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+	return rec
+}
+
+func TestParseLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, logging.ParseLevel("debug"))
+	assert.Equal(t, slog.LevelWarn, logging.ParseLevel("WARN"))
+	assert.Equal(t, slog.LevelError, logging.ParseLevel("error"))
+	assert.Equal(t, slog.LevelInfo, logging.ParseLevel(""))
+	assert.Equal(t, slog.LevelInfo, logging.ParseLevel("loud"))
+}
+
+func TestNew_AddsCorrelationFieldsFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, "", "").With(logging.ServiceKey, "api")
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID,
+	}))
+	ctx = logging.WithVideoID(logging.WithRequestID(ctx, "req-1"), "42")
+
+	logger.InfoContext(ctx, "video published", "queue", "states_machine_queue")
+
+	rec := decode(t, &buf)
+	assert.Equal(t, "video published", rec["msg"])
+	assert.Equal(t, "api", rec[logging.ServiceKey])
+	assert.Equal(t, "req-1", rec[logging.RequestIDKey])
+	assert.Equal(t, "42", rec[logging.VideoIDKey])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", rec[logging.TraceIDKey])
+	assert.Equal(t, "states_machine_queue", rec["queue"])
+}
+
+func TestNew_LevelFilterAndTextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, "error", "text")
+
+	logger.Warn("dropped")
+	assert.Zero(t, buf.Len())
+
+	logger.ErrorContext(logging.WithRequestID(context.Background(), "abc"), "kept")
+	assert.Contains(t, buf.String(), "msg=kept")
+	assert.Contains(t, buf.String(), "request_id=abc")
+	assert.NotContains(t, buf.String(), "video_id")
+}
@@ -0,0 +1,136 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"api/internal/presentation/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockCommentRepo struct {
+	comment *entities.Comment
+	recent  int64
+	deleted bool
+}
+
+func (m *mockCommentRepo) Create(ctx context.Context, c *entities.Comment) error {
+	c.CommentID = 11
+	return nil
+}
+
+func (m *mockCommentRepo) GetByID(ctx context.Context, id uint) (*entities.Comment, error) {
+	if m.comment == nil {
+		return nil, domain.ErrNotFound
+	}
+	return m.comment, nil
+}
+
+func (m *mockCommentRepo) List(ctx context.Context, videoID uint, parentID *uint, afterID uint, limit int) ([]responses.CommentResponse, error) {
+	return []responses.CommentResponse{{CommentID: 11, Author: "juan.perez", Body: "hola"}}, nil
+}
+
+func (m *mockCommentRepo) UpdateBody(ctx context.Context, id uint, body string) error { return nil }
+
+func (m *mockCommentRepo) Delete(ctx context.Context, id uint) error {
+	m.deleted = true
+	return nil
+}
+
+func (m *mockCommentRepo) CreateWithinLimit(ctx context.Context, c *entities.Comment, max int, since time.Time) error {
+	if m.recent >= int64(max) {
+		return domain.ErrRateLimited
+	}
+	return m.Create(ctx, c)
+}
+
+func (m *mockCommentRepo) VideoOwnerID(ctx context.Context, videoID uint) (uint, error) {
+	return 1, nil
+}
+
+func setupCommentRouter(repo *mockCommentRepo, perms []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	uc := useCase.NewCommentUseCase(&visibleVideoRepo{}, repo)
+	h := handlers.NewCommentHandlers(uc)
+	r := gin.New()
+	r.GET("/api/public/videos/:video_id/comments", h.ListComments)
+	auth := r.Group("/", func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Set("permissions", perms)
+		c.Next()
+	})
+	auth.POST("/api/public/videos/:video_id/comments", h.CreateComment)
+	auth.PATCH("/api/public/videos/:video_id/comments/:comment_id", h.UpdateComment)
+	auth.DELETE("/api/public/videos/:video_id/comments/:comment_id", h.DeleteComment)
+	return r
+}
+
+func TestCommentHandlers_ListComments(t *testing.T) {
+	r := setupCommentRouter(&mockCommentRepo{}, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/videos/3/comments?limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"author":"juan.perez"`)
+	assert.Contains(t, w.Body.String(), `"next_cursor":null`)
+}
+
+func TestCommentHandlers_ListComments_InvalidLimit(t *testing.T) {
+	r := setupCommentRouter(&mockCommentRepo{}, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/videos/3/comments?limit=0", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCommentHandlers_CreateComment(t *testing.T) {
+	r := setupCommentRouter(&mockCommentRepo{}, nil)
+
+	w := postJSON(r, "/api/public/videos/3/comments", `{"body":"gran video"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"comment_id":11`)
+}
+
+func TestCommentHandlers_CreateComment_RateLimited(t *testing.T) {
+	r := setupCommentRouter(&mockCommentRepo{recent: useCase.DefaultCommentRateLimit}, nil)
+
+	w := postJSON(r, "/api/public/videos/3/comments", `{"body":"otra vez"}`)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func TestCommentHandlers_UpdateComment_NotAuthor(t *testing.T) {
+	repo := &mockCommentRepo{comment: &entities.Comment{CommentID: 11, VideoID: 3, UserID: 99}}
+	r := setupCommentRouter(repo, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/public/videos/3/comments/11", strings.NewReader(`{"body":"editado"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestCommentHandlers_DeleteComment_Moderator(t *testing.T) {
+	repo := &mockCommentRepo{comment: &entities.Comment{CommentID: 11, VideoID: 3, UserID: 99}}
+	r := setupCommentRouter(repo, []string{handlers.PrivilegeModerateVideos})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/public/videos/3/comments/11", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, repo.deleted)
+}
@@ -0,0 +1,163 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"api/internal/presentation/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockContestRepo struct {
+	contest  *entities.Contest
+	closeErr error
+	ranking  []responses.RankingItem
+}
+
+func (m *mockContestRepo) Create(ctx context.Context, contest *entities.Contest) error {
+	contest.ContestID = 1
+	m.contest = contest
+	return nil
+}
+
+func (m *mockContestRepo) GetByID(ctx context.Context, contestID uint) (*entities.Contest, error) {
+	if m.contest == nil || m.contest.ContestID != contestID {
+		return nil, domain.ErrNotFound
+	}
+	return m.contest, nil
+}
+
+func (m *mockContestRepo) List(ctx context.Context) ([]entities.Contest, error) {
+	if m.contest == nil {
+		return nil, nil
+	}
+	return []entities.Contest{*m.contest}, nil
+}
+
+func (m *mockContestRepo) GetForVideo(ctx context.Context, videoID uint) (*entities.Contest, error) {
+	return m.contest, nil
+}
+
+func (m *mockContestRepo) Close(ctx context.Context, contestID uint) (*entities.Contest, error) {
+	if m.closeErr != nil {
+		return nil, m.closeErr
+	}
+	now := time.Now()
+	m.contest.ClosedAt = &now
+	return m.contest, nil
+}
+
+func (m *mockContestRepo) Rankings(ctx context.Context, contestID uint, city *string, page, pageSize int) ([]responses.RankingItem, error) {
+	return m.ranking, nil
+}
+
+func newContestRouter(repo *mockContestRepo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	uc := useCase.NewContestUseCase(repo)
+	h := handlers.NewContestHandlers(uc)
+	public := handlers.NewPublicHandlers(useCase.NewPublicService(&visibleVideoRepo{}, nil)).WithContests(uc)
+	r := gin.New()
+	r.GET("/api/public/contests", h.ListContests)
+	r.GET("/api/public/contests/:contest_id/rankings", public.ListContestRankings)
+	r.POST("/api/admin/contests", h.CreateContest)
+	r.POST("/api/admin/contests/:contest_id/close", h.CloseContest)
+	return r
+}
+
+func TestContestHandlers_CreateContest(t *testing.T) {
+	repo := &mockContestRepo{}
+	r := newContestRouter(repo)
+
+	w := postJSON(r, "/api/admin/contests", `{"name":"Temporada 1",
+		"submission_starts_at":"2025-03-01T00:00:00Z","submission_ends_at":"2025-03-15T00:00:00Z",
+		"voting_starts_at":"2025-03-08T00:00:00Z","voting_ends_at":"2025-03-22T00:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var body responses.ContestResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, uint(1), body.ContestID)
+	assert.False(t, body.VotingOpen)
+}
+
+func TestContestHandlers_CreateContest_Invalid(t *testing.T) {
+	r := newContestRouter(&mockContestRepo{})
+
+	w := postJSON(r, "/api/admin/contests", `{"name":"T","submission_starts_at":"ayer"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = postJSON(r, "/api/admin/contests", `{"name":"T",
+		"submission_starts_at":"2025-03-15T00:00:00Z","submission_ends_at":"2025-03-01T00:00:00Z",
+		"voting_starts_at":"2025-03-16T00:00:00Z","voting_ends_at":"2025-03-22T00:00:00Z"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestContestHandlers_CloseContest(t *testing.T) {
+	repo := &mockContestRepo{contest: &entities.Contest{ContestID: 1}}
+	r := newContestRouter(repo)
+
+	w := postJSON(r, "/api/admin/contests/1/close", `{}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	repo.closeErr = domain.ErrConflict
+	w = postJSON(r, "/api/admin/contests/1/close", `{}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = postJSON(r, "/api/admin/contests/abc/close", `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPublicHandlers_ListContestRankings(t *testing.T) {
+	repo := &mockContestRepo{
+		contest: &entities.Contest{ContestID: 1},
+		ranking: []responses.RankingItem{{Username: "ana", Votes: 4}, {Username: "luis", Votes: 2}},
+	}
+	r := newContestRouter(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/public/contests/1/rankings", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var entries []responses.RankingEntry
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, 1, entries[0].Position)
+	assert.Equal(t, "ana", entries[0].Username)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/public/contests/2/rankings", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPublicHandlers_VotePublicVideo_VotingClosed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ended := &entities.Contest{ContestID: 1, VotingStartsAt: time.Now().Add(-2 * time.Hour), VotingEndsAt: time.Now().Add(-time.Hour)}
+	svc := useCase.NewPublicService(&visibleVideoRepo{}, &retractVoteRepo{}).WithContests(&mockContestRepo{contest: ended})
+	h := handlers.NewPublicHandlers(svc)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.POST("/api/public/videos/:video_id/vote", h.VotePublicVideo)
+	r.DELETE("/api/public/videos/:video_id/vote", h.RetractVote)
+
+	w := postJSON(r, "/api/public/videos/1/vote", `{}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/public/videos/1/vote", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
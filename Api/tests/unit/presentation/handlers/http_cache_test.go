@@ -0,0 +1,176 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"api/internal/application/useCase"
+	"api/internal/domain/responses"
+	"api/internal/presentation/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedCacheEntry arma una entrada global con tres usuarios y la ventana fresh/stale indicada.
+func pagedCacheEntry(t *testing.T, freshIn, staleIn time.Duration, complete bool) []byte {
+	t.Helper()
+	now := time.Now().UTC()
+	entry := map[string]any{
+		"schema_version": "v2",
+		"scope":          "global",
+		"complete":       complete,
+		"as_of":          now.Format(time.RFC3339),
+		"fresh_until":    now.Add(freshIn).Format(time.RFC3339),
+		"stale_until":    now.Add(staleIn).Format(time.RFC3339),
+		"items": []map[string]any{
+			{"rank": 1, "user_id": 1, "username": "alice", "score": 12},
+			{"rank": 2, "user_id": 2, "username": "bob", "score": 9},
+			{"rank": 3, "user_id": 3, "username": "caro", "score": 7},
+		},
+	}
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("failed to marshal cache entry: %v", err)
+	}
+	return payload
+}
+
+func newPagedRankingsRouter(repo *cacheRepo, payload []byte) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	svc := useCase.NewPublicService(repo, nil)
+	cache := &fakeCache{data: map[string][]byte{"rank:global:v2": payload}}
+	h := handlers.NewPublicHandlersWithCache(svc, cache, "v2")
+	r := gin.New()
+	r.GET("/api/public/rankings", h.ListRankings)
+	return r
+}
+
+func decodeRankingEntries(t *testing.T, w *httptest.ResponseRecorder) []responses.RankingEntry {
+	t.Helper()
+	var got []responses.RankingEntry
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	return got
+}
+
+func TestPublicHandlers_ListRankings_CachePageSlice(t *testing.T) {
+	repo := &cacheRepo{users: map[uint]responses.UserBasic{}}
+	r := newPagedRankingsRouter(repo, pagedCacheEntry(t, 2*time.Minute, 10*time.Minute, false))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/rankings?page=2&pageSize=1", nil))
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+	got := decodeRankingEntries(t, w)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "bob", got[0].Username)
+		assert.Equal(t, 1, got[0].Position)
+	}
+	assert.Equal(t, 0, repo.rankingsCalls)
+	assert.Equal(t, "fresh", w.Header().Get("X-Cache"))
+	assert.True(t, strings.HasPrefix(w.Header().Get("Cache-Control"), "public, max-age="))
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+}
+
+func TestPublicHandlers_ListRankings_PageBeyondCacheDepth(t *testing.T) {
+	repo := &cacheRepo{
+		users:           map[uint]responses.UserBasic{},
+		rankingResponse: []responses.RankingItem{{Username: "db", Votes: 1}},
+	}
+	r := newPagedRankingsRouter(repo, pagedCacheEntry(t, 2*time.Minute, 10*time.Minute, false))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/rankings?page=2&pageSize=3", nil))
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+	got := decodeRankingEntries(t, w)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "db", got[0].Username)
+	}
+	assert.Equal(t, 1, repo.rankingsCalls)
+	assert.Equal(t, "miss", w.Header().Get("X-Cache"))
+	assert.Equal(t, "public, no-cache", w.Header().Get("Cache-Control"))
+}
+
+func TestPublicHandlers_ListRankings_CompleteCacheEmptyPage(t *testing.T) {
+	repo := &cacheRepo{users: map[uint]responses.UserBasic{}}
+	r := newPagedRankingsRouter(repo, pagedCacheEntry(t, 2*time.Minute, 10*time.Minute, true))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/rankings?page=2&pageSize=3", nil))
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+	assert.JSONEq(t, `[]`, w.Body.String())
+	assert.Equal(t, 0, repo.rankingsCalls)
+	assert.Equal(t, "fresh", w.Header().Get("X-Cache"))
+}
+
+func TestPublicHandlers_ListRankings_PartialPageFromIncompleteCache(t *testing.T) {
+	repo := &cacheRepo{
+		users:           map[uint]responses.UserBasic{},
+		rankingResponse: []responses.RankingItem{{Username: "alice", Votes: 12}, {Username: "bob", Votes: 9}, {Username: "caro", Votes: 7}, {Username: "dani", Votes: 5}},
+	}
+	r := newPagedRankingsRouter(repo, pagedCacheEntry(t, 2*time.Minute, 10*time.Minute, false))
+
+	// La pagina por defecto (20) no cabe en el top-3 cacheado: se lee completa del repositorio
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/rankings", nil))
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+	assert.Len(t, decodeRankingEntries(t, w), 4)
+	assert.Equal(t, 1, repo.rankingsCalls)
+	assert.Equal(t, "miss", w.Header().Get("X-Cache"))
+}
+
+func TestPublicHandlers_ListRankings_StaleCacheHeaders(t *testing.T) {
+	repo := &cacheRepo{users: map[uint]responses.UserBasic{}}
+	r := newPagedRankingsRouter(repo, pagedCacheEntry(t, -time.Minute, 5*time.Minute, true))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/rankings", nil))
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+	assert.Len(t, decodeRankingEntries(t, w), 3)
+	assert.Equal(t, 0, repo.rankingsCalls)
+	assert.Equal(t, "stale", w.Header().Get("X-Cache"))
+	assert.True(t, strings.HasPrefix(w.Header().Get("Cache-Control"), "public, max-age=0, stale-while-revalidate="))
+}
+
+func TestPublicHandlers_ListRankings_IfNoneMatch(t *testing.T) {
+	repo := &cacheRepo{users: map[uint]responses.UserBasic{}}
+	r := newPagedRankingsRouter(repo, pagedCacheEntry(t, 2*time.Minute, 10*time.Minute, true))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/rankings", nil))
+	etag := w.Header().Get("ETag")
+	if !assert.NotEmpty(t, etag) {
+		return
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/public/rankings", nil)
+	req.Header.Set("If-None-Match", `"otro", W/`+etag)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+
+	// Otra pagina produce otro cuerpo y por lo tanto otro ETag
+	req = httptest.NewRequest(http.MethodGet, "/api/public/rankings?page=2&pageSize=2", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+}
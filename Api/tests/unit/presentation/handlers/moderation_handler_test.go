@@ -0,0 +1,142 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"api/internal/presentation/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockModerationRepo struct {
+	items      []responses.ModerationQueueItem
+	err        error
+	approvedBy uint
+	reason     string
+}
+
+func (m *mockModerationRepo) ListPending(ctx context.Context, page, pageSize int) ([]responses.ModerationQueueItem, error) {
+	return m.items, m.err
+}
+
+func (m *mockModerationRepo) Approve(ctx context.Context, videoID, moderatorID uint) (*entities.Video, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.approvedBy = moderatorID
+	return &entities.Video{VideoID: videoID, Status: string(entities.StatusPublished)}, nil
+}
+
+func (m *mockModerationRepo) Reject(ctx context.Context, videoID, moderatorID uint, reason string) (*entities.Video, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.reason = reason
+	return &entities.Video{VideoID: videoID, Status: string(entities.StatusRejected)}, nil
+}
+
+func setupModerationRouter(repo *mockModerationRepo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	uc := useCase.NewModerationUseCase(repo)
+	h := handlers.NewModerationHandlers(uc, useCase.NewMediaURLService(nil, processedBaseURL))
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(2))
+		c.Next()
+	})
+	r.GET("/api/moderation/videos", h.ListPending)
+	r.POST("/api/moderation/videos/:video_id/approve", h.Approve)
+	r.POST("/api/moderation/videos/:video_id/reject", h.Reject)
+	return r
+}
+
+func TestModerationHandlers_ListPending(t *testing.T) {
+	key := "final.mp4"
+	repo := &mockModerationRepo{items: []responses.ModerationQueueItem{{VideoID: 3, Title: "Clip", ProcessedURL: &key}}}
+	r := setupModerationRouter(repo)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/moderation/videos?page=1&pageSize=10", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var got []responses.ModerationQueueItem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	if assert.Len(t, got, 1) && assert.NotNil(t, got[0].ProcessedURL) {
+		assert.Equal(t, "https://media.example.com/final.mp4", *got[0].ProcessedURL)
+	}
+}
+
+func TestModerationHandlers_ListPending_InvalidPageSize(t *testing.T) {
+	r := setupModerationRouter(&mockModerationRepo{})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/moderation/videos?pageSize=500", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestModerationHandlers_Approve(t *testing.T) {
+	repo := &mockModerationRepo{}
+	r := setupModerationRouter(repo)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/moderation/videos/3/approve", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, uint(2), repo.approvedBy)
+	assert.Contains(t, w.Body.String(), "published")
+}
+
+func TestModerationHandlers_Approve_NotPending(t *testing.T) {
+	r := setupModerationRouter(&mockModerationRepo{err: domain.ErrConflict})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/moderation/videos/3/approve", nil))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestModerationHandlers_Reject(t *testing.T) {
+	repo := &mockModerationRepo{}
+	r := setupModerationRouter(repo)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/moderation/videos/3/reject", strings.NewReader(`{"reason":"audio ilegible"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "audio ilegible", repo.reason)
+}
+
+func TestModerationHandlers_Reject_MissingReason(t *testing.T) {
+	r := setupModerationRouter(&mockModerationRepo{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/moderation/videos/3/reject", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestModerationHandlers_Reject_NotFound(t *testing.T) {
+	r := setupModerationRouter(&mockModerationRepo{err: domain.ErrNotFound})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/moderation/videos/3/reject", strings.NewReader(`{"reason":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
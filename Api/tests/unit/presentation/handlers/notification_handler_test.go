@@ -0,0 +1,86 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/responses"
+	"api/internal/presentation/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubNotificationRepo struct {
+	items    []responses.NotificationResponse
+	markErr  error
+	lastUser uint
+	lastPage int
+	lastSize int
+}
+
+func (s *stubNotificationRepo) ListByUser(ctx context.Context, userID uint, page, pageSize int) ([]responses.NotificationResponse, error) {
+	s.lastUser, s.lastPage, s.lastSize = userID, page, pageSize
+	return s.items, nil
+}
+
+func (s *stubNotificationRepo) MarkRead(ctx context.Context, userID, notificationID uint) error {
+	s.lastUser = userID
+	return s.markErr
+}
+
+func newNotificationRouter(repo *stubNotificationRepo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewNotificationHandlers(useCase.NewNotificationUseCase(repo))
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.GET("/api/me/notifications", h.ListNotifications)
+	r.POST("/api/me/notifications/:notification_id/read", h.MarkNotificationRead)
+	return r
+}
+
+func TestNotificationHandlers_ListNotifications(t *testing.T) {
+	repo := &stubNotificationRepo{}
+	r := newNotificationRouter(repo)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/me/notifications?page=2&pageSize=5", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+	assert.Equal(t, uint(5), repo.lastUser)
+	assert.Equal(t, 2, repo.lastPage)
+	assert.Equal(t, 5, repo.lastSize)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/me/notifications?pageSize=500", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNotificationHandlers_MarkNotificationRead(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		err  error
+		want int
+	}{
+		{"read", "/api/me/notifications/3/read", nil, http.StatusOK},
+		{"invalid id", "/api/me/notifications/abc/read", nil, http.StatusBadRequest},
+		{"not found or not owned", "/api/me/notifications/3/read", domain.ErrNotFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newNotificationRouter(&stubNotificationRepo{markErr: tt.err})
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, nil))
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api/internal/application/useCase"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"api/internal/presentation/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// profileRepo agrega la galeria a rankLookupRepo.
+type profileRepo struct {
+	rankLookupRepo
+	videos  []responses.GalleryVideo
+	queries []entities.GalleryQuery
+}
+
+func (r *profileRepo) SearchPublicVideos(ctx context.Context, q entities.GalleryQuery) ([]responses.GalleryVideo, error) {
+	r.queries = append(r.queries, q)
+	if len(r.videos) > q.Limit {
+		return r.videos[:q.Limit], nil
+	}
+	return r.videos, nil
+}
+
+func TestPublicHandlers_UserProfile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &profileRepo{
+		rankLookupRepo: *rankLookupFixture(),
+		videos: []responses.GalleryVideo{
+			{PublicVideoResponse: responses.PublicVideoResponse{VideoID: 8, Title: "Triple", Votes: 11}},
+			{PublicVideoResponse: responses.PublicVideoResponse{VideoID: 5, Title: "Bandeja", Votes: 7}},
+		},
+	}
+	svc := useCase.NewPublicService(repo, nil)
+	cache := &fakeLeaderboardCache{boards: map[string][]responses.RankedUserItem{
+		"lb:global:v2": {
+			{UserID: 1, Position: 4, Votes: 20},
+			{UserID: 2, Position: 5, Votes: 18},
+		},
+		"lb:city:bogota:v2": {
+			{UserID: 2, Position: 1, Votes: 18},
+		},
+	}}
+	h := handlers.NewPublicHandlersWithCache(svc, cache, "v2")
+	r := gin.New()
+	r.GET("/api/public/users/:username", h.UserProfile)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/users/CARO?limit=1", nil))
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+	var got responses.PlayerProfileResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	assert.Equal(t, "caro", got.Username)
+	assert.Equal(t, 18, got.Votes)
+	if assert.NotNil(t, got.GlobalRank) && assert.NotNil(t, got.CityRank) {
+		assert.Equal(t, 5, *got.GlobalRank)
+		assert.Equal(t, 1, *got.CityRank)
+	}
+	if assert.Len(t, got.Videos, 1) {
+		assert.Equal(t, "Triple", got.Videos[0].Title)
+	}
+	assert.NotNil(t, got.NextCursor)
+	if assert.Len(t, repo.queries, 1) && assert.NotNil(t, repo.queries[0].OwnerUserID) {
+		assert.Equal(t, uint(2), *repo.queries[0].OwnerUserID)
+	}
+	assert.Empty(t, repo.dbCalls)
+
+	// Sin videos publicos: sin posiciones ni videos
+	repo.videos = nil
+	cache.boards["lb:city:cali:v2"] = []responses.RankedUserItem{}
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/users/ana", nil))
+	if assert.Equal(t, http.StatusOK, w.Code) {
+		assert.JSONEq(t, `{"username":"ana","city":"Cali","votes":20,"global_rank":4,"city_rank":null,"videos":[],"next_cursor":null}`, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/users/nadie", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/users/ana?sort=oldest", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
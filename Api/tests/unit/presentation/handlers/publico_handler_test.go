@@ -10,7 +10,9 @@ import (
 
 	"api/internal/application/useCase"
 	"api/internal/domain"
+	"api/internal/domain/entities"
 	"api/internal/domain/responses"
+	"api/internal/infrastructure/metrics"
 	"api/internal/presentation/handlers"
 
 	"github.com/gin-gonic/gin"
@@ -61,6 +63,7 @@ func TestPublicHandlers_ListRankings_UsesCache(t *testing.T) {
 	now := time.Now().UTC()
 	entry := map[string]any{
 		"schema_version": "v2",
+		"complete":       true,
 		"scope":          "global",
 		"as_of":          now.Format(time.RFC3339),
 		"fresh_until":    now.Add(2 * time.Minute).Format(time.RFC3339),
@@ -101,6 +104,42 @@ func TestPublicHandlers_ListRankings_UsesCache(t *testing.T) {
 	}
 }
 
+func TestPublicHandlers_ListRankings_ObservesCacheMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &cacheRepo{users: map[uint]responses.UserBasic{1: {UserID: 1, Username: "alice"}}}
+	now := time.Now().UTC()
+	payload, err := json.Marshal(map[string]any{
+		"schema_version": "v2",
+		"complete":       true,
+		"scope":          "global",
+		"as_of":          now.Format(time.RFC3339),
+		"fresh_until":    now.Add(2 * time.Minute).Format(time.RFC3339),
+		"stale_until":    now.Add(10 * time.Minute).Format(time.RFC3339),
+		"items":          []map[string]any{{"rank": 1, "user_id": 1, "username": "alice", "score": 12}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal cache entry: %v", err)
+	}
+	cache := &fakeCache{data: map[string][]byte{"rank:global:v2": payload}}
+	m := metrics.New()
+	h := handlers.NewPublicHandlersWithCache(useCase.NewPublicService(repo, nil), cache, "v2").WithMetrics(m)
+	r := gin.New()
+	r.GET("/api/public/rankings", h.ListRankings)
+	r.GET("/api/public/rankings/videos", h.ListVideoRankings)
+
+	// Global cacheado (hit), ciudad sin clave (miss) y videos sin clave (miss)
+	for _, target := range []string{"/api/public/rankings", "/api/public/rankings?city=Cali", "/api/public/rankings/videos"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusOK, w.Code, target)
+	}
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, w.Body.String(), `api_ranking_cache_requests_total{result="hit"} 1`)
+	assert.Contains(t, w.Body.String(), `api_ranking_cache_requests_total{result="miss"} 2`)
+}
+
 func TestPublicHandlers_ListRankings_CacheCityScope(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	repo := &cacheRepo{
@@ -114,6 +153,7 @@ func TestPublicHandlers_ListRankings_CacheCityScope(t *testing.T) {
 	now := time.Now().UTC()
 	entry := map[string]any{
 		"schema_version": "v2",
+		"complete":       true,
 		"scope":          "city",
 		"city":           "Bogotá",
 		"city_slug":      "bogota",
@@ -167,6 +207,7 @@ func TestPublicHandlers_ListRankings_CacheStaleFallback(t *testing.T) {
 	now := time.Now().UTC()
 	entry := map[string]any{
 		"schema_version": "v2",
+		"complete":       true,
 		"scope":          "global",
 		"as_of":          now.Add(-10 * time.Minute).Format(time.RFC3339),
 		"fresh_until":    now.Add(-5 * time.Minute).Format(time.RFC3339),
@@ -203,6 +244,206 @@ func TestPublicHandlers_ListRankings_CacheStaleFallback(t *testing.T) {
 	assert.Equal(t, 1, repo.rankingsCalls)
 }
 
+func TestPublicHandlers_ListVideoRankings_UsesCityCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	city := "Bogotá"
+	repo := &cacheRepo{
+		users: map[uint]responses.UserBasic{
+			5: {UserID: 5, Username: "caro", City: &city},
+		},
+	}
+	svc := useCase.NewPublicService(repo, nil)
+	cache := &fakeCache{data: make(map[string][]byte)}
+
+	now := time.Now().UTC()
+	entry := map[string]any{
+		"schema_version": "v2",
+		"complete":       true,
+		"scope":          "city",
+		"city":           "Bogotá",
+		"city_slug":      "bogota",
+		"as_of":          now.Format(time.RFC3339),
+		"fresh_until":    now.Add(time.Minute).Format(time.RFC3339),
+		"stale_until":    now.Add(2 * time.Minute).Format(time.RFC3339),
+		"items": []map[string]any{
+			{"rank": 1, "video_id": 42, "title": "Dunk", "user_id": 5, "username": "caro", "score": 9},
+		},
+	}
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("failed to marshal cache entry: %v", err)
+	}
+	cache.data["rank:videos:city:bogota:v2"] = payload
+
+	h := handlers.NewPublicHandlersWithCache(svc, cache, "v2")
+	r := gin.New()
+	r.GET("/api/public/rankings/videos", h.ListVideoRankings)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/public/rankings/videos?city=Bogot%C3%A1", nil)
+	r.ServeHTTP(w, req)
+
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+
+	var got []responses.VideoRankingEntry
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, uint(42), got[0].VideoID)
+		assert.Equal(t, "Dunk", got[0].Title)
+		assert.Equal(t, "caro", got[0].Username)
+		assert.Equal(t, 9, got[0].Votes)
+		if assert.NotNil(t, got[0].City) {
+			assert.Equal(t, "Bogotá", *got[0].City)
+		}
+	}
+	assert.Equal(t, 0, repo.videoCalls)
+}
+
+func TestPublicHandlers_ListVideoRankings_FallsBackToDB(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &cacheRepo{
+		videoResponse: []responses.VideoRankingItem{
+			{VideoID: 7, Title: "Triple", Username: "ana", Votes: 4},
+			{VideoID: 3, Title: "Pase", Username: "beto", Votes: 2},
+		},
+	}
+	svc := useCase.NewPublicService(repo, nil)
+	// Entrada de usuarios en cache: no debe usarse para el ranking de videos.
+	cache := &fakeCache{data: map[string][]byte{"rank:global:v2": []byte(`{"schema_version":"v2"}`)}}
+
+	h := handlers.NewPublicHandlersWithCache(svc, cache, "v2")
+	r := gin.New()
+	r.GET("/api/public/rankings/videos", h.ListVideoRankings)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/public/rankings/videos?page=1&pageSize=2", nil)
+	r.ServeHTTP(w, req)
+
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+
+	var got []responses.VideoRankingEntry
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, 1, got[0].Position)
+		assert.Equal(t, uint(7), got[0].VideoID)
+		assert.Equal(t, 2, got[1].Position)
+	}
+	assert.Equal(t, 1, repo.videoCalls)
+}
+
+func TestPublicHandlers_ListVideoRankings_InvalidParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewPublicHandlers(useCase.NewPublicService(&cacheRepo{}, nil))
+	r := gin.New()
+	r.GET("/api/public/rankings/videos", h.ListVideoRankings)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/public/rankings/videos?pageSize=101", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// filteredCacheRepo agrega FilteredRankings a cacheRepo.
+type filteredCacheRepo struct {
+	cacheRepo
+	filters []entities.RankingFilter
+}
+
+func (r *filteredCacheRepo) FilteredRankings(ctx context.Context, filter entities.RankingFilter, page, pageSize int) ([]responses.RankingItem, error) {
+	r.filters = append(r.filters, filter)
+	return []responses.RankingItem{{Username: "db", Votes: 1}}, nil
+}
+
+func windowCacheEntry(t *testing.T, fields map[string]any, username string) []byte {
+	t.Helper()
+	now := time.Now().UTC()
+	entry := map[string]any{
+		"schema_version": "v2",
+		"complete":       true,
+		"as_of":          now.Format(time.RFC3339),
+		"fresh_until":    now.Add(time.Minute).Format(time.RFC3339),
+		"stale_until":    now.Add(2 * time.Minute).Format(time.RFC3339),
+		"items": []map[string]any{
+			{"rank": 1, "user_id": 1, "username": username, "score": 4},
+		},
+	}
+	for k, v := range fields {
+		entry[k] = v
+	}
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("failed to marshal cache entry: %v", err)
+	}
+	return payload
+}
+
+func TestPublicHandlers_ListRankings_WindowAndCountryCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &filteredCacheRepo{}
+	svc := useCase.NewPublicService(repo, nil)
+	cache := &fakeCache{data: map[string][]byte{
+		"rank:window:week:global:v2":           windowCacheEntry(t, map[string]any{"scope": "global", "window": "week"}, "weekly"),
+		"rank:window:week:country:colombia:v2": windowCacheEntry(t, map[string]any{"scope": "country", "country_slug": "colombia", "window": "week"}, "colweek"),
+		"rank:country:colombia:v2":             windowCacheEntry(t, map[string]any{"scope": "country", "country_slug": "colombia"}, "colall"),
+		"rank:window:day:global:v2":            windowCacheEntry(t, map[string]any{"scope": "global", "window": "week"}, "mislabeled"),
+	}}
+
+	h := handlers.NewPublicHandlersWithCache(svc, cache, "v2")
+	r := gin.New()
+	r.GET("/api/public/rankings", h.ListRankings)
+
+	cases := []struct {
+		query    string
+		username string
+	}{
+		{"?window=week", "weekly"},
+		{"?window=week&country=Colombia", "colweek"},
+		{"?country=Colombia", "colall"},
+		// La entrada declara otra ventana: se ignora y se consulta la base de datos
+		{"?window=day", "db"},
+		// Ciudad y pais a la vez no tienen clave cacheada
+		{"?window=week&country=Colombia&city=Cali", "db"},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/rankings"+tc.query, nil))
+		if !assert.Equal(t, http.StatusOK, w.Code, tc.query) {
+			continue
+		}
+		var got []responses.RankingEntry
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if assert.Len(t, got, 1, tc.query) {
+			assert.Equal(t, tc.username, got[0].Username, tc.query)
+		}
+	}
+	if assert.Len(t, repo.filters, 2) {
+		assert.Equal(t, entities.RankingWindowDay, repo.filters[0].Window)
+		assert.NotNil(t, repo.filters[1].City)
+		assert.NotNil(t, repo.filters[1].Country)
+	}
+}
+
+func TestPublicHandlers_ListRankings_InvalidWindow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewPublicHandlers(useCase.NewPublicService(&filteredCacheRepo{}, nil))
+	r := gin.New()
+	r.GET("/api/public/rankings", h.ListRankings)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/rankings?window=year", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func strPtr(v string) *string {
 	out := v
 	return &out
@@ -226,6 +467,8 @@ type cacheRepo struct {
 	users           map[uint]responses.UserBasic
 	rankingResponse []responses.RankingItem
 	rankingsCalls   int
+	videoResponse   []responses.VideoRankingItem
+	videoCalls      int
 }
 
 func (r *cacheRepo) ListPublicVideos(ctx context.Context) ([]responses.PublicVideoResponse, error) {
@@ -244,6 +487,14 @@ func (r *cacheRepo) Rankings(ctx context.Context, city *string, page, pageSize i
 	return r.rankingResponse, nil
 }
 
+func (r *cacheRepo) VideoRankings(ctx context.Context, city *string, page, pageSize int) ([]responses.VideoRankingItem, error) {
+	r.videoCalls++
+	if len(r.videoResponse) == 0 {
+		return []responses.VideoRankingItem{}, nil
+	}
+	return r.videoResponse, nil
+}
+
 func (r *cacheRepo) GetUsersBasicByIDs(ctx context.Context, ids []uint) ([]responses.UserBasic, error) {
 	if len(ids) == 0 {
 		return []responses.UserBasic{}, nil
@@ -256,3 +507,214 @@ func (r *cacheRepo) GetUsersBasicByIDs(ctx context.Context, ids []uint) ([]respo
 	}
 	return res, nil
 }
+
+// galleryRepo agrega SearchPublicVideos a cacheRepo.
+type galleryRepo struct {
+	cacheRepo
+	rows    []responses.GalleryVideo
+	queries []entities.GalleryQuery
+}
+
+func (r *galleryRepo) SearchPublicVideos(ctx context.Context, q entities.GalleryQuery) ([]responses.GalleryVideo, error) {
+	r.queries = append(r.queries, q)
+	if len(r.rows) > q.Limit {
+		return r.rows[:q.Limit], nil
+	}
+	return r.rows, nil
+}
+
+func TestPublicHandlers_ListPublicVideos_SearchAndCursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uploaded := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := &galleryRepo{rows: []responses.GalleryVideo{
+		{PublicVideoResponse: responses.PublicVideoResponse{VideoID: 9, Title: "Gol de cabeza"}, UploadedAt: uploaded},
+		{PublicVideoResponse: responses.PublicVideoResponse{VideoID: 4, Title: "Golazo"}, UploadedAt: uploaded},
+	}}
+	h := handlers.NewPublicHandlers(useCase.NewPublicService(repo, nil))
+	r := gin.New()
+	r.GET("/api/public/videos", h.ListPublicVideos)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/videos?q=gol&country=CO&limit=1", nil))
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+	var got []responses.PublicVideoResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, uint(9), got[0].VideoID)
+	}
+	next := w.Header().Get("X-Next-Cursor")
+	assert.NotEmpty(t, next)
+	if assert.Len(t, repo.queries, 1) {
+		assert.Equal(t, "gol", repo.queries[0].Search)
+		assert.Equal(t, entities.GallerySortNewest, repo.queries[0].Sort)
+		if assert.NotNil(t, repo.queries[0].Country) {
+			assert.Equal(t, "CO", *repo.queries[0].Country)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/videos?q=gol&country=CO&limit=1&cursor="+next, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, repo.queries, 2) && assert.NotNil(t, repo.queries[1].After) {
+		assert.Equal(t, uint(9), repo.queries[1].After.VideoID)
+		assert.Equal(t, uploaded.UnixMicro(), repo.queries[1].After.Key)
+	}
+
+	for _, query := range []string{"?sort=oldest", "?limit=0", "?limit=101", "?sort=trending&cursor=" + next} {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/videos"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+// detailRepo sirve un unico video publico sobre galleryRepo.
+type detailRepo struct {
+	galleryRepo
+	video responses.PublicVideoResponse
+}
+
+func (r *detailRepo) GetPublicByID(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+	if id != r.video.VideoID {
+		return nil, domain.ErrNotFound
+	}
+	out := r.video
+	return &out, nil
+}
+
+// votedLookupRepo responde votos desde un mapa y cuenta las consultas en lote.
+type votedLookupRepo struct {
+	voted   map[uint]bool
+	lookups int
+}
+
+func (r *votedLookupRepo) HasUserVoted(ctx context.Context, videoID, userID uint) (bool, error) {
+	return r.voted[videoID], nil
+}
+
+func (r *votedLookupRepo) Create(ctx context.Context, videoID, userID uint) error { return nil }
+
+func (r *votedLookupRepo) VotedVideoIDs(ctx context.Context, userID uint, videoIDs []uint) (map[uint]bool, error) {
+	r.lookups++
+	return r.voted, nil
+}
+
+func TestPublicHandlers_GetPublicVideo_VotedByMe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	published := time.Date(2026, 3, 2, 15, 4, 5, 0, time.UTC)
+	repo := &detailRepo{video: responses.PublicVideoResponse{
+		VideoID: 4, Title: "Clavada", City: strPtr("Cali"), Votes: 12, OwnerUserID: 9, PublishedAt: &published,
+	}}
+	repo.users = map[uint]responses.UserBasic{9: {UserID: 9, Username: "ana"}}
+	votes := &votedLookupRepo{voted: map[uint]bool{4: true}}
+	h := handlers.NewPublicHandlers(useCase.NewPublicService(repo, votes))
+
+	withUser := func(userID uint) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			if userID != 0 {
+				c.Set("userID", userID)
+			}
+			c.Next()
+		}
+	}
+	anon := gin.New()
+	anon.GET("/api/public/videos/:video_id", h.GetPublicVideo)
+	authed := gin.New()
+	authed.GET("/api/public/videos/:video_id", withUser(5), h.GetPublicVideo)
+
+	w := httptest.NewRecorder()
+	authed.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/videos/4", nil))
+	if assert.Equal(t, http.StatusOK, w.Code) {
+		assert.JSONEq(t, `{"video_id":4,"title":"Clavada","processed_url":null,"city":"Cali","votes":12,"comments":0,
+			"published_at":"2026-03-02T15:04:05Z","voted_by_me":true,"owner_username":"ana"}`, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	anon.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/videos/4", nil))
+	if assert.Equal(t, http.StatusOK, w.Code) {
+		assert.NotContains(t, w.Body.String(), "voted_by_me")
+	}
+
+	w = httptest.NewRecorder()
+	anon.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/videos/5", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// El listado marca voted_by_me con una sola consulta para toda la pagina
+	repo.rows = []responses.GalleryVideo{
+		{PublicVideoResponse: responses.PublicVideoResponse{VideoID: 4}},
+		{PublicVideoResponse: responses.PublicVideoResponse{VideoID: 3}},
+	}
+	authed.GET("/api/public/videos", withUser(5), h.ListPublicVideos)
+	votes.lookups = 0
+	w = httptest.NewRecorder()
+	authed.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/videos", nil))
+	if assert.Equal(t, http.StatusOK, w.Code) {
+		var got []responses.PublicVideoResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if assert.Len(t, got, 2) && assert.NotNil(t, got[0].VotedByMe) && assert.NotNil(t, got[1].VotedByMe) {
+			assert.True(t, *got[0].VotedByMe)
+			assert.False(t, *got[1].VotedByMe)
+		}
+	}
+	assert.Equal(t, 1, votes.lookups)
+}
+
+// auditVoteRepo guarda la auditoria recibida por CreateWithAudit.
+type auditVoteRepo struct {
+	votedLookupRepo
+	audits []entities.VoteAudit
+}
+
+func (r *auditVoteRepo) CreateWithAudit(ctx context.Context, videoID, userID uint, eventID *string, audit entities.VoteAudit, policies []entities.VoteBudgetPolicy) error {
+	r.audits = append(r.audits, audit)
+	return nil
+}
+
+type staticVoteSignals struct{}
+
+func (staticVoteSignals) AccountCreatedAt(ctx context.Context, userID uint) (time.Time, error) {
+	return time.Now().Add(-30 * 24 * time.Hour), nil
+}
+
+func (staticVoteSignals) CountUserVotesSince(ctx context.Context, userID uint, since time.Time) (int, error) {
+	return 0, nil
+}
+
+func (staticVoteSignals) CountOtherAccountsByIPSince(ctx context.Context, ip string, userID uint, since time.Time) (int, error) {
+	return 0, nil
+}
+
+// La IP guardada con el voto alimenta la regla shared_ip: un X-Forwarded-For enviado por el
+// cliente solo se respeta cuando llega desde un proxy configurado en TRUSTED_PROXIES.
+func TestPublicHandlers_VotePublicVideo_MetadataIPFromTrustedProxyOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	votes := &auditVoteRepo{}
+	svc := useCase.NewPublicService(&detailRepo{video: responses.PublicVideoResponse{VideoID: 4}}, votes).
+		WithFraudDetector(useCase.NewVoteFraudDetector(staticVoteSignals{}))
+	h := handlers.NewPublicHandlers(svc)
+
+	vote := func(trusted []string) string {
+		r := gin.New()
+		if err := r.SetTrustedProxies(trusted); err != nil {
+			t.Fatalf("trusted proxies: %v", err)
+		}
+		r.POST("/api/public/videos/:video_id/vote", func(c *gin.Context) { c.Set("userID", uint(5)) }, h.VotePublicVideo)
+		req := httptest.NewRequest(http.MethodPost, "/api/public/videos/4/vote", nil)
+		req.RemoteAddr = "10.0.0.2:5000"
+		req.Header.Set("X-Forwarded-For", "198.51.100.7")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if !assert.Equal(t, http.StatusOK, w.Code) || !assert.NotEmpty(t, votes.audits) {
+			return ""
+		}
+		return votes.audits[len(votes.audits)-1].Metadata.IP
+	}
+
+	assert.Equal(t, "10.0.0.2", vote(nil))
+	assert.Equal(t, "198.51.100.7", vote([]string{"10.0.0.0/8"}))
+}
@@ -0,0 +1,209 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/responses"
+	"api/internal/presentation/handlers"
+
+	"github.com/gin-gonic/gin"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// rankLookupRepo agrega la busqueda de posiciones a cacheRepo.
+type rankLookupRepo struct {
+	cacheRepo
+	neighborhoods map[string][]responses.RankedUserItem
+	dbCalls       []string
+}
+
+func (r *rankLookupRepo) UserBasicByUsername(ctx context.Context, username string) (*responses.UserBasic, error) {
+	for _, ub := range r.users {
+		if strings.EqualFold(ub.Username, username) {
+			out := ub
+			return &out, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *rankLookupRepo) RankNeighborhood(ctx context.Context, userID uint, city *string) ([]responses.RankedUserItem, error) {
+	scope := "global"
+	if city != nil {
+		scope = *city
+	}
+	r.dbCalls = append(r.dbCalls, scope)
+	return r.neighborhoods[scope], nil
+}
+
+// fakeLeaderboardCache sirve vecindarios por clave de leaderboard; las claves ausentes
+// se comportan como un leaderboard inexistente.
+type fakeLeaderboardCache struct {
+	fakeCache
+	boards map[string][]responses.RankedUserItem
+}
+
+func (f *fakeLeaderboardCache) LeaderboardNeighborhood(ctx context.Context, boardKey, usersKey string, userID uint) ([]responses.RankedUserItem, error) {
+	rows, ok := f.boards[boardKey]
+	if !ok {
+		return nil, redis.Nil
+	}
+	out := make([]responses.RankedUserItem, len(rows))
+	copy(out, rows)
+	return out, nil
+}
+
+func rankLookupFixture() *rankLookupRepo {
+	bogota := "Bogotá"
+	cali := "Cali"
+	return &rankLookupRepo{cacheRepo: cacheRepo{users: map[uint]responses.UserBasic{
+		1: {UserID: 1, Username: "ana", City: &cali},
+		2: {UserID: 2, Username: "caro", City: &bogota},
+		3: {UserID: 3, Username: "dani", City: &bogota},
+	}}}
+}
+
+func TestPublicHandlers_UserRank_FromLeaderboardCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := rankLookupFixture()
+	svc := useCase.NewPublicService(repo, nil)
+	cache := &fakeLeaderboardCache{boards: map[string][]responses.RankedUserItem{
+		"lb:global:v2": {
+			{UserID: 1, Position: 4, Votes: 20},
+			{UserID: 2, Position: 5, Username: "caro", Votes: 18},
+			{UserID: 3, Position: 6, Votes: 18},
+		},
+		"lb:city:bogota:v2": {
+			{UserID: 2, Position: 1, Username: "caro", Votes: 18},
+			{UserID: 3, Position: 2, Votes: 18},
+		},
+	}}
+
+	h := handlers.NewPublicHandlersWithCache(svc, cache, "v2")
+	r := gin.New()
+	r.GET("/api/public/rankings/users/:username", h.UserRank)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/rankings/users/Caro", nil))
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+
+	var got responses.UserRankResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	assert.Equal(t, "caro", got.Username)
+	assert.Equal(t, 18, got.Votes)
+	if assert.NotNil(t, got.Global) {
+		assert.Equal(t, 5, got.Global.Position)
+		if assert.NotNil(t, got.Global.Above) {
+			assert.Equal(t, "ana", got.Global.Above.Username)
+			assert.Equal(t, 4, got.Global.Above.Position)
+			if assert.NotNil(t, got.Global.Above.City) {
+				assert.Equal(t, "Cali", *got.Global.Above.City)
+			}
+		}
+		if assert.NotNil(t, got.Global.Below) {
+			assert.Equal(t, "dani", got.Global.Below.Username)
+		}
+	}
+	if assert.NotNil(t, got.CityRank) {
+		assert.Equal(t, 1, got.CityRank.Position)
+		assert.Nil(t, got.CityRank.Above)
+		assert.Equal(t, "dani", got.CityRank.Below.Username)
+	}
+	assert.Empty(t, repo.dbCalls)
+}
+
+func TestPublicHandlers_MyRank_FallsBackToDB(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := rankLookupFixture()
+	cali := "Cali"
+	repo.neighborhoods = map[string][]responses.RankedUserItem{
+		"global": {
+			{UserID: 1, Position: 1, Username: "ana", City: &cali, Votes: 20},
+			{UserID: 2, Position: 2, Username: "caro", Votes: 18},
+		},
+		"Cali": {
+			{UserID: 1, Position: 1, Username: "ana", City: &cali, Votes: 20},
+		},
+	}
+	svc := useCase.NewPublicService(repo, nil)
+	// Leaderboards no mantenidos por AdminCache
+	cache := &fakeLeaderboardCache{}
+
+	h := handlers.NewPublicHandlersWithCache(svc, cache, "v2")
+	r := gin.New()
+	r.GET("/api/me/rank", func(c *gin.Context) {
+		c.Set("userID", uint(1))
+		h.MyRank(c)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/me/rank", nil))
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+
+	var got responses.UserRankResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	assert.Equal(t, "ana", got.Username)
+	assert.Equal(t, 20, got.Votes)
+	if assert.NotNil(t, got.Global) {
+		assert.Equal(t, 1, got.Global.Position)
+		assert.Nil(t, got.Global.Above)
+		assert.Equal(t, "caro", got.Global.Below.Username)
+	}
+	if assert.NotNil(t, got.CityRank) {
+		assert.Nil(t, got.CityRank.Below)
+	}
+	assert.Equal(t, []string{"global", "Cali"}, repo.dbCalls)
+}
+
+func TestPublicHandlers_UserRank_UnrankedAndUnknown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := rankLookupFixture()
+	svc := useCase.NewPublicService(repo, nil)
+	// El usuario no esta en el leaderboard global: sin posicion y sin consultar la base de datos.
+	cache := &fakeLeaderboardCache{boards: map[string][]responses.RankedUserItem{
+		"lb:global:v2":    {},
+		"lb:city:cali:v2": {},
+	}}
+
+	h := handlers.NewPublicHandlersWithCache(svc, cache, "v2")
+	r := gin.New()
+	r.GET("/api/public/rankings/users/:username", h.UserRank)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/rankings/users/ana", nil))
+	if assert.Equal(t, http.StatusOK, w.Code) {
+		assert.JSONEq(t, `{"username":"ana","city":"Cali","votes":0,"global":null,"city_rank":null}`, w.Body.String())
+	}
+	assert.Empty(t, repo.dbCalls)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/rankings/users/nadie", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPublicHandlers_MyRank_Unauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewPublicHandlers(useCase.NewPublicService(rankLookupFixture(), nil))
+	r := gin.New()
+	r.GET("/api/me/rank", h.MyRank)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/me/rank", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
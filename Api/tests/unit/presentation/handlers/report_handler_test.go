@@ -0,0 +1,138 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"api/internal/presentation/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// visibleVideoRepo expone un unico video publico para las denuncias.
+type visibleVideoRepo struct {
+	cacheRepo
+}
+
+func (r *visibleVideoRepo) GetPublicByID(ctx context.Context, id uint) (*responses.PublicVideoResponse, error) {
+	return &responses.PublicVideoResponse{VideoID: id}, nil
+}
+
+type mockReportRepo struct {
+	err      error
+	reporter uint
+	takedown uint
+}
+
+func (m *mockReportRepo) Create(ctx context.Context, report *entities.VideoReport, hideThreshold int) (bool, error) {
+	m.reporter = report.ReporterID
+	return false, m.err
+}
+
+func (m *mockReportRepo) ListOpen(ctx context.Context, page, pageSize int) ([]responses.ReportResponse, error) {
+	return []responses.ReportResponse{{ReportID: 1, VideoID: 3, OpenReports: 2}}, m.err
+}
+
+func (m *mockReportRepo) Dismiss(ctx context.Context, reportID, adminID uint, hideThreshold int) error {
+	return m.err
+}
+
+func (m *mockReportRepo) TakeDown(ctx context.Context, reportID, adminID uint) (uint, error) {
+	m.takedown = reportID
+	return 3, m.err
+}
+
+func setupReportRouter(repo *mockReportRepo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	uc := useCase.NewReportUseCase(&visibleVideoRepo{}, repo, 0)
+	h := handlers.NewReportHandlers(uc)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.POST("/api/public/videos/:video_id/report", h.ReportVideo)
+	r.GET("/api/admin/reports", h.ListReports)
+	r.POST("/api/admin/reports/:report_id/dismiss", h.DismissReport)
+	r.POST("/api/admin/reports/:report_id/takedown", h.TakeDownVideo)
+	return r
+}
+
+func postJSON(r *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestReportHandlers_ReportVideo_Created(t *testing.T) {
+	repo := &mockReportRepo{}
+	r := setupReportRouter(repo)
+
+	w := postJSON(r, "/api/public/videos/3/report", `{"category":"spam"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, uint(5), repo.reporter)
+}
+
+func TestReportHandlers_ReportVideo_Duplicate(t *testing.T) {
+	r := setupReportRouter(&mockReportRepo{err: domain.ErrConflict})
+
+	w := postJSON(r, "/api/public/videos/3/report", `{"category":"abuse"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestReportHandlers_ReportVideo_InvalidCategory(t *testing.T) {
+	r := setupReportRouter(&mockReportRepo{})
+
+	w := postJSON(r, "/api/public/videos/3/report", `{"category":"meh"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestReportHandlers_ListReports(t *testing.T) {
+	r := setupReportRouter(&mockReportRepo{})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/reports", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"open_reports":2`)
+}
+
+func TestReportHandlers_Dismiss_AlreadyResolved(t *testing.T) {
+	r := setupReportRouter(&mockReportRepo{err: domain.ErrConflict})
+
+	w := postJSON(r, "/api/admin/reports/1/dismiss", "")
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestReportHandlers_TakeDown(t *testing.T) {
+	repo := &mockReportRepo{}
+	r := setupReportRouter(repo)
+
+	w := postJSON(r, "/api/admin/reports/1/takedown", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, uint(1), repo.takedown)
+	assert.Contains(t, w.Body.String(), `"video_id":"3"`)
+}
+
+func TestReportHandlers_TakeDown_InvalidID(t *testing.T) {
+	r := setupReportRouter(&mockReportRepo{})
+
+	w := postJSON(r, "/api/admin/reports/abc/takedown", "")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -91,3 +91,44 @@ func TestUserHandlers_Register_PasswordsDoNotMatch(t *testing.T) {
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
+
+type stubLocationRepo struct{}
+
+func (stubLocationRepo) GetCityID(ctx context.Context, country, city string) (int, error) {
+	return 1, nil
+}
+
+func TestUserHandlers_Register_UsernameUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	userRepo := &mocks.MockUserRepository{
+		GetByEmailFunc: func(ctx context.Context, email string) (*entities.User, error) {
+			return nil, domain.ErrNotFound
+		},
+		CreateFunc: func(ctx context.Context, user *entities.User) error {
+			return domain.ErrConflict
+		},
+	}
+	svc := usecase.NewUserService(userRepo, stubLocationRepo{})
+	h := handlers.NewUserHandlers(svc)
+	r.POST("/api/auth/signup", h.Register)
+
+	body := map[string]any{
+		"first_name": "Ana",
+		"last_name":  "Lopez",
+		"email":      "ana@example.com",
+		"password1":  "abc123",
+		"password2":  "abc123",
+		"country":    "Peru",
+		"city":       "Lima",
+	}
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/signup", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "username_unavailable")
+}
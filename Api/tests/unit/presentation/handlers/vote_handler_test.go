@@ -0,0 +1,157 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/entities"
+	"api/internal/domain/responses"
+	"api/internal/presentation/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type retractVoteRepo struct {
+	err     error
+	eventID *string
+}
+
+func (m *retractVoteRepo) HasUserVoted(ctx context.Context, videoID, userID uint) (bool, error) {
+	return false, nil
+}
+
+func (m *retractVoteRepo) Create(ctx context.Context, videoID, userID uint) error { return nil }
+
+func (m *retractVoteRepo) Retract(ctx context.Context, videoID, userID uint, eventID *string) error {
+	m.eventID = eventID
+	return m.err
+}
+
+func newRetractRouter(repo *retractVoteRepo, userID uint) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewPublicHandlers(useCase.NewPublicService(&visibleVideoRepo{}, repo))
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if userID != 0 {
+			c.Set("userID", userID)
+		}
+		c.Next()
+	})
+	r.DELETE("/api/public/videos/:video_id/vote", h.RetractVote)
+	return r
+}
+
+func TestPublicHandlers_RetractVote(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID uint
+		path   string
+		err    error
+		want   int
+	}{
+		{"unauthorized", 0, "/api/public/videos/1/vote", nil, http.StatusUnauthorized},
+		{"invalid id", 5, "/api/public/videos/abc/vote", nil, http.StatusBadRequest},
+		{"retracted", 5, "/api/public/videos/1/vote", nil, http.StatusOK},
+		{"replayed event", 5, "/api/public/videos/1/vote", domain.ErrIdempotent, http.StatusOK},
+		{"not voted", 5, "/api/public/videos/1/vote", domain.ErrConflict, http.StatusBadRequest},
+		{"video missing", 5, "/api/public/videos/1/vote", domain.ErrNotFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRetractRouter(&retractVoteRepo{err: tt.err}, tt.userID)
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestPublicHandlers_RetractVote_ForwardsEventID(t *testing.T) {
+	repo := &retractVoteRepo{}
+	r := newRetractRouter(repo, 5)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/public/videos/1/vote?eventId=q-evt", nil)
+	req.Header.Set("X-Event-Id", " h-evt ")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.NotNil(t, repo.eventID) {
+		assert.Equal(t, "h-evt", *repo.eventID)
+	}
+}
+
+type budgetVoteRepo struct {
+	retractVoteRepo
+	createErr error
+}
+
+func (m *budgetVoteRepo) CreateWithBudget(ctx context.Context, videoID, userID uint, eventID *string, policies []entities.VoteBudgetPolicy) error {
+	return m.createErr
+}
+
+func (m *budgetVoteRepo) CountVotes(ctx context.Context, userID uint, since *time.Time, contestID *uint) (int, error) {
+	return 1, nil
+}
+
+func (m *budgetVoteRepo) ListByUser(ctx context.Context, userID uint, page, pageSize int) ([]responses.UserVoteItem, error) {
+	return nil, nil
+}
+
+func newBudgetRouter(repo *budgetVoteRepo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	svc := useCase.NewPublicService(&visibleVideoRepo{}, repo).
+		WithVoteBudget([]entities.VoteBudgetPolicy{{Scope: entities.VoteBudgetDaily, Limit: 3}})
+	h := handlers.NewPublicHandlers(svc)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.POST("/api/public/videos/:video_id/vote", h.VotePublicVideo)
+	r.GET("/api/me/votes", h.MyVotes)
+	return r
+}
+
+func TestPublicHandlers_VotePublicVideo_BudgetExceeded(t *testing.T) {
+	r := newBudgetRouter(&budgetVoteRepo{createErr: domain.ErrVoteBudgetExceeded})
+	w := postJSON(r, "/api/public/videos/1/vote", `{}`)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func TestPublicHandlers_MyVotes(t *testing.T) {
+	r := newBudgetRouter(&budgetVoteRepo{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/me/votes", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body responses.MyVotesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Budgets, 1)
+	assert.Equal(t, 2, body.Budgets[0].Remaining)
+	assert.NotNil(t, body.Votes)
+
+	// Paginacion invalida o parametros ajenos al historial (filtros de ranking)
+	for _, query := range []string{"?pageSize=500", "?page=0", "?city=Lima", "?page=1&window=week"} {
+		req = httptest.NewRequest(http.MethodGet, "/api/me/votes"+query, nil)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/me/votes?page=2&pageSize=5", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,102 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api/internal/application/useCase"
+	"api/internal/domain"
+	"api/internal/domain/responses"
+	"api/internal/presentation/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockQuarantineRepo struct {
+	err      error
+	cleared  uint
+	discard  uint
+	reviewer uint
+	pageSize int
+}
+
+func (m *mockQuarantineRepo) ListQuarantined(ctx context.Context, page, pageSize int) ([]responses.QuarantinedVote, error) {
+	m.pageSize = pageSize
+	return []responses.QuarantinedVote{{VoteID: 4, VideoID: 3, Flags: []string{"shared_ip"}}}, m.err
+}
+
+func (m *mockQuarantineRepo) Clear(ctx context.Context, voteID, reviewerID uint) error {
+	m.cleared, m.reviewer = voteID, reviewerID
+	return m.err
+}
+
+func (m *mockQuarantineRepo) Discard(ctx context.Context, voteID, reviewerID uint) error {
+	m.discard, m.reviewer = voteID, reviewerID
+	return m.err
+}
+
+func setupVoteReviewRouter(repo *mockQuarantineRepo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewVoteReviewHandlers(useCase.NewVoteReviewUseCase(repo))
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(9))
+		c.Next()
+	})
+	r.GET("/api/admin/votes/quarantine", h.ListQuarantined)
+	r.POST("/api/admin/votes/:vote_id/clear", h.ClearVote)
+	r.POST("/api/admin/votes/:vote_id/discard", h.DiscardVote)
+	return r
+}
+
+func TestVoteReviewHandlers_ListQuarantined(t *testing.T) {
+	repo := &mockQuarantineRepo{}
+	r := setupVoteReviewRouter(repo)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/votes/quarantine?pageSize=50", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 50, repo.pageSize)
+	var items []map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &items))
+	require.Len(t, items, 1)
+	assert.Equal(t, []any{"shared_ip"}, items[0]["flags"])
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/votes/quarantine?pageSize=500", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestVoteReviewHandlers_ClearAndDiscard(t *testing.T) {
+	repo := &mockQuarantineRepo{}
+	r := setupVoteReviewRouter(repo)
+
+	w := postJSON(r, "/api/admin/votes/4/clear", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, uint(4), repo.cleared)
+	assert.Equal(t, uint(9), repo.reviewer)
+
+	w = postJSON(r, "/api/admin/votes/6/discard", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, uint(6), repo.discard)
+
+	w = postJSON(r, "/api/admin/votes/abc/clear", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestVoteReviewHandlers_ErrorMapping(t *testing.T) {
+	cases := map[error]int{
+		domain.ErrNotFound: http.StatusNotFound,
+		domain.ErrConflict: http.StatusConflict,
+	}
+	for err, code := range cases {
+		r := setupVoteReviewRouter(&mockQuarantineRepo{err: err})
+		assert.Equal(t, code, postJSON(r, "/api/admin/votes/4/clear", "").Code, err.Error())
+		assert.Equal(t, code, postJSON(r, "/api/admin/votes/4/discard", "").Code, err.Error())
+	}
+}
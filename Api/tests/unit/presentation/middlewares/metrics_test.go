@@ -0,0 +1,33 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api/internal/infrastructure/metrics"
+	"api/internal/presentation/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_LabelsByRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := metrics.New()
+	r := gin.New()
+	r.Use(middlewares.Metrics(m))
+	r.GET("/items/:item_id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	r.GET("/metrics", gin.WrapH(m.Handler()))
+
+	for _, target := range []string{"/items/1", "/items/2", "/unknown/path"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := w.Body.String()
+	assert.Contains(t, out, `api_http_request_duration_seconds_count{method="GET",route="/items/:item_id",status="204"} 2`)
+	assert.Contains(t, out, `api_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, out, `route="/items/1"`)
+}
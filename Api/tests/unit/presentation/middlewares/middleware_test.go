@@ -155,3 +155,74 @@ func TestJWTMiddleware_BlacklistedToken(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name     string
+		perms    any
+		set      bool
+		expected int
+	}{
+		{"granted", []string{"vote", "moderate_videos"}, true, http.StatusOK},
+		{"missing privilege", []string{"vote"}, true, http.StatusForbidden},
+		{"no permissions in context", nil, false, http.StatusUnauthorized},
+		{"invalid permissions type", "moderate_videos", true, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tt.set {
+					c.Set("permissions", tt.perms)
+				}
+				c.Next()
+			})
+			r.GET("/moderation", middlewares.RequirePermission("moderate_videos"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/moderation", nil))
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
+
+func TestOptionalJWTMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authService := useCase.NewAuthService(&mockUserRepoMiddleware{}, "secret")
+
+	claims := useCase.AuthClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "7"}}
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+
+	r := gin.New()
+	r.Use(middlewares.OptionalJWTMiddleware(authService, "secret"))
+	r.GET("/public", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": c.GetUint("userID")})
+	})
+
+	cases := []struct {
+		name   string
+		header string
+		code   int
+		body   string
+	}{
+		{"anonymous", "", http.StatusOK, `{"user_id":0}`},
+		{"valid token", "Bearer " + token, http.StatusOK, `{"user_id":7}`},
+		{"invalid token", "Bearer invalid", http.StatusUnauthorized, ""},
+		{"malformed header", "Token " + token, http.StatusUnauthorized, ""},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/public", nil)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tc.code, w.Code, tc.name)
+		if tc.body != "" {
+			assert.JSONEq(t, tc.body, w.Body.String(), tc.name)
+		}
+	}
+}
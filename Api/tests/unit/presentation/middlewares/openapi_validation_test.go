@@ -0,0 +1,168 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api/internal/presentation/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: 3.1.0
+info:
+  title: test
+  version: 1.0.0
+paths:
+  /items/{item_id}:
+    get:
+      parameters:
+      - name: item_id
+        in: path
+        required: true
+        schema:
+          type: integer
+      - name: limit
+        in: query
+        schema:
+          type: integer
+          maximum: 10
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  name:
+                    type: string
+  /items:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        '201':
+          description: Created
+`
+
+func newValidatedRouter(t *testing.T, v *middlewares.OpenAPIValidator, itemBody string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(v.Middleware())
+	r.GET("/items/:item_id", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", []byte(itemBody))
+	})
+	r.POST("/items", func(c *gin.Context) {
+		var body struct{ Name string }
+		if err := c.ShouldBindJSON(&body); err != nil || body.Name == "" {
+			c.Status(http.StatusTeapot)
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+	r.GET("/internal", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func serve(r *gin.Engine, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestOpenAPIValidator_RejectsInvalidRequests(t *testing.T) {
+	v, err := middlewares.NewOpenAPIValidator([]byte(testSpec))
+	require.NoError(t, err)
+	r := newValidatedRouter(t, v, `{"name":"uno"}`)
+
+	tests := []struct {
+		name, method, target, body string
+		status                     int
+		code                       string
+	}{
+		{"valid request", http.MethodGet, "/items/1?limit=5", "", http.StatusOK, ""},
+		{"invalid path parameter", http.MethodGet, "/items/abc", "", http.StatusBadRequest, "invalid_parameter"},
+		{"invalid query parameter", http.MethodGet, "/items/1?limit=50", "", http.StatusBadRequest, "invalid_query"},
+		{"invalid body", http.MethodPost, "/items", `{"name":1}`, http.StatusBadRequest, "invalid_body"},
+		{"missing body", http.MethodPost, "/items", "", http.StatusBadRequest, "invalid_body"},
+		{"valid body reaches the handler intact", http.MethodPost, "/items", `{"name":"uno"}`, http.StatusCreated, ""},
+		{"undocumented route passes through", http.MethodGet, "/internal", "", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, tt.method, tt.target, tt.body)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.code != "" {
+				assert.Contains(t, w.Header().Get("Content-Type"), "application/problem+json")
+				assert.Contains(t, w.Body.String(), `"code":"`+tt.code+`"`)
+			}
+		})
+	}
+}
+
+func TestOpenAPIValidator_SkipsLargeBodies(t *testing.T) {
+	v, err := middlewares.NewOpenAPIValidator([]byte(testSpec))
+	require.NoError(t, err)
+	r := newValidatedRouter(t, v.WithMaxBodyBytes(4), "{}")
+
+	// El cuerpo supera el limite: no se valida y el handler decide
+	w := serve(r, http.MethodPost, "/items", `{"name":1}`)
+	assert.Equal(t, http.StatusTeapot, w.Code)
+}
+
+func TestOpenAPIValidator_ResponseValidation(t *testing.T) {
+	tests := []struct {
+		name, target, itemBody string
+		violation              string
+	}{
+		{"conforming response", "/items/1", `{"name":"uno"}`, ""},
+		{"body outside the schema", "/items/1", `{"title":"uno"}`, "doesn't match schema"},
+		{"undocumented status", "/items/1?limit=50", `{"name":"uno"}`, "status is not supported"},
+		{"undocumented route", "/internal", "", "GET /internal is not documented"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var violations []string
+			v, err := middlewares.NewOpenAPIValidator([]byte(testSpec))
+			require.NoError(t, err)
+			v.WithResponseValidation(func(_ *gin.Context, err error) {
+				violations = append(violations, err.Error())
+			})
+			r := newValidatedRouter(t, v, tt.itemBody)
+
+			w := serve(r, http.MethodGet, tt.target, "")
+			if tt.violation == "" {
+				assert.Empty(t, violations)
+				assert.Equal(t, http.StatusOK, w.Code)
+				return
+			}
+			require.Len(t, violations, 1)
+			assert.Contains(t, violations[0], tt.violation)
+		})
+	}
+}
+
+func TestNewOpenAPIValidator_InvalidSpec(t *testing.T) {
+	_, err := middlewares.NewOpenAPIValidator([]byte("openapi: 3.1.0\npaths: [}"))
+	assert.Error(t, err)
+}
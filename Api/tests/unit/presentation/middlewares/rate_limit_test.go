@@ -0,0 +1,137 @@
+package middlewares_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api/internal/domain/entities"
+	"api/internal/infrastructure/cache"
+	"api/internal/presentation/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, entities.RateLimit) (entities.RateLimitDecision, error) {
+	return entities.RateLimitDecision{}, errors.New("redis down")
+}
+
+func newRateLimitedRouter(mw gin.HandlerFunc, userID uint) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/login", func(c *gin.Context) {
+		if userID != 0 {
+			c.Set("userID", userID)
+		}
+		c.Next()
+	}, mw, func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func doRateLimited(r *gin.Engine, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/login", nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_HeadersAndTooManyRequests(t *testing.T) {
+	limit := entities.RateLimit{Limit: 2, Period: time.Minute}
+	r := newRateLimitedRouter(middlewares.RateLimit(cache.NewMemoryRateLimiter(), "auth", limit), 0)
+
+	w := doRateLimited(r, "10.0.0.1:1234")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", w.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=60", w.Header().Get("RateLimit-Policy"))
+	assert.Empty(t, w.Header().Get("Retry-After"))
+
+	w = doRateLimited(r, "10.0.0.1:1234")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+	w = doRateLimited(r, "10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Contains(t, w.Body.String(), `"code":"rate_limited"`)
+
+	// Otra IP no comparte el limite
+	w = doRateLimited(r, "10.0.0.2:1234")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func doRateLimitedVia(r *gin.Engine, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/login", nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("X-Forwarded-For", forwardedFor)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_IgnoresSpoofedForwardedFor(t *testing.T) {
+	limit := entities.RateLimit{Limit: 1, Period: time.Minute}
+	r := newRateLimitedRouter(middlewares.RateLimit(cache.NewMemoryRateLimiter(), "auth", limit), 0)
+	// Como cmd/api con TRUSTED_PROXIES vacio
+	require.NoError(t, r.SetTrustedProxies(nil))
+
+	assert.Equal(t, http.StatusOK, doRateLimitedVia(r, "198.51.100.7:1", "203.0.113.1").Code)
+	// Rotar X-Forwarded-For no da un presupuesto nuevo: la clave es la IP del peer
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitedVia(r, "198.51.100.7:1", "203.0.113.2").Code)
+}
+
+func TestRateLimit_ClientIPFromTrustedProxy(t *testing.T) {
+	limit := entities.RateLimit{Limit: 1, Period: time.Minute}
+	r := newRateLimitedRouter(middlewares.RateLimit(cache.NewMemoryRateLimiter(), "auth", limit), 0)
+	require.NoError(t, r.SetTrustedProxies([]string{"172.16.0.0/12"}))
+
+	// Detras de nginx cada cliente real tiene su propio limite
+	assert.Equal(t, http.StatusOK, doRateLimitedVia(r, "172.18.0.5:1", "203.0.113.1").Code)
+	assert.Equal(t, http.StatusOK, doRateLimitedVia(r, "172.18.0.5:1", "203.0.113.2").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitedVia(r, "172.18.0.5:1", "203.0.113.1").Code)
+	// Un peer fuera de TRUSTED_PROXIES no puede fijar la IP
+	assert.Equal(t, http.StatusOK, doRateLimitedVia(r, "198.51.100.7:1", "203.0.113.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitedVia(r, "198.51.100.7:1", "203.0.113.9").Code)
+}
+
+func TestRateLimit_KeyedByUser(t *testing.T) {
+	limiter := cache.NewMemoryRateLimiter()
+	limit := entities.RateLimit{Limit: 1, Period: time.Minute}
+	userA := newRateLimitedRouter(middlewares.RateLimit(limiter, "vote", limit), 7)
+	userB := newRateLimitedRouter(middlewares.RateLimit(limiter, "vote", limit), 8)
+
+	assert.Equal(t, http.StatusOK, doRateLimited(userA, "10.0.0.1:1").Code)
+	// Misma IP, otro usuario: presupuesto independiente
+	assert.Equal(t, http.StatusOK, doRateLimited(userB, "10.0.0.1:1").Code)
+	// Mismo usuario desde otra IP: comparte el presupuesto
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimited(userA, "10.0.0.9:1").Code)
+}
+
+func TestRateLimit_DisabledOrFailingLimiter(t *testing.T) {
+	limit := entities.RateLimit{Limit: 1, Period: time.Minute}
+
+	r := newRateLimitedRouter(middlewares.RateLimit(failingLimiter{}, "auth", limit), 0)
+	for i := 0; i < 3; i++ {
+		w := doRateLimited(r, "10.0.0.1:1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	}
+
+	r = newRateLimitedRouter(middlewares.RateLimit(nil, "auth", limit), 0)
+	assert.Equal(t, http.StatusOK, doRateLimited(r, "10.0.0.1:1").Code)
+
+	r = newRateLimitedRouter(middlewares.RateLimit(cache.NewMemoryRateLimiter(), "auth", entities.RateLimit{}), 0)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, doRateLimited(r, "10.0.0.1:1").Code)
+	}
+}
@@ -0,0 +1,91 @@
+package middlewares_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api/internal/logging"
+	"api/internal/presentation/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requestIDRouter(seen *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middlewares.RequestID())
+	r.GET("/items", func(c *gin.Context) {
+		*seen = logging.RequestID(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+	return r
+}
+
+func TestRequestID_ReusesValidIncomingHeader(t *testing.T) {
+	var seen string
+	r := requestIDRouter(&seen)
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set(middlewares.RequestIDHeader, "client-req.42")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, "client-req.42", w.Header().Get(middlewares.RequestIDHeader))
+	assert.Equal(t, "client-req.42", seen)
+}
+
+func TestRequestID_GeneratesWhenMissingOrUnsafe(t *testing.T) {
+	for name, incoming := range map[string]string{
+		"missing":  "",
+		"newline":  "abc\ninjected",
+		"too long": strings.Repeat("a", 129),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var seen string
+			r := requestIDRouter(&seen)
+
+			req := httptest.NewRequest(http.MethodGet, "/items", nil)
+			if incoming != "" {
+				req.Header[middlewares.RequestIDHeader] = []string{incoming}
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			got := w.Header().Get(middlewares.RequestIDHeader)
+			_, err := uuid.Parse(got)
+			assert.NoError(t, err)
+			assert.Equal(t, got, seen)
+		})
+	}
+}
+
+func TestAccessLog_LogsRequestWithRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(logging.New(&buf, "info", "json"))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middlewares.RequestID(), middlewares.AccessLog())
+	r.GET("/items/:item_id", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	req := httptest.NewRequest(http.MethodGet, "/items/7", nil)
+	req.Header.Set(middlewares.RequestIDHeader, "req-7")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	var rec map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+	assert.Equal(t, "ERROR", rec["level"])
+	assert.Equal(t, "req-7", rec[logging.RequestIDKey])
+	assert.Equal(t, "/items/:item_id", rec["route"])
+	assert.Equal(t, float64(http.StatusInternalServerError), rec["status"])
+}
@@ -0,0 +1,158 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"api/internal/domain"
+	"api/internal/presentation/problem"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateLanguage(t *testing.T) {
+	cases := map[string]string{
+		"":                          problem.LangSpanish,
+		"en":                        problem.LangEnglish,
+		"en-US,en;q=0.9":            problem.LangEnglish,
+		"es-CO":                     problem.LangSpanish,
+		"fr-FR, en;q=0.5, es;q=0.8": problem.LangSpanish,
+		"fr, en;q=0.3":              problem.LangEnglish,
+		"de":                        problem.LangSpanish,
+		"*":                         problem.LangSpanish,
+		"en;q=bad, es;q=0.1":        problem.LangSpanish,
+	}
+	for header, want := range cases {
+		assert.Equal(t, want, problem.NegotiateLanguage(header), header)
+	}
+}
+
+// declaredCodes lee de codes.go todas las constantes de tipo Code, para que un codigo nuevo
+// sin traduccion haga fallar TestCatalogs_SameCodes sin mantener una lista aparte.
+func declaredCodes(t *testing.T) []problem.Code {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "../../../../internal/presentation/problem/codes.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parse codes.go: %v", err)
+	}
+	var codes []problem.Code
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if ident, ok := vs.Type.(*ast.Ident); !ok || ident.Name != "Code" {
+				continue
+			}
+			for _, v := range vs.Values {
+				lit, ok := v.(*ast.BasicLit)
+				if !ok {
+					continue
+				}
+				value, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					t.Fatalf("code %s: %v", lit.Value, err)
+				}
+				codes = append(codes, problem.Code(value))
+			}
+		}
+	}
+	return codes
+}
+
+func TestCatalogs_SameCodes(t *testing.T) {
+	codes := declaredCodes(t)
+	assert.Contains(t, codes, problem.CodeUsernameUnavailable)
+	for _, code := range codes {
+		es := problem.Message(problem.LangSpanish, code)
+		en := problem.Message(problem.LangEnglish, code)
+		assert.NotEmpty(t, es, code)
+		assert.NotEmpty(t, en, code)
+		// Sin traduccion Message cae al idioma por defecto
+		assert.NotEqual(t, es, en, code)
+	}
+}
+
+func serveError(t *testing.T, err error, acceptLanguage string, rules ...problem.Rule) (*httptest.ResponseRecorder, problem.Problem) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/things/:id", func(c *gin.Context) {
+		problem.AbortWithError(c, err, rules...)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/things/7", nil)
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var p problem.Problem
+	if jerr := json.Unmarshal(w.Body.Bytes(), &p); jerr != nil {
+		t.Fatalf("invalid json: %v", jerr)
+	}
+	return w, p
+}
+
+func TestAbortWithError_DomainDefaults(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   problem.Code
+	}{
+		{fmt.Errorf("%w: title is required", domain.ErrInvalid), http.StatusBadRequest, problem.CodeInvalidInput},
+		{domain.ErrNotFound, http.StatusNotFound, problem.CodeNotFound},
+		{domain.ErrForbidden, http.StatusForbidden, problem.CodeForbidden},
+		{domain.ErrConflict, http.StatusConflict, problem.CodeConflict},
+		{domain.ErrIdempotent, http.StatusConflict, problem.CodeDuplicateRequest},
+		{domain.ErrRateLimited, http.StatusTooManyRequests, problem.CodeRateLimited},
+		{domain.ErrVotingClosed, http.StatusForbidden, problem.CodeVotingClosed},
+		{domain.ErrVoteBudgetExceeded, http.StatusTooManyRequests, problem.CodeVoteBudgetExceeded},
+	}
+	for _, tc := range cases {
+		w, p := serveError(t, tc.err, "")
+		assert.Equal(t, tc.status, w.Code, tc.code)
+		assert.Equal(t, tc.status, p.Status)
+		assert.Equal(t, tc.code, p.Code)
+		assert.Equal(t, "urn:videorank:error:"+string(tc.code), p.Type)
+		assert.Equal(t, "/api/things/7", p.Instance)
+		assert.NotContains(t, w.Body.String(), "title is required")
+	}
+}
+
+func TestAbortWithError_RulesAndLocalization(t *testing.T) {
+	rule := problem.On(domain.ErrConflict, http.StatusBadRequest, problem.CodeAlreadyVoted)
+
+	w, p := serveError(t, domain.ErrConflict, "", rule)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, problem.CodeAlreadyVoted, p.Code)
+	assert.Equal(t, "Solicitud inválida", p.Title)
+	assert.Equal(t, "Ya has votado por este video.", p.Detail)
+	assert.Equal(t, "application/problem+json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "es", w.Header().Get("Content-Language"))
+
+	w, p = serveError(t, domain.ErrConflict, "en-GB,en;q=0.9", rule)
+	assert.Equal(t, problem.CodeAlreadyVoted, p.Code)
+	assert.Equal(t, "Bad Request", p.Title)
+	assert.Equal(t, "You already voted for this video.", p.Detail)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+	assert.Contains(t, w.Header().Values("Vary"), "Accept-Language")
+}
+
+func TestAbortWithError_UnknownErrorDoesNotLeak(t *testing.T) {
+	w, p := serveError(t, errors.New(`pq: relation "users" does not exist`), "en")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, problem.CodeInternal, p.Code)
+	assert.NotContains(t, w.Body.String(), "pq:")
+}
@@ -1,24 +1,46 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"admincache/internal/application/leaderboard"
 	"admincache/internal/application/ranking"
 	"admincache/internal/application/scheduler"
 	"admincache/internal/infrastructure"
 )
 
+// consumerStopTimeout acota la espera al consumidor de votos al apagar: cubre el handler
+// (5s) y la pausa antes de reencolar un evento fallido.
+const consumerStopTimeout = 10 * time.Second
+
 func main() {
 	cfg := infrastructure.LoadConfig()
+	if len(os.Args) > 1 && os.Args[1] == "probe" {
+		if err := infrastructure.ProbeHealth(cfg.HealthAddr); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 	logger := infrastructure.NewLogger()
+	slog.SetDefault(logger)
+
+	if srv := infrastructure.ServeMetrics(cfg.MetricsAddr, logger); srv != nil {
+		defer srv.Close()
+	}
 
 	rdb := infrastructure.MustRedis(cfg.RedisAddr)
 	db := infrastructure.MustPostgres(cfg.PostgresDSN)
 
 	comp := ranking.NewRankComputer(db)
+	watcher := ranking.NewRetractionWatcher(db)
 	cache := infrastructure.NewCache(rdb, infrastructure.CacheSettings{
 		Prefix:        cfg.CachePrefix,
 		FreshTTL:      time.Duration(cfg.TTLFreshSeconds) * time.Second,
@@ -27,12 +49,66 @@ func main() {
 		LockLease:     time.Duration(cfg.LockLeaseSeconds) * time.Second,
 	})
 
+	healthChecks := []infrastructure.HealthCheck{
+		infrastructure.RedisHealthCheck(rdb),
+		infrastructure.PostgresHealthCheck(db),
+	}
+	var consumerStatus *infrastructure.ConsumerStatus
+	if cfg.RabbitMQURL != "" {
+		consumerStatus = &infrastructure.ConsumerStatus{}
+		healthChecks = append(healthChecks, consumerStatus.HealthCheck())
+	}
+	if srv := infrastructure.ServeHealth(cfg.HealthAddr,
+		time.Duration(cfg.HealthCheckTimeoutMs)*time.Millisecond, logger, healthChecks...); srv != nil {
+		defer srv.Close()
+	}
+
 	stopWarm := make(chan struct{})
-	go scheduler.StartWarmup(comp, cache, cfg, logger, stopWarm)
+	go scheduler.StartWarmupWithWatcher(comp, watcher, cache, cfg, logger, stopWarm)
+
+	// consumerDone se cierra cuando el consumidor de votos termino el evento en curso.
+	consumerDone := make(chan struct{})
+	if cfg.RabbitMQURL != "" {
+		store := infrastructure.NewLeaderboardStore(rdb, cfg.CachePrefix, cfg.SchemaVersion,
+			time.Duration(cfg.LeaderboardSeenTTLSeconds)*time.Second)
+		board := leaderboard.NewService(store, logger)
+		go func() {
+			defer close(consumerDone)
+			infrastructure.ConsumeVoteEvents(infrastructure.VoteEventConsumerConfig{
+				URL:      cfg.RabbitMQURL,
+				Exchange: cfg.VoteEventsExchange,
+				Queue:    cfg.LeaderboardQueue,
+				Prefetch: cfg.LeaderboardPrefetch,
+				Status:   consumerStatus,
+			}, func(ctx context.Context, messageID string, body []byte) error {
+				err := board.HandleEvent(ctx, messageID, body)
+				if errors.Is(err, leaderboard.ErrMalformedEvent) {
+					// Reintentar no lo arreglaria: se descarta.
+					logger.Error("vote event discarded", "message_id", messageID, "err", err)
+					return nil
+				}
+				return err
+			}, logger, stopWarm)
+		}()
+		if lister, ok := comp.(ranking.ScoreLister); ok {
+			reconciler := leaderboard.NewReconciler(lister, store, store, logger)
+			go reconciler.Start(time.Duration(cfg.LeaderboardReconcileSeconds)*time.Second,
+				time.Duration(cfg.DBReadTimeoutSeconds*10)*time.Second, stopWarm)
+		}
+	} else {
+		logger.Info("RABBITMQ_URL not set, vote event leaderboards disabled")
+		close(consumerDone)
+	}
 
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 	<-sig
 	logger.Info("shutting down AdminCache...")
 	close(stopWarm)
+	// El evento en curso tarda a lo sumo el timeout del handler; los no confirmados vuelven a la cola
+	select {
+	case <-consumerDone:
+	case <-time.After(consumerStopTimeout):
+		logger.Warn("vote events consumer did not stop in time")
+	}
 }
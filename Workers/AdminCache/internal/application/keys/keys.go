@@ -76,3 +76,102 @@ func RankLockCity(citySlug, version string) string {
 func CityIndex(version string) string {
 	return fmt.Sprintf("rank:index:cities:%s", version)
 }
+
+func RankContest(contestID int64, version string) string {
+	return fmt.Sprintf("rank:contest:%d:global:%s", contestID, version)
+}
+
+func RankContestCity(contestID int64, citySlug, version string) string {
+	return fmt.Sprintf("rank:contest:%d:city:%s:%s", contestID, citySlug, version)
+}
+
+func RankLockContest(contestID int64, version string) string {
+	return fmt.Sprintf("rank:lock:contest:%d:global:%s", contestID, version)
+}
+
+func RankLockContestCity(contestID int64, citySlug, version string) string {
+	return fmt.Sprintf("rank:lock:contest:%d:city:%s:%s", contestID, citySlug, version)
+}
+
+func RankCountry(countrySlug, version string) string {
+	return fmt.Sprintf("rank:country:%s:%s", countrySlug, version)
+}
+
+func RankLockCountry(countrySlug, version string) string {
+	return fmt.Sprintf("rank:lock:country:%s:%s", countrySlug, version)
+}
+
+// Rankings por ventana de tiempo (day, week, month); la ventana all usa las claves historicas.
+
+func RankWindow(window, version string) string {
+	return fmt.Sprintf("rank:window:%s:global:%s", window, version)
+}
+
+func RankWindowCity(window, citySlug, version string) string {
+	return fmt.Sprintf("rank:window:%s:city:%s:%s", window, citySlug, version)
+}
+
+func RankWindowCountry(window, countrySlug, version string) string {
+	return fmt.Sprintf("rank:window:%s:country:%s:%s", window, countrySlug, version)
+}
+
+func RankLockWindow(window, version string) string {
+	return fmt.Sprintf("rank:lock:window:%s:global:%s", window, version)
+}
+
+func RankLockWindowCity(window, citySlug, version string) string {
+	return fmt.Sprintf("rank:lock:window:%s:city:%s:%s", window, citySlug, version)
+}
+
+func RankLockWindowCountry(window, countrySlug, version string) string {
+	return fmt.Sprintf("rank:lock:window:%s:country:%s:%s", window, countrySlug, version)
+}
+
+// Ranking de videos (top videos por votos), con claves propias para no mezclarse
+// con el ranking por usuario.
+
+func RankVideosGlobal(version string) string {
+	return fmt.Sprintf("rank:videos:global:%s", version)
+}
+
+func RankVideosCity(citySlug, version string) string {
+	return fmt.Sprintf("rank:videos:city:%s:%s", citySlug, version)
+}
+
+func RankLockVideosGlobal(version string) string {
+	return fmt.Sprintf("rank:lock:videos:global:%s", version)
+}
+
+func RankLockVideosCity(citySlug, version string) string {
+	return fmt.Sprintf("rank:lock:videos:city:%s:%s", citySlug, version)
+}
+
+// Leaderboards incrementales (ZSET) alimentados por eventos de voto.
+
+func LeaderboardGlobal(version string) string {
+	return fmt.Sprintf("lb:global:%s", version)
+}
+
+func LeaderboardCity(citySlug, version string) string {
+	return fmt.Sprintf("lb:city:%s:%s", citySlug, version)
+}
+
+// LeaderboardUsers es el hash user_id -> username usado para resolver los miembros de los ZSET.
+func LeaderboardUsers(version string) string {
+	return fmt.Sprintf("lb:users:%s", version)
+}
+
+// LeaderboardCityIndex es el set de slugs de ciudad con leaderboard.
+func LeaderboardCityIndex(version string) string {
+	return fmt.Sprintf("lb:index:cities:%s", version)
+}
+
+// LeaderboardSeen marca un evento ya aplicado (entrega al menos una vez).
+func LeaderboardSeen(eventID, version string) string {
+	return fmt.Sprintf("lb:seen:%s:%s", eventID, version)
+}
+
+// LeaderboardReconcileLease la toma la replica que reconcilia los leaderboards en el periodo.
+func LeaderboardReconcileLease(version string) string {
+	return fmt.Sprintf("lb:lock:reconcile:%s", version)
+}
@@ -46,6 +46,31 @@ func TestLockKeys(t *testing.T) {
 	assert.Equal(t, "rank:lock:city:madrid:v2", RankLockCity("madrid", "v2"))
 }
 
+func TestContestKeys(t *testing.T) {
+	assert.Equal(t, "rank:contest:7:global:v2", RankContest(7, "v2"))
+	assert.Equal(t, "rank:contest:7:city:madrid:v2", RankContestCity(7, "madrid", "v2"))
+	assert.Equal(t, "rank:lock:contest:7:global:v2", RankLockContest(7, "v2"))
+	assert.Equal(t, "rank:lock:contest:7:city:madrid:v2", RankLockContestCity(7, "madrid", "v2"))
+}
+
+func TestCountryAndWindowKeys(t *testing.T) {
+	assert.Equal(t, "rank:country:colombia:v2", RankCountry("colombia", "v2"))
+	assert.Equal(t, "rank:lock:country:colombia:v2", RankLockCountry("colombia", "v2"))
+	assert.Equal(t, "rank:window:week:global:v2", RankWindow("week", "v2"))
+	assert.Equal(t, "rank:window:day:city:cali:v2", RankWindowCity("day", "cali", "v2"))
+	assert.Equal(t, "rank:window:month:country:colombia:v2", RankWindowCountry("month", "colombia", "v2"))
+	assert.Equal(t, "rank:lock:window:week:global:v2", RankLockWindow("week", "v2"))
+	assert.Equal(t, "rank:lock:window:day:city:cali:v2", RankLockWindowCity("day", "cali", "v2"))
+	assert.Equal(t, "rank:lock:window:month:country:colombia:v2", RankLockWindowCountry("month", "colombia", "v2"))
+}
+
+func TestVideoRankingKeys(t *testing.T) {
+	assert.Equal(t, "rank:videos:global:v2", RankVideosGlobal("v2"))
+	assert.Equal(t, "rank:videos:city:madrid:v2", RankVideosCity("madrid", "v2"))
+	assert.Equal(t, "rank:lock:videos:global:v2", RankLockVideosGlobal("v2"))
+	assert.Equal(t, "rank:lock:videos:city:madrid:v2", RankLockVideosCity("madrid", "v2"))
+}
+
 func TestCityIndex(t *testing.T) {
 	assert.Equal(t, "rank:index:cities:v2", CityIndex("v2"))
 }
@@ -57,3 +82,11 @@ func TestSlugCityIntegration(t *testing.T) {
 
 	assert.Equal(t, "rank:city:sao-paulo:v2", rankKey)
 }
+
+func TestLeaderboardKeys(t *testing.T) {
+	assert.Equal(t, "lb:global:v2", LeaderboardGlobal("v2"))
+	assert.Equal(t, "lb:city:bogota:v2", LeaderboardCity("bogota", "v2"))
+	assert.Equal(t, "lb:users:v2", LeaderboardUsers("v2"))
+	assert.Equal(t, "lb:index:cities:v2", LeaderboardCityIndex("v2"))
+	assert.Equal(t, "lb:seen:42:v2", LeaderboardSeen("42", "v2"))
+}
@@ -0,0 +1,121 @@
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"admincache/internal/application/keys"
+)
+
+// Tipos de evento publicados por la API en el exchange de votos.
+const (
+	EventVoteCast      = "vote.cast"
+	EventVoteRetracted = "vote.retracted"
+)
+
+// ErrMalformedEvent identifica eventos que nunca podran aplicarse; el consumidor los descarta.
+var ErrMalformedEvent = errors.New("malformed vote event")
+
+// VoteEvent es el cuerpo de vote.cast / vote.retracted (ver entities.VoteEvent en la API).
+// El puntaje es del dueño del video y cuenta en la ciudad del dueño.
+type VoteEvent struct {
+	Type          string    `json:"type"`
+	VoteID        int64     `json:"vote_id"`
+	VideoID       int64     `json:"video_id"`
+	OwnerID       int64     `json:"owner_id"`
+	OwnerUsername string    `json:"owner_username"`
+	OwnerCity     string    `json:"owner_city"`
+	Quarantined   bool      `json:"quarantined"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// Entry es una posicion del leaderboard. Rank empieza en 1.
+type Entry struct {
+	Rank     int64  `json:"rank"`
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+	Votes    int64  `json:"votes"`
+}
+
+// Store guarda los leaderboards (ZSET de Redis en produccion). citySlug vacio es el global.
+// Los empates se ordenan por user_id ascendente, igual que el ranking SQL.
+type Store interface {
+	// Apply suma delta votos al usuario en el leaderboard global y en el de citySlug,
+	// una sola vez por eventID; devuelve false si el evento ya se habia aplicado.
+	Apply(ctx context.Context, eventID, citySlug string, userID int64, username string, delta int64) (bool, error)
+	// Top devuelve hasta n posiciones a partir de offset (0 = primera).
+	Top(ctx context.Context, citySlug string, offset, n int) ([]Entry, error)
+	// Rank devuelve la posicion del usuario; ok=false si no figura en el leaderboard.
+	Rank(ctx context.Context, citySlug string, userID int64) (entry Entry, ok bool, err error)
+	// Scores devuelve el leaderboard completo como user_id -> votos.
+	Scores(ctx context.Context, citySlug string) (map[int64]int64, error)
+	// Adjust suma deltas[user_id] votos (creando la entrada si falta) y quita los usuarios de
+	// remove, de forma atomica. Suma en vez de fijar el valor para no pisar eventos aplicados
+	// entre la lectura de Scores y la correccion.
+	Adjust(ctx context.Context, citySlug string, deltas map[int64]int64, remove []int64, usernames map[int64]string) error
+	// Cities devuelve los slugs de ciudad con leaderboard.
+	Cities(ctx context.Context) ([]string, error)
+	RemoveCity(ctx context.Context, citySlug string) error
+}
+
+// Service mantiene los leaderboards a partir de eventos de voto y responde consultas.
+type Service struct {
+	store Store
+	log   *slog.Logger
+}
+
+func NewService(store Store, log *slog.Logger) *Service {
+	return &Service{store: store, log: log}
+}
+
+// HandleEvent aplica un evento de voto. messageID deduplica reentregas; si el broker no lo
+// trae se deriva del propio evento. Los votos en cuarentena no suman ni restan.
+func (s *Service) HandleEvent(ctx context.Context, messageID string, body []byte) error {
+	var ev VoteEvent
+	if err := json.Unmarshal(body, &ev); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedEvent, err)
+	}
+	var delta int64
+	switch ev.Type {
+	case EventVoteCast:
+		delta = 1
+	case EventVoteRetracted:
+		delta = -1
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrMalformedEvent, ev.Type)
+	}
+	if ev.OwnerID <= 0 {
+		return fmt.Errorf("%w: missing owner_id", ErrMalformedEvent)
+	}
+	if ev.Quarantined {
+		return nil
+	}
+	if messageID == "" {
+		messageID = fmt.Sprintf("%s:%d:%d", ev.Type, ev.VoteID, ev.OccurredAt.UnixNano())
+	}
+	applied, err := s.store.Apply(ctx, messageID, keys.SlugCity(ev.OwnerCity), ev.OwnerID, ev.OwnerUsername, delta)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		s.log.Debug("leaderboard duplicate event skipped", "message_id", messageID)
+	}
+	return nil
+}
+
+// Top devuelve las primeras n posiciones; city vacio es el leaderboard global.
+func (s *Service) Top(ctx context.Context, city string, n int) ([]Entry, error) {
+	if n <= 0 {
+		return []Entry{}, nil
+	}
+	return s.store.Top(ctx, keys.SlugCity(city), 0, n)
+}
+
+// Rank devuelve la posicion del usuario en el leaderboard global o de city.
+func (s *Service) Rank(ctx context.Context, city string, userID int64) (Entry, bool, error) {
+	return s.store.Rank(ctx, keys.SlugCity(city), userID)
+}
@@ -0,0 +1,304 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"admincache/internal/application/ranking"
+)
+
+// memStore es un Store en memoria con el mismo orden que los ZSET (votos DESC, user_id ASC).
+type memStore struct {
+	boards    map[string]map[int64]int64
+	usernames map[int64]string
+	seen      map[string]bool
+	adjusted  []string
+}
+
+func newMemStore() *memStore {
+	return &memStore{boards: map[string]map[int64]int64{}, usernames: map[int64]string{}, seen: map[string]bool{}}
+}
+
+func (m *memStore) Apply(ctx context.Context, eventID, citySlug string, userID int64, username string, delta int64) (bool, error) {
+	if m.seen[eventID] {
+		return false, nil
+	}
+	m.seen[eventID] = true
+	slugs := []string{""}
+	if citySlug != "" {
+		slugs = append(slugs, citySlug)
+	}
+	for _, slug := range slugs {
+		if m.boards[slug] == nil {
+			m.boards[slug] = map[int64]int64{}
+		}
+		m.boards[slug][userID] += delta
+	}
+	if username != "" {
+		m.usernames[userID] = username
+	}
+	return true, nil
+}
+
+func (m *memStore) sorted(citySlug string) []Entry {
+	var out []Entry
+	for id, votes := range m.boards[citySlug] {
+		out = append(out, Entry{UserID: id, Votes: votes, Username: m.usernames[id]})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Votes != out[j].Votes {
+			return out[i].Votes > out[j].Votes
+		}
+		return out[i].UserID < out[j].UserID
+	})
+	for i := range out {
+		out[i].Rank = int64(i + 1)
+	}
+	return out
+}
+
+func (m *memStore) Top(ctx context.Context, citySlug string, offset, n int) ([]Entry, error) {
+	all := m.sorted(citySlug)
+	if offset >= len(all) {
+		return []Entry{}, nil
+	}
+	end := offset + n
+	if end > len(all) {
+		end = len(all)
+	}
+	return all[offset:end], nil
+}
+
+func (m *memStore) Rank(ctx context.Context, citySlug string, userID int64) (Entry, bool, error) {
+	for _, e := range m.sorted(citySlug) {
+		if e.UserID == userID {
+			return e, true, nil
+		}
+	}
+	return Entry{}, false, nil
+}
+
+func (m *memStore) Scores(ctx context.Context, citySlug string) (map[int64]int64, error) {
+	out := map[int64]int64{}
+	for id, v := range m.boards[citySlug] {
+		out[id] = v
+	}
+	return out, nil
+}
+
+func (m *memStore) Adjust(ctx context.Context, citySlug string, deltas map[int64]int64, remove []int64, usernames map[int64]string) error {
+	m.adjusted = append(m.adjusted, citySlug)
+	if m.boards[citySlug] == nil {
+		m.boards[citySlug] = map[int64]int64{}
+	}
+	for _, id := range remove {
+		delete(m.boards[citySlug], id)
+	}
+	for id, delta := range deltas {
+		m.boards[citySlug][id] += delta
+		if usernames[id] != "" {
+			m.usernames[id] = usernames[id]
+		}
+	}
+	return nil
+}
+
+func (m *memStore) Cities(ctx context.Context) ([]string, error) {
+	var out []string
+	for slug := range m.boards {
+		if slug != "" {
+			out = append(out, slug)
+		}
+	}
+	return out, nil
+}
+
+func (m *memStore) RemoveCity(ctx context.Context, citySlug string) error {
+	delete(m.boards, citySlug)
+	return nil
+}
+
+type fakeLister struct {
+	items []ranking.RankItem
+	err   error
+}
+
+func (f *fakeLister) AllScores(ctx context.Context) ([]ranking.RankItem, error) {
+	return f.items, f.err
+}
+
+// fakeLease simula la lease de Redis compartida entre replicas: la toma la primera y solo
+// su duenia la renueva.
+type fakeLease struct {
+	holder *string
+	owner  string
+}
+
+func (f *fakeLease) AcquireReconcileLease(ctx context.Context, ttl time.Duration) (bool, error) {
+	if *f.holder != "" && *f.holder != f.owner {
+		return false, nil
+	}
+	*f.holder = f.owner
+	return true, nil
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestHandleEventUpdatesGlobalAndOwnerCity(t *testing.T) {
+	store := newMemStore()
+	svc := NewService(store, testLogger())
+	ctx := context.Background()
+
+	cast := []byte(`{"type":"vote.cast","vote_id":1,"owner_id":7,"owner_username":"ana","owner_city":"Bogotá","voter_city":"Cali"}`)
+	require.NoError(t, svc.HandleEvent(ctx, "1", cast))
+	// Reentrega del mismo mensaje: no vuelve a sumar.
+	require.NoError(t, svc.HandleEvent(ctx, "1", cast))
+	require.NoError(t, svc.HandleEvent(ctx, "2", []byte(`{"type":"vote.cast","vote_id":2,"owner_id":8,"owner_city":"Cali"}`)))
+
+	top, err := svc.Top(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, Entry{Rank: 1, UserID: 7, Username: "ana", Votes: 1}, top[0])
+	// Empate: menor user_id primero.
+	assert.Equal(t, int64(8), top[1].UserID)
+
+	entry, ok, err := svc.Rank(ctx, "bogota", 7)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), entry.Votes)
+	_, ok, err = svc.Rank(ctx, "Cali", 7)
+	require.NoError(t, err)
+	assert.False(t, ok, "el puntaje cuenta en la ciudad del dueño, no del votante")
+
+	require.NoError(t, svc.HandleEvent(ctx, "3", []byte(`{"type":"vote.retracted","vote_id":1,"owner_id":7,"owner_city":"Bogotá"}`)))
+	entry, _, err = svc.Rank(ctx, "", 7)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), entry.Votes)
+}
+
+func TestHandleEventSkipsQuarantinedVotes(t *testing.T) {
+	store := newMemStore()
+	svc := NewService(store, testLogger())
+
+	require.NoError(t, svc.HandleEvent(context.Background(), "1",
+		[]byte(`{"type":"vote.cast","vote_id":1,"owner_id":7,"owner_city":"Bogotá","quarantined":true}`)))
+	assert.Empty(t, store.boards)
+}
+
+func TestHandleEventRejectsMalformedEvents(t *testing.T) {
+	svc := NewService(newMemStore(), testLogger())
+	for _, body := range []string{`{`, `{"type":"vote.other","owner_id":1}`, `{"type":"vote.cast"}`} {
+		err := svc.HandleEvent(context.Background(), "x", []byte(body))
+		assert.True(t, errors.Is(err, ErrMalformedEvent), body)
+	}
+}
+
+func TestReconcileFixesPersistentDriftAndDropsStaleCities(t *testing.T) {
+	store := newMemStore()
+	store.boards[""] = map[int64]int64{7: 5, 8: 2}
+	store.boards["bogota"] = map[int64]int64{7: 5}
+	store.boards["quito"] = map[int64]int64{9: 1}
+	lister := &fakeLister{items: []ranking.RankItem{
+		{UserID: 7, Username: "ana", City: "Bogotá", Votes: 5},
+		{UserID: 8, Username: "leo", City: "Cali", Votes: 3},
+	}}
+	r := NewReconciler(lister, store, nil, testLogger())
+
+	// Primera pasada: las desviaciones quedan pendientes, podrian ser votos en camino.
+	drift, err := r.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, drift)
+	assert.Empty(t, store.adjusted)
+
+	// Segunda pasada con la misma desviacion: user 8 global (2 -> 3), user 8 nuevo en cali, user 9 fuera de quito.
+	drift, err = r.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, drift)
+	assert.Equal(t, map[int64]int64{7: 5, 8: 3}, store.boards[""])
+	assert.Equal(t, map[int64]int64{8: 3}, store.boards["cali"])
+	assert.Equal(t, "leo", store.usernames[8])
+	assert.NotContains(t, store.adjusted, "bogota", "sin desvio no se corrige")
+
+	// quito quedo vacio y se elimina del indice.
+	drift, err = r.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, drift)
+	assert.NotContains(t, store.boards, "quito")
+}
+
+func TestReconcileDoesNotCountInFlightVotesTwice(t *testing.T) {
+	store := newMemStore()
+	store.boards[""] = map[int64]int64{7: 4}
+	store.boards["bogota"] = map[int64]int64{7: 4}
+	// PostgreSQL ya tiene el quinto voto; su evento todavia no llego.
+	lister := &fakeLister{items: []ranking.RankItem{{UserID: 7, Username: "ana", City: "Bogotá", Votes: 5}}}
+	r := NewReconciler(lister, store, nil, testLogger())
+
+	drift, err := r.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, drift)
+
+	svc := NewService(store, testLogger())
+	require.NoError(t, svc.HandleEvent(context.Background(), "5",
+		[]byte(`{"type":"vote.cast","vote_id":5,"owner_id":7,"owner_city":"Bogotá"}`)))
+
+	drift, err = r.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, drift)
+	assert.Equal(t, int64(5), store.boards[""][7])
+	assert.Equal(t, int64(5), store.boards["bogota"][7])
+	assert.Empty(t, store.adjusted)
+}
+
+func TestReconcileCorrectionKeepsEventsAppliedMeanwhile(t *testing.T) {
+	store := newMemStore()
+	store.boards[""] = map[int64]int64{7: 3}
+	// Se perdio un evento: falta un voto en forma persistente.
+	lister := &fakeLister{items: []ranking.RankItem{{UserID: 7, Username: "ana", Votes: 4}}}
+	r := NewReconciler(lister, store, nil, testLogger())
+
+	_, err := r.Reconcile(context.Background())
+	require.NoError(t, err)
+	// Llega un voto nuevo y PostgreSQL avanza igual: la desviacion sigue siendo +1.
+	store.boards[""][7]++
+	lister.items[0].Votes++
+
+	drift, err := r.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, drift)
+	assert.Equal(t, int64(5), store.boards[""][7])
+}
+
+func TestReconcilePropagatesSourceErrors(t *testing.T) {
+	_, err := NewReconciler(&fakeLister{err: errors.New("db down")}, newMemStore(), nil, testLogger()).Reconcile(context.Background())
+	assert.Error(t, err)
+}
+
+func TestReconcileRunsOnlyOnTheReplicaHoldingTheLease(t *testing.T) {
+	store := newMemStore()
+	store.boards[""] = map[int64]int64{7: 4}
+	lister := &fakeLister{items: []ranking.RankItem{{UserID: 7, Username: "ana", Votes: 5}}}
+	var holder string
+	first := NewReconciler(lister, store, &fakeLease{holder: &holder, owner: "a"}, testLogger())
+	second := NewReconciler(lister, store, &fakeLease{holder: &holder, owner: "b"}, testLogger())
+
+	// La primera replica toma la lease y la renueva; la segunda nunca reconcilia.
+	for i := 0; i < 2; i++ {
+		first.runOnce(context.Background(), time.Minute)
+		second.runOnce(context.Background(), time.Minute)
+	}
+
+	assert.Equal(t, "a", holder)
+	assert.Equal(t, int64(5), store.boards[""][7])
+	assert.Len(t, store.adjusted, 1)
+}
@@ -0,0 +1,188 @@
+package leaderboard
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"admincache/internal/application/keys"
+	"admincache/internal/application/ranking"
+)
+
+// ReconcileLease reparte la reconciliacion entre replicas de AdminCache.
+type ReconcileLease interface {
+	// AcquireReconcileLease toma la lease por ttl si esta libre o la renueva si ya es de
+	// esta replica (SET NX PX en Redis). No se libera: la replica que reconcilia conserva
+	// la lease mientras siga viva, y con ella las desviaciones pendientes de confirmar.
+	AcquireReconcileLease(ctx context.Context, ttl time.Duration) (bool, error)
+}
+
+// Reconciler compara los leaderboards con PostgreSQL y corrige las entradas que se desviaron
+// (eventos perdidos, reinicios de Redis, videos ocultos o retirados despues de votados).
+//
+// Una diferencia puntual no basta: los votos cuyo evento aun esta en camino (outbox, cola)
+// ya figuran en PostgreSQL pero no en Redis. Por eso una entrada solo se corrige si muestra
+// la misma desviacion en dos pasadas consecutivas; un voto en camino se aplica entre ambas y
+// cambia la desviacion observada. La correccion suma la diferencia en vez de fijar el valor,
+// asi los eventos aplicados entre la lectura y la escritura no se pisan ni se cuentan dos veces.
+type Reconciler struct {
+	source ranking.ScoreLister
+	store  Store
+	lease  ReconcileLease
+	log    *slog.Logger
+	// pending guarda, por leaderboard (slug; "" es el global), la desviacion want-got
+	// observada en la pasada anterior para cada usuario.
+	pending map[string]map[int64]int64
+}
+
+// NewReconciler crea el reconciliador. Con lease nil cada instancia reconcilia en cada
+// periodo (una sola replica o pruebas).
+func NewReconciler(source ranking.ScoreLister, store Store, lease ReconcileLease, log *slog.Logger) *Reconciler {
+	return &Reconciler{source: source, store: store, lease: lease, log: log, pending: map[string]map[int64]int64{}}
+}
+
+// Start reconcilia al iniciar y luego cada interval hasta que stop se cierre. La lease dura
+// interval+timeout: la replica que la tiene la renueva en su siguiente pasada antes de que expire.
+func (r *Reconciler) Start(interval time.Duration, timeout time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	leaseTTL := interval + timeout
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		r.runOnce(ctx, leaseTTL)
+		cancel()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Reconciler) runOnce(ctx context.Context, leaseTTL time.Duration) {
+	if r.lease != nil {
+		acquired, err := r.lease.AcquireReconcileLease(ctx, leaseTTL)
+		if err != nil {
+			r.log.Error("leaderboard reconcile lease failed", "err", err)
+			return
+		}
+		if !acquired {
+			// Otra replica reconcilia; lo observado aqui ya no es de la pasada anterior.
+			r.pending = map[string]map[int64]int64{}
+			r.log.Debug("leaderboard reconcile skipped, lease held by another replica")
+			return
+		}
+	}
+	start := time.Now()
+	drift, err := r.Reconcile(ctx)
+	if err != nil {
+		r.log.Error("leaderboard reconcile failed", "err", err)
+		return
+	}
+	level := slog.LevelInfo
+	if drift > 0 {
+		level = slog.LevelWarn
+	}
+	r.log.Log(ctx, level, "leaderboard reconciled", "drifted_entries", drift, "duration_ms", time.Since(start).Milliseconds())
+}
+
+// Reconcile devuelve cuantas entradas corrigio, es decir, las que mostraron la misma
+// desviacion que en la pasada anterior. Las que se desviaron por primera vez quedan
+// pendientes para la siguiente.
+func (r *Reconciler) Reconcile(ctx context.Context) (int, error) {
+	items, err := r.source.AllScores(ctx)
+	if err != nil {
+		return 0, err
+	}
+	global := make(map[int64]int64, len(items))
+	usernames := make(map[int64]string, len(items))
+	cities := map[string]map[int64]int64{}
+	for _, it := range items {
+		global[it.UserID] = it.Votes
+		usernames[it.UserID] = it.Username
+		slug := keys.SlugCity(it.City)
+		if slug == "" {
+			continue
+		}
+		if cities[slug] == nil {
+			cities[slug] = map[int64]int64{}
+		}
+		cities[slug][it.UserID] = it.Votes
+	}
+
+	pending := map[string]map[int64]int64{}
+	drift, err := r.sync(ctx, "", global, usernames, pending)
+	if err != nil {
+		return drift, err
+	}
+	for slug, scores := range cities {
+		n, err := r.sync(ctx, slug, scores, usernames, pending)
+		drift += n
+		if err != nil {
+			return drift, err
+		}
+	}
+
+	existing, err := r.store.Cities(ctx)
+	if err != nil {
+		return drift, err
+	}
+	for _, slug := range existing {
+		if _, ok := cities[slug]; ok {
+			continue
+		}
+		n, err := r.sync(ctx, slug, nil, usernames, pending)
+		drift += n
+		if err != nil {
+			return drift, err
+		}
+	}
+	r.pending = pending
+	return drift, nil
+}
+
+// sync corrige en slug las entradas con la misma desviacion que en la pasada anterior y
+// anota en pending las que se desviaron. Un leaderboard de ciudad sin entradas esperadas ni
+// actuales se elimina del indice.
+func (r *Reconciler) sync(ctx context.Context, slug string, want map[int64]int64, usernames map[int64]string, pending map[string]map[int64]int64) (int, error) {
+	current, err := r.store.Scores(ctx, slug)
+	if err != nil {
+		return 0, err
+	}
+	if slug != "" && len(want) == 0 && len(current) == 0 {
+		return 0, r.store.RemoveCity(ctx, slug)
+	}
+
+	observed := map[int64]int64{}
+	for id, votes := range want {
+		if got, ok := current[id]; !ok || got != votes {
+			observed[id] = votes - got
+		}
+	}
+	for id, got := range current {
+		if _, ok := want[id]; !ok {
+			observed[id] = -got
+		}
+	}
+
+	deltas := map[int64]int64{}
+	var remove []int64
+	for id, delta := range observed {
+		if prev, ok := r.pending[slug][id]; !ok || prev != delta {
+			if pending[slug] == nil {
+				pending[slug] = map[int64]int64{}
+			}
+			pending[slug][id] = delta
+			continue
+		}
+		if _, ok := want[id]; ok {
+			deltas[id] = delta
+		} else {
+			remove = append(remove, id)
+		}
+	}
+	if len(deltas) == 0 && len(remove) == 0 {
+		return 0, nil
+	}
+	return len(deltas) + len(remove), r.store.Adjust(ctx, slug, deltas, remove, usernames)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 )
 
 type RankItem struct {
@@ -14,8 +15,71 @@ type RankItem struct {
 	Votes    int64  `json:"votes"`
 }
 
+// VideoRankItem es una fila del ranking de videos: votos por video y su autor.
+type VideoRankItem struct {
+	VideoID  int64  `json:"video_id"`
+	Position int    `json:"position"`
+	Title    string `json:"title"`
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+	City     string `json:"city"`
+	Votes    int64  `json:"votes"`
+}
+
+// Window limita el ranking a los votos emitidos en el periodo calendario en curso (UTC).
+type Window string
+
+const (
+	WindowAll   Window = "all"
+	WindowDay   Window = "day"
+	WindowWeek  Window = "week"
+	WindowMonth Window = "month"
+)
+
+// ParseWindow valida una ventana; vacio equivale a WindowAll.
+func ParseWindow(raw string) (Window, bool) {
+	switch w := Window(strings.ToLower(strings.TrimSpace(raw))); w {
+	case "", WindowAll:
+		return WindowAll, true
+	case WindowDay, WindowWeek, WindowMonth:
+		return w, true
+	default:
+		return "", false
+	}
+}
+
+// Query filtra el ranking por ciudad y/o pais (nombre sin tildes ni mayusculas; el pais
+// tambien por codigo ISO) y por ventana de tiempo. El valor cero es el ranking historico global.
+type Query struct {
+	City    *string
+	Country *string
+	Window  Window
+}
+
 type Computer interface {
-	Compute(ctx context.Context, city *string, page, size int) ([]RankItem, error)
+	Compute(ctx context.Context, q Query, page, size int) ([]RankItem, error)
+}
+
+// ContestComputer es una extension opcional de Computer para clasificaciones por concurso.
+type ContestComputer interface {
+	Computer
+	// ActiveContests devuelve los concursos cuya clasificacion debe mantenerse en cache:
+	// con votacion iniciada y abiertos, o cerrados/finalizados hace menos de un dia.
+	ActiveContests(ctx context.Context) ([]int64, error)
+	// ComputeContest calcula la clasificacion del concurso; si ya fue cerrado lee la
+	// clasificacion congelada (contest_standing) igual que la API.
+	ComputeContest(ctx context.Context, contestID int64, city *string, page, size int) ([]RankItem, error)
+}
+
+// ScoreLister es una extension opcional de Computer usada para reconciliar los leaderboards
+// incrementales: devuelve todos los usuarios del ranking global, sin paginar.
+type ScoreLister interface {
+	AllScores(ctx context.Context) ([]RankItem, error)
+}
+
+// VideoComputer es una extension opcional de Computer para el ranking de videos.
+type VideoComputer interface {
+	ComputeVideos(ctx context.Context, city *string, page, size int) ([]VideoRankItem, error)
 }
 
 type computer struct{ db *sql.DB }
@@ -24,55 +88,151 @@ func NewRankComputer(db *sql.DB) Computer { return &computer{db: db} }
 
 // SQL alineado con la API:
 // - Cuenta votos por usuario sobre videos publicados y procesados (processed_file no nulo)
-// - username: split_part(u.email,'@',1)
+// - Excluye videos ocultos por denuncias o retirados (hidden_at no nulo)
+// - No cuenta votos en cuarentena antifraude (quarantined_at no nulo)
+// - username: u.username (columna unica, migracion 0019 de la API)
 // - city: c.name
-// - Agrupa por u.user_id, u.email, c.name
+// - Agrupa por u.user_id, u.username, c.name
 // - Orden: votes DESC, u.user_id ASC (desempate estable)
+// El primer %s agrega condiciones al JOIN de votos (ventana de tiempo) y el segundo al WHERE.
 const baseSQL = `
 SELECT
   u.user_id,
-  split_part(u.email,'@',1) AS username,
+  u.username,
   COALESCE(c.name, '')      AS city,
   COUNT(vt.vote_id)         AS votes
 FROM users u
 JOIN video v       ON v.user_id   = u.user_id
-LEFT JOIN vote vt  ON vt.video_id = v.video_id
+LEFT JOIN vote vt  ON vt.video_id = v.video_id AND vt.quarantined_at IS NULL%s
 LEFT JOIN city c   ON c.city_id   = u.city_id
+LEFT JOIN country co ON co.country_id = c.country_id
 WHERE v.status = 'PUBLISHED' AND v.processed_file IS NOT NULL
+  AND v.hidden_at IS NULL
 %s
-GROUP BY u.user_id, u.email, c.name
+GROUP BY u.user_id, u.username, c.name
 ORDER BY votes DESC, u.user_id ASC
 LIMIT $1 OFFSET $2
 `
 
-func (c *computer) Compute(ctx context.Context, city *string, page, size int) ([]RankItem, error) {
+func (c *computer) Compute(ctx context.Context, q Query, page, size int) ([]RankItem, error) {
+	offset := (page - 1) * size
+
+	args := []any{size, offset}
+	var where strings.Builder
+	if q.City != nil && *q.City != "" {
+		args = append(args, *q.City)
+		where.WriteString(cityWhere("c.name", len(args)))
+	}
+	if q.Country != nil && *q.Country != "" {
+		args = append(args, *q.Country)
+		where.WriteString(countryWhere(len(args)))
+	}
+
+	rows, err := c.db.QueryContext(ctx, fmt.Sprintf(baseSQL, windowJoin(q.Window), where.String()), args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanRankItems(rows)
+}
+
+// windowJoin limita los votos contados al periodo calendario en curso, calculado en la base
+// de datos igual que en la API. Los usuarios sin votos en la ventana siguen con 0 votos.
+func windowJoin(w Window) string {
+	switch w {
+	case WindowDay, WindowWeek, WindowMonth:
+		return fmt.Sprintf(" AND vt.voted_at >= date_trunc('%s', now() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'", w)
+	default:
+		return ""
+	}
+}
+
+func (c *computer) AllScores(ctx context.Context) ([]RankItem, error) {
+	// LIMIT NULL equivale a sin limite en PostgreSQL.
+	rows, err := c.db.QueryContext(ctx, fmt.Sprintf(baseSQL, "", ""), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return scanRankItems(rows)
+}
+
+// videoSQL aplica los mismos filtros que baseSQL pero agrupa por video.
+// La ciudad es la del autor del video. Orden: votes DESC, v.video_id ASC.
+const videoSQL = `
+SELECT
+  v.video_id,
+  v.title,
+  u.user_id,
+  u.username,
+  COALESCE(c.name, '')      AS city,
+  COUNT(vt.vote_id)         AS votes
+FROM video v
+JOIN users u       ON u.user_id   = v.user_id
+LEFT JOIN vote vt  ON vt.video_id = v.video_id AND vt.quarantined_at IS NULL
+LEFT JOIN city c   ON c.city_id   = u.city_id
+WHERE v.status = 'PUBLISHED' AND v.processed_file IS NOT NULL
+  AND v.hidden_at IS NULL
+%s
+GROUP BY v.video_id, v.title, u.user_id, u.username, c.name
+ORDER BY votes DESC, v.video_id ASC
+LIMIT $1 OFFSET $2
+`
+
+func (c *computer) ComputeVideos(ctx context.Context, city *string, page, size int) ([]VideoRankItem, error) {
 	offset := (page - 1) * size
 
 	var rows *sql.Rows
 	var err error
-
 	if city != nil && *city != "" {
-		// Filtro por nombre de ciudad:
-		// - Si existe immutable_unaccent(text), lo usamos para ignorar tildes
-		// - De lo contrario, fallback a lower() simple
-		where := `
- AND (
-   to_regprocedure('immutable_unaccent(text)') IS NOT NULL
-   AND immutable_unaccent(lower(COALESCE(c.name,''))) = immutable_unaccent(lower($3))
- )
- OR (
-   to_regprocedure('immutable_unaccent(text)') IS NULL
-   AND lower(COALESCE(c.name,'')) = lower($3)
- )`
-		rows, err = c.db.QueryContext(ctx, fmt.Sprintf(baseSQL, where), size, offset, *city)
+		rows, err = c.db.QueryContext(ctx, fmt.Sprintf(videoSQL, cityWhere("c.name", 3)), size, offset, *city)
 	} else {
-		rows, err = c.db.QueryContext(ctx, fmt.Sprintf(baseSQL, ""), size, offset)
+		rows, err = c.db.QueryContext(ctx, fmt.Sprintf(videoSQL, ""), size, offset)
 	}
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	var res []VideoRankItem
+	pos := 0
+	for rows.Next() {
+		pos++
+		var it VideoRankItem
+		if err := rows.Scan(&it.VideoID, &it.Title, &it.UserID, &it.Username, &it.City, &it.Votes); err != nil {
+			return nil, err
+		}
+		it.Position = pos
+		res = append(res, it)
+	}
+	return res, rows.Err()
+}
+
+// cityWhere filtra por nombre de ciudad usando el parametro $n:
+// - Si existe immutable_unaccent(text), lo usamos para ignorar tildes
+// - De lo contrario, fallback a lower() simple
+// Ambas ramas van entre parentesis para no escapar de los filtros de estado/visibilidad.
+func cityWhere(column string, n int) string {
+	return "\n AND " + nameMatch(column, n)
+}
+
+// countryWhere filtra por nombre de pais (mismo criterio que cityWhere) o por codigo ISO.
+func countryWhere(n int) string {
+	return fmt.Sprintf("\n AND (co.iso_code = upper($%d) OR %s)", n, nameMatch("co.name", n))
+}
+
+func nameMatch(column string, n int) string {
+	return fmt.Sprintf(`((
+   to_regprocedure('immutable_unaccent(text)') IS NOT NULL
+   AND immutable_unaccent(lower(COALESCE(%[1]s,''))) = immutable_unaccent(lower($%[2]d))
+ )
+ OR (
+   to_regprocedure('immutable_unaccent(text)') IS NULL
+   AND lower(COALESCE(%[1]s,'')) = lower($%[2]d)
+ ))`, column, n)
+}
+
+func scanRankItems(rows *sql.Rows) ([]RankItem, error) {
+	defer rows.Close()
+
 	var res []RankItem
 	pos := 0
 	for rows.Next() {
@@ -86,3 +246,75 @@ func (c *computer) Compute(ctx context.Context, city *string, page, size int) ([
 	}
 	return res, rows.Err()
 }
+
+// standingSQL lee la clasificacion congelada al cerrar un concurso.
+const standingSQL = `
+SELECT
+  s.user_id,
+  s.username,
+  COALESCE(s.city, '') AS city,
+  s.votes
+FROM contest_standing s
+WHERE s.contest_id = $3
+%s
+ORDER BY s.position ASC
+LIMIT $1 OFFSET $2
+`
+
+const activeContestsSQL = `
+SELECT contest_id
+FROM contest
+WHERE voting_starts_at <= now()
+  AND (
+    (closed_at IS NULL AND voting_ends_at > now() - interval '1 day')
+    OR closed_at > now() - interval '1 day'
+  )
+ORDER BY contest_id
+`
+
+func (c *computer) ActiveContests(ctx context.Context) ([]int64, error) {
+	rows, err := c.db.QueryContext(ctx, activeContestsSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (c *computer) ComputeContest(ctx context.Context, contestID int64, city *string, page, size int) ([]RankItem, error) {
+	offset := (page - 1) * size
+
+	var closed bool
+	if err := c.db.QueryRowContext(ctx,
+		`SELECT closed_at IS NOT NULL FROM contest WHERE contest_id = $1`, contestID).Scan(&closed); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(baseSQL, "", " AND v.contest_id = $3%s")
+	column := "c.name"
+	if closed {
+		query = standingSQL
+		column = "s.city"
+	}
+
+	var rows *sql.Rows
+	var err error
+	if city != nil && *city != "" {
+		rows, err = c.db.QueryContext(ctx, fmt.Sprintf(query, cityWhere(column, 4)), size, offset, contestID, *city)
+	} else {
+		rows, err = c.db.QueryContext(ctx, fmt.Sprintf(query, ""), size, offset, contestID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scanRankItems(rows)
+}
@@ -0,0 +1,35 @@
+package ranking
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// RetractionWatcher expone la marca temporal del ultimo cambio de votos que no pasa por
+// un insert: votos retirados y votos liberados de la cuarentena antifraude.
+// El scheduler la usa para refrescar rankings antes del siguiente intervalo.
+type RetractionWatcher interface {
+	LastRetraction(ctx context.Context) (time.Time, error)
+}
+
+type retractionWatcher struct{ db *sql.DB }
+
+func NewRetractionWatcher(db *sql.DB) RetractionWatcher { return &retractionWatcher{db: db} }
+
+// LastRetraction devuelve el mayor entre MAX(vote_history.retracted_at) y MAX(vote.reviewed_at),
+// o el valor cero si no hubo cambios.
+func (w *retractionWatcher) LastRetraction(ctx context.Context) (time.Time, error) {
+	var last sql.NullTime
+	if err := w.db.QueryRowContext(ctx, `
+SELECT GREATEST(
+  (SELECT MAX(retracted_at) FROM vote_history),
+  (SELECT MAX(reviewed_at) FROM vote)
+)`).Scan(&last); err != nil {
+		return time.Time{}, err
+	}
+	if !last.Valid {
+		return time.Time{}, nil
+	}
+	return last.Time, nil
+}
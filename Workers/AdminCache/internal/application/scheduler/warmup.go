@@ -19,26 +19,38 @@ import (
 )
 
 const (
-	scopeGlobal = "global"
-	scopeCity   = "city"
+	scopeGlobal  = "global"
+	scopeCity    = "city"
+	scopeCountry = "country"
 )
 
+// rankingCacheItem es comun a los rankings de usuarios y de videos;
+// VideoID y Title solo se informan en el ranking de videos.
 type rankingCacheItem struct {
 	Rank     int    `json:"rank"`
+	VideoID  int64  `json:"video_id,omitempty"`
+	Title    string `json:"title,omitempty"`
 	UserID   int64  `json:"user_id"`
 	Username string `json:"username"`
 	Score    int64  `json:"score"`
 }
 
 type rankingCacheEntry struct {
-	SchemaVersion string             `json:"schema_version"`
-	Scope         string             `json:"scope"`
-	City          string             `json:"city,omitempty"`
-	CitySlug      string             `json:"city_slug,omitempty"`
-	AsOf          time.Time          `json:"as_of"`
-	FreshUntil    time.Time          `json:"fresh_until"`
-	StaleUntil    time.Time          `json:"stale_until"`
-	Items         []rankingCacheItem `json:"items"`
+	SchemaVersion string `json:"schema_version"`
+	Scope         string `json:"scope"`
+	City          string `json:"city,omitempty"`
+	CitySlug      string `json:"city_slug,omitempty"`
+	Country       string `json:"country,omitempty"`
+	CountrySlug   string `json:"country_slug,omitempty"`
+	Window        string `json:"window,omitempty"`
+	ContestID     int64  `json:"contest_id,omitempty"`
+	// Complete indica que Items contiene el ranking entero; la API responde paginas
+	// posteriores vacias sin consultar la base de datos.
+	Complete   bool               `json:"complete,omitempty"`
+	AsOf       time.Time          `json:"as_of"`
+	FreshUntil time.Time          `json:"fresh_until"`
+	StaleUntil time.Time          `json:"stale_until"`
+	Items      []rankingCacheItem `json:"items"`
 }
 
 type cityIndexEntry struct {
@@ -59,16 +71,56 @@ type cycleStats struct {
 	lockReleaseErrors int
 }
 
+// outcomes devuelve los contadores del ciclo con las etiquetas de las metricas.
+func (s *cycleStats) outcomes() map[string]int {
+	return map[string]int{
+		infrastructure.OutcomeRefreshed:        s.refreshed,
+		infrastructure.OutcomeSkippedLock:      s.skippedLock,
+		infrastructure.OutcomeFetchError:       s.fetchErrors,
+		infrastructure.OutcomeValidationError:  s.validationErrors,
+		infrastructure.OutcomeWriteError:       s.writeErrors,
+		infrastructure.OutcomeStaleServed:      s.staleServed,
+		infrastructure.OutcomeStaleExpired:     s.staleExpired,
+		infrastructure.OutcomeLockError:        s.lockErrors,
+		infrastructure.OutcomeLockReleaseError: s.lockReleaseErrors,
+	}
+}
+
 type scopeInput struct {
-	scope    string
-	cityName string
-	citySlug string
+	scope       string
+	cityName    string
+	citySlug    string
+	countryName string
+	countrySlug string
+	// window distinta de all limita el ranking por usuario a los votos del periodo en curso
+	window ranking.Window
+	// contestID distinto de cero limita el ranking a los videos de ese concurso
+	contestID int64
+	// videos indica el ranking de videos en lugar del de usuarios
+	videos bool
 }
 
 func StartWarmup(comp ranking.Computer, cache *infrastructure.Cache, cfg infrastructure.Config, log *slog.Logger, stop <-chan struct{}) {
+	StartWarmupWithWatcher(comp, nil, cache, cfg, log, stop)
+}
+
+// StartWarmupWithWatcher ademas consulta watcher cada RetractionPollSeconds y
+// ejecuta un ciclo extra cuando se retiraron votos desde la ultima consulta.
+func StartWarmupWithWatcher(comp ranking.Computer, watcher ranking.RetractionWatcher, cache *infrastructure.Cache, cfg infrastructure.Config, log *slog.Logger, stop <-chan struct{}) {
 	ticker := time.NewTicker(time.Duration(cfg.RefreshIntervalSeconds) * time.Second)
 	defer ticker.Stop()
 
+	// Canal nil (nunca dispara) cuando no hay watcher o el sondeo esta deshabilitado.
+	var retractions <-chan time.Time
+	var lastRetraction time.Time
+	if watcher != nil && cfg.RetractionPollSeconds > 0 {
+		poll := time.NewTicker(time.Duration(cfg.RetractionPollSeconds) * time.Second)
+		defer poll.Stop()
+		retractions = poll.C
+		// El ciclo de arranque ya incluye los retiros previos.
+		lastRetraction, _, _ = pollRetractions(context.Background(), watcher, time.Time{})
+	}
+
 	rand := mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
 
 	runCycle := func(trigger string) {
@@ -78,10 +130,16 @@ func StartWarmup(comp ranking.Computer, cache *infrastructure.Cache, cfg infrast
 
 		refreshGlobal(ctx, comp, cache, cfg, log, stats)
 		refreshCities(ctx, comp, cache, cfg, log, stats, rand)
+		refreshCountries(ctx, comp, cache, cfg, log, stats, ranking.WindowAll)
+		refreshWindows(ctx, comp, cache, cfg, log, stats)
+		refreshContests(ctx, comp, cache, cfg, log, stats)
+		refreshVideos(ctx, comp, cache, cfg, log, stats)
 
+		elapsed := time.Since(start)
+		infrastructure.RecordWarmupCycle(trigger, elapsed, stats.outcomes())
 		log.Info("warmup cycle completed",
 			"trigger", trigger,
-			"duration_ms", time.Since(start).Milliseconds(),
+			"duration_ms", elapsed.Milliseconds(),
 			"refreshed", stats.refreshed,
 			"skipped_lock", stats.skippedLock,
 			"fetch_errors", stats.fetchErrors,
@@ -103,10 +161,34 @@ func StartWarmup(comp ranking.Computer, cache *infrastructure.Cache, cfg infrast
 			return
 		case <-ticker.C:
 			runCycle("interval")
+		case <-retractions:
+			latest, changed, err := pollRetractions(context.Background(), watcher, lastRetraction)
+			if err != nil {
+				log.Warn("retraction poll failed", "err", err)
+				continue
+			}
+			if changed {
+				lastRetraction = latest
+				runCycle("retraction")
+			}
 		}
 	}
 }
 
+// pollRetractions devuelve la ultima marca de retiro y si avanzo respecto a seen.
+func pollRetractions(ctx context.Context, watcher ranking.RetractionWatcher, seen time.Time) (time.Time, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	latest, err := watcher.LastRetraction(ctx)
+	if err != nil {
+		return seen, false, err
+	}
+	if !latest.After(seen) {
+		return seen, false, nil
+	}
+	return latest, true, nil
+}
+
 func refreshGlobal(ctx context.Context, comp ranking.Computer, cache *infrastructure.Cache, cfg infrastructure.Config, log *slog.Logger, stats *cycleStats) {
 	processScope(ctx, comp, cache, cfg, log, stats, scopeInput{scope: scopeGlobal})
 }
@@ -151,16 +233,151 @@ func refreshCities(ctx context.Context, comp ranking.Computer, cache *infrastruc
 	}
 }
 
-func processScope(ctx context.Context, comp ranking.Computer, cache *infrastructure.Cache, cfg infrastructure.Config, log *slog.Logger, stats *cycleStats, scope scopeInput) {
-	lockKey := keys.RankLockGlobal(cfg.SchemaVersion)
-	dataKey := keys.RankGlobal(cfg.SchemaVersion)
-	description := "global"
+// refreshCountries refresca el ranking por usuario de cada pais de WarmCountries en la ventana dada.
+func refreshCountries(ctx context.Context, comp ranking.Computer, cache *infrastructure.Cache, cfg infrastructure.Config, log *slog.Logger, stats *cycleStats, window ranking.Window) {
+	for _, name := range cfg.WarmCountries {
+		trimmed := strings.TrimSpace(name)
+		if trimmed == "" {
+			continue
+		}
+		processScope(ctx, comp, cache, cfg, log, stats, scopeInput{
+			scope:       scopeCountry,
+			countryName: trimmed,
+			countrySlug: keys.SlugCity(trimmed),
+			window:      window,
+		})
+	}
+}
 
-	if scope.scope == scopeCity {
-		lockKey = keys.RankLockCity(scope.citySlug, cfg.SchemaVersion)
-		dataKey = keys.RankCity(scope.citySlug, cfg.SchemaVersion)
-		description = fmt.Sprintf("city:%s", scope.citySlug)
+// refreshWindows refresca, para cada ventana de WarmWindows, el ranking global,
+// por ciudad y por pais.
+func refreshWindows(ctx context.Context, comp ranking.Computer, cache *infrastructure.Cache, cfg infrastructure.Config, log *slog.Logger, stats *cycleStats) {
+	for _, raw := range cfg.WarmWindows {
+		window, ok := ranking.ParseWindow(raw)
+		if !ok || window == ranking.WindowAll {
+			continue
+		}
+		processScope(ctx, comp, cache, cfg, log, stats, scopeInput{scope: scopeGlobal, window: window})
+		for _, name := range cfg.WarmCities {
+			trimmed := strings.TrimSpace(name)
+			if trimmed == "" {
+				continue
+			}
+			processScope(ctx, comp, cache, cfg, log, stats, scopeInput{
+				scope:    scopeCity,
+				cityName: trimmed,
+				citySlug: keys.SlugCity(trimmed),
+				window:   window,
+			})
+		}
+		refreshCountries(ctx, comp, cache, cfg, log, stats, window)
 	}
+}
+
+// refreshContests refresca el ranking global y por ciudad de cada concurso activo.
+// Requiere que comp implemente ranking.ContestComputer.
+func refreshContests(ctx context.Context, comp ranking.Computer, cache *infrastructure.Cache, cfg infrastructure.Config, log *slog.Logger, stats *cycleStats) {
+	cc, ok := comp.(ranking.ContestComputer)
+	if !ok {
+		return
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.DBReadTimeoutSeconds)*time.Second)
+	contests, err := cc.ActiveContests(listCtx)
+	cancel()
+	if err != nil {
+		stats.fetchErrors++
+		log.Error("active contests fetch failed", "err", err)
+		return
+	}
+
+	for _, contestID := range contests {
+		processScope(ctx, comp, cache, cfg, log, stats, scopeInput{scope: scopeGlobal, contestID: contestID})
+		for _, name := range cfg.WarmCities {
+			trimmed := strings.TrimSpace(name)
+			if trimmed == "" {
+				continue
+			}
+			processScope(ctx, comp, cache, cfg, log, stats, scopeInput{
+				scope:     scopeCity,
+				cityName:  trimmed,
+				citySlug:  keys.SlugCity(trimmed),
+				contestID: contestID,
+			})
+		}
+	}
+}
+
+// refreshVideos refresca el ranking de videos global y por ciudad.
+// Requiere que comp implemente ranking.VideoComputer.
+func refreshVideos(ctx context.Context, comp ranking.Computer, cache *infrastructure.Cache, cfg infrastructure.Config, log *slog.Logger, stats *cycleStats) {
+	if _, ok := comp.(ranking.VideoComputer); !ok {
+		return
+	}
+
+	processScope(ctx, comp, cache, cfg, log, stats, scopeInput{scope: scopeGlobal, videos: true})
+	for _, name := range cfg.WarmCities {
+		trimmed := strings.TrimSpace(name)
+		if trimmed == "" {
+			continue
+		}
+		processScope(ctx, comp, cache, cfg, log, stats, scopeInput{
+			scope:    scopeCity,
+			cityName: trimmed,
+			citySlug: keys.SlugCity(trimmed),
+			videos:   true,
+		})
+	}
+}
+
+// scopeKeys devuelve las claves de lock y de datos del alcance.
+func scopeKeys(version string, scope scopeInput) (lockKey, dataKey, description string) {
+	switch {
+	case scope.videos && scope.scope == scopeCity:
+		return keys.RankLockVideosCity(scope.citySlug, version),
+			keys.RankVideosCity(scope.citySlug, version),
+			fmt.Sprintf("videos:city:%s", scope.citySlug)
+	case scope.videos:
+		return keys.RankLockVideosGlobal(version), keys.RankVideosGlobal(version), "videos:global"
+	case isWindowed(scope.window) && scope.scope == scopeCountry:
+		return keys.RankLockWindowCountry(string(scope.window), scope.countrySlug, version),
+			keys.RankWindowCountry(string(scope.window), scope.countrySlug, version),
+			fmt.Sprintf("window:%s:country:%s", scope.window, scope.countrySlug)
+	case isWindowed(scope.window) && scope.scope == scopeCity:
+		return keys.RankLockWindowCity(string(scope.window), scope.citySlug, version),
+			keys.RankWindowCity(string(scope.window), scope.citySlug, version),
+			fmt.Sprintf("window:%s:city:%s", scope.window, scope.citySlug)
+	case isWindowed(scope.window):
+		return keys.RankLockWindow(string(scope.window), version),
+			keys.RankWindow(string(scope.window), version),
+			fmt.Sprintf("window:%s", scope.window)
+	case scope.scope == scopeCountry:
+		return keys.RankLockCountry(scope.countrySlug, version),
+			keys.RankCountry(scope.countrySlug, version),
+			fmt.Sprintf("country:%s", scope.countrySlug)
+	case scope.contestID != 0 && scope.scope == scopeCity:
+		return keys.RankLockContestCity(scope.contestID, scope.citySlug, version),
+			keys.RankContestCity(scope.contestID, scope.citySlug, version),
+			fmt.Sprintf("contest:%d:city:%s", scope.contestID, scope.citySlug)
+	case scope.contestID != 0:
+		return keys.RankLockContest(scope.contestID, version),
+			keys.RankContest(scope.contestID, version),
+			fmt.Sprintf("contest:%d", scope.contestID)
+	case scope.scope == scopeCity:
+		return keys.RankLockCity(scope.citySlug, version),
+			keys.RankCity(scope.citySlug, version),
+			fmt.Sprintf("city:%s", scope.citySlug)
+	default:
+		return keys.RankLockGlobal(version), keys.RankGlobal(version), "global"
+	}
+}
+
+func isWindowed(w ranking.Window) bool {
+	return w != "" && w != ranking.WindowAll
+}
+
+func processScope(ctx context.Context, comp ranking.Computer, cache *infrastructure.Cache, cfg infrastructure.Config, log *slog.Logger, stats *cycleStats, scope scopeInput) {
+	lockKey, dataKey, description := scopeKeys(cfg.SchemaVersion, scope)
 
 	token, acquired, err := cache.AcquireLock(ctx, lockKey)
 	if err != nil {
@@ -180,15 +397,28 @@ func processScope(ctx context.Context, comp ranking.Computer, cache *infrastruct
 		}
 	}()
 
-	items, err := fetchRankingWithRetry(ctx, comp, cfg, scope)
-	if err != nil {
+	var normalized []rankingCacheItem
+	var fetched int
+	var fetchErr error
+	if scope.videos {
+		var items []ranking.VideoRankItem
+		if items, fetchErr = fetchVideoRankingWithRetry(ctx, comp, cfg, scope); fetchErr == nil {
+			fetched = len(items)
+			normalized, err = normalizeVideoRanking(items, cfg.MaxTopUsers)
+		}
+	} else {
+		var items []ranking.RankItem
+		if items, fetchErr = fetchRankingWithRetry(ctx, comp, cfg, scope); fetchErr == nil {
+			fetched = len(items)
+			normalized, err = normalizeRanking(items, cfg.MaxTopUsers)
+		}
+	}
+	if fetchErr != nil {
 		stats.fetchErrors++
-		log.Error("ranking fetch failed", "scope", description, "err", err)
+		log.Error("ranking fetch failed", "scope", description, "err", fetchErr)
 		handleStaleAssessment(ctx, cache, dataKey, description, log, stats)
 		return
 	}
-
-	normalized, err := normalizeRanking(items, cfg.MaxTopUsers)
 	if err != nil {
 		stats.validationErrors++
 		log.Error("ranking validation failed", "scope", description, "err", err)
@@ -202,10 +432,18 @@ func processScope(ctx context.Context, comp ranking.Computer, cache *infrastruct
 		Scope:         scope.scope,
 		City:          scope.cityName,
 		CitySlug:      scope.citySlug,
+		Country:       scope.countryName,
+		CountrySlug:   scope.countrySlug,
+		ContestID:     scope.contestID,
 		AsOf:          now,
 		FreshUntil:    now.Add(cache.FreshTTL()),
 		StaleUntil:    now.Add(cache.FreshTTL() + cache.MaxStale()),
 		Items:         normalized,
+		// Se piden hasta 2*MaxTopUsers filas: si no sobro ninguna, el ranking cabe entero.
+		Complete: fetched == len(normalized),
+	}
+	if isWindowed(scope.window) {
+		entry.Window = string(scope.window)
 	}
 
 	payload, err := json.Marshal(entry)
@@ -226,6 +464,38 @@ func processScope(ctx context.Context, comp ranking.Computer, cache *infrastruct
 }
 
 func fetchRankingWithRetry(ctx context.Context, comp ranking.Computer, cfg infrastructure.Config, scope scopeInput) ([]ranking.RankItem, error) {
+	city, size := scopeCityAndSize(cfg, scope)
+	q := ranking.Query{City: city, Window: scope.window}
+	if scope.scope == scopeCountry {
+		q.Country = &scope.countryName
+	}
+
+	var items []ranking.RankItem
+	err := withRetry(ctx, cfg, func(attemptCtx context.Context) error {
+		var err error
+		items, err = compute(attemptCtx, comp, scope.contestID, q, size)
+		return err
+	})
+	return items, err
+}
+
+func fetchVideoRankingWithRetry(ctx context.Context, comp ranking.Computer, cfg infrastructure.Config, scope scopeInput) ([]ranking.VideoRankItem, error) {
+	vc, ok := comp.(ranking.VideoComputer)
+	if !ok {
+		return nil, errors.New("video rankings not supported by computer")
+	}
+	city, size := scopeCityAndSize(cfg, scope)
+
+	var items []ranking.VideoRankItem
+	err := withRetry(ctx, cfg, func(attemptCtx context.Context) error {
+		var err error
+		items, err = vc.ComputeVideos(attemptCtx, city, 1, size)
+		return err
+	})
+	return items, err
+}
+
+func scopeCityAndSize(cfg infrastructure.Config, scope scopeInput) (*string, int) {
 	var city *string
 	if scope.scope == scopeCity {
 		city = &scope.cityName
@@ -235,7 +505,11 @@ func fetchRankingWithRetry(ctx context.Context, comp ranking.Computer, cfg infra
 	if size < cfg.MaxTopUsers {
 		size = cfg.MaxTopUsers
 	}
+	return city, size
+}
 
+// withRetry ejecuta fn hasta DBMaxRetries veces con timeout por intento y backoff exponencial.
+func withRetry(ctx context.Context, cfg infrastructure.Config, fn func(ctx context.Context) error) error {
 	timeout := time.Duration(cfg.DBReadTimeoutSeconds) * time.Second
 	if timeout <= 0 {
 		timeout = 3 * time.Second
@@ -243,11 +517,14 @@ func fetchRankingWithRetry(ctx context.Context, comp ranking.Computer, cfg infra
 
 	var lastErr error
 	for attempt := 0; attempt < cfg.DBMaxRetries; attempt++ {
+		if attempt > 0 {
+			infrastructure.RecordDBRetry()
+		}
 		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
-		items, err := comp.Compute(attemptCtx, city, 1, size)
+		err := fn(attemptCtx)
 		cancel()
 		if err == nil {
-			return items, nil
+			return nil
 		}
 		lastErr = err
 		sleep := time.Duration(1<<attempt) * 150 * time.Millisecond
@@ -256,7 +533,18 @@ func fetchRankingWithRetry(ctx context.Context, comp ranking.Computer, cfg infra
 	if lastErr == nil {
 		lastErr = errors.New("unknown fetch error")
 	}
-	return nil, lastErr
+	return lastErr
+}
+
+func compute(ctx context.Context, comp ranking.Computer, contestID int64, q ranking.Query, size int) ([]ranking.RankItem, error) {
+	if contestID == 0 {
+		return comp.Compute(ctx, q, 1, size)
+	}
+	cc, ok := comp.(ranking.ContestComputer)
+	if !ok {
+		return nil, errors.New("contest rankings not supported by computer")
+	}
+	return cc.ComputeContest(ctx, contestID, q.City, 1, size)
 }
 
 func normalizeRanking(items []ranking.RankItem, limit int) ([]rankingCacheItem, error) {
@@ -303,6 +591,47 @@ func normalizeRanking(items []ranking.RankItem, limit int) ([]rankingCacheItem,
 	return result, nil
 }
 
+// normalizeVideoRanking ordena por votos DESC, video_id ASC y rechaza videos duplicados.
+func normalizeVideoRanking(items []ranking.VideoRankItem, limit int) ([]rankingCacheItem, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Votes == items[j].Votes {
+			return items[i].VideoID < items[j].VideoID
+		}
+		return items[i].Votes > items[j].Votes
+	})
+
+	seen := make(map[int64]struct{})
+	result := make([]rankingCacheItem, 0, min(limit, len(items)))
+	rank := 0
+	for _, it := range items {
+		if it.VideoID == 0 {
+			return nil, errors.New("video ranking item without video_id")
+		}
+		if _, exists := seen[it.VideoID]; exists {
+			return nil, fmt.Errorf("duplicate video_id %d", it.VideoID)
+		}
+		seen[it.VideoID] = struct{}{}
+		rank++
+		result = append(result, rankingCacheItem{
+			Rank:     rank,
+			VideoID:  it.VideoID,
+			Title:    it.Title,
+			UserID:   it.UserID,
+			Username: it.Username,
+			Score:    it.Votes,
+		})
+		if rank == limit {
+			break
+		}
+	}
+
+	return result, nil
+}
+
 func handleStaleAssessment(ctx context.Context, cache *infrastructure.Cache, key, scope string, log *slog.Logger, stats *cycleStats) {
 	raw, err := cache.GetBytes(ctx, key)
 	if err != nil {
@@ -1,11 +1,15 @@
 package scheduler
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"admincache/internal/application/ranking"
+	"admincache/internal/infrastructure"
 )
 
 func TestNormalizeRankingLimit(t *testing.T) {
@@ -43,3 +47,165 @@ func TestNormalizeRankingDetectsDuplicateUsernameWhenNoID(t *testing.T) {
 	_, err := normalizeRanking(items, 10)
 	require.Error(t, err)
 }
+
+func TestNormalizeVideoRankingTieBreaksByVideoID(t *testing.T) {
+	items := []ranking.VideoRankItem{
+		{VideoID: 9, Title: "c", UserID: 1, Username: "alice", Votes: 5},
+		{VideoID: 4, Title: "b", UserID: 1, Username: "alice", Votes: 5},
+		{VideoID: 7, Title: "a", UserID: 2, Username: "bob", Votes: 8},
+	}
+
+	normalized, err := normalizeVideoRanking(items, 10)
+	require.NoError(t, err)
+	require.Len(t, normalized, 3)
+	require.Equal(t, int64(7), normalized[0].VideoID)
+	require.Equal(t, int64(4), normalized[1].VideoID)
+	require.Equal(t, "b", normalized[1].Title)
+	require.Equal(t, int64(9), normalized[2].VideoID)
+	require.Equal(t, 3, normalized[2].Rank)
+}
+
+func TestNormalizeVideoRankingDetectsDuplicateVideo(t *testing.T) {
+	items := []ranking.VideoRankItem{
+		{VideoID: 4, UserID: 1, Votes: 5},
+		{VideoID: 4, UserID: 1, Votes: 3},
+	}
+
+	_, err := normalizeVideoRanking(items, 10)
+	require.Error(t, err)
+}
+
+type fakeRetractionWatcher struct {
+	last time.Time
+	err  error
+}
+
+func (f fakeRetractionWatcher) LastRetraction(ctx context.Context) (time.Time, error) {
+	return f.last, f.err
+}
+
+func TestPollRetractionsDetectsNewRetraction(t *testing.T) {
+	seen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	latest, changed, err := pollRetractions(context.Background(), fakeRetractionWatcher{last: seen}, seen)
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Equal(t, seen, latest)
+
+	next := seen.Add(time.Second)
+	latest, changed, err = pollRetractions(context.Background(), fakeRetractionWatcher{last: next}, seen)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, next, latest)
+}
+
+func TestPollRetractionsKeepsSeenOnError(t *testing.T) {
+	seen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	latest, changed, err := pollRetractions(context.Background(), fakeRetractionWatcher{err: errors.New("db down")}, seen)
+	require.Error(t, err)
+	require.False(t, changed)
+	require.Equal(t, seen, latest)
+}
+
+func TestScopeKeys(t *testing.T) {
+	lock, data, desc := scopeKeys("v2", scopeInput{scope: scopeGlobal})
+	require.Equal(t, "rank:lock:global:v2", lock)
+	require.Equal(t, "rank:global:v2", data)
+	require.Equal(t, "global", desc)
+
+	lock, data, _ = scopeKeys("v2", scopeInput{scope: scopeCity, citySlug: "cali"})
+	require.Equal(t, "rank:lock:city:cali:v2", lock)
+	require.Equal(t, "rank:city:cali:v2", data)
+
+	lock, data, desc = scopeKeys("v2", scopeInput{scope: scopeGlobal, contestID: 3})
+	require.Equal(t, "rank:lock:contest:3:global:v2", lock)
+	require.Equal(t, "rank:contest:3:global:v2", data)
+	require.Equal(t, "contest:3", desc)
+
+	lock, data, _ = scopeKeys("v2", scopeInput{scope: scopeCity, citySlug: "cali", contestID: 3})
+	require.Equal(t, "rank:lock:contest:3:city:cali:v2", lock)
+	require.Equal(t, "rank:contest:3:city:cali:v2", data)
+
+	lock, data, desc = scopeKeys("v2", scopeInput{scope: scopeGlobal, videos: true})
+	require.Equal(t, "rank:lock:videos:global:v2", lock)
+	require.Equal(t, "rank:videos:global:v2", data)
+	require.Equal(t, "videos:global", desc)
+
+	lock, data, _ = scopeKeys("v2", scopeInput{scope: scopeCity, citySlug: "cali", videos: true})
+	require.Equal(t, "rank:lock:videos:city:cali:v2", lock)
+	require.Equal(t, "rank:videos:city:cali:v2", data)
+
+	lock, data, desc = scopeKeys("v2", scopeInput{scope: scopeCountry, countrySlug: "colombia"})
+	require.Equal(t, "rank:lock:country:colombia:v2", lock)
+	require.Equal(t, "rank:country:colombia:v2", data)
+	require.Equal(t, "country:colombia", desc)
+
+	// La ventana all comparte las claves historicas.
+	_, data, _ = scopeKeys("v2", scopeInput{scope: scopeGlobal, window: ranking.WindowAll})
+	require.Equal(t, "rank:global:v2", data)
+
+	lock, data, desc = scopeKeys("v2", scopeInput{scope: scopeGlobal, window: ranking.WindowWeek})
+	require.Equal(t, "rank:lock:window:week:global:v2", lock)
+	require.Equal(t, "rank:window:week:global:v2", data)
+	require.Equal(t, "window:week", desc)
+
+	_, data, _ = scopeKeys("v2", scopeInput{scope: scopeCity, citySlug: "cali", window: ranking.WindowDay})
+	require.Equal(t, "rank:window:day:city:cali:v2", data)
+
+	_, data, _ = scopeKeys("v2", scopeInput{scope: scopeCountry, countrySlug: "colombia", window: ranking.WindowMonth})
+	require.Equal(t, "rank:window:month:country:colombia:v2", data)
+}
+
+type recordingComputer struct {
+	queries []ranking.Query
+}
+
+func (r *recordingComputer) Compute(ctx context.Context, q ranking.Query, page, size int) ([]ranking.RankItem, error) {
+	r.queries = append(r.queries, q)
+	return nil, nil
+}
+
+func TestFetchRankingPassesWindowAndCountry(t *testing.T) {
+	comp := &recordingComputer{}
+	cfg := infrastructure.Config{MaxTopUsers: 10, DBMaxRetries: 1, DBReadTimeoutSeconds: 1}
+
+	_, err := fetchRankingWithRetry(context.Background(), comp, cfg, scopeInput{
+		scope:       scopeCountry,
+		countryName: "Colombia",
+		countrySlug: "colombia",
+		window:      ranking.WindowWeek,
+	})
+	require.NoError(t, err)
+	require.Len(t, comp.queries, 1)
+	q := comp.queries[0]
+	require.Nil(t, q.City)
+	require.NotNil(t, q.Country)
+	require.Equal(t, "Colombia", *q.Country)
+	require.Equal(t, ranking.WindowWeek, q.Window)
+}
+
+func TestParseWindow(t *testing.T) {
+	w, ok := ranking.ParseWindow("")
+	require.True(t, ok)
+	require.Equal(t, ranking.WindowAll, w)
+
+	w, ok = ranking.ParseWindow(" Week ")
+	require.True(t, ok)
+	require.Equal(t, ranking.WindowWeek, w)
+
+	_, ok = ranking.ParseWindow("year")
+	require.False(t, ok)
+}
+
+func TestCycleStatsOutcomes(t *testing.T) {
+	stats := &cycleStats{refreshed: 4, skippedLock: 1, fetchErrors: 2, staleServed: 3}
+	outcomes := stats.outcomes()
+
+	require.Len(t, outcomes, 9)
+	require.Equal(t, 4, outcomes[infrastructure.OutcomeRefreshed])
+	require.Equal(t, 1, outcomes[infrastructure.OutcomeSkippedLock])
+	require.Equal(t, 2, outcomes[infrastructure.OutcomeFetchError])
+	require.Equal(t, 3, outcomes[infrastructure.OutcomeStaleServed])
+	require.Zero(t, outcomes[infrastructure.OutcomeLockReleaseError])
+}
@@ -20,10 +20,30 @@ type Config struct {
 	DBMaxRetries         int
 
 	RefreshIntervalSeconds int
+	RetractionPollSeconds  int
 	CityBatchSize          int
 	WarmCities             []string
+	// WarmCountries y WarmWindows amplian el warmup a rankings por pais y por ventana de tiempo.
+	WarmCountries []string
+	WarmWindows   []string
 
 	MaxTopUsers int
+
+	// Leaderboards incrementales por eventos de voto; RabbitMQURL vacio los deshabilita.
+	RabbitMQURL                 string
+	VoteEventsExchange          string
+	LeaderboardQueue            string
+	LeaderboardPrefetch         int
+	LeaderboardReconcileSeconds int
+	LeaderboardSeenTTLSeconds   int
+
+	// MetricsAddr es la direccion de GET /metrics; METRICS_ADDR=off la deshabilita.
+	MetricsAddr string
+
+	// HealthAddr es la direccion de GET /livez y /readyz; HEALTH_ADDR=off la deshabilita.
+	HealthAddr string
+	// HealthCheckTimeoutMs limita cada chequeo de /readyz.
+	HealthCheckTimeoutMs int
 }
 
 func getenv(key, def string) string {
@@ -42,6 +62,17 @@ func getenvInt(key string, def int) int {
 	return def
 }
 
+func splitList(raw string) []string {
+	var out []string
+	for _, v := range strings.Split(raw, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 func LoadConfig() Config {
 	cfg := Config{
 		RedisAddr:              getenv("REDIS_ADDR", "redis:6379"),
@@ -55,20 +86,27 @@ func LoadConfig() Config {
 		DBReadTimeoutSeconds:   getenvInt("DB_READ_TIMEOUT_SECONDS", 3),
 		DBMaxRetries:           getenvInt("DB_MAX_RETRIES", 3),
 		RefreshIntervalSeconds: getenvInt("REFRESH_INTERVAL_SECONDS", 300),
+		RetractionPollSeconds:  getenvInt("RETRACTION_POLL_SECONDS", 15),
 		CityBatchSize:          getenvInt("BATCH_SIZE_CITIES", 50),
 		MaxTopUsers:            getenvInt("CACHE_MAX_TOP_USERS", 10),
-	}
 
-	cities := getenv("WARM_CITIES", "")
-	if cities != "" {
-		for _, c := range strings.Split(cities, ",") {
-			c = strings.TrimSpace(c)
-			if c != "" {
-				cfg.WarmCities = append(cfg.WarmCities, c)
-			}
-		}
+		RabbitMQURL:                 getenv("RABBITMQ_URL", ""),
+		VoteEventsExchange:          getenv("VOTE_EVENTS_EXCHANGE", "votes"),
+		LeaderboardQueue:            getenv("LEADERBOARD_QUEUE", "admincache.leaderboard"),
+		LeaderboardPrefetch:         getenvInt("LEADERBOARD_PREFETCH", 50),
+		LeaderboardReconcileSeconds: getenvInt("LEADERBOARD_RECONCILE_SECONDS", 300),
+		LeaderboardSeenTTLSeconds:   getenvInt("LEADERBOARD_SEEN_TTL_SECONDS", 259200),
+
+		MetricsAddr: getenv("METRICS_ADDR", ":2112"),
+
+		HealthAddr:           getenv("HEALTH_ADDR", ":8081"),
+		HealthCheckTimeoutMs: getenvInt("HEALTH_CHECK_TIMEOUT_MS", 2000),
 	}
 
+	cfg.WarmCities = splitList(getenv("WARM_CITIES", ""))
+	cfg.WarmCountries = splitList(getenv("WARM_COUNTRIES", ""))
+	cfg.WarmWindows = splitList(getenv("WARM_WINDOWS", "day,week,month"))
+
 	if cfg.TTLFreshSeconds <= 0 {
 		cfg.TTLFreshSeconds = 900
 	}
@@ -90,12 +128,35 @@ func LoadConfig() Config {
 	if cfg.RefreshIntervalSeconds <= 0 {
 		cfg.RefreshIntervalSeconds = 300
 	}
+	// 0 deshabilita el refresco anticipado por votos retirados
+	if cfg.RetractionPollSeconds < 0 {
+		cfg.RetractionPollSeconds = 0
+	}
 	if cfg.CityBatchSize <= 0 {
 		cfg.CityBatchSize = 50
 	}
 	if cfg.MaxTopUsers <= 0 || cfg.MaxTopUsers > 10 {
 		cfg.MaxTopUsers = 10
 	}
+	if cfg.LeaderboardPrefetch <= 0 {
+		cfg.LeaderboardPrefetch = 50
+	}
+	if cfg.LeaderboardReconcileSeconds <= 0 {
+		cfg.LeaderboardReconcileSeconds = 300
+	}
+	if cfg.LeaderboardSeenTTLSeconds <= 0 {
+		cfg.LeaderboardSeenTTLSeconds = 259200
+	}
+
+	if strings.EqualFold(cfg.MetricsAddr, "off") {
+		cfg.MetricsAddr = ""
+	}
+	if strings.EqualFold(cfg.HealthAddr, "off") {
+		cfg.HealthAddr = ""
+	}
+	if cfg.HealthCheckTimeoutMs <= 0 {
+		cfg.HealthCheckTimeoutMs = 2000
+	}
 
 	return cfg
 }
@@ -0,0 +1,182 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Estados de cada chequeo y del reporte completo de /readyz.
+const (
+	HealthOK   = "ok"
+	HealthFail = "fail"
+)
+
+// HealthCheck prueba una dependencia; Run devuelve nil si se puede usar.
+type HealthCheck struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// HealthResult es el resultado de un chequeo.
+type HealthResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// HealthReport es el cuerpo de /readyz, con el mismo formato que el resto de workers.
+type HealthReport struct {
+	Status string                  `json:"status"`
+	Checks map[string]HealthResult `json:"checks"`
+}
+
+// EvaluateHealth corre los chequeos en paralelo, cada uno con su propio timeout; uno que
+// no responde a tiempo cuenta como fallido aunque ignore ctx.
+func EvaluateHealth(ctx context.Context, timeout time.Duration, checks ...HealthCheck) HealthReport {
+	report := HealthReport{Status: HealthOK, Checks: make(map[string]HealthResult, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c HealthCheck) {
+			defer wg.Done()
+			res := runHealthCheck(ctx, timeout, c)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.Name] = res
+			if res.Status == HealthFail {
+				report.Status = HealthFail
+			}
+		}(c)
+	}
+	wg.Wait()
+	return report
+}
+
+func runHealthCheck(ctx context.Context, timeout time.Duration, c HealthCheck) HealthResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	res := HealthResult{Status: HealthOK, LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		res.Status = HealthFail
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// HealthHandler sirve GET /livez (el proceso responde) y GET /readyz (503 si algun
+// chequeo falla).
+func HealthHandler(timeout time.Duration, checks ...HealthCheck) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", func(w http.ResponseWriter, r *http.Request) {
+		writeHealthJSON(w, http.StatusOK, map[string]string{"status": HealthOK})
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		report := EvaluateHealth(r.Context(), timeout, checks...)
+		status := http.StatusOK
+		if report.Status != HealthOK {
+			status = http.StatusServiceUnavailable
+		}
+		writeHealthJSON(w, status, report)
+	})
+	return mux
+}
+
+func writeHealthJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// ServeHealth expone /livez y /readyz en addr en segundo plano; addr vacio no expone nada.
+func ServeHealth(addr string, timeout time.Duration, log *slog.Logger, checks ...HealthCheck) *http.Server {
+	if addr == "" {
+		return nil
+	}
+	srv := &http.Server{Addr: addr, Handler: HealthHandler(timeout, checks...), ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("health server failed", "addr", addr, "err", err)
+		}
+	}()
+	log.Info("health server started", "addr", addr)
+	return srv
+}
+
+// RedisHealthCheck hace PING a Redis.
+func RedisHealthCheck(rdb *redis.Client) HealthCheck {
+	return HealthCheck{Name: "redis", Run: func(ctx context.Context) error {
+		return rdb.Ping(ctx).Err()
+	}}
+}
+
+// PostgresHealthCheck hace ping a Postgres.
+func PostgresHealthCheck(db *sql.DB) HealthCheck {
+	return HealthCheck{Name: "postgres", Run: db.PingContext}
+}
+
+// ConsumerStatus registra si el consumidor de eventos de voto esta conectado; ConsumeVoteEvents
+// lo actualiza y /readyz lo consulta.
+type ConsumerStatus struct {
+	connected atomic.Bool
+}
+
+// HealthCheck falla mientras el consumidor esta desconectado de RabbitMQ (incluido el
+// arranque, antes de la primera conexion).
+func (s *ConsumerStatus) HealthCheck() HealthCheck {
+	return HealthCheck{Name: "rabbitmq_consumer", Run: func(context.Context) error {
+		if !s.connected.Load() {
+			return errors.New("vote events consumer disconnected")
+		}
+		return nil
+	}}
+}
+
+func (s *ConsumerStatus) set(connected bool) {
+	if s != nil {
+		s.connected.Store(connected)
+	}
+}
+
+// ProbeHealth consulta GET /readyz del proceso que escucha en addr y falla si no responde
+// 200. La imagen distroless no trae wget ni curl, asi que el healthcheck del contenedor
+// ejecuta el propio binario ("admincache probe").
+func ProbeHealth(addr string) error {
+	if addr == "" {
+		return errors.New("health server disabled (HEALTH_ADDR=off)")
+	}
+	host := addr
+	if host[0] == ':' {
+		host = "127.0.0.1" + host
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get("http://" + host + "/readyz")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("readyz returned %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateHealthTimesOutAndReportsEachCheck(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	report := EvaluateHealth(context.Background(), 20*time.Millisecond,
+		HealthCheck{Name: "redis", Run: func(context.Context) error { return nil }},
+		HealthCheck{Name: "postgres", Run: func(context.Context) error { <-block; return nil }},
+	)
+
+	assert.Equal(t, HealthFail, report.Status)
+	assert.Equal(t, HealthOK, report.Checks["redis"].Status)
+	assert.Contains(t, report.Checks["postgres"].Error, "timed out")
+}
+
+func TestHealthHandlerFollowsConsumerStatus(t *testing.T) {
+	status := &ConsumerStatus{}
+	h := HealthHandler(time.Second, status.HealthCheck())
+
+	readyz := func() (int, HealthReport) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var report HealthReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		return w.Code, report
+	}
+
+	code, report := readyz()
+	assert.Equal(t, http.StatusServiceUnavailable, code, "not ready before the first connection")
+	assert.Equal(t, "vote events consumer disconnected", report.Checks["rabbitmq_consumer"].Error)
+
+	status.set(true)
+	code, _ = readyz()
+	assert.Equal(t, http.StatusOK, code)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestProbeHealth(t *testing.T) {
+	srv := httptest.NewServer(HealthHandler(time.Second,
+		HealthCheck{Name: "redis", Run: func(context.Context) error { return errors.New("down") }}))
+	defer srv.Close()
+
+	err := ProbeHealth(srv.Listener.Addr().String())
+	assert.EqualError(t, err, "readyz returned 503")
+	assert.Error(t, ProbeHealth(""))
+}
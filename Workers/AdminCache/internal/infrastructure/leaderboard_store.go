@@ -0,0 +1,250 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"admincache/internal/application/keys"
+	"admincache/internal/application/leaderboard"
+)
+
+// Codificacion del puntaje en el ZSET: votos * 2^31 + (2^31 - 1 - user_id).
+// Asi ZREVRANGE ordena por votos DESC y, en empate, por user_id ASC como el ranking SQL.
+// Es exacta mientras votos < 2^22 (precision de 53 bits del double de Redis).
+const (
+	lbTieBits   = 31
+	lbVoteUnit  = int64(1) << lbTieBits
+	lbMaxUserID = lbVoteUnit - 1
+)
+
+func encodeScore(votes, userID int64) float64 {
+	return float64(votes*lbVoteUnit + (lbMaxUserID - userID))
+}
+
+func decodeVotes(score float64) int64 {
+	// Desplazamiento aritmetico: redondea hacia abajo tambien con votos negativos.
+	return int64(score) >> lbTieBits
+}
+
+// applyVoteScript aplica el evento una sola vez:
+// KEYS[1]=seen KEYS[2]=usuarios KEYS[3]=indice de ciudades KEYS[4..]=leaderboards
+// ARGV[1]=ttl seen ARGV[2]=user_id ARGV[3]=delta codificado ARGV[4]=desempate ARGV[5]=username ARGV[6]=city slug
+var applyVoteScript = redis.NewScript(`
+if not redis.call("set", KEYS[1], "1", "NX", "EX", ARGV[1]) then
+    return 0
+end
+for i = 4, #KEYS do
+    if not redis.call("zscore", KEYS[i], ARGV[2]) then
+        redis.call("zadd", KEYS[i], ARGV[4], ARGV[2])
+    end
+    redis.call("zincrby", KEYS[i], ARGV[3], ARGV[2])
+end
+if ARGV[5] ~= "" then
+    redis.call("hset", KEYS[2], ARGV[2], ARGV[5])
+end
+if ARGV[6] ~= "" then
+    redis.call("sadd", KEYS[3], ARGV[6])
+end
+return 1
+`)
+
+// reconcileLeaseScript renueva la lease si ya es de ARGV[1] o la toma si esta libre.
+// KEYS[1]=lease ARGV[1]=duenio ARGV[2]=ttl en ms
+var reconcileLeaseScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+    redis.call("pexpire", KEYS[1], ARGV[2])
+    return 1
+end
+if redis.call("set", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+    return 1
+end
+return 0
+`)
+
+// LeaderboardStore implementa leaderboard.Store sobre ZSET de Redis.
+type LeaderboardStore struct {
+	rdb     *redis.Client
+	prefix  string
+	version string
+	seenTTL time.Duration
+	// owner identifica a esta replica como duenia de la lease de reconciliacion.
+	owner string
+}
+
+var (
+	_ leaderboard.Store          = (*LeaderboardStore)(nil)
+	_ leaderboard.ReconcileLease = (*LeaderboardStore)(nil)
+)
+
+// NewLeaderboardStore usa el mismo prefijo y version de esquema que el cache de rankings.
+// seenTTL es cuanto se recuerdan los eventos aplicados para descartar reentregas.
+func NewLeaderboardStore(rdb *redis.Client, prefix, version string, seenTTL time.Duration) *LeaderboardStore {
+	if prefix == "" {
+		prefix = "videorank:"
+	}
+	if seenTTL <= 0 {
+		seenTTL = 72 * time.Hour
+	}
+	host, _ := os.Hostname()
+	owner := fmt.Sprintf("%s:%d", host, os.Getpid())
+	return &LeaderboardStore{rdb: rdb, prefix: prefix, version: version, seenTTL: seenTTL, owner: owner}
+}
+
+func (s *LeaderboardStore) board(citySlug string) string {
+	if citySlug == "" {
+		return s.prefix + keys.LeaderboardGlobal(s.version)
+	}
+	return s.prefix + keys.LeaderboardCity(citySlug, s.version)
+}
+
+func (s *LeaderboardStore) Apply(ctx context.Context, eventID, citySlug string, userID int64, username string, delta int64) (bool, error) {
+	ks := []string{
+		s.prefix + keys.LeaderboardSeen(eventID, s.version),
+		s.prefix + keys.LeaderboardUsers(s.version),
+		s.prefix + keys.LeaderboardCityIndex(s.version),
+		s.board(""),
+	}
+	if citySlug != "" {
+		ks = append(ks, s.board(citySlug))
+	}
+	member := strconv.FormatInt(userID, 10)
+	res, err := applyVoteScript.Run(ctx, s.rdb, ks,
+		int64(s.seenTTL/time.Second), member, delta*lbVoteUnit, lbMaxUserID-userID, username, citySlug).Int()
+	if err != nil {
+		return false, err
+	}
+	return res == 1, nil
+}
+
+func (s *LeaderboardStore) Top(ctx context.Context, citySlug string, offset, n int) ([]leaderboard.Entry, error) {
+	if n <= 0 {
+		return []leaderboard.Entry{}, nil
+	}
+	zs, err := s.rdb.ZRevRangeWithScores(ctx, s.board(citySlug), int64(offset), int64(offset+n-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]leaderboard.Entry, 0, len(zs))
+	ids := make([]string, 0, len(zs))
+	for i, z := range zs {
+		member, _ := z.Member.(string)
+		id, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, leaderboard.Entry{Rank: int64(offset + i + 1), UserID: id, Votes: decodeVotes(z.Score)})
+		ids = append(ids, member)
+	}
+	if len(ids) == 0 {
+		return entries, nil
+	}
+	names, err := s.rdb.HMGet(ctx, s.prefix+keys.LeaderboardUsers(s.version), ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range names {
+		if v, ok := name.(string); ok {
+			entries[i].Username = v
+		}
+	}
+	return entries, nil
+}
+
+func (s *LeaderboardStore) Rank(ctx context.Context, citySlug string, userID int64) (leaderboard.Entry, bool, error) {
+	member := strconv.FormatInt(userID, 10)
+	board := s.board(citySlug)
+	pipe := s.rdb.Pipeline()
+	rankCmd := pipe.ZRevRank(ctx, board, member)
+	scoreCmd := pipe.ZScore(ctx, board, member)
+	nameCmd := pipe.HGet(ctx, s.prefix+keys.LeaderboardUsers(s.version), member)
+	_, _ = pipe.Exec(ctx)
+
+	rank, err := rankCmd.Result()
+	if errors.Is(err, redis.Nil) {
+		return leaderboard.Entry{}, false, nil
+	}
+	if err != nil {
+		return leaderboard.Entry{}, false, err
+	}
+	score, err := scoreCmd.Result()
+	if err != nil {
+		return leaderboard.Entry{}, false, err
+	}
+	name, err := nameCmd.Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return leaderboard.Entry{}, false, err
+	}
+	return leaderboard.Entry{Rank: rank + 1, UserID: userID, Username: name, Votes: decodeVotes(score)}, true, nil
+}
+
+func (s *LeaderboardStore) AcquireReconcileLease(ctx context.Context, ttl time.Duration) (bool, error) {
+	res, err := reconcileLeaseScript.Run(ctx, s.rdb, []string{s.prefix + keys.LeaderboardReconcileLease(s.version)},
+		s.owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return res == 1, nil
+}
+
+func (s *LeaderboardStore) Scores(ctx context.Context, citySlug string) (map[int64]int64, error) {
+	zs, err := s.rdb.ZRangeWithScores(ctx, s.board(citySlug), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[int64]int64, len(zs))
+	for _, z := range zs {
+		member, _ := z.Member.(string)
+		id, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		out[id] = decodeVotes(z.Score)
+	}
+	return out, nil
+}
+
+func (s *LeaderboardStore) Adjust(ctx context.Context, citySlug string, deltas map[int64]int64, remove []int64, usernames map[int64]string) error {
+	board := s.board(citySlug)
+	names := make(map[string]interface{}, len(deltas))
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range remove {
+			pipe.ZRem(ctx, board, strconv.FormatInt(id, 10))
+		}
+		for id, delta := range deltas {
+			member := strconv.FormatInt(id, 10)
+			// Igual que applyVoteScript: la entrada nueva arranca con el desempate y luego suma.
+			pipe.ZAddNX(ctx, board, redis.Z{Score: encodeScore(0, id), Member: member})
+			pipe.ZIncrBy(ctx, board, float64(delta*lbVoteUnit), member)
+			if name := usernames[id]; name != "" {
+				names[member] = name
+			}
+		}
+		if len(names) > 0 {
+			pipe.HSet(ctx, s.prefix+keys.LeaderboardUsers(s.version), names)
+		}
+		if citySlug != "" && len(deltas) > 0 {
+			pipe.SAdd(ctx, s.prefix+keys.LeaderboardCityIndex(s.version), citySlug)
+		}
+		return nil
+	})
+	return err
+}
+
+func (s *LeaderboardStore) Cities(ctx context.Context) ([]string, error) {
+	return s.rdb.SMembers(ctx, s.prefix+keys.LeaderboardCityIndex(s.version)).Result()
+}
+
+func (s *LeaderboardStore) RemoveCity(ctx context.Context, citySlug string) error {
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, s.board(citySlug))
+		pipe.SRem(ctx, s.prefix+keys.LeaderboardCityIndex(s.version), citySlug)
+		return nil
+	})
+	return err
+}
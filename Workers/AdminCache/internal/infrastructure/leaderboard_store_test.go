@@ -0,0 +1,25 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeScoreOrdersByVotesThenUserID(t *testing.T) {
+	// Mas votos gana; en empate gana el menor user_id (ZREVRANGE ordena por puntaje DESC).
+	assert.Greater(t, encodeScore(3, 100), encodeScore(2, 1))
+	assert.Greater(t, encodeScore(3, 1), encodeScore(3, 2))
+	assert.Greater(t, encodeScore(0, 1), encodeScore(-1, 1))
+}
+
+func TestDecodeVotesRoundTrip(t *testing.T) {
+	for _, votes := range []int64{0, 1, 42, 1<<22 - 1, -1, -5} {
+		for _, id := range []int64{1, 12345, lbMaxUserID} {
+			assert.Equal(t, votes, decodeVotes(encodeScore(votes, id)), "votes=%d id=%d", votes, id)
+		}
+	}
+	// Apply suma delta*lbVoteUnit sobre el desempate inicial.
+	score := encodeScore(0, 9) + float64(2*lbVoteUnit) - float64(lbVoteUnit)
+	assert.Equal(t, int64(1), decodeVotes(score))
+}
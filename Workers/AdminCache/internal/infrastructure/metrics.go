@@ -0,0 +1,93 @@
+package infrastructure
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Resultados por alcance de un ciclo de warmup; son los valores de la etiqueta outcome.
+const (
+	OutcomeRefreshed        = "refreshed"
+	OutcomeSkippedLock      = "skipped_lock"
+	OutcomeFetchError       = "fetch_error"
+	OutcomeValidationError  = "validation_error"
+	OutcomeWriteError       = "write_error"
+	OutcomeStaleServed      = "stale_served"
+	OutcomeStaleExpired     = "stale_expired"
+	OutcomeLockError        = "lock_error"
+	OutcomeLockReleaseError = "lock_release_error"
+)
+
+var (
+	warmupCycles = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "admincache",
+		Name:      "warmup_cycles_total",
+		Help:      "Warmup cycles by trigger (startup, interval, retraction).",
+	}, []string{"trigger"})
+
+	warmupCycleDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "admincache",
+		Name:      "warmup_cycle_duration_seconds",
+		Help:      "Duration of each warmup cycle.",
+		Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	})
+
+	warmupScopes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "admincache",
+		Name:      "warmup_scopes_total",
+		Help:      "Ranking scopes processed by warmup cycles, by outcome.",
+	}, []string{"outcome"})
+
+	dbRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "admincache",
+		Name:      "db_retries_total",
+		Help:      "Ranking queries retried after a failed attempt.",
+	})
+
+	voteEventsConsumed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "admincache",
+		Name:      "vote_events_consumed_total",
+		Help:      "Vote events received from the leaderboard queue.",
+	})
+
+	voteEventsFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "admincache",
+		Name:      "vote_events_failed_total",
+		Help:      "Vote events requeued because the handler failed.",
+	})
+)
+
+// RecordWarmupCycle registra un ciclo de warmup y sus contadores por resultado.
+func RecordWarmupCycle(trigger string, elapsed time.Duration, outcomes map[string]int) {
+	warmupCycles.WithLabelValues(trigger).Inc()
+	warmupCycleDuration.Observe(elapsed.Seconds())
+	for outcome, n := range outcomes {
+		warmupScopes.WithLabelValues(outcome).Add(float64(n))
+	}
+}
+
+// RecordDBRetry cuenta un reintento de consulta a la base de datos.
+func RecordDBRetry() { dbRetries.Inc() }
+
+// ServeMetrics expone GET /metrics en addr en segundo plano; addr vacio no expone nada.
+func ServeMetrics(addr string, log *slog.Logger) *http.Server {
+	if addr == "" {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("metrics server failed", "addr", addr, "err", err)
+		}
+	}()
+	log.Info("metrics server started", "addr", addr)
+	return srv
+}
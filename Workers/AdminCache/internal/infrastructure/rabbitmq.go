@@ -0,0 +1,119 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// VoteEventConsumerConfig configura la cola durable de AdminCache enlazada al exchange de votos.
+type VoteEventConsumerConfig struct {
+	URL      string
+	Exchange string
+	Queue    string
+	Prefetch int
+	// Status refleja si el consumidor esta conectado; nil no lo registra.
+	Status *ConsumerStatus
+}
+
+// DeliveryHandler procesa un mensaje; con error el mensaje se reencola.
+type DeliveryHandler func(ctx context.Context, messageID string, body []byte) error
+
+const (
+	consumerMaxBackoff = 30 * time.Second
+	// Pausa antes de reencolar un mensaje fallido, para no girar en vacio si Redis no responde.
+	consumerRetryDelay = time.Second
+)
+
+// ConsumeVoteEvents consume vote.* hasta que stop se cierre, reconectando con backoff.
+// Los mensajes se confirman solo despues de que handle termina sin error.
+func ConsumeVoteEvents(cfg VoteEventConsumerConfig, handle DeliveryHandler, log *slog.Logger, stop <-chan struct{}) {
+	backoff := time.Second
+	for {
+		err := consumeOnce(cfg, handle, log, stop)
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		if err == nil {
+			backoff = time.Second
+			continue
+		}
+		log.Error("vote events consumer disconnected", "err", err, "retry_in", backoff.String())
+		select {
+		case <-stop:
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > consumerMaxBackoff {
+			backoff = consumerMaxBackoff
+		}
+	}
+}
+
+func consumeOnce(cfg VoteEventConsumerConfig, handle DeliveryHandler, log *slog.Logger, stop <-chan struct{}) error {
+	conn, err := amqp.Dial(cfg.URL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if err := ch.ExchangeDeclare(cfg.Exchange, "topic", true, false, false, false, nil); err != nil {
+		return err
+	}
+	if _, err := ch.QueueDeclare(cfg.Queue, true, false, false, false, nil); err != nil {
+		return err
+	}
+	if err := ch.QueueBind(cfg.Queue, "vote.*", cfg.Exchange, false, nil); err != nil {
+		return err
+	}
+	if cfg.Prefetch > 0 {
+		if err := ch.Qos(cfg.Prefetch, 0, false); err != nil {
+			return err
+		}
+	}
+	deliveries, err := ch.Consume(cfg.Queue, "", false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+	log.Info("vote events consumer started", "exchange", cfg.Exchange, "queue", cfg.Queue)
+	cfg.Status.set(true)
+	defer cfg.Status.set(false)
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case d, ok := <-deliveries:
+			if !ok {
+				return errors.New("delivery channel closed")
+			}
+			voteEventsConsumed.Inc()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			err := handle(ctx, d.MessageId, d.Body)
+			cancel()
+			if err != nil {
+				voteEventsFailed.Inc()
+				log.Warn("vote event requeued", "message_id", d.MessageId, "err", err)
+				time.Sleep(consumerRetryDelay)
+				if err := d.Nack(false, true); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.Ack(false); err != nil {
+				return err
+			}
+		}
+	}
+}
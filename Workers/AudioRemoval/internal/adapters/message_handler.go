@@ -1,15 +1,18 @@
 package adapters
 
 import (
+	"context"
 	"audioremoval/internal/application/usecases"
 	"encoding/json"
-	"github.com/sirupsen/logrus"
+	"log/slog"
+	"shared/logging"
 	"shared/security"
 )
 
 type VideoMessage struct {
-	VideoID  string `json:"video_id"`
-	Filename string `json:"filename"`
+	VideoID       string `json:"video_id"`
+	Filename      string `json:"filename"`
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 type MessageHandler struct {
@@ -20,23 +23,24 @@ func NewMessageHandler(processVideoUC *usecases.ProcessVideoUseCase) *MessageHan
 	return &MessageHandler{processVideoUC: processVideoUC}
 }
 
-func (h *MessageHandler) HandleMessage(body []byte) error {
-	logrus.Infof("Received message: %s", security.SanitizeLogInput(string(body)))
+func (h *MessageHandler) HandleMessage(ctx context.Context, body []byte) error {
+	slog.DebugContext(ctx, "Received message", "body", security.SanitizeLogInput(string(body)))
 	
 	var msg VideoMessage
 	if err := json.Unmarshal(body, &msg); err != nil {
-		logrus.Errorf("Failed to unmarshal message: %v", err)
+		slog.ErrorContext(ctx, "Failed to unmarshal message", "err", err)
 		return err
 	}
 
-	logrus.Infof("Processing video_id: %s, filename: %s", security.SanitizeLogInput(msg.VideoID), security.SanitizeLogInput(msg.Filename))
+	ctx = logging.WithCorrelationID(logging.WithVideoID(ctx, security.SanitizeLogInput(msg.VideoID)), security.SanitizeLogInput(msg.CorrelationID))
+	slog.InfoContext(ctx, "Processing video", "filename", security.SanitizeLogInput(msg.Filename))
 	
-	if err := h.processVideoUC.Execute(msg.VideoID, msg.Filename); err != nil {
-		logrus.Errorf("Error processing video %s: %v", security.SanitizeLogInput(msg.Filename), err)
+	if err := h.processVideoUC.Execute(ctx, msg.VideoID, msg.Filename); err != nil {
+		slog.ErrorContext(ctx, "Error processing video", "filename", security.SanitizeLogInput(msg.Filename), "err", err)
 		return err
 	}
 
-	logrus.Infof("Video processed successfully: %s", security.SanitizeLogInput(msg.Filename))
+	slog.InfoContext(ctx, "Video processed successfully", "filename", security.SanitizeLogInput(msg.Filename))
 	return nil
 }
 
@@ -4,10 +4,14 @@ import (
 	"audioremoval/internal/ports"
 	"strconv"
 	"github.com/streadway/amqp"
-	"github.com/sirupsen/logrus"
+	"log/slog"
+	"shared/shutdown"
 )
 
+// RabbitMQConsumer declara la cola; el loop de entregas y el apagado (Shutdown, Ready, Done)
+// los aporta shutdown.Consumer.
 type RabbitMQConsumer struct {
+	*shutdown.Consumer
 	conn           *amqp.Connection
 	channel        *amqp.Channel
 	maxRetries     int
@@ -26,6 +30,7 @@ func NewRabbitMQConsumer(url string, maxRetries, queueMaxLength int) (*RabbitMQC
 	}
 
 	return &RabbitMQConsumer{
+		Consumer:       shutdown.NewConsumer(conn, ch),
 		conn:           conn,
 		channel:        ch,
 		maxRetries:     maxRetries,
@@ -34,7 +39,7 @@ func NewRabbitMQConsumer(url string, maxRetries, queueMaxLength int) (*RabbitMQC
 }
 
 func (r *RabbitMQConsumer) StartConsuming(queueName string, handler ports.MessageHandler) error {
-	logrus.Infof("Starting to consume queue: %s", queueName)
+	slog.Info("Starting to consume queue", "queue", queueName)
 
 	// Declare main queue with simple configuration (consistent with TrimVideo and EditVideo)
 	args := amqp.Table{
@@ -42,28 +47,15 @@ func (r *RabbitMQConsumer) StartConsuming(queueName string, handler ports.Messag
 	}
 	_, err := r.channel.QueueDeclare(queueName, true, false, false, false, args)
 	if err != nil {
-		logrus.Errorf("Failed to declare main queue %s: %v", queueName, err)
+		slog.Error("Failed to declare main queue", "queue", queueName, "err", err)
 		return err
 	}
 
-	logrus.Infof("Starting to consume messages from queue: %s", queueName)
-	msgs, err := r.channel.Consume(queueName, "", false, false, false, false, nil)
-	if err != nil {
-		logrus.Errorf("Failed to start consuming from queue %s: %v", queueName, err)
+	slog.Info("Starting to consume messages", "queue", queueName)
+	if err := r.Start(queueName, handler.HandleMessage); err != nil {
+		slog.Error("Failed to start consuming", "queue", queueName, "err", err)
 		return err
 	}
-
-	go func() {
-		for msg := range msgs {
-			if err := handler.HandleMessage(msg.Body); err != nil {
-				logrus.Error("Error processing message:", err)
-				msg.Nack(false, false)
-			} else {
-				msg.Ack(false)
-			}
-		}
-	}()
-
 	return nil
 }
 
@@ -76,6 +68,7 @@ func (r *RabbitMQConsumer) Close() error {
 	}
 	return nil
 }
+
 func (r *RabbitMQConsumer) getRetryCount(msg amqp.Delivery) int {
 	if msg.Headers == nil {
 		return 0
@@ -103,4 +96,4 @@ func (r *RabbitMQConsumer) getRetryCount(msg amqp.Delivery) int {
 	}
 	
 	return 0
-}
\ No newline at end of file
+}
@@ -1,8 +1,11 @@
 package adapters
 
 import (
+	"errors"
+	"context"
 	"encoding/json"
 	"github.com/streadway/amqp"
+	"shared/tracing"
 )
 
 type RabbitMQPublisher struct {
@@ -22,8 +25,12 @@ func NewRabbitMQPublisher(url string) (*RabbitMQPublisher, error) {
 	return &RabbitMQPublisher{conn: conn, channel: ch}, nil
 }
 
-func (p *RabbitMQPublisher) PublishMessage(queueName string, message interface{}) error {
-	_, err := p.channel.QueueDeclare(queueName, true, false, false, false, amqp.Table{
+// PublishMessage publica message como JSON; el contexto de traza de ctx viaja en los headers.
+func (p *RabbitMQPublisher) PublishMessage(ctx context.Context, queueName string, message interface{}) (err error) {
+	ctx, span := tracing.StartPublish(ctx, queueName)
+	defer func() { tracing.End(span, err) }()
+
+	_, err = p.channel.QueueDeclare(queueName, true, false, false, false, amqp.Table{
 		"x-max-length": 1000,
 	})
 	if err != nil {
@@ -36,6 +43,7 @@ func (p *RabbitMQPublisher) PublishMessage(queueName string, message interface{}
 	}
 
 	return p.channel.Publish("", queueName, false, false, amqp.Publishing{
+		Headers:     tracing.InjectHeaders(ctx, nil),
 		ContentType: "application/json",
 		Body:        body,
 	})
@@ -49,4 +57,13 @@ func (p *RabbitMQPublisher) Close() error {
 		return p.conn.Close()
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// Ready devuelve error si la conexion usada para notificar al orquestador se cerro; es el
+// chequeo rabbitmq_publisher de /readyz.
+func (p *RabbitMQPublisher) Ready(context.Context) error {
+	if p.conn == nil || p.conn.IsClosed() {
+		return errors.New("rabbitmq connection closed")
+	}
+	return nil
+}
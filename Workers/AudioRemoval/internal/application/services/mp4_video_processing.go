@@ -1,11 +1,15 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"shared/metrics"
+	"shared/tracing"
 	"shared/security"
+	"time"
 )
 
 type MP4VideoProcessingService struct{}
@@ -14,7 +18,7 @@ func NewMP4VideoProcessingService() *MP4VideoProcessingService {
 	return &MP4VideoProcessingService{}
 }
 
-func (s *MP4VideoProcessingService) RemoveAudio(inputData []byte) ([]byte, error) {
+func (s *MP4VideoProcessingService) RemoveAudio(ctx context.Context, inputData []byte) ([]byte, error) {
 	// Validate FFmpeg is available
 	if _, err := exec.LookPath("ffmpeg"); err != nil {
 		return nil, fmt.Errorf("ffmpeg not found: %w", err)
@@ -37,7 +41,8 @@ func (s *MP4VideoProcessingService) RemoveAudio(inputData []byte) ([]byte, error
 	outputPath := filepath.Join(tmpDir, security.SanitizeFilename("output.mp4"))
 
 	// Use FFmpeg to remove audio (copy video stream only)
-	cmd := exec.Command("ffmpeg",
+	_, span := tracing.Start(ctx, "ffmpeg audio_removal")
+	cmd := exec.CommandContext(ctx, "ffmpeg",
 		"-i", inputPath,
 		"-c:v", "copy", // Copy video stream without re-encoding
 		"-an", // Remove audio stream
@@ -45,7 +50,11 @@ func (s *MP4VideoProcessingService) RemoveAudio(inputData []byte) ([]byte, error
 		outputPath,
 	)
 
-	if err := cmd.Run(); err != nil {
+	start := time.Now()
+	err = cmd.Run()
+	metrics.ObserveFFmpeg("audio_removal", start, err)
+	tracing.End(span, err)
+	if err != nil {
 		return nil, fmt.Errorf("ffmpeg failed: %w", err)
 	}
 
@@ -1,10 +1,10 @@
 package usecases
 
 import (
+	"context"
 	"audioremoval/internal/domain"
 	"fmt"
-	"time"
-	"github.com/sirupsen/logrus"
+	"log/slog"
 )
 
 type ProcessVideoUseCase struct {
@@ -33,7 +33,7 @@ func NewProcessVideoUseCase(
 	}
 }
 
-func (uc *ProcessVideoUseCase) Execute(videoID, filename string) error {
+func (uc *ProcessVideoUseCase) Execute(ctx context.Context, videoID, filename string) error {
 	video, err := uc.videoRepo.FindByFilename(filename)
 	if err != nil {
 		return fmt.Errorf("video not found: %w", err)
@@ -43,19 +43,19 @@ func (uc *ProcessVideoUseCase) Execute(videoID, filename string) error {
 		return fmt.Errorf("failed to update status: %w", err)
 	}
 
-	inputData, err := uc.storageRepo.Download(uc.rawBucket, filename)
+	inputData, err := uc.storageRepo.Download(ctx, uc.rawBucket, filename)
 	if err != nil {
 		uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("failed to download video: %w", err)
 	}
 
-	processedData, err := uc.processingService.RemoveAudio(inputData)
+	processedData, err := uc.processingService.RemoveAudio(ctx, inputData)
 	if err != nil {
 		uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("failed to process video: %w", err)
 	}
 
-	if err := uc.storageRepo.Upload(uc.processedBucket, filename, processedData); err != nil {
+	if err := uc.storageRepo.Upload(ctx, uc.processedBucket, filename, processedData); err != nil {
 		uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("failed to upload processed video: %w", err)
 	}
@@ -65,17 +65,11 @@ func (uc *ProcessVideoUseCase) Execute(videoID, filename string) error {
 	}
 
 	bucketPath := fmt.Sprintf("%s/%s", uc.processedBucket, filename)
-	if err := uc.notificationService.NotifyVideoProcessed(videoID, filename, bucketPath); err != nil {
-		logrus.Errorf("Failed to notify state machine: %v", err)
+	if err := uc.notificationService.NotifyVideoProcessed(ctx, videoID, filename, bucketPath); err != nil {
+		slog.ErrorContext(ctx, "Failed to notify state machine", "err", err)
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"video_id": video.ID,
-		"filename": filename,
-		"bucket_from": uc.rawBucket,
-		"bucket_to": uc.processedBucket,
-		"timestamp": time.Now().UTC(),
-	}).Info("AudioRemoval processing completed successfully")
+	slog.InfoContext(ctx, "AudioRemoval processing completed successfully", "filename", filename, "bucket_from", uc.rawBucket, "bucket_to", uc.processedBucket)
 
 	return nil
 }
\ No newline at end of file
@@ -1,11 +1,13 @@
 package domain
 
+import "context"
+
 type VideoRepository interface {
 	FindByFilename(filename string) (*Video, error)
 	UpdateStatus(id string, status ProcessingStatus) error
 }
 
 type StorageRepository interface {
-	Download(bucket, filename string) ([]byte, error)
-	Upload(bucket, filename string, data []byte) error
+	Download(ctx context.Context, bucket, filename string) ([]byte, error)
+	Upload(ctx context.Context, bucket, filename string, data []byte) error
 }
\ No newline at end of file
@@ -1,10 +1,12 @@
 package domain
 
+import "context"
+
 type VideoProcessingService interface {
-	RemoveAudio(inputData []byte) ([]byte, error)
+	RemoveAudio(ctx context.Context, inputData []byte) ([]byte, error)
 }
 
 type NotificationService interface {
-	NotifyVideoProcessed(videoID, filename, bucketPath string) error
-	NotifyProcessingComplete(videoID string, success bool) error
+	NotifyVideoProcessed(ctx context.Context, videoID, filename, bucketPath string) error
+	NotifyProcessingComplete(ctx context.Context, videoID string, success bool) error
 }
\ No newline at end of file
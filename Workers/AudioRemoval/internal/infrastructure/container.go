@@ -13,6 +13,7 @@ type Container struct {
 	Consumer       *adapters.RabbitMQConsumer
 	Publisher      *adapters.RabbitMQPublisher
 	MessageHandler *adapters.MessageHandler
+	Storage        *sharedstorage.Client
 	ProcessVideoUC *usecases.ProcessVideoUseCase
 }
 
@@ -59,6 +60,7 @@ func NewContainer(config *Config) (*Container, error) {
 		Consumer:       consumer,
 		Publisher:      publisher,
 		MessageHandler: messageHandler,
+		Storage:        storageClient,
 		ProcessVideoUC: processVideoUC,
 	}, nil
 }
@@ -1,6 +1,9 @@
 package mocks
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 type NotificationServiceMock struct {
 	NotifyVideoProcessedFunc    func(videoID, filename, bucketPath string) error
@@ -28,7 +31,7 @@ func NewNotificationServiceMock() *NotificationServiceMock {
 	}
 }
 
-func (m *NotificationServiceMock) NotifyVideoProcessed(videoID, filename, bucketPath string) error {
+func (m *NotificationServiceMock) NotifyVideoProcessed(ctx context.Context, videoID, filename, bucketPath string) error {
 	m.VideoProcessedCalls = append(m.VideoProcessedCalls, VideoProcessedCall{
 		VideoID:    videoID,
 		Filename:   filename,
@@ -46,7 +49,7 @@ func (m *NotificationServiceMock) NotifyVideoProcessed(videoID, filename, bucket
 	return nil
 }
 
-func (m *NotificationServiceMock) NotifyProcessingComplete(videoID string, success bool) error {
+func (m *NotificationServiceMock) NotifyProcessingComplete(ctx context.Context, videoID string, success bool) error {
 	m.ProcessingCompleteCalls = append(m.ProcessingCompleteCalls, ProcessingCompleteCall{
 		VideoID: videoID,
 		Success: success,
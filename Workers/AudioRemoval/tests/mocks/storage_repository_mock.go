@@ -1,6 +1,9 @@
 package mocks
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 type StorageRepositoryMock struct {
 	DownloadFunc func(bucket, filename string) ([]byte, error)
@@ -22,7 +25,7 @@ func NewStorageRepositoryMock() *StorageRepositoryMock {
 	}
 }
 
-func (m *StorageRepositoryMock) Download(bucket, filename string) ([]byte, error) {
+func (m *StorageRepositoryMock) Download(ctx context.Context, bucket, filename string) ([]byte, error) {
 	if m.DownloadFunc != nil {
 		return m.DownloadFunc(bucket, filename)
 	}
@@ -35,7 +38,7 @@ func (m *StorageRepositoryMock) Download(bucket, filename string) ([]byte, error
 	return nil, errors.New("file not found")
 }
 
-func (m *StorageRepositoryMock) Upload(bucket, filename string, data []byte) error {
+func (m *StorageRepositoryMock) Upload(ctx context.Context, bucket, filename string, data []byte) error {
 	if m.UploadFunc != nil {
 		return m.UploadFunc(bucket, filename, data)
 	}
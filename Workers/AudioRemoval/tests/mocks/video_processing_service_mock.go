@@ -1,6 +1,9 @@
 package mocks
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 type VideoProcessingServiceMock struct {
 	RemoveAudioFunc func(inputData []byte) ([]byte, error)
@@ -15,7 +18,7 @@ func NewVideoProcessingServiceMock() *VideoProcessingServiceMock {
 	}
 }
 
-func (m *VideoProcessingServiceMock) RemoveAudio(inputData []byte) ([]byte, error) {
+func (m *VideoProcessingServiceMock) RemoveAudio(ctx context.Context, inputData []byte) ([]byte, error) {
 	m.CallCount++
 	
 	if m.RemoveAudioFunc != nil {
@@ -1,6 +1,7 @@
 package adapters_test
 
 import (
+	"context"
 	"audioremoval/internal/adapters"
 	"encoding/json"
 	"testing"
@@ -19,7 +20,7 @@ func TestMessageHandler_HandleMessage_InvalidJSON(t *testing.T) {
 	
 	invalidJSON := []byte(`{"invalid": json}`)
 	
-	err := handler.HandleMessage(invalidJSON)
+	err := handler.HandleMessage(context.Background(), invalidJSON)
 	
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid character")
@@ -30,7 +31,7 @@ func TestMessageHandler_HandleMessage_MalformedJSON(t *testing.T) {
 	
 	malformedJSON := []byte(`{"video_id": "test", "filename":}`)
 	
-	err := handler.HandleMessage(malformedJSON)
+	err := handler.HandleMessage(context.Background(), malformedJSON)
 	
 	assert.Error(t, err)
 }
@@ -1,6 +1,7 @@
 package adapters_test
 
 import (
+	"context"
 	"audioremoval/internal/adapters"
 	"testing"
 
@@ -28,7 +29,7 @@ func TestRabbitMQConsumer_StartConsuming_InvalidURL(t *testing.T) {
 func TestRabbitMQPublisher_PublishMessage_InvalidURL(t *testing.T) {
 	publisher := adapters.NewRabbitMQPublisher("invalid-url")
 	
-	err := publisher.PublishMessage("test-queue", "test message")
+	err := publisher.PublishMessage(context.Background(), "test-queue", "test message")
 	
 	assert.Error(t, err)
 }
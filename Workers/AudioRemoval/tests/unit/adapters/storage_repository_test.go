@@ -1,6 +1,7 @@
 package adapters_test
 
 import (
+	"context"
 	"audioremoval/internal/adapters"
 	"testing"
 
@@ -15,7 +16,7 @@ func TestNewStorageRepository(t *testing.T) {
 func TestStorageRepository_Download(t *testing.T) {
 	repo := adapters.NewStorageRepository(nil)
 	
-	data, err := repo.Download("test-bucket", "test.mp4")
+	data, err := repo.Download(context.Background(), "test-bucket", "test.mp4")
 	
 	assert.NoError(t, err)
 	assert.NotNil(t, data)
@@ -25,7 +26,7 @@ func TestStorageRepository_Upload(t *testing.T) {
 	repo := adapters.NewStorageRepository(nil)
 	testData := []byte("test video data")
 	
-	err := repo.Upload("test-bucket", "test.mp4", testData)
+	err := repo.Upload(context.Background(), "test-bucket", "test.mp4", testData)
 	
 	assert.NoError(t, err)
 }
@@ -33,7 +34,7 @@ func TestStorageRepository_Upload(t *testing.T) {
 func TestStorageRepository_DownloadEmptyBucket(t *testing.T) {
 	repo := adapters.NewStorageRepository(nil)
 	
-	data, err := repo.Download("", "test.mp4")
+	data, err := repo.Download(context.Background(), "", "test.mp4")
 	
 	assert.NoError(t, err)
 	assert.NotNil(t, data)
@@ -43,7 +44,7 @@ func TestStorageRepository_UploadEmptyFilename(t *testing.T) {
 	repo := adapters.NewStorageRepository(nil)
 	testData := []byte("test video data")
 	
-	err := repo.Upload("test-bucket", "", testData)
+	err := repo.Upload(context.Background(), "test-bucket", "", testData)
 	
 	assert.NoError(t, err)
 }
@@ -51,7 +52,7 @@ func TestStorageRepository_UploadEmptyFilename(t *testing.T) {
 func TestStorageRepository_UploadNilData(t *testing.T) {
 	repo := adapters.NewStorageRepository(nil)
 	
-	err := repo.Upload("test-bucket", "test.mp4", nil)
+	err := repo.Upload(context.Background(), "test-bucket", "test.mp4", nil)
 	
 	assert.NoError(t, err)
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"context"
 	"audioremoval/internal/application/services"
 	"errors"
 	"testing"
@@ -20,7 +21,7 @@ type PublishedMessage struct {
 	Message interface{}
 }
 
-func (m *MockMessagePublisher) PublishMessage(queue string, message interface{}) error {
+func (m *MockMessagePublisher) PublishMessage(ctx context.Context, queue string, message interface{}) error {
 	m.PublishedMessages = append(m.PublishedMessages, PublishedMessage{
 		Queue:   queue,
 		Message: message,
@@ -50,7 +51,7 @@ func TestNotificationService_NotifyVideoProcessed_Success(t *testing.T) {
 	service := services.NewNotificationService(publisher, "state-queue")
 
 	// Act
-	err := service.NotifyVideoProcessed("video-123", "test.mp4", "processed-bucket/test.mp4")
+	err := service.NotifyVideoProcessed(context.Background(), "video-123", "test.mp4", "processed-bucket/test.mp4")
 
 	// Assert
 	require.NoError(t, err)
@@ -73,7 +74,7 @@ func TestNotificationService_NotifyVideoProcessed_PublishFails(t *testing.T) {
 	service := services.NewNotificationService(publisher, "state-queue")
 
 	// Act
-	err := service.NotifyVideoProcessed("video-123", "test.mp4", "processed-bucket/test.mp4")
+	err := service.NotifyVideoProcessed(context.Background(), "video-123", "test.mp4", "processed-bucket/test.mp4")
 
 	// Assert
 	require.Error(t, err)
@@ -86,7 +87,7 @@ func TestNotificationService_NotifyProcessingComplete(t *testing.T) {
 	service := services.NewNotificationService(publisher, "state-queue")
 
 	// Act
-	err := service.NotifyProcessingComplete("video-123", true)
+	err := service.NotifyProcessingComplete(context.Background(), "video-123", true)
 
 	// Assert
 	require.NoError(t, err)
@@ -1,6 +1,7 @@
 package usecases_test
 
 import (
+	"context"
 	"audioremoval/internal/application/usecases"
 	"audioremoval/internal/domain"
 	"audioremoval/tests/mocks"
@@ -40,7 +41,7 @@ func TestProcessVideoUseCase_Execute_Success(t *testing.T) {
 	)
 
 	// Act
-	err := useCase.Execute("video-123", "test.mp4")
+	err := useCase.Execute(context.Background(), "video-123", "test.mp4")
 
 	// Assert
 	require.NoError(t, err)
@@ -69,7 +70,7 @@ func TestProcessVideoUseCase_Execute_VideoNotFound(t *testing.T) {
 	)
 
 	// Act
-	err := useCase.Execute("video-123", "nonexistent.mp4")
+	err := useCase.Execute(context.Background(), "video-123", "nonexistent.mp4")
 
 	// Assert
 	require.Error(t, err)
@@ -104,7 +105,7 @@ func TestProcessVideoUseCase_Execute_DownloadFails(t *testing.T) {
 	)
 
 	// Act
-	err := useCase.Execute("video-123", "test.mp4")
+	err := useCase.Execute(context.Background(), "video-123", "test.mp4")
 
 	// Assert
 	require.Error(t, err)
@@ -141,7 +142,7 @@ func TestProcessVideoUseCase_Execute_ProcessingFails(t *testing.T) {
 	)
 
 	// Act
-	err := useCase.Execute("video-123", "test.mp4")
+	err := useCase.Execute(context.Background(), "video-123", "test.mp4")
 
 	// Assert
 	require.Error(t, err)
@@ -180,7 +181,7 @@ func TestProcessVideoUseCase_Execute_UploadFails(t *testing.T) {
 	)
 
 	// Act
-	err := useCase.Execute("video-123", "test.mp4")
+	err := useCase.Execute(context.Background(), "video-123", "test.mp4")
 
 	// Assert
 	require.Error(t, err)
@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"context"
 	"audioremoval/internal/application/services"
 	"os"
 	"os/exec"
@@ -22,7 +23,7 @@ func TestMP4VideoProcessingService_RemoveAudio_LargeFile(t *testing.T) {
 		largeData[i] = byte(i % 256)
 	}
 
-	_, err := service.RemoveAudio(largeData)
+	_, err := service.RemoveAudio(context.Background(), largeData)
 
 	// Should fail because it's not valid MP4, but tests large data handling
 	assert.Error(t, err)
@@ -38,7 +39,7 @@ func TestMP4VideoProcessingService_RemoveAudio_TempDirPermissions(t *testing.T)
 	// Test with minimal data
 	minimalData := []byte("test")
 
-	_, err := service.RemoveAudio(minimalData)
+	_, err := service.RemoveAudio(context.Background(), minimalData)
 
 	// Should fail because it's not valid MP4
 	assert.Error(t, err)
@@ -57,7 +58,7 @@ func TestMP4VideoProcessingService_RemoveAudio_ConcurrentCalls(t *testing.T) {
 	done := make(chan bool, 3)
 	for i := 0; i < 3; i++ {
 		go func() {
-			_, err := service.RemoveAudio(testData)
+			_, err := service.RemoveAudio(context.Background(), testData)
 			// All should fail with invalid MP4, but shouldn't crash
 			assert.Error(t, err)
 			done <- true
@@ -80,7 +81,7 @@ func TestMP4VideoProcessingService_RemoveAudio_SpecialCharacters(t *testing.T) {
 	// Test with data containing special characters
 	specialData := []byte("test data with special chars: áéíóú ñ @#$%^&*()")
 
-	_, err := service.RemoveAudio(specialData)
+	_, err := service.RemoveAudio(context.Background(), specialData)
 
 	// Should fail because it's not valid MP4
 	assert.Error(t, err)
@@ -96,7 +97,7 @@ func TestMP4VideoProcessingService_RemoveAudio_BinaryData(t *testing.T) {
 	// Test with random binary data
 	binaryData := []byte{0x00, 0x01, 0x02, 0x03, 0xFF, 0xFE, 0xFD, 0xFC}
 
-	_, err := service.RemoveAudio(binaryData)
+	_, err := service.RemoveAudio(context.Background(), binaryData)
 
 	// Should fail because it's not valid MP4
 	assert.Error(t, err)
@@ -113,7 +114,7 @@ func TestMP4VideoProcessingService_RemoveAudio_SystemLimits(t *testing.T) {
 	// Create 10MB of data
 	largeData := make([]byte, 10*1024*1024)
 	
-	_, err := service.RemoveAudio(largeData)
+	_, err := service.RemoveAudio(context.Background(), largeData)
 
 	// Should handle large data gracefully
 	assert.Error(t, err) // Will fail due to invalid MP4 format
@@ -133,7 +134,7 @@ func TestMP4VideoProcessingService_RemoveAudio_TempDirCleanup(t *testing.T) {
 	initialCount := len(initialFiles)
 
 	// Process video (will fail but should cleanup)
-	_, err := service.RemoveAudio(testData)
+	_, err := service.RemoveAudio(context.Background(), testData)
 	assert.Error(t, err)
 
 	// Check that temp directories are cleaned up
@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"context"
 	"audioremoval/internal/application/services"
 	"os"
 	"os/exec"
@@ -23,7 +24,7 @@ func TestMP4VideoProcessingService_RemoveAudio_FFmpegNotFound(t *testing.T) {
 	service := services.NewMP4VideoProcessingService()
 	inputData := []byte("fake video data")
 	
-	_, err := service.RemoveAudio(inputData)
+	_, err := service.RemoveAudio(context.Background(), inputData)
 	
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "ffmpeg not found")
@@ -38,7 +39,7 @@ func TestMP4VideoProcessingService_RemoveAudio_EmptyInput(t *testing.T) {
 	service := services.NewMP4VideoProcessingService()
 	inputData := []byte{}
 	
-	_, err := service.RemoveAudio(inputData)
+	_, err := service.RemoveAudio(context.Background(), inputData)
 	
 	// Should fail because empty data is not a valid MP4
 	assert.Error(t, err)
@@ -53,7 +54,7 @@ func TestMP4VideoProcessingService_RemoveAudio_InvalidMP4(t *testing.T) {
 	service := services.NewMP4VideoProcessingService()
 	inputData := []byte("not a valid mp4 file")
 	
-	_, err := service.RemoveAudio(inputData)
+	_, err := service.RemoveAudio(context.Background(), inputData)
 	
 	// Should fail because data is not a valid MP4
 	assert.Error(t, err)
@@ -86,7 +87,7 @@ func TestMP4VideoProcessingService_RemoveAudio_ValidMP4(t *testing.T) {
 	minimalMP4 := createMinimalMP4()
 	
 	// This will likely fail with the minimal MP4, but tests the flow
-	_, err := service.RemoveAudio(minimalMP4)
+	_, err := service.RemoveAudio(context.Background(), minimalMP4)
 	
 	// We expect an error because our minimal MP4 is not actually valid
 	// In a real test environment, you'd use actual MP4 test files
@@ -109,7 +110,7 @@ func createMinimalMP4() []byte {
 func TestMP4VideoProcessingService_RemoveAudio_NilInput(t *testing.T) {
 	service := services.NewMP4VideoProcessingService()
 	
-	_, err := service.RemoveAudio(nil)
+	_, err := service.RemoveAudio(context.Background(), nil)
 	
 	assert.Error(t, err)
 }
\ No newline at end of file
@@ -1,12 +1,16 @@
 package adapters
 
 import (
-    "github.com/sirupsen/logrus"
+    "log/slog"
     "github.com/streadway/amqp"
+    "shared/shutdown"
     "editvideo/internal/ports"
 )
 
+// RabbitMQConsumer declara la cola; el loop de entregas y el apagado (Shutdown, Ready, Done)
+// los aporta shutdown.Consumer.
 type RabbitMQConsumer struct {
+	*shutdown.Consumer
 	conn           *amqp.Connection
 	channel        *amqp.Channel
 	maxRetries     int
@@ -18,7 +22,8 @@ func NewRabbitMQConsumer(url string, maxRetries, queueMaxLength int) (*RabbitMQC
 	if err != nil { return nil, err }
 	ch, err := conn.Channel()
 	if err != nil { return nil, err }
-	return &RabbitMQConsumer{conn: conn, channel: ch, maxRetries: maxRetries, queueMaxLength: queueMaxLength}, nil
+	return &RabbitMQConsumer{Consumer: shutdown.NewConsumer(conn, ch), conn: conn, channel: ch,
+		maxRetries: maxRetries, queueMaxLength: queueMaxLength}, nil
 }
 
 func (r *RabbitMQConsumer) StartConsuming(queueName string, handler ports.MessageHandler) error {
@@ -27,19 +32,11 @@ func (r *RabbitMQConsumer) StartConsuming(queueName string, handler ports.Messag
 	_, err := r.channel.QueueDeclare(queueName, true, false, false, false, args)
 	if err != nil { return err }
 
-	deliveries, err := r.channel.Consume(queueName, "", false, false, false, false, nil)
-	if err != nil { return err }
+	if err := r.Start(queueName, handler.HandleMessage); err != nil { return err }
 
-	logrus.Infof("Consumiendo cola %s (maxlen=%d)", queueName, r.queueMaxLength)
+	slog.Info("Consumiendo cola", "queue", queueName, "max_length", r.queueMaxLength)
 
-	for msg := range deliveries {
-		if err := handler.HandleMessage(msg.Body); err != nil {
-			logrus.Errorf("handler error: %v", err)
-			_ = msg.Nack(false, false)
-			continue
-		}
-		_ = msg.Ack(false)
-	}
+	<-r.Done()
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"context"
 	"editvideo/internal/ports"
 	"bytes"
 	"io"
@@ -14,12 +15,12 @@ func NewStorageRepository(storage ports.StorageService) *StorageRepository {
 	return &StorageRepository{storage: storage}
 }
 
-func (r *StorageRepository) Download(bucket, filename string) ([]byte, error) {
-	reader, err := r.storage.GetObject(bucket, filename)
+func (r *StorageRepository) Download(ctx context.Context, bucket, filename string) ([]byte, error) {
+	reader, err := r.storage.GetObject(ctx, bucket, filename)
 	if err != nil { return nil, err }
 	return io.ReadAll(reader)
 }
 
-func (r *StorageRepository) Upload(bucket, filename string, data []byte) error {
-	return r.storage.PutObject(bucket, filename, bytes.NewReader(data), int64(len(data)))
+func (r *StorageRepository) Upload(ctx context.Context, bucket, filename string, data []byte) error {
+	return r.storage.PutObject(ctx, bucket, filename, bytes.NewReader(data), int64(len(data)))
 }
@@ -1,10 +1,15 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
+
+	"shared/metrics"
+	"shared/tracing"
 )
 
 // MP4VideoProcessingService cumple con domain.VideoProcessingService
@@ -17,7 +22,7 @@ func NewMP4VideoProcessingService() *MP4VideoProcessingService { return &MP4Vide
 // TrimToMaxSeconds recibe el video como []byte y devuelve []byte.
 // Mantenemos la firma para cumplir la interfaz, pero ignoramos el "trim" y
 // normalizamos a 1280x720 (16:9) sin distorsión.
-func (s *MP4VideoProcessingService) TrimToMaxSeconds(input []byte, maxSeconds int) ([]byte, error) {
+func (s *MP4VideoProcessingService) TrimToMaxSeconds(ctx context.Context, input []byte, maxSeconds int) ([]byte, error) {
 	if _, err := exec.LookPath("ffmpeg"); err != nil {
 		return nil, fmt.Errorf("ffmpeg not found: %w", err)
 	}
@@ -44,8 +49,13 @@ func (s *MP4VideoProcessingService) TrimToMaxSeconds(input []byte, maxSeconds in
 		outputPath,
 	}
 
-	cmd := exec.Command("ffmpeg", args...)
-	if err := cmd.Run(); err != nil {
+	_, span := tracing.Start(ctx, "ffmpeg normalize")
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	start := time.Now()
+	err = cmd.Run()
+	metrics.ObserveFFmpeg("normalize", start, err)
+	tracing.End(span, err)
+	if err != nil {
 		return nil, fmt.Errorf("ffmpeg failed: %w", err)
 	}
 
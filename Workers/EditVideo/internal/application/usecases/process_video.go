@@ -1,6 +1,7 @@
 package usecases
 
 import (
+    "context"
     "fmt"
     "editvideo/internal/domain"
 )
@@ -34,24 +35,24 @@ func NewEditVideoUseCase(
 	}
 }
 
-func (uc *EditVideoUseCase) Execute(videoID, filename string) error {
+func (uc *EditVideoUseCase) Execute(ctx context.Context, videoID, filename string) error {
 	video, err := uc.videoRepo.FindByFilename(filename)
 	if err != nil { return fmt.Errorf("find video: %w", err) }
 	if err := uc.videoRepo.UpdateStatus(video.ID, domain.StatusProcessing); err != nil { return err }
 
-	data, err := uc.storageRepo.Download(uc.rawBucket, filename)
+	data, err := uc.storageRepo.Download(ctx, uc.rawBucket, filename)
 	if err != nil {
 		_ = uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("download: %w", err)
 	}
 
-	processedData, err := uc.processingService.TrimToMaxSeconds(data, uc.maxSeconds)
+	processedData, err := uc.processingService.TrimToMaxSeconds(ctx, data, uc.maxSeconds)
 	if err != nil {
 		_ = uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("processing: %w", err)
 	}
 
-	if err := uc.storageRepo.Upload(uc.processedBucket, filename, processedData); err != nil {
+	if err := uc.storageRepo.Upload(ctx, uc.processedBucket, filename, processedData); err != nil {
 		_ = uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("upload: %w", err)
 	}
@@ -61,7 +62,7 @@ func (uc *EditVideoUseCase) Execute(videoID, filename string) error {
 	}
 
 	bucketPath := fmt.Sprintf("%s/%s", uc.processedBucket, filename)
-	if err := uc.notificationService.NotifyVideoProcessed(videoID, filename, bucketPath); err != nil {
+	if err := uc.notificationService.NotifyVideoProcessed(ctx, videoID, filename, bucketPath); err != nil {
 		return fmt.Errorf("notify state machine: %w", err)
 	}
 
@@ -13,6 +13,7 @@ type Container struct {
 	Consumer       *adapters.RabbitMQConsumer
 	Publisher      *adapters.RabbitMQPublisher
 	MessageHandler *adapters.MessageHandler
+	Storage        *sharedstorage.Client
 }
 
 func NewContainer(config *Config) (*Container, error) {
@@ -53,5 +54,5 @@ func NewContainer(config *Config) (*Container, error) {
 	}
 	handler := adapters.NewMessageHandler(uc)
 
-	return &Container{Config: config, Consumer: consumer, Publisher: publisher, MessageHandler: handler}, nil
+	return &Container{Config: config, Consumer: consumer, Publisher: publisher, MessageHandler: handler, Storage: storageClient}, nil
 }
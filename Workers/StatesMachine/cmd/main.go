@@ -1,29 +1,60 @@
 package main
 
 import (
-	"os"
-	"os/signal"
-	"syscall"
-	"github.com/sirupsen/logrus"
+	"context"
+	"log/slog"
+	"shared/health"
+	"shared/logging"
+	"shared/metrics"
+	"shared/shutdown"
+	"shared/tracing"
 	"statesmachine/internal/infrastructure"
 )
 
 func main() {
 
+	logging.Setup("statesmachine")
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "statesmachine")
+	if err != nil { logging.Fatal("tracing setup", "err", err) }
+	defer func() { _ = shutdownTracing(context.Background()) }()
+
 	config := infrastructure.LoadConfig()
 	container, err := infrastructure.NewContainer(config)
-	if err != nil { logrus.Fatal("bootstrap error:", err) }
+	if err != nil { logging.Fatal("bootstrap error", "err", err) }
 	defer container.Consumer.Close()
+	defer container.Publisher.Close()
+	if sqlDB, err := container.DB.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	if srv := metrics.Serve(); srv != nil {
+		defer srv.Close()
+		slog.Info("Metrics available", "addr", srv.Addr, "path", "/metrics")
+	}
+
+	if srv := health.Serve(container.HealthChecks()...); srv != nil {
+		defer srv.Close()
+		slog.Info("Health probes available", "addr", srv.Addr, "paths", "/livez,/readyz")
+	}
 
 	if err := container.Consumer.StartConsuming(config.QueueName, container.MessageHandler); err != nil {
-		logrus.Fatal("start consuming:", err)
+		logging.Fatal("start consuming", "err", err)
 	}
 
-	logrus.Info("StatesMachine worker started. Waiting for messages...")
+	slog.Info("StatesMachine worker started. Waiting for messages...")
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	select {
+	case <-shutdown.Signals():
+		slog.Info("Shutting down StatesMachine worker...")
+	case <-container.Consumer.Done():
+		slog.Error("RabbitMQ deliveries closed, shutting down StatesMachine worker")
+	}
 
-	logrus.Info("Shutting down StatesMachine worker...")
-}
\ No newline at end of file
+	// Termina el mensaje en curso (o lo devuelve a la cola) antes de cerrar las conexiones
+	ctx, cancel := context.WithTimeout(context.Background(), shutdown.Timeout())
+	defer cancel()
+	if err := container.Consumer.Shutdown(ctx); err != nil {
+		slog.Warn("Shutdown deadline exceeded, current job requeued", "err", err)
+	}
+}
@@ -1,11 +1,13 @@
 package adapters
 
 import (
+	"context"
 	"statesmachine/internal/domain"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/sirupsen/logrus"
+	"log/slog"
+	"shared/logging"
 	"strings"
 	"shared/security"
 	"time"
@@ -45,6 +47,8 @@ type VideoMessage struct {
 	RetryCount  int    `json:"retry_count,omitempty"`
 	MaxRetries  int    `json:"max_retries,omitempty"`
 	LastRetry   int64  `json:"last_retry,omitempty"`
+	// CorrelationID llega desde el API (X-Request-ID) y se reenvia a cada worker.
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 type VideoProcessedMessage struct {
@@ -55,6 +59,7 @@ type VideoProcessedMessage struct {
 	RetryCount  int    `json:"retry_count,omitempty"`
 	MaxRetries  int    `json:"max_retries,omitempty"`
 	LastRetry   int64  `json:"last_retry,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 type MessageHandler struct {
@@ -65,22 +70,23 @@ func NewMessageHandler(uc domain.OrchestrateVideoUseCaseInterface) *MessageHandl
 	return &MessageHandler{orchestrateUC: uc}
 }
 
-func (h *MessageHandler) HandleMessage(body []byte) error {
+func (h *MessageHandler) HandleMessage(ctx context.Context, body []byte) error {
 	var processedMsg VideoProcessedMessage
 	if err := json.Unmarshal(body, &processedMsg); err == nil && processedMsg.VideoID != "" {
-		logrus.Infof("StatesMachine received processed video: %s from %s", security.SanitizeLogInput(processedMsg.Filename), security.SanitizeLogInput(processedMsg.BucketPath))
+		ctx = logging.WithCorrelationID(logging.WithVideoID(ctx, security.SanitizeLogInput(processedMsg.VideoID)), security.SanitizeLogInput(processedMsg.CorrelationID))
+		slog.InfoContext(ctx, "StatesMachine received processed video", "filename", security.SanitizeLogInput(processedMsg.Filename), "bucket_path", security.SanitizeLogInput(processedMsg.BucketPath))
 		
 		var handlerErr error
 		if contains(processedMsg.BucketPath, "trim") {
-			handlerErr = h.orchestrateUC.HandleTrimCompleted(processedMsg.VideoID, processedMsg.Filename)
+			handlerErr = h.orchestrateUC.HandleTrimCompleted(ctx, processedMsg.VideoID, processedMsg.Filename)
 		} else if contains(processedMsg.BucketPath, "edit") {
-			handlerErr = h.orchestrateUC.HandleEditCompleted(processedMsg.VideoID, processedMsg.Filename)
+			handlerErr = h.orchestrateUC.HandleEditCompleted(ctx, processedMsg.VideoID, processedMsg.Filename)
 		} else if contains(processedMsg.BucketPath, "audio-removal") {
-			handlerErr = h.orchestrateUC.HandleAudioRemovalCompleted(processedMsg.VideoID, processedMsg.Filename)
+			handlerErr = h.orchestrateUC.HandleAudioRemovalCompleted(ctx, processedMsg.VideoID, processedMsg.Filename)
 		} else if contains(processedMsg.BucketPath, "watermarking") {
-			handlerErr = h.orchestrateUC.HandleWatermarkingCompleted(processedMsg.VideoID, processedMsg.Filename)
+			handlerErr = h.orchestrateUC.HandleWatermarkingCompleted(ctx, processedMsg.VideoID, processedMsg.Filename)
 		} else if contains(processedMsg.BucketPath, "processed-videos") {
-			handlerErr = h.orchestrateUC.HandleGossipOpenCloseCompleted(processedMsg.VideoID, processedMsg.Filename)
+			handlerErr = h.orchestrateUC.HandleGossipOpenCloseCompleted(ctx, processedMsg.VideoID, processedMsg.Filename)
 		}
 		
 		if handlerErr != nil && strings.Contains(handlerErr.Error(), "invalid video ID format") {
@@ -94,20 +100,18 @@ func (h *MessageHandler) HandleMessage(body []byte) error {
 
 	var msg VideoMessage
 	if err := json.Unmarshal(body, &msg); err != nil {
-		logrus.Errorf("Failed to unmarshal message: %v", err)
+		slog.ErrorContext(ctx, "Failed to unmarshal message", "err", err)
 		return &NonRetryableError{
 			OriginalError: err,
 			Message:       "Invalid message format",
 		}
 	}
 
+	ctx = logging.WithCorrelationID(logging.WithVideoID(ctx, security.SanitizeLogInput(msg.VideoID)), security.SanitizeLogInput(msg.CorrelationID))
+
 	// Check retry limits and delay
 	if msg.MaxRetries > 0 && msg.RetryCount >= msg.MaxRetries {
-		logrus.WithFields(logrus.Fields{
-			"video_id": msg.VideoID,
-			"retry_count": msg.RetryCount,
-			"max_retries": msg.MaxRetries,
-		}).Warn("StatesMachine: Max retries exceeded, discarding message")
+		slog.WarnContext(ctx, "StatesMachine: Max retries exceeded, discarding message", "retry_count", msg.RetryCount, "max_retries", msg.MaxRetries)
 		return &NonRetryableError{
 			OriginalError: fmt.Errorf("max retries exceeded"),
 			Message:       "Max retries exceeded",
@@ -119,17 +123,13 @@ func (h *MessageHandler) HandleMessage(body []byte) error {
 		timeSinceLastRetryMinutes := (time.Now().Unix() - msg.LastRetry) / 60
 		requiredDelayMinutes := int64(h.orchestrateUC.GetRetryDelayMinutes())
 		if timeSinceLastRetryMinutes < requiredDelayMinutes {
-			logrus.WithFields(logrus.Fields{
-				"video_id": msg.VideoID,
-				"time_since_last_retry_minutes": timeSinceLastRetryMinutes,
-				"required_delay_minutes": requiredDelayMinutes,
-			}).Info("StatesMachine: Retry delay not met, requeuing message")
+			slog.InfoContext(ctx, "StatesMachine: Retry delay not met, requeuing message", "time_since_last_retry_minutes", timeSinceLastRetryMinutes, "required_delay_minutes", requiredDelayMinutes)
 			return fmt.Errorf("retry delay not met, requeue message")
 		}
 	}
 
-	logrus.Infof("StatesMachine received videoId: '%s'", security.SanitizeLogInput(msg.VideoID))
-	execErr := h.orchestrateUC.Execute(msg.VideoID)
+	slog.InfoContext(ctx, "StatesMachine received video")
+	execErr := h.orchestrateUC.Execute(ctx, msg.VideoID)
 	if execErr != nil && strings.Contains(execErr.Error(), "invalid video ID format") {
 		return &NonRetryableError{
 			OriginalError: execErr,
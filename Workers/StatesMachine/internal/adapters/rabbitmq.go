@@ -1,16 +1,24 @@
 package adapters
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/streadway/amqp"
-	"github.com/sirupsen/logrus"
+	"log/slog"
+	"shared/metrics"
+	"shared/shutdown"
+	"shared/tracing"
 	"time"
 )
 
+// RabbitMQConsumer declara la cola; el loop de entregas y el apagado (Shutdown, Ready, Done)
+// los aporta shutdown.Consumer, y los fallos se reintentan con retryOrDiscard.
 type RabbitMQConsumer struct {
+	*shutdown.Consumer
 	conn    *amqp.Connection
 	channel *amqp.Channel
+	queue   string
 }
 
 type RabbitMQPublisher struct {
@@ -26,7 +34,9 @@ func NewRabbitMQConsumer(url string) (*RabbitMQConsumer, error) {
 	ch, err := conn.Channel()
 	if err != nil { return nil, err }
 
-	return &RabbitMQConsumer{conn: conn, channel: ch}, nil
+	r := &RabbitMQConsumer{conn: conn, channel: ch}
+	r.Consumer = shutdown.NewConsumer(conn, ch).WithFailureHandler(r.retryOrDiscard)
+	return r, nil
 }
 
 func NewRabbitMQPublisher(url string) (*RabbitMQPublisher, error) {
@@ -48,43 +58,47 @@ func (r *RabbitMQConsumer) StartConsuming(queueName string, handler MessageHandl
 	})
 	if err != nil { return err }
 
-	msgs, err := r.channel.Consume(q.Name, "", false, false, false, false, nil)
-	if err != nil { return err }
+	r.queue = q.Name
+	if err := r.Start(q.Name, handler.HandleMessage); err != nil { return err }
 
-	logrus.Infof("Consumiendo cola %s (maxlen=1000)", queueName)
-
-	go func() {
-		for d := range msgs {
-			if err := handler.HandleMessage(d.Body); err != nil {
-				logrus.Errorf("Error processing message: %v", err)
-				// Check if it's a non-retryable error
-				if IsNonRetryableError(err) {
-					logrus.Warnf("Non-retryable error, discarding message: %v", err)
-					d.Ack(false) // Acknowledge to remove from queue
-				} else {
-					// Increment retry count and update timestamp
-					if updatedBody := r.incrementRetryCount(d.Body); updatedBody != nil {
-						// Republish with updated retry info
-						if pubErr := r.republishWithDelay(queueName, updatedBody); pubErr != nil {
-							logrus.Errorf("Failed to republish message: %v", pubErr)
-						}
-					}
-					d.Ack(false) // Acknowledge original message
-				}
-			} else {
-				d.Ack(false)
-			}
-		}
-	}()
+	slog.Info("Consumiendo cola", "queue", queueName, "max_length", 1000)
 
 	return nil
 }
 
-func (r *RabbitMQPublisher) PublishMessage(queueName string, message []byte) error {
+// retryOrDiscard resuelve un mensaje fallido: los errores no reintentables se descartan y
+// el resto se republica con el contador de reintentos incrementado.
+func (r *RabbitMQConsumer) retryOrDiscard(ctx context.Context, d amqp.Delivery, err error) {
+	// Check if it's a non-retryable error
+	if IsNonRetryableError(err) {
+		slog.WarnContext(ctx, "Non-retryable error, discarding message", "err", err)
+		d.Ack(false) // Acknowledge to remove from queue
+		return
+	}
+	// Increment retry count and update timestamp
+	if updatedBody := r.incrementRetryCount(d.Body); updatedBody != nil {
+		// Republish with updated retry info
+		metrics.Retry("message")
+		if pubErr := r.republishWithDelay(ctx, r.queue, updatedBody); pubErr != nil {
+			slog.ErrorContext(ctx, "Failed to republish message", "err", pubErr)
+		}
+	}
+	d.Ack(false) // Acknowledge original message
+}
+
+// PublishMessage publica message en queueName (hasta 3 intentos) propagando en los headers
+// el contexto de traza de ctx.
+func (r *RabbitMQPublisher) PublishMessage(ctx context.Context, queueName string, message []byte) (err error) {
+	ctx, span := tracing.StartPublish(ctx, queueName)
+	defer func() { tracing.End(span, err) }()
+	headers := tracing.InjectHeaders(ctx, nil)
 	for attempts := 0; attempts < 3; attempts++ {
+		if attempts > 0 {
+			metrics.Retry("publish")
+		}
 		if r.channel == nil {
 			if err := r.reconnect(); err != nil {
-				logrus.Errorf("Reconnect attempt %d failed: %v", attempts+1, err)
+				slog.ErrorContext(ctx, "Reconnect attempt failed", "attempt", attempts+1, "err", err)
 				continue
 			}
 		}
@@ -94,19 +108,20 @@ func (r *RabbitMQPublisher) PublishMessage(queueName string, message []byte) err
 			"x-max-length": 1000,
 		}
 
-		_, err := r.channel.QueueDeclare(queueName, true, false, false, false, args)
+		_, err = r.channel.QueueDeclare(queueName, true, false, false, false, args)
 		if err != nil {
-			logrus.Errorf("Queue declare failed: %v", err)
+			slog.ErrorContext(ctx, "Queue declare failed", "err", err)
 			r.channel = nil
 			continue
 		}
 
 		err = r.channel.Publish("", queueName, false, false, amqp.Publishing{
+			Headers:     headers,
 			ContentType: "application/json",
 			Body:        message,
 		})
 		if err != nil {
-			logrus.Errorf("Publish failed: %v", err)
+			slog.ErrorContext(ctx, "Publish failed", "err", err)
 			r.channel = nil
 			continue
 		}
@@ -115,6 +130,13 @@ func (r *RabbitMQPublisher) PublishMessage(queueName string, message []byte) err
 	return fmt.Errorf("failed to publish after 3 attempts")
 }
 
+// Close cierra el canal y la conexion del publisher.
+func (r *RabbitMQPublisher) Close() error {
+	if r.channel != nil { r.channel.Close() }
+	if r.conn != nil { return r.conn.Close() }
+	return nil
+}
+
 func (r *RabbitMQPublisher) reconnect() error {
 	if r.conn != nil {
 		r.conn.Close()
@@ -135,9 +157,6 @@ func (r *RabbitMQPublisher) reconnect() error {
 }
 
 
-
-
-
 func (r *RabbitMQConsumer) Close() error {
 	if r.channel != nil { r.channel.Close() }
 	if r.conn != nil { r.conn.Close() }
@@ -145,7 +164,7 @@ func (r *RabbitMQConsumer) Close() error {
 }
 
 type MessageHandlerInterface interface {
-	HandleMessage(body []byte) error
+	HandleMessage(ctx context.Context, body []byte) error
 }
 
 func (r *RabbitMQConsumer) incrementRetryCount(body []byte) []byte {
@@ -173,10 +192,12 @@ func (r *RabbitMQConsumer) incrementRetryCount(body []byte) []byte {
 	return updatedBody
 }
 
-func (r *RabbitMQConsumer) republishWithDelay(queueName string, message []byte) error {
-	// Simple republish - delay is handled by message handler
+func (r *RabbitMQConsumer) republishWithDelay(ctx context.Context, queueName string, message []byte) error {
+	// Simple republish - delay is handled by message handler.
+	// The retry stays in the same trace as the failed attempt.
 	return r.channel.Publish("", queueName, false, false, amqp.Publishing{
+		Headers:     tracing.InjectHeaders(ctx, nil),
 		ContentType: "application/json",
 		Body:        message,
 	})
-}
\ No newline at end of file
+}
@@ -1,11 +1,12 @@
 package usecases
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 	"statesmachine/internal/domain"
-	"github.com/sirupsen/logrus"
+	"log/slog"
+	"shared/logging"
 )
 
 type WorkerMessage struct {
@@ -14,6 +15,7 @@ type WorkerMessage struct {
 	RetryCount  int    `json:"retry_count,omitempty"`
 	MaxRetries  int    `json:"max_retries,omitempty"`
 	LastRetry   int64  `json:"last_retry,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 type OrchestrateVideoUseCase struct {
@@ -24,7 +26,6 @@ type OrchestrateVideoUseCase struct {
 	watermarkingQueue string
 	maxRetries        int
 	retryDelayMinutes int
-	processedVideoURL string
 }
 
 func NewOrchestrateVideoUseCase(
@@ -32,7 +33,6 @@ func NewOrchestrateVideoUseCase(
 	publisher domain.MessagePublisher,
 	editVideoQueue, audioRemovalQueue, watermarkingQueue string,
 	maxRetries, retryDelayMinutes int,
-	processedVideoURL string,
 ) *OrchestrateVideoUseCase {
 	return &OrchestrateVideoUseCase{
 		videoRepo:         videoRepo,
@@ -42,25 +42,16 @@ func NewOrchestrateVideoUseCase(
 		watermarkingQueue: watermarkingQueue,
 		maxRetries:        maxRetries,
 		retryDelayMinutes: retryDelayMinutes,
-		processedVideoURL: processedVideoURL,
 	}
 }
 
-func (uc *OrchestrateVideoUseCase) Execute(videoID string) error {
-	logrus.WithFields(logrus.Fields{
-		"video_id":  videoID,
-		"timestamp": time.Now().UTC(),
-		"stage":     "orchestration_start",
-	}).Info("StatesMachine: Starting video processing orchestration")
+func (uc *OrchestrateVideoUseCase) Execute(ctx context.Context, videoID string) error {
+	slog.InfoContext(ctx, "StatesMachine: Starting video processing orchestration", "stage", "orchestration_start")
 
 	// Convert string ID to uint
 	var id uint
 	if _, err := fmt.Sscanf(videoID, "%d", &id); err != nil {
-		logrus.WithFields(logrus.Fields{
-			"video_id": videoID,
-			"error":    err.Error(),
-			"stage":    "id_validation",
-		}).Error("StatesMachine: Invalid video ID format, skipping message")
+		slog.ErrorContext(ctx, "StatesMachine: Invalid video ID format, skipping message", "err", err, "stage", "id_validation")
 		return fmt.Errorf("invalid video ID format '%s': %w", videoID, err)
 	}
 
@@ -75,13 +66,14 @@ func (uc *OrchestrateVideoUseCase) Execute(videoID string) error {
 		RetryCount:  0,
 		MaxRetries:  uc.maxRetries,
 		LastRetry:   0,
+		CorrelationID: logging.CorrelationID(ctx),
 	}
 	messageBytes, err := json.Marshal(message)
 	if err != nil {
 		return fmt.Errorf("marshal message: %w", err)
 	}
 
-	if err := uc.publisher.PublishMessage("trim_video_queue", messageBytes); err != nil {
+	if err := uc.publisher.PublishMessage(ctx, "trim_video_queue", messageBytes); err != nil {
 		return fmt.Errorf("publish to trim_video_queue: %w", err)
 	}
 
@@ -89,225 +81,152 @@ func (uc *OrchestrateVideoUseCase) Execute(videoID string) error {
 		return fmt.Errorf("update status: %w", err)
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"video_id":    videoID,
-		"filename":    video.OriginalFile,
-		"next_queue":  "trim_video_queue",
-		"timestamp":   time.Now().UTC(),
-	}).Info("StatesMachine: Message published to TrimVideo queue")
+	slog.InfoContext(ctx, "StatesMachine: Message published to TrimVideo queue", "filename", video.OriginalFile, "next_queue", "trim_video_queue")
 
 	return nil
 }
 
-func (uc *OrchestrateVideoUseCase) HandleTrimCompleted(videoID, filename string) error {
-	logrus.WithFields(logrus.Fields{
-		"video_id":  videoID,
-		"filename":  filename,
-		"timestamp": time.Now().UTC(),
-		"stage":     "trim_completed",
-	}).Info("StatesMachine: TrimVideo completed, sending to EditVideo")
+func (uc *OrchestrateVideoUseCase) HandleTrimCompleted(ctx context.Context, videoID, filename string) error {
+	slog.InfoContext(ctx, "StatesMachine: TrimVideo completed, sending to EditVideo", "filename", filename, "stage", "trim_completed")
 
 	message := WorkerMessage{
-		VideoID:  videoID,
-		Filename: filename,
+		VideoID:       videoID,
+		Filename:      filename,
+		CorrelationID: logging.CorrelationID(ctx),
 	}
 	messageBytes, err := json.Marshal(message)
 	if err != nil {
 		return fmt.Errorf("marshal message: %w", err)
 	}
 
-	if err := uc.publisher.PublishMessage(uc.editVideoQueue, messageBytes); err != nil {
+	if err := uc.publisher.PublishMessage(ctx, uc.editVideoQueue, messageBytes); err != nil {
 		return fmt.Errorf("publish to edit_video_queue: %w", err)
 	}
 
 	// Update status to ADJUSTING_RESOLUTION
 	var id uint
 	if _, err := fmt.Sscanf(videoID, "%d", &id); err != nil {
-		logrus.WithFields(logrus.Fields{
-			"video_id": videoID,
-			"error":    err.Error(),
-			"stage":    "trim_completed_id_validation",
-		}).Error("StatesMachine: Invalid video ID format in trim completion")
+		slog.ErrorContext(ctx, "StatesMachine: Invalid video ID format in trim completion", "err", err, "stage", "trim_completed_id_validation")
 		return fmt.Errorf("invalid video ID format '%s': %w", videoID, err)
 	}
 	if err := uc.videoRepo.UpdateStatus(id, domain.StatusAdjustingRes); err != nil {
 		return fmt.Errorf("update status: %w", err)
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"video_id":   videoID,
-		"filename":   filename,
-		"next_queue": uc.editVideoQueue,
-		"timestamp":  time.Now().UTC(),
-	}).Info("StatesMachine: Message published to EditVideo queue")
+	slog.InfoContext(ctx, "StatesMachine: Message published to EditVideo queue", "filename", filename, "next_queue", uc.editVideoQueue)
 
 	return nil
 }
 
-func (uc *OrchestrateVideoUseCase) HandleEditCompleted(videoID, filename string) error {
-	logrus.WithFields(logrus.Fields{
-		"video_id":  videoID,
-		"filename":  filename,
-		"timestamp": time.Now().UTC(),
-		"stage":     "edit_completed",
-		"result":    "success",
-	}).Info("StatesMachine: EditVideo completed successfully, sending to AudioRemoval")
+func (uc *OrchestrateVideoUseCase) HandleEditCompleted(ctx context.Context, videoID, filename string) error {
+	slog.InfoContext(ctx, "StatesMachine: EditVideo completed successfully, sending to AudioRemoval", "filename", filename, "stage", "edit_completed", "result", "success")
 
 	message := WorkerMessage{
-		VideoID:  videoID,
-		Filename: filename,
+		VideoID:       videoID,
+		Filename:      filename,
+		CorrelationID: logging.CorrelationID(ctx),
 	}
 	messageBytes, err := json.Marshal(message)
 	if err != nil {
 		return fmt.Errorf("marshal message: %w", err)
 	}
 
-	if err := uc.publisher.PublishMessage(uc.audioRemovalQueue, messageBytes); err != nil {
+	if err := uc.publisher.PublishMessage(ctx, uc.audioRemovalQueue, messageBytes); err != nil {
 		return fmt.Errorf("publish to audio_removal_queue: %w", err)
 	}
 
 	// Update status to REMOVING_AUDIO
 	var id uint
 	if _, err := fmt.Sscanf(videoID, "%d", &id); err != nil {
-		logrus.WithFields(logrus.Fields{
-			"video_id": videoID,
-			"error":    err.Error(),
-			"stage":    "edit_completed_id_validation",
-		}).Error("StatesMachine: Invalid video ID format in edit completion")
+		slog.ErrorContext(ctx, "StatesMachine: Invalid video ID format in edit completion", "err", err, "stage", "edit_completed_id_validation")
 		return fmt.Errorf("invalid video ID format '%s': %w", videoID, err)
 	}
 	if err := uc.videoRepo.UpdateStatus(id, domain.StatusRemovingAudio); err != nil {
 		return fmt.Errorf("update status: %w", err)
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"video_id":   videoID,
-		"filename":   filename,
-		"next_queue": uc.audioRemovalQueue,
-		"timestamp":  time.Now().UTC(),
-	}).Info("StatesMachine: Message published to AudioRemoval queue")
+	slog.InfoContext(ctx, "StatesMachine: Message published to AudioRemoval queue", "filename", filename, "next_queue", uc.audioRemovalQueue)
 
 	return nil
 }
 
-func (uc *OrchestrateVideoUseCase) HandleAudioRemovalCompleted(videoID, filename string) error {
-	logrus.WithFields(logrus.Fields{
-		"video_id":  videoID,
-		"filename":  filename,
-		"timestamp": time.Now().UTC(),
-		"stage":     "audio_removal_completed",
-		"result":    "success",
-	}).Info("StatesMachine: AudioRemoval completed successfully, sending to Watermarking")
+func (uc *OrchestrateVideoUseCase) HandleAudioRemovalCompleted(ctx context.Context, videoID, filename string) error {
+	slog.InfoContext(ctx, "StatesMachine: AudioRemoval completed successfully, sending to Watermarking", "filename", filename, "stage", "audio_removal_completed", "result", "success")
 
 	message := WorkerMessage{
-		VideoID:  videoID,
-		Filename: filename,
+		VideoID:       videoID,
+		Filename:      filename,
+		CorrelationID: logging.CorrelationID(ctx),
 	}
 	messageBytes, err := json.Marshal(message)
 	if err != nil {
 		return fmt.Errorf("marshal message: %w", err)
 	}
 
-	if err := uc.publisher.PublishMessage(uc.watermarkingQueue, messageBytes); err != nil {
+	if err := uc.publisher.PublishMessage(ctx, uc.watermarkingQueue, messageBytes); err != nil {
 		return fmt.Errorf("publish to watermarking_queue: %w", err)
 	}
 
 	// Update status to ADDING_WATERMARK
 	var id uint
 	if _, err := fmt.Sscanf(videoID, "%d", &id); err != nil {
-		logrus.WithFields(logrus.Fields{
-			"video_id": videoID,
-			"error":    err.Error(),
-			"stage":    "audio_removal_completed_id_validation",
-		}).Error("StatesMachine: Invalid video ID format in audio removal completion")
+		slog.ErrorContext(ctx, "StatesMachine: Invalid video ID format in audio removal completion", "err", err, "stage", "audio_removal_completed_id_validation")
 		return fmt.Errorf("invalid video ID format '%s': %w", videoID, err)
 	}
 	if err := uc.videoRepo.UpdateStatus(id, domain.StatusAddingWatermark); err != nil {
 		return fmt.Errorf("update status: %w", err)
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"video_id":   videoID,
-		"filename":   filename,
-		"next_queue": uc.watermarkingQueue,
-		"timestamp":  time.Now().UTC(),
-	}).Info("StatesMachine: Message published to Watermarking queue")
+	slog.InfoContext(ctx, "StatesMachine: Message published to Watermarking queue", "filename", filename, "next_queue", uc.watermarkingQueue)
 
 	return nil
 }
 
-func (uc *OrchestrateVideoUseCase) HandleWatermarkingCompleted(videoID, filename string) error {
-	logrus.WithFields(logrus.Fields{
-		"video_id":  videoID,
-		"filename":  filename,
-		"timestamp": time.Now().UTC(),
-		"stage":     "watermarking_completed",
-		"result":    "success",
-	}).Info("StatesMachine: Watermarking completed successfully, sending to GossipOpenClose")
+func (uc *OrchestrateVideoUseCase) HandleWatermarkingCompleted(ctx context.Context, videoID, filename string) error {
+	slog.InfoContext(ctx, "StatesMachine: Watermarking completed successfully, sending to GossipOpenClose", "filename", filename, "stage", "watermarking_completed", "result", "success")
 
 	message := WorkerMessage{
-		VideoID:  videoID,
-		Filename: filename,
+		VideoID:       videoID,
+		Filename:      filename,
+		CorrelationID: logging.CorrelationID(ctx),
 	}
 	messageBytes, err := json.Marshal(message)
 	if err != nil {
 		return fmt.Errorf("marshal message: %w", err)
 	}
 
-	if err := uc.publisher.PublishMessage("gossip_open_close_queue", messageBytes); err != nil {
+	if err := uc.publisher.PublishMessage(ctx, "gossip_open_close_queue", messageBytes); err != nil {
 		return fmt.Errorf("publish to gossip_open_close_queue: %w", err)
 	}
 
 	// Update status to ADDING_INTRO_OUTRO
 	var id uint
 	if _, err := fmt.Sscanf(videoID, "%d", &id); err != nil {
-		logrus.WithFields(logrus.Fields{
-			"video_id": videoID,
-			"error":    err.Error(),
-			"stage":    "watermarking_completed_id_validation",
-		}).Error("StatesMachine: Invalid video ID format in watermarking completion")
+		slog.ErrorContext(ctx, "StatesMachine: Invalid video ID format in watermarking completion", "err", err, "stage", "watermarking_completed_id_validation")
 		return fmt.Errorf("invalid video ID format '%s': %w", videoID, err)
 	}
 	if err := uc.videoRepo.UpdateStatus(id, domain.StatusAddingIntroOutro); err != nil {
 		return fmt.Errorf("update status: %w", err)
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"video_id":   videoID,
-		"filename":   filename,
-		"next_queue": "gossip_open_close_queue",
-		"timestamp":  time.Now().UTC(),
-	}).Info("StatesMachine: Message published to GossipOpenClose queue")
+	slog.InfoContext(ctx, "StatesMachine: Message published to GossipOpenClose queue", "filename", filename, "next_queue", "gossip_open_close_queue")
 
 	return nil
 }
 
-func (uc *OrchestrateVideoUseCase) HandleGossipOpenCloseCompleted(videoID, filename string) error {
+func (uc *OrchestrateVideoUseCase) HandleGossipOpenCloseCompleted(ctx context.Context, videoID, filename string) error {
 	// Update status to PROCESSED and set processed_file
 	var id uint
 	if _, err := fmt.Sscanf(videoID, "%d", &id); err != nil {
-		logrus.WithFields(logrus.Fields{
-			"video_id": videoID,
-			"error":    err.Error(),
-			"stage":    "gossip_completed_id_validation",
-		}).Error("StatesMachine: Invalid video ID format in gossip completion")
+		slog.ErrorContext(ctx, "StatesMachine: Invalid video ID format in gossip completion", "err", err, "stage", "gossip_completed_id_validation")
 		return fmt.Errorf("invalid video ID format '%s': %w", videoID, err)
 	}
-	// Generate the full URL for the processed file
-	processedURL := fmt.Sprintf(uc.processedVideoURL, filename)
-	if err := uc.videoRepo.UpdateStatusAndProcessedFile(id, domain.StatusProcessed, processedURL); err != nil {
+	// Store only the object key; the API turns it into a short-lived signed URL at response time
+	if err := uc.videoRepo.UpdateStatusAndProcessedFile(id, domain.StatusProcessed, filename); err != nil {
 		return fmt.Errorf("update final status and processed file: %w", err)
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"video_id":      videoID,
-		"filename":      filename,
-		"processed_file": processedURL,
-		"timestamp":     time.Now().UTC(),
-		"stage":         "gossip_open_close_completed",
-		"result":        "success",
-		"pipeline":      "finished",
-	}).Info("StatesMachine: GossipOpenClose completed successfully, entire video processing pipeline finished")
+	slog.InfoContext(ctx, "StatesMachine: GossipOpenClose completed successfully, entire video processing pipeline finished", "filename", filename, "processed_file", filename, "stage", "gossip_open_close_completed", "result", "success", "pipeline", "finished")
 
 	return nil
 }
@@ -21,4 +21,9 @@ const (
 	StatusAddingIntroOutro VideoStatus = "ADDING_INTRO_OUTRO"
 	StatusProcessed        VideoStatus = "PROCESSED"
 	StatusFailed           VideoStatus = "FAILED"
+	// Estados posteriores al pipeline, gestionados por la API (moderacion).
+	// El orquestador no los asigna, pero deben coincidir con el enum video_status.
+	StatusPendingReview VideoStatus = "PENDING_REVIEW"
+	StatusPublished     VideoStatus = "PUBLISHED"
+	StatusRejected      VideoStatus = "REJECTED"
 )
\ No newline at end of file
@@ -1,5 +1,7 @@
 package domain
 
+import "context"
+
 type VideoRepository interface {
 	FindByID(id uint) (*Video, error)
 	UpdateStatus(id uint, status VideoStatus) error
@@ -7,5 +9,5 @@ type VideoRepository interface {
 }
 
 type MessagePublisher interface {
-	PublishMessage(queueName string, message []byte) error
+	PublishMessage(ctx context.Context, queueName string, message []byte) error
 }
\ No newline at end of file
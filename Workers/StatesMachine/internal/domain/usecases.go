@@ -1,12 +1,14 @@
 package domain
 
+import "context"
+
 // OrchestrateVideoUseCaseInterface defines the interface for video orchestration use case
 type OrchestrateVideoUseCaseInterface interface {
-	Execute(videoID string) error
-	HandleTrimCompleted(videoID, filename string) error
-	HandleEditCompleted(videoID, filename string) error
-	HandleAudioRemovalCompleted(videoID, filename string) error
-	HandleWatermarkingCompleted(videoID, filename string) error
-	HandleGossipOpenCloseCompleted(videoID, filename string) error
+	Execute(ctx context.Context, videoID string) error
+	HandleTrimCompleted(ctx context.Context, videoID, filename string) error
+	HandleEditCompleted(ctx context.Context, videoID, filename string) error
+	HandleAudioRemovalCompleted(ctx context.Context, videoID, filename string) error
+	HandleWatermarkingCompleted(ctx context.Context, videoID, filename string) error
+	HandleGossipOpenCloseCompleted(ctx context.Context, videoID, filename string) error
 	GetRetryDelayMinutes() int
 }
\ No newline at end of file
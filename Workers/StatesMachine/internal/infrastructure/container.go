@@ -26,7 +26,7 @@ func NewContainer(config *Config) (*Container, error) {
 	if err != nil { return nil, err }
 
 	videoRepo := adapters.NewPostgresVideoRepository(db)
-	orchestrateUC := usecases.NewOrchestrateVideoUseCase(videoRepo, publisher, config.EditVideoQueue, config.AudioRemovalQueue, config.WatermarkingQueue, config.MaxRetries, config.RetryDelayMinutes, config.ProcessedVideoURL)
+	orchestrateUC := usecases.NewOrchestrateVideoUseCase(videoRepo, publisher, config.EditVideoQueue, config.AudioRemovalQueue, config.WatermarkingQueue, config.MaxRetries, config.RetryDelayMinutes)
 	messageHandler := adapters.NewMessageHandler(orchestrateUC)
 
 	return &Container{
@@ -0,0 +1,22 @@
+package infrastructure
+
+import (
+	"context"
+
+	"shared/health"
+)
+
+// HealthChecks son los chequeos de /readyz: el consumidor de RabbitMQ y Postgres. El
+// publisher no se chequea porque reconecta solo en el siguiente envio.
+func (c *Container) HealthChecks() []health.Check {
+	return []health.Check{
+		{Name: "rabbitmq_consumer", Run: c.Consumer.Ready},
+		{Name: "postgres", Run: func(ctx context.Context) error {
+			sqlDB, err := c.DB.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}},
+	}
+}
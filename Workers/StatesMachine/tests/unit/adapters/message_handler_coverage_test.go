@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -24,7 +25,7 @@ func TestMessageHandler_HandleMessage_EditCompleted(t *testing.T) {
 	msgBytes, _ := json.Marshal(processedMsg)
 	mockUC.On("HandleEditCompleted", "123", "edited.mp4").Return(nil)
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.NoError(t, err)
 	mockUC.AssertExpectations(t)
@@ -44,7 +45,7 @@ func TestMessageHandler_HandleMessage_AudioRemovalCompleted(t *testing.T) {
 	msgBytes, _ := json.Marshal(processedMsg)
 	mockUC.On("HandleAudioRemovalCompleted", "123", "no-audio.mp4").Return(nil)
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.NoError(t, err)
 	mockUC.AssertExpectations(t)
@@ -64,7 +65,7 @@ func TestMessageHandler_HandleMessage_WatermarkingCompleted(t *testing.T) {
 	msgBytes, _ := json.Marshal(processedMsg)
 	mockUC.On("HandleWatermarkingCompleted", "123", "watermarked.mp4").Return(nil)
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.NoError(t, err)
 	mockUC.AssertExpectations(t)
@@ -84,7 +85,7 @@ func TestMessageHandler_HandleMessage_GossipCompleted(t *testing.T) {
 	msgBytes, _ := json.Marshal(processedMsg)
 	mockUC.On("HandleGossipOpenCloseCompleted", "123", "final.mp4").Return(nil)
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.NoError(t, err)
 	mockUC.AssertExpectations(t)
@@ -104,7 +105,7 @@ func TestMessageHandler_HandleMessage_ProcessedInvalidVideoID(t *testing.T) {
 	msgBytes, _ := json.Marshal(processedMsg)
 	mockUC.On("HandleTrimCompleted", "invalid", "test.mp4").Return(errors.New("invalid video ID format"))
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.Error(t, err)
 	assert.IsType(t, &adapters.NonRetryableError{}, err)
@@ -124,7 +125,7 @@ func TestMessageHandler_HandleMessage_MaxRetriesExceeded(t *testing.T) {
 
 	msgBytes, _ := json.Marshal(msg)
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.Error(t, err)
 	assert.IsType(t, &adapters.NonRetryableError{}, err)
@@ -146,7 +147,7 @@ func TestMessageHandler_HandleMessage_RetryDelayNotMet(t *testing.T) {
 	msgBytes, _ := json.Marshal(msg)
 	mockUC.On("GetRetryDelayMinutes").Return(5) // 5 minutes required
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "retry delay not met")
@@ -169,7 +170,7 @@ func TestMessageHandler_HandleMessage_RetryDelayMet(t *testing.T) {
 	mockUC.On("GetRetryDelayMinutes").Return(5) // 5 minutes required
 	mockUC.On("Execute", "123").Return(nil)
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.NoError(t, err)
 	mockUC.AssertExpectations(t)
@@ -187,7 +188,7 @@ func TestMessageHandler_HandleMessage_ExecuteInvalidVideoID(t *testing.T) {
 	msgBytes, _ := json.Marshal(msg)
 	mockUC.On("Execute", "invalid").Return(errors.New("invalid video ID format"))
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.Error(t, err)
 	assert.IsType(t, &adapters.NonRetryableError{}, err)
@@ -206,7 +207,7 @@ func TestMessageHandler_HandleMessage_ExecuteOtherError(t *testing.T) {
 	msgBytes, _ := json.Marshal(msg)
 	mockUC.On("Execute", "123").Return(errors.New("database connection error"))
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.Error(t, err)
 	assert.NotEqual(t, &adapters.NonRetryableError{}, err)
@@ -226,7 +227,7 @@ func TestMessageHandler_HandleMessage_ProcessedUnknownBucketPath(t *testing.T) {
 
 	msgBytes, _ := json.Marshal(processedMsg)
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.NoError(t, err)
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -14,32 +15,32 @@ type MockOrchestrateUseCase struct {
 	mock.Mock
 }
 
-func (m *MockOrchestrateUseCase) Execute(videoID string) error {
+func (m *MockOrchestrateUseCase) Execute(ctx context.Context, videoID string) error {
 	args := m.Called(videoID)
 	return args.Error(0)
 }
 
-func (m *MockOrchestrateUseCase) HandleTrimCompleted(videoID, filename string) error {
+func (m *MockOrchestrateUseCase) HandleTrimCompleted(ctx context.Context, videoID, filename string) error {
 	args := m.Called(videoID, filename)
 	return args.Error(0)
 }
 
-func (m *MockOrchestrateUseCase) HandleEditCompleted(videoID, filename string) error {
+func (m *MockOrchestrateUseCase) HandleEditCompleted(ctx context.Context, videoID, filename string) error {
 	args := m.Called(videoID, filename)
 	return args.Error(0)
 }
 
-func (m *MockOrchestrateUseCase) HandleAudioRemovalCompleted(videoID, filename string) error {
+func (m *MockOrchestrateUseCase) HandleAudioRemovalCompleted(ctx context.Context, videoID, filename string) error {
 	args := m.Called(videoID, filename)
 	return args.Error(0)
 }
 
-func (m *MockOrchestrateUseCase) HandleWatermarkingCompleted(videoID, filename string) error {
+func (m *MockOrchestrateUseCase) HandleWatermarkingCompleted(ctx context.Context, videoID, filename string) error {
 	args := m.Called(videoID, filename)
 	return args.Error(0)
 }
 
-func (m *MockOrchestrateUseCase) HandleGossipOpenCloseCompleted(videoID, filename string) error {
+func (m *MockOrchestrateUseCase) HandleGossipOpenCloseCompleted(ctx context.Context, videoID, filename string) error {
 	args := m.Called(videoID, filename)
 	return args.Error(0)
 }
@@ -137,7 +138,7 @@ func TestMessageHandler_HandleMessage_ProcessedMessage_Trim(t *testing.T) {
 	msgBytes, _ := json.Marshal(processedMsg)
 	mockUC.On("HandleTrimCompleted", "123", "test.mp4").Return(nil)
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.NoError(t, err)
 	mockUC.AssertExpectations(t)
@@ -149,7 +150,7 @@ func TestMessageHandler_HandleMessage_InvalidJSON(t *testing.T) {
 
 	invalidJSON := []byte("invalid json")
 
-	err := handler.HandleMessage(invalidJSON)
+	err := handler.HandleMessage(context.Background(), invalidJSON)
 
 	assert.Error(t, err)
 	assert.IsType(t, &adapters.NonRetryableError{}, err)
@@ -168,7 +169,7 @@ func TestMessageHandler_HandleMessage_Success(t *testing.T) {
 	msgBytes, _ := json.Marshal(msg)
 	mockUC.On("Execute", "123").Return(nil)
 
-	err := handler.HandleMessage(msgBytes)
+	err := handler.HandleMessage(context.Background(), msgBytes)
 
 	assert.NoError(t, err)
 	mockUC.AssertExpectations(t)
@@ -1,7 +1,10 @@
 package usecases
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"shared/logging"
 	"statesmachine/internal/application/usecases"
 	"statesmachine/internal/domain"
 	"testing"
@@ -27,7 +30,7 @@ func TestOrchestrateVideoUseCase_HandleEditCompleted_Success(t *testing.T) {
 		5,
 	)
 
-	err := useCase.HandleEditCompleted("123", "edited.mp4")
+	err := useCase.HandleEditCompleted(context.Background(), "123", "edited.mp4")
 
 	assert.NoError(t, err)
 	videoRepo.AssertExpectations(t)
@@ -51,7 +54,7 @@ func TestOrchestrateVideoUseCase_HandleAudioRemovalCompleted_Success(t *testing.
 		5,
 	)
 
-	err := useCase.HandleAudioRemovalCompleted("123", "no-audio.mp4")
+	err := useCase.HandleAudioRemovalCompleted(context.Background(), "123", "no-audio.mp4")
 
 	assert.NoError(t, err)
 	videoRepo.AssertExpectations(t)
@@ -75,7 +78,7 @@ func TestOrchestrateVideoUseCase_HandleWatermarkingCompleted_Success(t *testing.
 		5,
 	)
 
-	err := useCase.HandleWatermarkingCompleted("123", "watermarked.mp4")
+	err := useCase.HandleWatermarkingCompleted(context.Background(), "123", "watermarked.mp4")
 
 	assert.NoError(t, err)
 	videoRepo.AssertExpectations(t)
@@ -116,7 +119,7 @@ func TestOrchestrateVideoUseCase_Execute_VideoNotFound(t *testing.T) {
 		5,
 	)
 
-	err := useCase.Execute("123")
+	err := useCase.Execute(context.Background(), "123")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "find video")
@@ -139,9 +142,30 @@ func TestOrchestrateVideoUseCase_HandleTrimCompleted_PublishError(t *testing.T)
 		5,
 	)
 
-	err := useCase.HandleTrimCompleted("123", "trimmed.mp4")
+	err := useCase.HandleTrimCompleted(context.Background(), "123", "trimmed.mp4")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "publish to edit_video_queue")
 	publisher.AssertExpectations(t)
-}
\ No newline at end of file
+}
+func TestOrchestrateVideoUseCase_HandleTrimCompleted_ForwardsCorrelationID(t *testing.T) {
+	videoRepo := &MockVideoRepository{}
+	publisher := &MockMessagePublisher{}
+
+	var published usecases.WorkerMessage
+	publisher.On("PublishMessage", "edit-queue", mock.AnythingOfType("[]uint8")).
+		Run(func(args mock.Arguments) {
+			_ = json.Unmarshal(args.Get(1).([]byte), &published)
+		}).
+		Return(nil)
+	videoRepo.On("UpdateStatus", uint(123), domain.StatusAdjustingRes).Return(nil)
+
+	useCase := usecases.NewOrchestrateVideoUseCase(videoRepo, publisher, "edit-queue", "audio-queue", "watermark-queue", 3, 5)
+
+	ctx := logging.WithCorrelationID(context.Background(), "req-123")
+	err := useCase.HandleTrimCompleted(ctx, "123", "trimmed.mp4")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "req-123", published.CorrelationID)
+	assert.Equal(t, "123", published.VideoID)
+}
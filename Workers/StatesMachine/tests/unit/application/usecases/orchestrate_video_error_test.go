@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"errors"
 	"statesmachine/internal/application/usecases"
 	"statesmachine/internal/domain"
@@ -33,7 +34,7 @@ func TestOrchestrateVideoUseCase_Execute_PublishError(t *testing.T) {
 		5,
 	)
 
-	err := useCase.Execute("123")
+	err := useCase.Execute(context.Background(), "123")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "publish to trim_video_queue")
@@ -57,7 +58,7 @@ func TestOrchestrateVideoUseCase_HandleTrimCompleted_InvalidVideoID(t *testing.T
 		5,
 	)
 
-	err := useCase.HandleTrimCompleted("invalid-id", "trimmed.mp4")
+	err := useCase.HandleTrimCompleted(context.Background(), "invalid-id", "trimmed.mp4")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid video ID format")
@@ -68,7 +69,7 @@ func TestOrchestrateVideoUseCase_HandleGossipOpenCloseCompleted_UpdateError(t *t
 	videoRepo := &MockVideoRepository{}
 	publisher := &MockMessagePublisher{}
 
-	videoRepo.On("UpdateStatusAndProcessedFile", uint(123), domain.StatusProcessed, "http://localhost:8084/processed-videos/final.mp4").Return(errors.New("update failed"))
+	videoRepo.On("UpdateStatusAndProcessedFile", uint(123), domain.StatusProcessed, "final.mp4").Return(errors.New("update failed"))
 
 	useCase := usecases.NewOrchestrateVideoUseCase(
 		videoRepo,
@@ -80,7 +81,7 @@ func TestOrchestrateVideoUseCase_HandleGossipOpenCloseCompleted_UpdateError(t *t
 		5,
 	)
 
-	err := useCase.HandleGossipOpenCloseCompleted("123", "final.mp4")
+	err := useCase.HandleGossipOpenCloseCompleted(context.Background(), "123", "final.mp4")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "update final status and processed file")
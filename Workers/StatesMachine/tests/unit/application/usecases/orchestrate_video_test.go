@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"encoding/json"
 	"statesmachine/internal/application/usecases"
 	"statesmachine/internal/domain"
@@ -36,7 +37,7 @@ type MockMessagePublisher struct {
 	mock.Mock
 }
 
-func (m *MockMessagePublisher) PublishMessage(queue string, message []byte) error {
+func (m *MockMessagePublisher) PublishMessage(ctx context.Context, queue string, message []byte) error {
 	args := m.Called(queue, message)
 	return args.Error(0)
 }
@@ -82,7 +83,7 @@ func TestOrchestrateVideoUseCase_Execute_Success(t *testing.T) {
 		5,
 	)
 	
-	err := useCase.Execute("123")
+	err := useCase.Execute(context.Background(), "123")
 	
 	assert.NoError(t, err)
 	videoRepo.AssertExpectations(t)
@@ -103,7 +104,7 @@ func TestOrchestrateVideoUseCase_Execute_InvalidVideoID(t *testing.T) {
 		5,
 	)
 	
-	err := useCase.Execute("invalid-id")
+	err := useCase.Execute(context.Background(), "invalid-id")
 	
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid video ID format")
@@ -126,7 +127,7 @@ func TestOrchestrateVideoUseCase_HandleTrimCompleted_Success(t *testing.T) {
 		5,
 	)
 	
-	err := useCase.HandleTrimCompleted("123", "trimmed.mp4")
+	err := useCase.HandleTrimCompleted(context.Background(), "123", "trimmed.mp4")
 	
 	assert.NoError(t, err)
 	videoRepo.AssertExpectations(t)
@@ -137,7 +138,7 @@ func TestOrchestrateVideoUseCase_HandleGossipOpenCloseCompleted_Success(t *testi
 	videoRepo := &MockVideoRepository{}
 	publisher := &MockMessagePublisher{}
 	
-	videoRepo.On("UpdateStatusAndProcessedFile", uint(123), domain.StatusProcessed, "http://localhost:8084/processed-videos/final.mp4").Return(nil)
+	videoRepo.On("UpdateStatusAndProcessedFile", uint(123), domain.StatusProcessed, "final.mp4").Return(nil)
 	
 	useCase := usecases.NewOrchestrateVideoUseCase(
 		videoRepo,
@@ -149,7 +150,7 @@ func TestOrchestrateVideoUseCase_HandleGossipOpenCloseCompleted_Success(t *testi
 		5,
 	)
 	
-	err := useCase.HandleGossipOpenCloseCompleted("123", "final.mp4")
+	err := useCase.HandleGossipOpenCloseCompleted(context.Background(), "123", "final.mp4")
 	
 	assert.NoError(t, err)
 	videoRepo.AssertExpectations(t)
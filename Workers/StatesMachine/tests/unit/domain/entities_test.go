@@ -38,6 +38,9 @@ func TestVideoStatus_Constants(t *testing.T) {
 		{"StatusAddingIntroOutro", domain.StatusAddingIntroOutro, "ADDING_INTRO_OUTRO"},
 		{"StatusProcessed", domain.StatusProcessed, "PROCESSED"},
 		{"StatusFailed", domain.StatusFailed, "FAILED"},
+		{"StatusPendingReview", domain.StatusPendingReview, "PENDING_REVIEW"},
+		{"StatusPublished", domain.StatusPublished, "PUBLISHED"},
+		{"StatusRejected", domain.StatusRejected, "REJECTED"},
 	}
 
 	for _, tt := range tests {
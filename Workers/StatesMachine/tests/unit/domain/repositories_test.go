@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"statesmachine/internal/domain"
 	"testing"
 
@@ -24,7 +25,7 @@ func (t *TestVideoRepository) UpdateStatusAndProcessedFile(id uint, status domai
 
 type TestMessagePublisher struct{}
 
-func (t *TestMessagePublisher) PublishMessage(queue string, message []byte) error {
+func (t *TestMessagePublisher) PublishMessage(ctx context.Context, queue string, message []byte) error {
 	return nil
 }
 
@@ -52,6 +53,6 @@ func TestMessagePublisher_Interface(t *testing.T) {
 	assert.NotNil(t, publisher)
 
 	// Test PublishMessage
-	err := publisher.PublishMessage("test_queue", []byte("test message"))
+	err := publisher.PublishMessage(context.Background(), "test_queue", []byte("test message"))
 	assert.NoError(t, err)
 }
\ No newline at end of file
@@ -1,15 +1,18 @@
 package adapters
 
 import (
+	"log/slog"
+	"shared/logging"
+	"context"
 	"trimvideo/internal/application/usecases"
 	"encoding/json"
-	"github.com/sirupsen/logrus"
 	"shared/security"
 )
 
 type VideoMessage struct {
-	VideoID  string `json:"video_id"`
-	Filename string `json:"filename"`
+	VideoID       string `json:"video_id"`
+	Filename      string `json:"filename"`
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 type MessageHandler struct {
@@ -20,15 +23,16 @@ func NewMessageHandler(uc *usecases.ProcessVideoUseCase) *MessageHandler {
 	return &MessageHandler{processVideoUC: uc}
 }
 
-func (h *MessageHandler) HandleMessage(body []byte) error {
+func (h *MessageHandler) HandleMessage(ctx context.Context, body []byte) error {
 	var msg VideoMessage
 	if err := json.Unmarshal(body, &msg); err != nil {
-		logrus.Errorf("Failed to unmarshal message: %v", err)
+		slog.ErrorContext(ctx, "Failed to unmarshal message", "err", err)
 		return err
 	}
 
-	logrus.Infof("Received video_id: '%s', filename: '%s'", security.SanitizeLogInput(msg.VideoID), security.SanitizeLogInput(msg.Filename))
-	return h.processVideoUC.Execute(msg.VideoID, msg.Filename)
+	ctx = logging.WithCorrelationID(logging.WithVideoID(ctx, security.SanitizeLogInput(msg.VideoID)), security.SanitizeLogInput(msg.CorrelationID))
+	slog.InfoContext(ctx, "Received video", "filename", security.SanitizeLogInput(msg.Filename))
+	return h.processVideoUC.Execute(ctx, msg.VideoID, msg.Filename)
 }
 
 
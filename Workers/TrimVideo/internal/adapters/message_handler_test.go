@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -13,13 +14,13 @@ func TestNewMessageHandler(t *testing.T) {
 
 func TestMessageHandler_HandleMessage_InvalidJSON(t *testing.T) {
 	handler := NewMessageHandler(nil)
-	err := handler.HandleMessage([]byte("invalid json"))
+	err := handler.HandleMessage(context.Background(), []byte("invalid json"))
 	assert.Error(t, err)
 }
 
 func TestMessageHandler_HandleMessage_EmptyBody(t *testing.T) {
 	handler := NewMessageHandler(nil)
-	err := handler.HandleMessage([]byte(""))
+	err := handler.HandleMessage(context.Background(), []byte(""))
 	assert.Error(t, err)
 }
 
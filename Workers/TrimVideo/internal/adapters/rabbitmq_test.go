@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,4 +28,17 @@ func TestRabbitMQ_ValidParams(t *testing.T) {
 	assert.Greater(t, maxRetries, 0)
 	assert.Greater(t, queueMaxLength, 0)
 	assert.Contains(t, url, "amqp://")
-}
\ No newline at end of file
+}
+
+func TestRabbitMQ_ReadyWithoutConnection(t *testing.T) {
+	consumer := &RabbitMQConsumer{}
+	assert.EqualError(t, consumer.Ready(context.Background()), "rabbitmq connection closed")
+
+	publisher := &RabbitMQPublisher{}
+	assert.EqualError(t, publisher.Ready(context.Background()), "rabbitmq connection closed")
+}
+
+func TestRabbitMQ_ShutdownWhenNotConsuming(t *testing.T) {
+	consumer := &RabbitMQConsumer{}
+	assert.NoError(t, consumer.Shutdown(context.Background()))
+}
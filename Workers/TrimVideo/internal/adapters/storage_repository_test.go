@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"context"
 	"bytes"
 	"errors"
 	"io"
@@ -14,7 +15,7 @@ type MockStorageService struct {
 	mock.Mock
 }
 
-func (m *MockStorageService) GetObject(bucket, filename string) (io.Reader, error) {
+func (m *MockStorageService) GetObject(ctx context.Context, bucket, filename string) (io.Reader, error) {
 	args := m.Called(bucket, filename)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -22,7 +23,7 @@ func (m *MockStorageService) GetObject(bucket, filename string) (io.Reader, erro
 	return args.Get(0).(io.Reader), args.Error(1)
 }
 
-func (m *MockStorageService) PutObject(bucket, filename string, reader io.Reader, size int64) error {
+func (m *MockStorageService) PutObject(ctx context.Context, bucket, filename string, reader io.Reader, size int64) error {
 	args := m.Called(bucket, filename, reader, size)
 	return args.Error(0)
 }
@@ -46,7 +47,7 @@ func TestStorageRepository_Download_Success(t *testing.T) {
 	reader := bytes.NewReader(expectedData)
 	storage.On("GetObject", bucket, filename).Return(reader, nil)
 	
-	result, err := repo.Download(bucket, filename)
+	result, err := repo.Download(context.Background(), bucket, filename)
 	
 	assert.NoError(t, err)
 	assert.Equal(t, expectedData, result)
@@ -63,7 +64,7 @@ func TestStorageRepository_Download_Error(t *testing.T) {
 	
 	storage.On("GetObject", bucket, filename).Return(nil, expectedError)
 	
-	result, err := repo.Download(bucket, filename)
+	result, err := repo.Download(context.Background(), bucket, filename)
 	
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
@@ -81,7 +82,7 @@ func TestStorageRepository_Upload_Success(t *testing.T) {
 	
 	storage.On("PutObject", bucket, filename, mock.AnythingOfType("*bytes.Reader"), int64(len(data))).Return(nil)
 	
-	err := repo.Upload(bucket, filename, data)
+	err := repo.Upload(context.Background(), bucket, filename, data)
 	
 	assert.NoError(t, err)
 	storage.AssertExpectations(t)
@@ -98,7 +99,7 @@ func TestStorageRepository_Upload_Error(t *testing.T) {
 	
 	storage.On("PutObject", bucket, filename, mock.AnythingOfType("*bytes.Reader"), int64(len(data))).Return(expectedError)
 	
-	err := repo.Upload(bucket, filename, data)
+	err := repo.Upload(context.Background(), bucket, filename, data)
 	
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
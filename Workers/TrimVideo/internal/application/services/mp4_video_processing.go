@@ -1,10 +1,15 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
+
+	"shared/metrics"
+	"shared/tracing"
 )
 
 type MP4VideoProcessingService struct{}
@@ -12,7 +17,7 @@ type MP4VideoProcessingService struct{}
 func NewMP4VideoProcessingService() *MP4VideoProcessingService { return &MP4VideoProcessingService{} }
 
 // TrimToMaxSeconds recorta el video a maxSeconds usando ffmpeg (-c copy).
-func (s *MP4VideoProcessingService) TrimToMaxSeconds(inputData []byte, maxSeconds int) ([]byte, error) {
+func (s *MP4VideoProcessingService) TrimToMaxSeconds(ctx context.Context, inputData []byte, maxSeconds int) ([]byte, error) {
 	if _, err := exec.LookPath("ffmpeg"); err != nil {
 		return nil, fmt.Errorf("ffmpeg not found: %w", err)
 	}
@@ -31,8 +36,13 @@ func (s *MP4VideoProcessingService) TrimToMaxSeconds(inputData []byte, maxSecond
 	}
 
 	args := []string{"-y", "-i", inputPath, "-t", fmt.Sprintf("%d", maxSeconds), "-c", "copy", outputPath}
-	cmd := exec.Command("ffmpeg", args...)
-	if err := cmd.Run(); err != nil {
+	_, span := tracing.Start(ctx, "ffmpeg trim")
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	start := time.Now()
+	err = cmd.Run()
+	metrics.ObserveFFmpeg("trim", start, err)
+	tracing.End(span, err)
+	if err != nil {
 		return nil, fmt.Errorf("ffmpeg failed: %w", err)
 	}
 
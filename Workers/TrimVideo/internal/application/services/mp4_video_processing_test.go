@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -16,7 +17,7 @@ func TestMP4VideoProcessingService_TrimToMaxSeconds_NoFFmpeg(t *testing.T) {
 	service := NewMP4VideoProcessingService()
 	inputData := []byte("fake video data")
 	
-	_, err := service.TrimToMaxSeconds(inputData, 30)
+	_, err := service.TrimToMaxSeconds(context.Background(), inputData, 30)
 	if err != nil {
 		assert.Contains(t, err.Error(), "ffmpeg not found")
 	}
@@ -25,7 +26,7 @@ func TestMP4VideoProcessingService_TrimToMaxSeconds_NoFFmpeg(t *testing.T) {
 func TestMP4VideoProcessingService_TrimToMaxSeconds_EmptyInput(t *testing.T) {
 	service := NewMP4VideoProcessingService()
 	
-	_, err := service.TrimToMaxSeconds([]byte{}, 30)
+	_, err := service.TrimToMaxSeconds(context.Background(), []byte{}, 30)
 	assert.Error(t, err)
 }
 
@@ -33,7 +34,7 @@ func TestMP4VideoProcessingService_TrimToMaxSeconds_InvalidSeconds(t *testing.T)
 	service := NewMP4VideoProcessingService()
 	inputData := []byte("test data")
 	
-	_, err := service.TrimToMaxSeconds(inputData, 0)
+	_, err := service.TrimToMaxSeconds(context.Background(), inputData, 0)
 	assert.Error(t, err)
 }
 
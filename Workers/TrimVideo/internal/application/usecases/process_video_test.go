@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -31,12 +32,12 @@ type MockStorageRepository struct {
 	mock.Mock
 }
 
-func (m *MockStorageRepository) Download(bucket, filename string) ([]byte, error) {
+func (m *MockStorageRepository) Download(ctx context.Context, bucket, filename string) ([]byte, error) {
 	args := m.Called(bucket, filename)
 	return args.Get(0).([]byte), args.Error(1)
 }
 
-func (m *MockStorageRepository) Upload(bucket, filename string, data []byte) error {
+func (m *MockStorageRepository) Upload(ctx context.Context, bucket, filename string, data []byte) error {
 	args := m.Called(bucket, filename, data)
 	return args.Error(0)
 }
@@ -45,7 +46,7 @@ type MockVideoProcessingService struct {
 	mock.Mock
 }
 
-func (m *MockVideoProcessingService) TrimToMaxSeconds(data []byte, maxSeconds int) ([]byte, error) {
+func (m *MockVideoProcessingService) TrimToMaxSeconds(ctx context.Context, data []byte, maxSeconds int) ([]byte, error) {
 	args := m.Called(data, maxSeconds)
 	return args.Get(0).([]byte), args.Error(1)
 }
@@ -54,7 +55,7 @@ type MockNotificationService struct {
 	mock.Mock
 }
 
-func (m *MockNotificationService) NotifyVideoProcessed(videoID, filename, bucketPath string) error {
+func (m *MockNotificationService) NotifyVideoProcessed(ctx context.Context, videoID, filename, bucketPath string) error {
 	args := m.Called(videoID, filename, bucketPath)
 	return args.Error(0)
 }
@@ -115,7 +116,7 @@ func TestProcessVideoUseCase_Execute_Success(t *testing.T) {
 		30,
 	)
 	
-	err := useCase.Execute("video-123", "test.mp4")
+	err := useCase.Execute(context.Background(), "video-123", "test.mp4")
 	
 	assert.NoError(t, err)
 	videoRepo.AssertExpectations(t)
@@ -142,7 +143,7 @@ func TestProcessVideoUseCase_Execute_VideoNotFound(t *testing.T) {
 		30,
 	)
 	
-	err := useCase.Execute("video-123", "test.mp4")
+	err := useCase.Execute(context.Background(), "video-123", "test.mp4")
 	
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "find video")
@@ -179,7 +180,7 @@ func TestProcessVideoUseCase_Execute_ProcessingFails(t *testing.T) {
 		30,
 	)
 	
-	err := useCase.Execute("video-123", "test.mp4")
+	err := useCase.Execute(context.Background(), "video-123", "test.mp4")
 	
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "processing")
@@ -1,14 +1,17 @@
 package adapters
 
 import (
+	"log/slog"
+	"shared/logging"
+	"context"
 	"watermarking/internal/application/usecases"
 	"encoding/json"
-	"github.com/sirupsen/logrus"
 )
 
 type VideoMessage struct {
-	VideoID  string `json:"video_id"`
-	Filename string `json:"filename"`
+	VideoID       string `json:"video_id"`
+	Filename      string `json:"filename"`
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 type MessageHandler struct {
@@ -19,13 +22,14 @@ func NewMessageHandler(uc *usecases.WatermarkingUseCase) *MessageHandler {
 	return &MessageHandler{editVideoUC: uc}
 }
 
-func (h *MessageHandler) HandleMessage(body []byte) error {
+func (h *MessageHandler) HandleMessage(ctx context.Context, body []byte) error {
 	var msg VideoMessage
 	if err := json.Unmarshal(body, &msg); err != nil {
-		logrus.Errorf("Failed to unmarshal message: %v", err)
+		slog.ErrorContext(ctx, "Failed to unmarshal message", "err", err)
 		return err
 	}
 
-	logrus.Infof("Recibido video_id: '%s', filename: '%s'", msg.VideoID, msg.Filename)
-	return h.editVideoUC.Execute(msg.VideoID, msg.Filename)
+	ctx = logging.WithCorrelationID(logging.WithVideoID(ctx, msg.VideoID), msg.CorrelationID)
+	slog.InfoContext(ctx, "Recibido video", "filename", msg.Filename)
+	return h.editVideoUC.Execute(ctx, msg.VideoID, msg.Filename)
 }
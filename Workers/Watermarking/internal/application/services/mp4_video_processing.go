@@ -1,10 +1,16 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
+
+	"shared/metrics"
+
+	"shared/tracing"
 )
 
 // MP4VideoProcessingService cumple con domain.VideoProcessingService.
@@ -15,7 +21,7 @@ func NewMP4VideoProcessingService() *MP4VideoProcessingService { return &MP4Vide
 
 // TrimToMaxSeconds: mantiene la firma []byte→[]byte y agrega watermark.
 // Ignoramos el "recorte" y normalizamos a 720p con overlay del logo.
-func (s *MP4VideoProcessingService) TrimToMaxSeconds(input []byte, maxSeconds int) ([]byte, error) {
+func (s *MP4VideoProcessingService) TrimToMaxSeconds(ctx context.Context, input []byte, maxSeconds int) ([]byte, error) {
 	if _, err := exec.LookPath("ffmpeg"); err != nil {
 		return nil, fmt.Errorf("ffmpeg not found: %w", err)
 	}
@@ -53,8 +59,13 @@ func (s *MP4VideoProcessingService) TrimToMaxSeconds(input []byte, maxSeconds in
 		outputPath,
 	}
 
-	cmd := exec.Command("ffmpeg", args...)
-	if err := cmd.Run(); err != nil {
+	_, span := tracing.Start(ctx, "ffmpeg watermark")
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	start := time.Now()
+	err = cmd.Run()
+	metrics.ObserveFFmpeg("watermark", start, err)
+	tracing.End(span, err)
+	if err != nil {
 		return nil, fmt.Errorf("ffmpeg failed: %w", err)
 	}
 
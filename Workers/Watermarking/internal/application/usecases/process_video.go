@@ -1,10 +1,10 @@
 package usecases
 
 import (
+    "context"
     "fmt"
-    "time"
     "watermarking/internal/domain"
-    "github.com/sirupsen/logrus"
+    "log/slog"
 )
 
 type WatermarkingUseCase struct {
@@ -36,24 +36,24 @@ func NewWatermarkingUseCase(
 	}
 }
 
-func (uc *WatermarkingUseCase) Execute(videoID, filename string) error {
+func (uc *WatermarkingUseCase) Execute(ctx context.Context, videoID, filename string) error {
 	video, err := uc.videoRepo.FindByFilename(filename)
 	if err != nil { return fmt.Errorf("find video: %w", err) }
 	if err := uc.videoRepo.UpdateStatus(video.ID, domain.StatusProcessing); err != nil { return err }
 
-	data, err := uc.storageRepo.Download(uc.rawBucket, filename)
+	data, err := uc.storageRepo.Download(ctx, uc.rawBucket, filename)
 	if err != nil {
 		_ = uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("download: %w", err)
 	}
 
-	processedData, err := uc.processingService.TrimToMaxSeconds(data, uc.maxSeconds)
+	processedData, err := uc.processingService.TrimToMaxSeconds(ctx, data, uc.maxSeconds)
 	if err != nil {
 		_ = uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("processing: %w", err)
 	}
 
-	if err := uc.storageRepo.Upload(uc.processedBucket, filename, processedData); err != nil {
+	if err := uc.storageRepo.Upload(ctx, uc.processedBucket, filename, processedData); err != nil {
 		_ = uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("upload: %w", err)
 	}
@@ -63,18 +63,11 @@ func (uc *WatermarkingUseCase) Execute(videoID, filename string) error {
 	}
 
 	bucketPath := fmt.Sprintf("%s/%s", uc.processedBucket, filename)
-	if err := uc.notificationService.NotifyVideoProcessed(videoID, filename, bucketPath); err != nil {
-		logrus.Errorf("Failed to notify state machine: %v", err)
+	if err := uc.notificationService.NotifyVideoProcessed(ctx, videoID, filename, bucketPath); err != nil {
+		slog.ErrorContext(ctx, "Failed to notify state machine", "err", err)
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"video_id": video.ID,
-		"filename": filename,
-		"bucket_from": uc.rawBucket,
-		"bucket_to": uc.processedBucket,
-		"max_seconds": uc.maxSeconds,
-		"timestamp": time.Now().UTC(),
-	}).Info("Watermarking processing completed successfully")
+	slog.InfoContext(ctx, "Watermarking processing completed successfully", "filename", filename, "bucket_from", uc.rawBucket, "bucket_to", uc.processedBucket, "max_seconds", uc.maxSeconds)
 
 	return nil
 }
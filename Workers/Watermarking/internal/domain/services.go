@@ -1,9 +1,11 @@
 package domain
 
+import "context"
+
 type VideoProcessingService interface {
-	TrimToMaxSeconds(inputData []byte, maxSeconds int) ([]byte, error)
+	TrimToMaxSeconds(ctx context.Context, inputData []byte, maxSeconds int) ([]byte, error)
 }
 
 type NotificationService interface {
-	NotifyVideoProcessed(videoID, filename, bucketPath string) error
+	NotifyVideoProcessed(ctx context.Context, videoID, filename, bucketPath string) error
 }
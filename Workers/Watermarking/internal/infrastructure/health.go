@@ -0,0 +1,24 @@
+package infrastructure
+
+import (
+	"context"
+
+	"shared/health"
+)
+
+// HealthChecks son los chequeos de /readyz: el consumidor y el publisher de RabbitMQ y
+// los buckets de entrada y salida en S3.
+func (c *Container) HealthChecks() []health.Check {
+	return []health.Check{
+		{Name: "rabbitmq_consumer", Run: c.Consumer.Ready},
+		{Name: "rabbitmq_publisher", Run: c.Publisher.Ready},
+		{Name: "s3", Run: func(ctx context.Context) error {
+			for _, bucket := range []string{c.Config.RawBucket, c.Config.ProcessedBucket} {
+				if err := c.Storage.HeadBucket(ctx, bucket); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+	}
+}
@@ -1,8 +1,11 @@
 package ports
 
-import "io"
+import (
+	"context"
+	"io"
+)
 
 type StorageService interface {
-	GetObject(bucket, filename string) (io.Reader, error)
-	PutObject(bucket, filename string, data io.Reader, size int64) error
+	GetObject(ctx context.Context, bucket, filename string) (io.Reader, error)
+	PutObject(ctx context.Context, bucket, filename string, data io.Reader, size int64) error
 }
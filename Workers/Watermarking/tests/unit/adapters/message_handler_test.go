@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"context"
 	"testing"
 	"watermarking/internal/adapters"
 
@@ -14,13 +15,13 @@ func TestNewMessageHandler(t *testing.T) {
 
 func TestMessageHandler_HandleMessage_InvalidJSON(t *testing.T) {
 	handler := adapters.NewMessageHandler(nil)
-	err := handler.HandleMessage([]byte("invalid json"))
+	err := handler.HandleMessage(context.Background(), []byte("invalid json"))
 	assert.Error(t, err)
 }
 
 func TestMessageHandler_HandleMessage_EmptyBody(t *testing.T) {
 	handler := adapters.NewMessageHandler(nil)
-	err := handler.HandleMessage([]byte(""))
+	err := handler.HandleMessage(context.Background(), []byte(""))
 	assert.Error(t, err)
 }
 
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"testing"
 	"watermarking/internal/application/services"
 
@@ -16,7 +17,7 @@ func TestMP4VideoProcessingService_TrimToMaxSeconds_NoFFmpeg(t *testing.T) {
 	service := services.NewMP4VideoProcessingService()
 	inputData := []byte("fake video data")
 	
-	_, err := service.TrimToMaxSeconds(inputData, 30)
+	_, err := service.TrimToMaxSeconds(context.Background(), inputData, 30)
 	if err != nil {
 		assert.Contains(t, err.Error(), "ffmpeg not found")
 	}
@@ -25,6 +26,6 @@ func TestMP4VideoProcessingService_TrimToMaxSeconds_NoFFmpeg(t *testing.T) {
 func TestMP4VideoProcessingService_TrimToMaxSeconds_EmptyInput(t *testing.T) {
 	service := services.NewMP4VideoProcessingService()
 	
-	_, err := service.TrimToMaxSeconds([]byte{}, 30)
+	_, err := service.TrimToMaxSeconds(context.Background(), []byte{}, 30)
 	assert.Error(t, err)
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"watermarking/internal/application/services"
@@ -13,7 +14,7 @@ type MockMessagePublisher struct {
 	mock.Mock
 }
 
-func (m *MockMessagePublisher) PublishMessage(queue string, message interface{}) error {
+func (m *MockMessagePublisher) PublishMessage(ctx context.Context, queue string, message interface{}) error {
 	args := m.Called(queue, message)
 	return args.Error(0)
 }
@@ -49,7 +50,7 @@ func TestNotificationService_NotifyVideoProcessed_Success(t *testing.T) {
 	
 	publisher.On("PublishMessage", "state-queue", expectedMsg).Return(nil)
 	
-	err := service.NotifyVideoProcessed(videoID, filename, bucketPath)
+	err := service.NotifyVideoProcessed(context.Background(), videoID, filename, bucketPath)
 	
 	assert.NoError(t, err)
 	publisher.AssertExpectations(t)
@@ -73,7 +74,7 @@ func TestNotificationService_NotifyVideoProcessed_Error(t *testing.T) {
 	
 	publisher.On("PublishMessage", "state-queue", expectedMsg).Return(expectedError)
 	
-	err := service.NotifyVideoProcessed(videoID, filename, bucketPath)
+	err := service.NotifyVideoProcessed(context.Background(), videoID, filename, bucketPath)
 	
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"watermarking/internal/application/usecases"
@@ -31,7 +32,7 @@ type MockStorageRepository struct {
 	mock.Mock
 }
 
-func (m *MockStorageRepository) Download(bucket, filename string) ([]byte, error) {
+func (m *MockStorageRepository) Download(ctx context.Context, bucket, filename string) ([]byte, error) {
 	args := m.Called(bucket, filename)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -39,7 +40,7 @@ func (m *MockStorageRepository) Download(bucket, filename string) ([]byte, error
 	return args.Get(0).([]byte), args.Error(1)
 }
 
-func (m *MockStorageRepository) Upload(bucket, filename string, data []byte) error {
+func (m *MockStorageRepository) Upload(ctx context.Context, bucket, filename string, data []byte) error {
 	args := m.Called(bucket, filename, data)
 	return args.Error(0)
 }
@@ -48,7 +49,7 @@ type MockProcessingService struct {
 	mock.Mock
 }
 
-func (m *MockProcessingService) TrimToMaxSeconds(data []byte, maxSeconds int) ([]byte, error) {
+func (m *MockProcessingService) TrimToMaxSeconds(ctx context.Context, data []byte, maxSeconds int) ([]byte, error) {
 	args := m.Called(data, maxSeconds)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -60,7 +61,7 @@ type MockNotificationService struct {
 	mock.Mock
 }
 
-func (m *MockNotificationService) NotifyVideoProcessed(videoID, filename, bucketPath string) error {
+func (m *MockNotificationService) NotifyVideoProcessed(ctx context.Context, videoID, filename, bucketPath string) error {
 	args := m.Called(videoID, filename, bucketPath)
 	return args.Error(0)
 }
@@ -112,7 +113,7 @@ func TestWatermarkingUseCase_Execute_Success(t *testing.T) {
 		30,
 	)
 
-	err := useCase.Execute("123", "test.mp4")
+	err := useCase.Execute(context.Background(), "123", "test.mp4")
 
 	assert.NoError(t, err)
 	mockVideoRepo.AssertExpectations(t)
@@ -139,7 +140,7 @@ func TestWatermarkingUseCase_Execute_VideoNotFound(t *testing.T) {
 		30,
 	)
 
-	err := useCase.Execute("123", "missing.mp4")
+	err := useCase.Execute(context.Background(), "123", "missing.mp4")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "find video")
@@ -169,7 +170,7 @@ func TestWatermarkingUseCase_Execute_DownloadError(t *testing.T) {
 		30,
 	)
 
-	err := useCase.Execute("123", "test.mp4")
+	err := useCase.Execute(context.Background(), "123", "test.mp4")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "download")
@@ -202,7 +203,7 @@ func TestWatermarkingUseCase_Execute_ProcessingError(t *testing.T) {
 		30,
 	)
 
-	err := useCase.Execute("123", "test.mp4")
+	err := useCase.Execute(context.Background(), "123", "test.mp4")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "processing")
@@ -239,7 +240,7 @@ func TestWatermarkingUseCase_Execute_NotificationError(t *testing.T) {
 		30,
 	)
 
-	err := useCase.Execute("123", "test.mp4")
+	err := useCase.Execute(context.Background(), "123", "test.mp4")
 
 	// Should not return error even if notification fails (it only logs)
 	assert.NoError(t, err)
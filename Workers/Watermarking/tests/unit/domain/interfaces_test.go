@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"watermarking/internal/domain"
@@ -30,14 +31,14 @@ func (t *TestVideoRepository) UpdateStatus(id string, status domain.ProcessingSt
 
 type TestStorageRepository struct{}
 
-func (t *TestStorageRepository) Download(bucket, filename string) ([]byte, error) {
+func (t *TestStorageRepository) Download(ctx context.Context, bucket, filename string) ([]byte, error) {
 	if filename == "missing.mp4" {
 		return nil, errors.New("file not found")
 	}
 	return []byte("video data"), nil
 }
 
-func (t *TestStorageRepository) Upload(bucket, filename string, data []byte) error {
+func (t *TestStorageRepository) Upload(ctx context.Context, bucket, filename string, data []byte) error {
 	if bucket == "invalid-bucket" {
 		return errors.New("bucket not found")
 	}
@@ -46,7 +47,7 @@ func (t *TestStorageRepository) Upload(bucket, filename string, data []byte) err
 
 type TestProcessingService struct{}
 
-func (t *TestProcessingService) TrimToMaxSeconds(data []byte, maxSeconds int) ([]byte, error) {
+func (t *TestProcessingService) TrimToMaxSeconds(ctx context.Context, data []byte, maxSeconds int) ([]byte, error) {
 	if len(data) == 0 {
 		return nil, errors.New("empty data")
 	}
@@ -55,7 +56,7 @@ func (t *TestProcessingService) TrimToMaxSeconds(data []byte, maxSeconds int) ([
 
 type TestNotificationService struct{}
 
-func (t *TestNotificationService) NotifyVideoProcessed(videoID, filename, bucketPath string) error {
+func (t *TestNotificationService) NotifyVideoProcessed(ctx context.Context, videoID, filename, bucketPath string) error {
 	if videoID == "invalid" {
 		return errors.New("invalid video ID")
 	}
@@ -79,11 +80,11 @@ func TestStorageRepository_Interface(t *testing.T) {
 	var repo domain.StorageRepository = &TestStorageRepository{}
 	assert.NotNil(t, repo)
 
-	data, err := repo.Download("bucket", "test.mp4")
+	data, err := repo.Download(context.Background(), "bucket", "test.mp4")
 	assert.NoError(t, err)
 	assert.Equal(t, []byte("video data"), data)
 
-	err = repo.Upload("bucket", "test.mp4", []byte("data"))
+	err = repo.Upload(context.Background(), "bucket", "test.mp4", []byte("data"))
 	assert.NoError(t, err)
 }
 
@@ -91,7 +92,7 @@ func TestProcessingService_Interface(t *testing.T) {
 	var service domain.VideoProcessingService = &TestProcessingService{}
 	assert.NotNil(t, service)
 
-	result, err := service.TrimToMaxSeconds([]byte("video data"), 30)
+	result, err := service.TrimToMaxSeconds(context.Background(), []byte("video data"), 30)
 	assert.NoError(t, err)
 	assert.Equal(t, []byte("watermarked data"), result)
 }
@@ -100,6 +101,6 @@ func TestNotificationService_Interface(t *testing.T) {
 	var service domain.NotificationService = &TestNotificationService{}
 	assert.NotNil(t, service)
 
-	err := service.NotifyVideoProcessed("123", "test.mp4", "bucket/test.mp4")
+	err := service.NotifyVideoProcessed(context.Background(), "123", "test.mp4", "bucket/test.mp4")
 	assert.NoError(t, err)
 }
\ No newline at end of file
@@ -1,29 +1,58 @@
 package main
 
 import (
-	"os"
-	"os/signal"
-	"syscall"
-	"github.com/sirupsen/logrus"
+	"context"
+	"log/slog"
+	"shared/health"
+	"shared/logging"
+	"shared/metrics"
+	"shared/shutdown"
+	"shared/tracing"
 	"gossipopenclose/internal/infrastructure"
 )
 
 func main() {
 
+	logging.Setup("gossipopenclose")
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "gossipopenclose")
+	if err != nil { logging.Fatal("tracing setup", "err", err) }
+	defer func() { _ = shutdownTracing(context.Background()) }()
+
 	config := infrastructure.LoadConfig()
 	container, err := infrastructure.NewContainer(config)
-	if err != nil { logrus.Fatal("bootstrap error:", err) }
+	if err != nil { logging.Fatal("bootstrap error", "err", err) }
 	defer container.Consumer.Close()
+	defer container.Publisher.Close()
+
+	if srv := metrics.Serve(); srv != nil {
+		defer srv.Close()
+		slog.Info("Metrics available", "addr", srv.Addr, "path", "/metrics")
+	}
 
-	if err := container.Consumer.StartConsuming(config.QueueName, container.MessageHandler); err != nil {
-		logrus.Fatal("start consuming:", err)
+	if srv := health.Serve(container.HealthChecks()...); srv != nil {
+		defer srv.Close()
+		slog.Info("Health probes available", "addr", srv.Addr, "paths", "/livez,/readyz")
 	}
 
-	logrus.Info("gossipOpenClose worker started. Waiting for messages...")
+	// StartConsuming bloquea hasta que se cierra el canal de entregas
+	consumeErr := make(chan error, 1)
+	go func() { consumeErr <- container.Consumer.StartConsuming(config.QueueName, container.MessageHandler) }()
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	slog.Info("gossipOpenClose worker started. Waiting for messages...")
 
-	logrus.Info("Shutting down gossipOpenClose worker...")
+	select {
+	case <-shutdown.Signals():
+		slog.Info("Shutting down gossipOpenClose worker...")
+	case err := <-consumeErr:
+		if err != nil { logging.Fatal("start consuming", "err", err) }
+		slog.Error("RabbitMQ deliveries closed, shutting down gossipOpenClose worker")
+	}
+
+	// Termina el video en curso (o lo devuelve a la cola) antes de cerrar las conexiones
+	ctx, cancel := context.WithTimeout(context.Background(), shutdown.Timeout())
+	defer cancel()
+	if err := container.Consumer.Shutdown(ctx); err != nil {
+		slog.Warn("Shutdown deadline exceeded, current job requeued", "err", err)
+	}
 }
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -16,7 +17,7 @@ func NewMP4VideoProcessingService() *MP4VideoProcessingService { return &MP4Vide
 
 // TrimToMaxSeconds: mantiene la firma []byte→[]byte y agrega watermark.
 // Ignoramos el "recorte" y normalizamos a 720p con overlay del logo.
-func (s *MP4VideoProcessingService) TrimToMaxSeconds(input []byte, maxSeconds int) ([]byte, error) {
+func (s *MP4VideoProcessingService) TrimToMaxSeconds(ctx context.Context, input []byte, maxSeconds int) ([]byte, error) {
 	inPath := filepath.Join(os.TempDir(), fmt.Sprintf("wm_in_%d.mp4", time.Now().UnixNano()))
 	outPath := filepath.Join(os.TempDir(), fmt.Sprintf("wm_out_%d.mp4", time.Now().UnixNano()))
 	logoPath := os.Getenv("WATERMARK_PATH")
@@ -49,7 +50,7 @@ func (s *MP4VideoProcessingService) TrimToMaxSeconds(input []byte, maxSeconds in
 		outPath,
 	}
 
-	cmd := exec.Command("ffmpeg", args...)
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
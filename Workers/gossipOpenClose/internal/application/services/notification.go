@@ -1,7 +1,9 @@
 package services
 
 import (
-	"github.com/sirupsen/logrus"
+	"context"
+	"log/slog"
+	"shared/logging"
 	"gossipopenclose/internal/ports"
 )
 
@@ -10,6 +12,8 @@ type VideoProcessedMessage struct {
 	Filename   string `json:"filename"`
 	BucketPath string `json:"bucket_path"`
 	Status     string `json:"status"`
+	// CorrelationID se reenvia tal cual para seguir el video en los logs de todos los servicios.
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 type NotificationService struct {
@@ -24,24 +28,21 @@ func NewNotificationService(publisher ports.MessagePublisher, stateQueue string)
 	}
 }
 
-func (s *NotificationService) NotifyVideoProcessed(videoID, filename, bucketPath string) error {
+func (s *NotificationService) NotifyVideoProcessed(ctx context.Context, videoID, filename, bucketPath string) error {
 	msg := VideoProcessedMessage{
 		VideoID:    videoID,
 		Filename:   filename,
 		BucketPath: bucketPath,
 		Status:     "completed",
+		CorrelationID: logging.CorrelationID(ctx),
 	}
 
-	if err := s.publisher.PublishMessage(s.stateQueue, msg); err != nil {
-		logrus.Errorf("Failed to notify state machine: %v", err)
+	if err := s.publisher.PublishMessage(ctx, s.stateQueue, msg); err != nil {
+		slog.ErrorContext(ctx, "Failed to notify state machine", "err", err)
 		return err
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"video_id": videoID,
-		"filename": filename,
-		"bucket_path": bucketPath,
-	}).Info("GossipOpenClose processing notification sent to state machine")
+	slog.InfoContext(ctx, "GossipOpenClose processing notification sent to state machine", "filename", filename, "bucket_path", bucketPath)
 
 	return nil
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"bytes"
 	"fmt"
 	"os"
@@ -8,6 +9,9 @@ import (
 	"path/filepath"
 	"strconv"
 	"time"
+
+	"shared/metrics"
+	"shared/tracing"
 )
 
 // OpenCloseVideoProcessingService genera cortinillas (intro/outro) y concatena con el video original.
@@ -18,7 +22,7 @@ func NewOpenCloseVideoProcessingService() *OpenCloseVideoProcessingService { ret
 
 // Process agrega intro/outro con logo y concatena SIN normalizar el video base.
 // Se obtienen w/h/fps del video de entrada para generar cortinillas compatibles (mismo w/h/fps).
-func (s *OpenCloseVideoProcessingService) Process(inputData []byte, logoPath string, introSeconds, outroSeconds float64, width, height, fps int) ([]byte, error) {
+func (s *OpenCloseVideoProcessingService) Process(ctx context.Context, inputData []byte, logoPath string, introSeconds, outroSeconds float64, width, height, fps int) ([]byte, error) {
 	if introSeconds < 0 { introSeconds = 0 }
 	if outroSeconds < 0 { outroSeconds = 0 }
 	total := introSeconds + outroSeconds
@@ -60,12 +64,12 @@ func (s *OpenCloseVideoProcessingService) Process(inputData []byte, logoPath str
 
 	// --- Generar intro/outro al MISMO w/h/fps del video base (sin tocar el base)
 	if introSeconds > 0.0 {
-		if err := makeBumper(logoPath, width, height, fps, introSeconds, introPath, true); err != nil {
+		if err := makeBumper(ctx, logoPath, width, height, fps, introSeconds, introPath, true); err != nil {
 			return nil, fmt.Errorf("make intro: %w", err)
 		}
 	}
 	if outroSeconds > 0.0 {
-		if err := makeBumper(logoPath, width, height, fps, outroSeconds, outroPath, false); err != nil {
+		if err := makeBumper(ctx, logoPath, width, height, fps, outroSeconds, outroPath, false); err != nil {
 			return nil, fmt.Errorf("make outro: %w", err)
 		}
 	}
@@ -89,8 +93,9 @@ func (s *OpenCloseVideoProcessingService) Process(inputData []byte, logoPath str
 			"-c", "copy",
 			outPath,
 		}
-		if err := runFFmpeg(args); err != nil {
+		if err := runFFmpegStage(ctx, "concat", args); err != nil {
 			// Fallback: recodificar en caso de diferencias de codec/params
+			metrics.Retry("concat_reencode")
 			args = []string{
 				"-y",
 				"-f", "concat",
@@ -100,7 +105,7 @@ func (s *OpenCloseVideoProcessingService) Process(inputData []byte, logoPath str
 				"-c:a", "aac", "-b:a", "128k",
 				outPath,
 			}
-			if err2 := runFFmpeg(args); err2 != nil {
+			if err2 := runFFmpegStage(ctx, "concat_reencode", args); err2 != nil {
 				return nil, fmt.Errorf("concat: %v / fallback: %w", err, err2)
 			}
 		}
@@ -113,7 +118,7 @@ func (s *OpenCloseVideoProcessingService) Process(inputData []byte, logoPath str
 
 // makeBumper genera una cortinilla negra con el logo centrado y fade in/out.
 // Se fija al tamaño/fps indicados (los del video base), evitando tocar el video original.
-func makeBumper(logoPath string, width, height, fps int, seconds float64, outPath string, isIntro bool) error {
+func makeBumper(ctx context.Context, logoPath string, width, height, fps int, seconds float64, outPath string, isIntro bool) error {
 	// Duración de fade (máximo 0.5 s o 20% de la duración)
 	fadeDur := seconds * 0.2
 	if fadeDur > 0.5 { fadeDur = 0.5 }
@@ -146,12 +151,23 @@ func makeBumper(logoPath string, width, height, fps int, seconds float64, outPat
 		"-movflags", "+faststart",
 		outPath,
 	}
-	return runFFmpeg(args)
+	return runFFmpegStage(ctx, "bumper", args)
+}
+
+// runFFmpegStage ejecuta ffmpeg dentro de un span y registra su duracion en la metrica de la etapa stage.
+func runFFmpegStage(ctx context.Context, stage string, args []string) error {
+	_, span := tracing.Start(ctx, "ffmpeg "+stage)
+	start := time.Now()
+	err := runFFmpeg(ctx, args)
+	metrics.ObserveFFmpeg(stage, start, err)
+	tracing.End(span, err)
+	return err
 }
 
-// runFFmpeg ejecuta ffmpeg mostrando salida en consola (útil para logs en contenedor).
-func runFFmpeg(args []string) error {
-	cmd := exec.Command("ffmpeg", args...)
+// runFFmpeg ejecuta ffmpeg mostrando salida en consola (útil para logs en contenedor);
+// cancelar ctx mata el proceso.
+func runFFmpeg(ctx context.Context, args []string) error {
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
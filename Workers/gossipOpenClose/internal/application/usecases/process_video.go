@@ -2,14 +2,14 @@
 package usecases
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
-	"time"
 
 	"gossipopenclose/internal/domain"
 	"gossipopenclose/internal/application/services"
-	"github.com/sirupsen/logrus"
+	"log/slog"
 )
 
 type OpenCloseUseCase struct {
@@ -66,7 +66,7 @@ func envInt(key string, def int) int {
 	return def
 }
 
-func (uc *OpenCloseUseCase) Execute(videoID, filename string) error {
+func (uc *OpenCloseUseCase) Execute(ctx context.Context, videoID, filename string) error {
 	video, err := uc.videoRepo.FindByFilename(filename)
 	if err != nil { return fmt.Errorf("find video: %w", err) }
 
@@ -74,19 +74,19 @@ func (uc *OpenCloseUseCase) Execute(videoID, filename string) error {
 		return fmt.Errorf("update status: %w", err)
 	}
 
-	data, err := uc.storageRepo.Download(uc.rawBucket, filename)
+	data, err := uc.storageRepo.Download(ctx, uc.rawBucket, filename)
 	if err != nil {
 		_ = uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("download: %w", err)
 	}
 
-	processedData, err := uc.processingService.Process(data, uc.logoPath, uc.introSeconds, uc.outroSeconds, uc.targetW, uc.targetH, uc.fps)
+	processedData, err := uc.processingService.Process(ctx, data, uc.logoPath, uc.introSeconds, uc.outroSeconds, uc.targetW, uc.targetH, uc.fps)
 	if err != nil {
 		_ = uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("process: %w", err)
 	}
 
-	if err := uc.storageRepo.Upload(uc.processedBucket, filename, processedData); err != nil {
+	if err := uc.storageRepo.Upload(ctx, uc.processedBucket, filename, processedData); err != nil {
 		_ = uc.videoRepo.UpdateStatus(video.ID, domain.StatusFailed)
 		return fmt.Errorf("upload: %w", err)
 	}
@@ -96,17 +96,11 @@ func (uc *OpenCloseUseCase) Execute(videoID, filename string) error {
 	}
 
 	bucketPath := fmt.Sprintf("%s/%s", uc.processedBucket, filename)
-	if err := uc.notificationService.NotifyVideoProcessed(videoID, filename, bucketPath); err != nil {
-		logrus.Errorf("Failed to notify state machine: %v", err)
+	if err := uc.notificationService.NotifyVideoProcessed(ctx, videoID, filename, bucketPath); err != nil {
+		slog.ErrorContext(ctx, "Failed to notify state machine", "err", err)
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"video_id": video.ID,
-		"filename": filename,
-		"bucket_from": uc.rawBucket,
-		"bucket_to": uc.processedBucket,
-		"timestamp": time.Now().UTC(),
-	}).Info("GossipOpenClose processing completed successfully")
+	slog.InfoContext(ctx, "GossipOpenClose processing completed successfully", "filename", filename, "bucket_from", uc.rawBucket, "bucket_to", uc.processedBucket)
 
 	return nil
 }
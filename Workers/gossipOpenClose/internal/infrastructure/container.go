@@ -12,7 +12,9 @@ import (
 type Container struct {
 	Config         *Config
 	Consumer       *adapters.RabbitMQConsumer
+	Publisher      *adapters.RabbitMQPublisher
 	MessageHandler *adapters.MessageHandler
+	Storage        *sharedstorage.Client
 }
 
 func NewContainer(config *Config) (*Container, error) {
@@ -60,5 +62,5 @@ func NewContainer(config *Config) (*Container, error) {
 	}
 
 	handler := adapters.NewMessageHandler(uc)
-	return &Container{Config: config, Consumer: consumer, MessageHandler: handler}, nil
+	return &Container{Config: config, Consumer: consumer, Publisher: publisher, MessageHandler: handler, Storage: storageClient}, nil
 }
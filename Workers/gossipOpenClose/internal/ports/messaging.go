@@ -1,7 +1,9 @@
 package ports
 
+import "context"
+
 type MessageHandler interface {
-	HandleMessage(body []byte) error
+	HandleMessage(ctx context.Context, body []byte) error
 }
 
 type MessageConsumer interface {
@@ -10,5 +12,5 @@ type MessageConsumer interface {
 }
 
 type MessagePublisher interface {
-	PublishMessage(queueName string, message interface{}) error
+	PublishMessage(ctx context.Context, queueName string, message interface{}) error
 }
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -48,7 +49,7 @@ func TestOpenCloseVideoProcessingService_Process_ErrorCases(t *testing.T) {
 	
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := service.Process(tt.inputData, tt.logoPath, tt.intro, tt.outro, tt.width, tt.height, tt.fps)
+			_, err := service.Process(context.Background(), tt.inputData, tt.logoPath, tt.intro, tt.outro, tt.width, tt.height, tt.fps)
 			
 			if tt.expectError {
 				assert.Error(t, err)
@@ -84,7 +85,7 @@ func TestOpenCloseVideoProcessingService_Process_BoundaryValues(t *testing.T) {
 			minimalMP4 := createMinimalMP4Data()
 			
 			// This will likely fail due to ffmpeg requirements, but tests parameter handling
-			_, err := service.Process(minimalMP4, "nonexistent.png", tt.intro, tt.outro, tt.width, tt.height, tt.fps)
+			_, err := service.Process(context.Background(), minimalMP4, "nonexistent.png", tt.intro, tt.outro, tt.width, tt.height, tt.fps)
 			
 			// We expect errors due to invalid input, but we're testing parameter validation
 			assert.Error(t, err) // Expected due to invalid test data
@@ -0,0 +1,139 @@
+// Package health serves the liveness and readiness probes of every worker binary.
+// /livez only says the process is up; /readyz runs every dependency check concurrently,
+// each bounded by a timeout, and answers 503 when any of them fails.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultAddr is the listen address used when HEALTH_ADDR is not set.
+const DefaultAddr = ":8081"
+
+// DefaultTimeout bounds each check when HEALTH_CHECK_TIMEOUT_MS is not set.
+const DefaultTimeout = 2 * time.Second
+
+// Check statuses, used for each check and for the whole report.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Check probes one dependency; Run must return nil when it is usable.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the /readyz body: the overall status and the result of every check.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Evaluate runs checks concurrently, each with its own timeout. A check that does not
+// return in time counts as failed even if it ignores ctx.
+func Evaluate(ctx context.Context, timeout time.Duration, checks ...Check) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c Check) {
+			defer wg.Done()
+			res := run(ctx, timeout, c)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.Name] = res
+			if res.Status == StatusFail {
+				report.Status = StatusFail
+			}
+		}(c)
+	}
+	wg.Wait()
+	return report
+}
+
+func run(ctx context.Context, timeout time.Duration, c Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	res := Result{Status: StatusOK, LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		res.Status = StatusFail
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// Handler serves GET /livez and GET /readyz for checks.
+func Handler(timeout time.Duration, checks ...Check) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": StatusOK})
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		report := Evaluate(r.Context(), timeout, checks...)
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, report)
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// Serve exposes the probes on HEALTH_ADDR (default DefaultAddr) in the background, with
+// HEALTH_CHECK_TIMEOUT_MS (default DefaultTimeout) per check. HEALTH_ADDR=off disables
+// the server and returns nil.
+func Serve(checks ...Check) *http.Server {
+	addr := os.Getenv("HEALTH_ADDR")
+	if addr == "off" {
+		return nil
+	}
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	timeout := DefaultTimeout
+	if ms, err := strconv.Atoi(os.Getenv("HEALTH_CHECK_TIMEOUT_MS")); err == nil && ms > 0 {
+		timeout = time.Duration(ms) * time.Millisecond
+	}
+	srv := &http.Server{Addr: addr, Handler: Handler(timeout, checks...), ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("health server failed", "addr", addr, "err", err)
+		}
+	}()
+	return srv
+}
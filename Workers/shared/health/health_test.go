@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ok(context.Context) error { return nil }
+
+func TestEvaluateReportsEveryCheck(t *testing.T) {
+	report := Evaluate(context.Background(), time.Second,
+		Check{Name: "s3", Run: ok},
+		Check{Name: "rabbitmq", Run: func(context.Context) error { return errors.New("not consuming") }},
+	)
+
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, StatusOK, report.Checks["s3"].Status)
+	assert.Equal(t, StatusFail, report.Checks["rabbitmq"].Status)
+	assert.Equal(t, "not consuming", report.Checks["rabbitmq"].Error)
+}
+
+func TestEvaluateTimesOutChecksThatIgnoreContext(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	start := time.Now()
+	report := Evaluate(context.Background(), 20*time.Millisecond,
+		Check{Name: "stuck", Run: func(context.Context) error { <-block; return nil }},
+	)
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Contains(t, report.Checks["stuck"].Error, "timed out")
+	assert.GreaterOrEqual(t, report.Checks["stuck"].LatencyMs, 20.0)
+}
+
+func TestHandler(t *testing.T) {
+	healthy := true
+	h := Handler(time.Second, Check{Name: "rabbitmq", Run: func(context.Context) error {
+		if !healthy {
+			return errors.New("connection closed")
+		}
+		return nil
+	}})
+
+	get := func(path string) (*httptest.ResponseRecorder, Report) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var report Report
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		return w, report
+	}
+
+	w, report := get("/readyz")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, StatusOK, report.Checks["rabbitmq"].Status)
+
+	healthy = false
+	w, report = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "connection closed", report.Checks["rabbitmq"].Error)
+
+	w, report = get("/livez")
+	assert.Equal(t, http.StatusOK, w.Code, "liveness does not depend on the checks")
+	assert.Equal(t, StatusOK, report.Status)
+}
+
+func TestServeDisabled(t *testing.T) {
+	t.Setenv("HEALTH_ADDR", "off")
+	assert.Nil(t, Serve())
+}
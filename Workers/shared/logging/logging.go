@@ -0,0 +1,129 @@
+// Package logging builds the slog logger shared by every worker binary: JSON lines on
+// stdout, level and format from the environment, and the video_id / correlation_id /
+// trace_id of the context added to every record logged with a *Context method.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Field names shared with the API logs, so one grep follows a video across services.
+const (
+	ServiceKey       = "service"
+	VideoIDKey       = "video_id"
+	CorrelationIDKey = "correlation_id"
+	TraceIDKey       = "trace_id"
+)
+
+// Setup builds the logger for service from LOG_LEVEL (debug, info, warn, error; default
+// info) and LOG_FORMAT (json or text; default json), installs it as slog's default and
+// returns it.
+func Setup(service string) *slog.Logger {
+	logger := New(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT")).With(ServiceKey, service)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// New builds a logger writing to w with the given level and format names.
+func New(w io.Writer, level, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: ParseLevel(level)}
+	var h slog.Handler
+	if strings.EqualFold(strings.TrimSpace(format), "text") {
+		h = slog.NewTextHandler(w, opts)
+	} else {
+		h = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(contextHandler{h})
+}
+
+// ParseLevel maps a level name to slog.Level; unknown or empty names mean info.
+func ParseLevel(s string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+type ctxKey int
+
+const (
+	videoIDCtxKey ctxKey = iota
+	correlationIDCtxKey
+)
+
+// WithVideoID returns ctx tagged with the video being processed.
+func WithVideoID(ctx context.Context, videoID string) context.Context {
+	if videoID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, videoIDCtxKey, videoID)
+}
+
+// WithCorrelationID returns ctx tagged with the correlation ID received in the message
+// (the X-Request-ID of the API request that started the work).
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	if correlationID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, correlationIDCtxKey, correlationID)
+}
+
+// VideoID returns the video ID stored in ctx, or "".
+func VideoID(ctx context.Context) string {
+	v, _ := ctx.Value(videoIDCtxKey).(string)
+	return v
+}
+
+// CorrelationID returns the correlation ID stored in ctx, or "" so it can be forwarded
+// in the next message payload as is.
+func CorrelationID(ctx context.Context) string {
+	v, _ := ctx.Value(correlationIDCtxKey).(string)
+	return v
+}
+
+// contextHandler adds the correlation fields found in the record context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if v := VideoID(ctx); v != "" {
+			r.AddAttrs(slog.String(VideoIDKey, v))
+		}
+		if v := CorrelationID(ctx); v != "" {
+			r.AddAttrs(slog.String(CorrelationIDKey, v))
+		}
+		if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+			r.AddAttrs(slog.String(TraceIDKey, sc.TraceID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// Fatal logs msg at error level with the default logger and exits with status 1.
+// Reserved for startup errors the worker cannot recover from.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+	var rec map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+	return rec
+}
+
+func TestParseLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, ParseLevel("DEBUG"))
+	assert.Equal(t, slog.LevelWarn, ParseLevel("warning"))
+	assert.Equal(t, slog.LevelError, ParseLevel(" error "))
+	assert.Equal(t, slog.LevelInfo, ParseLevel(""))
+	assert.Equal(t, slog.LevelInfo, ParseLevel("verbose"))
+}
+
+func TestNewAddsContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, "info", "").With(ServiceKey, "trimvideo")
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID,
+	}))
+	ctx = WithCorrelationID(WithVideoID(ctx, "42"), "req-1")
+
+	logger.InfoContext(ctx, "video processed", "filename", "a.mp4")
+
+	rec := decode(t, &buf)
+	assert.Equal(t, "video processed", rec["msg"])
+	assert.Equal(t, "INFO", rec["level"])
+	assert.Equal(t, "trimvideo", rec[ServiceKey])
+	assert.Equal(t, "42", rec[VideoIDKey])
+	assert.Equal(t, "req-1", rec[CorrelationIDKey])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", rec[TraceIDKey])
+	assert.Equal(t, "a.mp4", rec["filename"])
+}
+
+func TestNewRespectsLevelAndOmitsEmptyFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, "warn", "json")
+
+	logger.InfoContext(context.Background(), "dropped")
+	assert.Zero(t, buf.Len())
+
+	logger.WarnContext(WithVideoID(context.Background(), ""), "kept")
+	rec := decode(t, &buf)
+	assert.NotContains(t, rec, VideoIDKey)
+	assert.NotContains(t, rec, CorrelationIDKey)
+	assert.NotContains(t, rec, TraceIDKey)
+}
+
+func TestTextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, "", "text").InfoContext(WithVideoID(context.Background(), "7"), "hello")
+	assert.Contains(t, buf.String(), "msg=hello")
+	assert.Contains(t, buf.String(), "video_id=7")
+}
@@ -0,0 +1,96 @@
+// Package metrics exposes the Prometheus metrics shared by every worker binary.
+// Each worker runs in its own process, so the collectors live in the default registry.
+package metrics
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultAddr is the listen address used when METRICS_ADDR is not set.
+const DefaultAddr = ":2112"
+
+var (
+	messagesConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "worker",
+		Name:      "messages_consumed_total",
+		Help:      "Messages received from the queue.",
+	}, []string{"queue"})
+
+	messagesFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "worker",
+		Name:      "messages_failed_total",
+		Help:      "Messages whose handler returned an error.",
+	}, []string{"queue"})
+
+	ffmpegDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "worker",
+		Name:      "ffmpeg_duration_seconds",
+		Help:      "Duration of each ffmpeg run by processing stage and result.",
+		Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"stage", "result"})
+
+	storageBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "worker",
+		Name:      "storage_bytes_total",
+		Help:      "Bytes transferred to and from object storage.",
+	}, []string{"direction"})
+
+	retries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "worker",
+		Name:      "retries_total",
+		Help:      "Retried operations by kind.",
+	}, []string{"operation"})
+)
+
+// MessageConsumed counts a message received from queue.
+func MessageConsumed(queue string) { messagesConsumed.WithLabelValues(queue).Inc() }
+
+// MessageFailed counts a message from queue whose handler failed.
+func MessageFailed(queue string) { messagesFailed.WithLabelValues(queue).Inc() }
+
+// ObserveFFmpeg records an ffmpeg run for stage that started at start and ended with err.
+func ObserveFFmpeg(stage string, start time.Time, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	ffmpegDuration.WithLabelValues(stage, result).Observe(time.Since(start).Seconds())
+}
+
+// AddDownloadedBytes counts n bytes read from object storage.
+func AddDownloadedBytes(n int64) { storageBytes.WithLabelValues("download").Add(float64(n)) }
+
+// AddUploadedBytes counts n bytes written to object storage.
+func AddUploadedBytes(n int64) { storageBytes.WithLabelValues("upload").Add(float64(n)) }
+
+// Retry counts one retry of operation (e.g. "publish", "message").
+func Retry(operation string) { retries.WithLabelValues(operation).Inc() }
+
+// Serve exposes GET /metrics on METRICS_ADDR (default DefaultAddr) in the background.
+// METRICS_ADDR=off disables the endpoint and returns nil.
+func Serve() *http.Server {
+	addr := os.Getenv("METRICS_ADDR")
+	if addr == "off" {
+		return nil
+	}
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("metrics server", "err", err)
+		}
+	}()
+	return srv
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounters(t *testing.T) {
+	MessageConsumed("test_queue")
+	MessageConsumed("test_queue")
+	MessageFailed("test_queue")
+	AddDownloadedBytes(10)
+	AddUploadedBytes(4)
+	Retry("publish")
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(messagesConsumed.WithLabelValues("test_queue")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(messagesFailed.WithLabelValues("test_queue")))
+	assert.Equal(t, 10.0, testutil.ToFloat64(storageBytes.WithLabelValues("download")))
+	assert.Equal(t, 4.0, testutil.ToFloat64(storageBytes.WithLabelValues("upload")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(retries.WithLabelValues("publish")))
+}
+
+func TestObserveFFmpeg(t *testing.T) {
+	ObserveFFmpeg("trim", time.Now(), nil)
+	ObserveFFmpeg("trim", time.Now(), errors.New("exit status 1"))
+
+	assert.Equal(t, 2, testutil.CollectAndCount(ffmpegDuration, "worker_ffmpeg_duration_seconds"))
+}
+
+func TestServeDisabled(t *testing.T) {
+	t.Setenv("METRICS_ADDR", "off")
+	assert.Nil(t, Serve())
+}
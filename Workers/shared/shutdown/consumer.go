@@ -0,0 +1,129 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/streadway/amqp"
+
+	"shared/metrics"
+	"shared/tracing"
+)
+
+// Handler procesa el cuerpo de un mensaje; ctx se cancela si Shutdown vence el plazo.
+type Handler func(ctx context.Context, body []byte) error
+
+// FailureFunc decide que hacer con un mensaje cuyo handler fallo. No se llama para los
+// trabajos cortados por Shutdown, que siempre vuelven a la cola.
+type FailureFunc func(ctx context.Context, msg amqp.Delivery, err error)
+
+// Consumer recorre las entregas de una cola y lleva el estado de apagado comun a los
+// workers: los adaptadores lo embeben y solo aportan su handler.
+type Consumer struct {
+	conn      *amqp.Connection
+	channel   *amqp.Channel
+	tag       string
+	onFailure FailureFunc
+	// consuming vale true mientras el loop de entregas esta activo; lo lee Ready.
+	consuming atomic.Bool
+	// stopping se activa en Shutdown: las entregas que sigan llegando vuelven a la cola.
+	stopping atomic.Bool
+	// jobs es el contexto padre de cada mensaje; Shutdown lo cancela si vence el plazo.
+	jobs      context.Context
+	abortJobs context.CancelFunc
+	stopped   chan struct{}
+}
+
+// NewConsumer crea un consumidor sobre ch. Por defecto un mensaje fallido se descarta
+// (Nack sin reencolar, va a la dead-letter si la cola la tiene).
+func NewConsumer(conn *amqp.Connection, ch *amqp.Channel) *Consumer {
+	jobs, abortJobs := context.WithCancel(context.Background())
+	return &Consumer{
+		conn:      conn,
+		channel:   ch,
+		onFailure: func(_ context.Context, msg amqp.Delivery, _ error) { _ = msg.Nack(false, false) },
+		jobs:      jobs,
+		abortJobs: abortJobs,
+		stopped:   make(chan struct{}),
+	}
+}
+
+// WithFailureHandler reemplaza el tratamiento de los mensajes fallidos.
+func (c *Consumer) WithFailureHandler(f FailureFunc) *Consumer {
+	c.onFailure = f
+	return c
+}
+
+// Start se suscribe a queue (ya declarada) y procesa las entregas en segundo plano hasta que
+// Shutdown cancele la suscripcion o se pierda la conexion; Done se cierra al terminar.
+func (c *Consumer) Start(queue string, handle Handler) error {
+	c.tag = queue
+	deliveries, err := c.channel.Consume(queue, c.tag, false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+	c.consuming.Store(true)
+	go c.run(queue, deliveries, handle)
+	return nil
+}
+
+func (c *Consumer) run(queue string, deliveries <-chan amqp.Delivery, handle Handler) {
+	defer close(c.stopped)
+	defer c.consuming.Store(false)
+	for msg := range deliveries {
+		if c.stopping.Load() {
+			// Recibida antes del Cancel pero sin empezar: queda para otra instancia
+			_ = msg.Nack(false, true)
+			continue
+		}
+		metrics.MessageConsumed(queue)
+		ctx, span := tracing.StartConsume(c.jobs, msg.Headers, queue)
+		err := handle(ctx, msg.Body)
+		tracing.End(span, err)
+		switch {
+		case err != nil && c.jobs.Err() != nil:
+			// Cortado por Shutdown: se reintenta tal cual, sin contar como fallo
+			slog.WarnContext(ctx, "job interrupted by shutdown, requeued", "err", err)
+			_ = msg.Nack(false, true)
+		case err != nil:
+			slog.ErrorContext(ctx, "handler error", "err", err)
+			metrics.MessageFailed(queue)
+			c.onFailure(ctx, msg, err)
+		default:
+			_ = msg.Ack(false)
+		}
+	}
+}
+
+// Done se cierra cuando termina el loop de entregas (Shutdown o conexion perdida).
+func (c *Consumer) Done() <-chan struct{} {
+	return c.stopped
+}
+
+// Shutdown deja de recibir entregas y espera a que termine el mensaje en curso. Si ctx vence
+// antes, cancela el contexto del trabajo (ffmpeg incluido) y el mensaje vuelve a la cola.
+// Un consumidor nil o que no esta consumiendo no tiene nada que drenar.
+func (c *Consumer) Shutdown(ctx context.Context) error {
+	if c == nil || !c.consuming.Load() {
+		return nil
+	}
+	c.stopping.Store(true)
+	if err := c.channel.Cancel(c.tag, false); err != nil {
+		slog.Warn("cancel consumer", "err", err)
+	}
+	return Drain(ctx, c.stopped, c.abortJobs)
+}
+
+// Ready devuelve error si la conexion con RabbitMQ se cerro o el consumidor dejo de
+// recibir de la cola; es el chequeo rabbitmq_consumer de /readyz.
+func (c *Consumer) Ready(context.Context) error {
+	if c == nil || c.conn == nil || c.conn.IsClosed() {
+		return errors.New("rabbitmq connection closed")
+	}
+	if !c.consuming.Load() {
+		return errors.New("consumer not running")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+)
+
+// acks registra como se resolvio cada entrega, por delivery tag.
+type acks struct {
+	mu     sync.Mutex
+	result map[uint64]string
+}
+
+func (a *acks) set(tag uint64, s string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.result[tag] = s
+	return nil
+}
+
+func (a *acks) Ack(tag uint64, _ bool) error { return a.set(tag, "ack") }
+func (a *acks) Nack(tag uint64, _ bool, requeue bool) error {
+	if requeue {
+		return a.set(tag, "requeue")
+	}
+	return a.set(tag, "nack")
+}
+func (a *acks) Reject(tag uint64, requeue bool) error { return a.Nack(tag, false, requeue) }
+
+func deliver(a *acks, bodies ...string) <-chan amqp.Delivery {
+	ch := make(chan amqp.Delivery, len(bodies))
+	for i, b := range bodies {
+		ch <- amqp.Delivery{Acknowledger: a, DeliveryTag: uint64(i + 1), Body: []byte(b)}
+	}
+	close(ch)
+	return ch
+}
+
+func TestConsumerAcksAndDiscardsFailures(t *testing.T) {
+	a := &acks{result: map[uint64]string{}}
+	c := NewConsumer(nil, nil)
+
+	c.run("q", deliver(a, "ok", "fail"), func(_ context.Context, body []byte) error {
+		if string(body) == "fail" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	assert.Equal(t, map[uint64]string{1: "ack", 2: "nack"}, a.result)
+	assert.False(t, c.consuming.Load())
+	<-c.Done()
+}
+
+func TestConsumerFailureHandler(t *testing.T) {
+	a := &acks{result: map[uint64]string{}}
+	var failed error
+	c := NewConsumer(nil, nil).WithFailureHandler(func(_ context.Context, msg amqp.Delivery, err error) {
+		failed = err
+		_ = msg.Ack(false)
+	})
+
+	c.run("q", deliver(a, "fail"), func(context.Context, []byte) error { return errors.New("boom") })
+
+	assert.EqualError(t, failed, "boom")
+	assert.Equal(t, map[uint64]string{1: "ack"}, a.result)
+}
+
+func TestConsumerRequeuesOnShutdown(t *testing.T) {
+	a := &acks{result: map[uint64]string{}}
+	c := NewConsumer(nil, nil)
+	calls := 0
+
+	c.run("q", deliver(a, "job", "pending"), func(ctx context.Context, _ []byte) error {
+		calls++
+		// Vence el plazo de Shutdown con el trabajo en curso
+		c.stopping.Store(true)
+		c.abortJobs()
+		return ctx.Err()
+	})
+
+	assert.Equal(t, 1, calls, "entregas recibidas despues de Shutdown no se procesan")
+	assert.Equal(t, map[uint64]string{1: "requeue", 2: "requeue"}, a.result)
+}
+
+func TestConsumerNil(t *testing.T) {
+	var c *Consumer
+	assert.EqualError(t, c.Ready(context.Background()), "rabbitmq connection closed")
+	assert.NoError(t, c.Shutdown(context.Background()))
+}
@@ -0,0 +1,46 @@
+// Package shutdown helps worker binaries stop on SIGTERM without losing work: the consumer
+// stops taking deliveries, the job in progress gets until the deadline to finish, and a
+// job that does not make it is cancelled so its message goes back to the queue.
+package shutdown
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// DefaultTimeout bounds the drain when SHUTDOWN_TIMEOUT_SECONDS is not set. It must stay
+// below the stop grace period of the container (docker-compose stop_grace_period).
+const DefaultTimeout = 60 * time.Second
+
+// Timeout returns SHUTDOWN_TIMEOUT_SECONDS, or DefaultTimeout when it is unset or invalid.
+func Timeout() time.Duration {
+	if s, err := strconv.Atoi(os.Getenv("SHUTDOWN_TIMEOUT_SECONDS")); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return DefaultTimeout
+}
+
+// Signals returns a channel that receives SIGINT and SIGTERM.
+func Signals() <-chan os.Signal {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	return quit
+}
+
+// Drain waits for done, which the consumer closes once its delivery loop returns. If ctx
+// ends first it calls abort, which must cancel the job in progress, waits for done anyway
+// and returns ctx.Err().
+func Drain(ctx context.Context, done <-chan struct{}, abort func()) error {
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+	abort()
+	<-done
+	return ctx.Err()
+}
@@ -0,0 +1,50 @@
+package shutdown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainWaitsForTheCurrentJob(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(done)
+	}()
+
+	aborted := false
+	err := Drain(context.Background(), done, func() { aborted = true })
+
+	assert.NoError(t, err)
+	assert.False(t, aborted)
+}
+
+func TestDrainAbortsAfterTheDeadline(t *testing.T) {
+	done := make(chan struct{})
+	job, cancelJob := context.WithCancel(context.Background())
+	go func() {
+		<-job.Done()
+		close(done)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := Drain(ctx, done, cancelJob)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Error(t, job.Err(), "the job context is cancelled")
+}
+
+func TestTimeout(t *testing.T) {
+	t.Setenv("SHUTDOWN_TIMEOUT_SECONDS", "")
+	assert.Equal(t, DefaultTimeout, Timeout())
+
+	t.Setenv("SHUTDOWN_TIMEOUT_SECONDS", "15")
+	assert.Equal(t, 15*time.Second, Timeout())
+
+	t.Setenv("SHUTDOWN_TIMEOUT_SECONDS", "abc")
+	assert.Equal(t, DefaultTimeout, Timeout())
+}
@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"io"
 
+	"shared/metrics"
+	"shared/tracing"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Config defines the minimal parameters needed to instantiate an S3 client.
@@ -59,35 +64,73 @@ func NewClient(cfg Config) (*Client, error) {
 }
 
 // GetObject downloads an object from S3 and returns its body reader.
-func (c *Client) GetObject(bucket, key string) (io.Reader, error) {
+// Bytes are counted as downloaded as the caller reads them.
+func (c *Client) GetObject(ctx context.Context, bucket, key string) (_ io.Reader, err error) {
 	if bucket == "" || key == "" {
 		return nil, fmt.Errorf("bucket and key are required")
 	}
+	ctx, span := startSpan(ctx, "s3 GetObject", bucket, key)
+	defer func() { tracing.End(span, err) }()
 
-	output, err := c.client.GetObject(context.Background(), &s3.GetObjectInput{
+	output, err := c.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
 	})
 	if err != nil {
 		return nil, err
 	}
-	return output.Body, nil
+	return &countingReader{ReadCloser: output.Body}, nil
 }
 
 // PutObject uploads the provided reader contents into S3.
-func (c *Client) PutObject(bucket, key string, data io.Reader, size int64) error {
+func (c *Client) PutObject(ctx context.Context, bucket, key string, data io.Reader, size int64) (err error) {
 	if bucket == "" || key == "" {
 		return fmt.Errorf("bucket and key are required")
 	}
 	if data == nil {
 		return fmt.Errorf("data reader cannot be nil")
 	}
+	ctx, span := startSpan(ctx, "s3 PutObject", bucket, key)
+	span.SetAttributes(attribute.Int64("s3.size", size))
+	defer func() { tracing.End(span, err) }()
 
-	_, err := c.client.PutObject(context.Background(), &s3.PutObjectInput{
+	_, err = c.client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:        aws.String(bucket),
 		Key:           aws.String(key),
 		Body:          data,
 		ContentLength: aws.Int64(size),
 	})
+	if err == nil {
+		metrics.AddUploadedBytes(size)
+	}
+	return err
+}
+
+// HeadBucket checks that bucket exists and is reachable with the configured credentials.
+// Used by the readiness probe, so it opens no span to keep probes out of the traces.
+func (c *Client) HeadBucket(ctx context.Context, bucket string) error {
+	_, err := c.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucket)})
 	return err
 }
+
+// startSpan opens a client span for an S3 call on bucket/key.
+func startSpan(ctx context.Context, name, bucket, key string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("s3.bucket", bucket),
+			attribute.String("s3.key", key),
+		),
+	)
+}
+
+// countingReader reports every byte read from an object body to the worker metrics.
+type countingReader struct {
+	io.ReadCloser
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	metrics.AddDownloadedBytes(int64(n))
+	return n, err
+}
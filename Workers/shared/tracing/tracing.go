@@ -0,0 +1,149 @@
+// Package tracing configures OpenTelemetry for the worker binaries and propagates the
+// trace context through AMQP message headers, so a video keeps a single trace from the
+// API request through every processing stage.
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "shared/tracing"
+
+// Setup installs the W3C trace-context propagator and, when an OTLP endpoint is
+// configured, an OTLP/HTTP exporter. It honours the standard OpenTelemetry variables
+// (OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS,
+// OTEL_SERVICE_NAME, OTEL_TRACES_SAMPLER...); OTEL_TRACES_EXPORTER=none disables export.
+// Without an exporter the trace context is still forwarded but no spans are recorded.
+// The returned function flushes pending spans and must be called before exiting.
+func Setup(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+	if !exporterEnabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+	// OTEL_SERVICE_NAME, when set, wins over serviceName.
+	if envRes, err := resource.New(ctx, resource.WithFromEnv()); err == nil {
+		if merged, err := resource.Merge(res, envRes); err == nil {
+			res = merged
+		}
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+func exporterEnabled() bool {
+	if strings.EqualFold(os.Getenv("OTEL_TRACES_EXPORTER"), "none") {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Tracer returns the tracer used by the workers on the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start opens an internal span (ffmpeg runs, use-case steps).
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartConsume continues the trace carried in the headers of a message received from
+// queue and opens a consumer span for its processing. ctx is the parent of the returned
+// context, so cancelling it (e.g. on shutdown) also cancels the job.
+func StartConsume(ctx context.Context, headers map[string]interface{}, queue string) (context.Context, trace.Span) {
+	ctx = ExtractHeaders(ctx, headers)
+	return Tracer().Start(ctx, queue+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(messagingAttrs(queue)...),
+	)
+}
+
+// StartPublish opens a producer span for a message sent to queue. Inject the returned
+// context into the message headers with InjectHeaders.
+func StartPublish(ctx context.Context, queue string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, queue+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(messagingAttrs(queue)...),
+	)
+}
+
+func messagingAttrs(queue string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("messaging.system", "rabbitmq"),
+		attribute.String("messaging.destination.name", queue),
+	}
+}
+
+// headerCarrier adapts AMQP headers (amqp.Table) to propagation.TextMapCarrier.
+type headerCarrier map[string]interface{}
+
+func (c headerCarrier) Get(key string) string {
+	if v, ok := c[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func (c headerCarrier) Set(key, value string) { c[key] = value }
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// InjectHeaders writes the trace context of ctx into headers, creating the map when nil.
+// amqp.Table can be passed and assigned back directly.
+func InjectHeaders(ctx context.Context, headers map[string]interface{}) map[string]interface{} {
+	if headers == nil {
+		headers = map[string]interface{}{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(headers))
+	return headers
+}
+
+// ExtractHeaders returns ctx extended with the trace context found in headers.
+func ExtractHeaders(ctx context.Context, headers map[string]interface{}) context.Context {
+	if headers == nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, headerCarrier(headers))
+}
@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func withRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+	return rec
+}
+
+func TestSetupWithoutEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	shutdown, err := Setup(context.Background(), "worker-test")
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+	assert.False(t, exporterEnabled())
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+	assert.True(t, exporterEnabled())
+	t.Setenv("OTEL_TRACES_EXPORTER", "none")
+	assert.False(t, exporterEnabled())
+}
+
+func TestPublishAndConsumeShareTrace(t *testing.T) {
+	rec := withRecorder(t)
+
+	ctx, parent := Start(context.Background(), "upstream")
+	pubCtx, pub := StartPublish(ctx, "trim_queue")
+	headers := InjectHeaders(pubCtx, nil)
+	pub.End()
+	parent.End()
+
+	_, consume := StartConsume(context.Background(), headers, "trim_queue")
+	End(consume, errors.New("ffmpeg failed"))
+
+	spans := rec.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "trim_queue publish", spans[0].Name())
+	assert.Equal(t, trace.SpanKindProducer, spans[0].SpanKind())
+	assert.Equal(t, "trim_queue process", spans[2].Name())
+	assert.Equal(t, trace.SpanKindConsumer, spans[2].SpanKind())
+	assert.Equal(t, parent.SpanContext().TraceID(), spans[2].SpanContext().TraceID())
+	assert.Equal(t, pub.SpanContext().SpanID(), spans[2].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+}
+
+func TestExtractHeadersIgnoresMissingContext(t *testing.T) {
+	withRecorder(t)
+	ctx := context.Background()
+	assert.Equal(t, ctx, ExtractHeaders(ctx, nil))
+	got := ExtractHeaders(ctx, map[string]interface{}{"traceparent": int32(5)})
+	assert.False(t, trace.SpanContextFromContext(got).IsValid())
+}